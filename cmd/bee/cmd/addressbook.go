@@ -0,0 +1,151 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethersphere/bee/v2/pkg/addressbook"
+	"github.com/ethersphere/bee/v2/pkg/statestore/storeadapter"
+	"github.com/ethersphere/bee/v2/pkg/storage/leveldbstore"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"github.com/spf13/cobra"
+)
+
+const optionNameAddressBookPrefix = "prefix"
+
+func (c *command) initAddressBookCmd() {
+	cmd := &cobra.Command{
+		Use:   "addressbook",
+		Short: "Inspect and manage the addressbook of a stopped node",
+	}
+
+	addressBookListCmd(cmd)
+	addressBookRemoveCmd(cmd)
+
+	c.root.AddCommand(cmd)
+}
+
+// openAddressBook opens the addressbook in the statestore of the data directory.
+func openAddressBook(cmd *cobra.Command) (addressbook.Interface, func() error, error) {
+	v, err := cmd.Flags().GetString(optionNameVerbosity)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get verbosity: %w", err)
+	}
+	logger, err := newLogger(cmd, strings.ToLower(v))
+	if err != nil {
+		return nil, nil, fmt.Errorf("new logger: %w", err)
+	}
+
+	dataDir, err := cmd.Flags().GetString(optionNameDataDir)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get data-dir: %w", err)
+	}
+	if dataDir == "" {
+		return nil, nil, errors.New("no data-dir provided")
+	}
+
+	logger.Debug("opening statestore", "data_dir", dataDir)
+
+	// the statestore is opened without the cache of the node,
+	// which does not release the underlying store when closed
+	ldb, err := leveldbstore.New(filepath.Join(dataDir, "statestore"), nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("new statestore: %w", err)
+	}
+	stateStore, err := storeadapter.NewStateStorerAdapter(ldb)
+	if err != nil {
+		return nil, nil, errors.Join(fmt.Errorf("new statestore: %w", err), ldb.Close())
+	}
+
+	return addressbook.New(stateStore), stateStore.Close, nil
+}
+
+func addressBookListCmd(cmd *cobra.Command) {
+	c := &cobra.Command{
+		Use:   "list",
+		Short: "Lists the overlay and underlay addresses of the known peers",
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			prefix, err := cmd.Flags().GetString(optionNameAddressBookPrefix)
+			if err != nil {
+				return fmt.Errorf("get prefix: %w", err)
+			}
+			prefix = strings.ToLower(strings.TrimPrefix(prefix, "0x"))
+
+			ab, closer, err := openAddressBook(cmd)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				err = errors.Join(err, closer())
+			}()
+
+			addresses, err := ab.Addresses()
+			if err != nil {
+				return fmt.Errorf("list addressbook: %w", err)
+			}
+
+			count := 0
+			for _, a := range addresses {
+				if !strings.HasPrefix(a.Overlay.String(), prefix) {
+					continue
+				}
+				cmd.Printf("%s %s\n", a.Overlay, a.Underlay)
+				count++
+			}
+			cmd.Printf("%d peers\n", count)
+
+			return nil
+		},
+	}
+	c.Flags().String(optionNameDataDir, "", "data directory")
+	c.Flags().String(optionNameVerbosity, "info", "verbosity level")
+	c.Flags().String(optionNameAddressBookPrefix, "", "hex prefix of the listed overlay addresses")
+	cmd.AddCommand(c)
+}
+
+func addressBookRemoveCmd(cmd *cobra.Command) {
+	c := &cobra.Command{
+		Use:   "remove <overlay>...",
+		Short: "Removes the peers from the addressbook",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			overlays := make([]swarm.Address, 0, len(args))
+			for _, arg := range args {
+				overlay, err := swarm.ParseHexAddress(arg)
+				if err != nil {
+					return fmt.Errorf("invalid overlay address %q: %w", arg, err)
+				}
+				overlays = append(overlays, overlay)
+			}
+
+			ab, closer, err := openAddressBook(cmd)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				err = errors.Join(err, closer())
+			}()
+
+			for _, overlay := range overlays {
+				if _, err := ab.Get(overlay); err != nil {
+					return fmt.Errorf("get peer %s: %w", overlay, err)
+				}
+				if err := ab.Remove(overlay); err != nil {
+					return fmt.Errorf("remove peer %s: %w", overlay, err)
+				}
+				cmd.Printf("removed %s\n", overlay)
+			}
+
+			return nil
+		},
+	}
+	c.Flags().String(optionNameDataDir, "", "data directory")
+	c.Flags().String(optionNameVerbosity, "info", "verbosity level")
+	cmd.AddCommand(c)
+}
@@ -0,0 +1,104 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cmd_test
+
+import (
+	"bytes"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/cmd/bee/cmd"
+	"github.com/ethersphere/bee/v2/pkg/addressbook"
+	"github.com/ethersphere/bee/v2/pkg/bzz"
+	"github.com/ethersphere/bee/v2/pkg/crypto"
+	"github.com/ethersphere/bee/v2/pkg/statestore/storeadapter"
+	"github.com/ethersphere/bee/v2/pkg/storage/leveldbstore"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+func TestAddressBook(t *testing.T) {
+	t.Parallel()
+
+	dataDir := t.TempDir()
+
+	ldb, err := leveldbstore.New(filepath.Join(dataDir, "statestore"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stateStore, err := storeadapter.NewStateStorerAdapter(ldb)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ab := addressbook.New(stateStore)
+	addresses := make([]bzz.Address, 3)
+	for i := range addresses {
+		underlay, err := ma.NewMultiaddr("/ip4/127.0.0.1/tcp/" + strconv.Itoa(1634+i))
+		if err != nil {
+			t.Fatal(err)
+		}
+		pk, err := crypto.GenerateSecp256k1Key()
+		if err != nil {
+			t.Fatal(err)
+		}
+		overlay := swarm.RandAddress(t)
+		addr, err := bzz.NewAddress(crypto.NewDefaultSigner(pk), underlay, overlay, 1, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := ab.Put(overlay, *addr); err != nil {
+			t.Fatal(err)
+		}
+		addresses[i] = *addr
+	}
+	if err := stateStore.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	list := func(t *testing.T, args ...string) string {
+		t.Helper()
+
+		var out bytes.Buffer
+		err := newCommand(t,
+			cmd.WithArgs(append([]string{"addressbook", "list", "--data-dir", dataDir}, args...)...),
+			cmd.WithOutput(&out),
+		).Execute()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return out.String()
+	}
+
+	out := list(t)
+	for _, a := range addresses {
+		if !strings.Contains(out, a.Overlay.String()+" "+a.Underlay.String()) {
+			t.Fatalf("peer %s not listed in %q", a.Overlay, out)
+		}
+	}
+	if !strings.Contains(out, "3 peers") {
+		t.Fatalf("got output %q, want 3 peers", out)
+	}
+
+	out = list(t, "--prefix", addresses[0].Overlay.String()[:16])
+	if !strings.Contains(out, addresses[0].Overlay.String()) || !strings.Contains(out, "1 peers") {
+		t.Fatalf("got output %q, want peer %s only", out, addresses[0].Overlay)
+	}
+
+	err = newCommand(t, cmd.WithArgs("addressbook", "remove", addresses[1].Overlay.String(), "--data-dir", dataDir)).Execute()
+	if err != nil {
+		t.Fatal(err)
+	}
+	out = list(t)
+	if strings.Contains(out, addresses[1].Overlay.String()) || !strings.Contains(out, "2 peers") {
+		t.Fatalf("got output %q, want peer %s removed", out, addresses[1].Overlay)
+	}
+
+	err = newCommand(t, cmd.WithArgs("addressbook", "remove", addresses[1].Overlay.String(), "--data-dir", dataDir)).Execute()
+	if err == nil {
+		t.Fatal("expected error removing unknown peer")
+	}
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/ethersphere/bee/v2/pkg/log"
 	"github.com/ethersphere/bee/v2/pkg/node"
 	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"github.com/ethersphere/bee/v2/pkg/topology/peerscore"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -28,16 +29,32 @@ const (
 	optionNameDBBlockCacheCapacity         = "db-block-cache-capacity"
 	optionNameDBWriteBufferSize            = "db-write-buffer-size"
 	optionNameDBDisableSeeksCompaction     = "db-disable-seeks-compaction"
+	optionNameDBScrubRate                  = "db-scrub-rate"
+	optionNameDBScrubInterval              = "db-scrub-interval"
+	optionNameDBEncryption                 = "db-encryption"
 	optionNamePassword                     = "password"
 	optionNamePasswordFile                 = "password-file"
 	optionNameAPIAddr                      = "api-addr"
 	optionNameP2PAddr                      = "p2p-addr"
 	optionNameNATAddr                      = "nat-addr"
 	optionNameP2PWSEnable                  = "p2p-ws-enable"
+	optionNameP2PQUICAddr                  = "p2p-quic-addr"
+	optionNameP2PWebTransportAddr          = "p2p-webtransport-addr"
+	optionNameP2PRelayEnable               = "p2p-relay-enable"
+	optionNameP2PHolePunchingEnable        = "p2p-hole-punching-enable"
+	optionNameP2PPrivateNetworkKey         = "p2p-private-network-key"
+	optionNameP2PAllowlistOverlays         = "p2p-allowlist-overlays"
+	optionNameP2PAllowlistUnderlays        = "p2p-allowlist-underlays"
+	optionNameP2PBandwidthLimit            = "p2p-bandwidth-limit"
+	optionNameP2PProtocolBandwidthLimits   = "p2p-protocol-bandwidth-limits"
+	optionNamePeerScoreLatencyWeight       = "peer-score-latency-weight"
+	optionNamePeerScoreHealthWeight        = "peer-score-health-weight"
+	optionNamePeerScoreReliabilityWeight   = "peer-score-reliability-weight"
 	optionNameBootnodes                    = "bootnode"
 	optionNameNetworkID                    = "network-id"
 	optionWelcomeMessage                   = "welcome-message"
 	optionCORSAllowedOrigins               = "cors-allowed-origins"
+	optionNameTopologyView                 = "topology-view"
 	optionNameTracingEnabled               = "tracing-enable"
 	optionNameTracingEndpoint              = "tracing-endpoint"
 	optionNameTracingHost                  = "tracing-host"
@@ -57,6 +74,14 @@ const (
 	optionNameFullNode                     = "full-node"
 	optionNamePostageContractAddress       = "postage-stamp-address"
 	optionNamePostageContractStartBlock    = "postage-stamp-start-block"
+	optionNamePostageEventsFile            = "postage-events-file"
+	optionNamePostageEventsFileHash        = "postage-events-file-hash"
+	optionNamePostageTopUpLimit            = "postage-topup-limit"
+	optionNamePostageSignerEndpoint        = "postage-signer-endpoint"
+	optionNamePostageSignerToken           = "postage-signer-token"
+	optionNamePostageWebhookURLs           = "postage-webhook-urls"
+	optionNamePostageNotifyTTLThresholds   = "postage-notify-ttl-thresholds"
+	optionNamePostageNotifySaturation      = "postage-notify-bucket-saturation"
 	optionNamePriceOracleAddress           = "price-oracle-address"
 	optionNameRedistributionAddress        = "redistribution-address"
 	optionNameStakingAddress               = "staking-address"
@@ -148,6 +173,10 @@ func newCommand(opts ...option) (c *command, err error) {
 
 	c.initVersionCmd()
 	c.initDBCmd()
+	c.initAddressBookCmd()
+	c.initStampsCmd()
+	c.initStampSignerCmd()
+	c.initPostageEventsCmd()
 	if err := c.initSplitCmd(); err != nil {
 		return nil, err
 	}
@@ -239,15 +268,31 @@ func (c *command) setAllFlags(cmd *cobra.Command) {
 	cmd.Flags().Uint64(optionNameDBBlockCacheCapacity, 32*1024*1024, "size of block cache of the database in bytes")
 	cmd.Flags().Uint64(optionNameDBWriteBufferSize, 32*1024*1024, "size of the database write buffer in bytes")
 	cmd.Flags().Bool(optionNameDBDisableSeeksCompaction, true, "disables db compactions triggered by seeks")
+	cmd.Flags().Int(optionNameDBScrubRate, 10, "number of chunks per second verified by the background scrubber, 0 disables scrubbing")
+	cmd.Flags().Duration(optionNameDBScrubInterval, 24*time.Hour, "pause between two background scrubbing passes")
+	cmd.Flags().Bool(optionNameDBEncryption, false, "encrypt the localstore at rest with a key derived from the swarm key")
 	cmd.Flags().String(optionNamePassword, "", "password for decrypting keys")
 	cmd.Flags().String(optionNamePasswordFile, "", "path to a file that contains password for decrypting keys")
 	cmd.Flags().String(optionNameAPIAddr, "127.0.0.1:1633", "HTTP API listen address")
 	cmd.Flags().String(optionNameP2PAddr, ":1634", "P2P listen address")
 	cmd.Flags().String(optionNameNATAddr, "", "NAT exposed address")
 	cmd.Flags().Bool(optionNameP2PWSEnable, false, "enable P2P WebSocket transport")
+	cmd.Flags().String(optionNameP2PQUICAddr, "", "P2P QUIC listen UDP address, QUIC transport is disabled if empty")
+	cmd.Flags().String(optionNameP2PWebTransportAddr, "", "P2P WebTransport listen UDP address for browser light clients, WebTransport transport is disabled if empty")
+	cmd.Flags().Bool(optionNameP2PRelayEnable, false, "enable acting as a P2P circuit relay for peers behind NAT, full node only")
+	cmd.Flags().Bool(optionNameP2PHolePunchingEnable, false, "enable P2P connectivity behind NAT through circuit relays and hole punching")
+	cmd.Flags().String(optionNameP2PPrivateNetworkKey, "", "hex encoded 32 byte pre-shared key of the private network, the peers without the key can not connect")
+	cmd.Flags().StringSlice(optionNameP2PAllowlistOverlays, []string{}, "overlay addresses of the only peers allowed to connect and to be gossiped")
+	cmd.Flags().StringSlice(optionNameP2PAllowlistUnderlays, []string{}, "underlay multiaddresses of the only peers allowed to connect and to be gossiped, matched by the host address and peer ID")
+	cmd.Flags().Int64(optionNameP2PBandwidthLimit, 0, "global P2P protocols bandwidth limit in bytes per second, unlimited if zero")
+	cmd.Flags().StringSlice(optionNameP2PProtocolBandwidthLimits, []string{}, "P2P protocol bandwidth limits in bytes per second as protocol=limit pairs, e.g. pullsync=1000000")
+	cmd.Flags().Float64(optionNamePeerScoreLatencyWeight, peerscore.DefaultWeights.Latency, "weight of the peer latency in choosing among the closest peers")
+	cmd.Flags().Float64(optionNamePeerScoreHealthWeight, peerscore.DefaultWeights.Health, "weight of the peer health in choosing among the closest peers")
+	cmd.Flags().Float64(optionNamePeerScoreReliabilityWeight, peerscore.DefaultWeights.Reliability, "weight of the peer reliability in choosing among the closest peers, peer scoring is disabled if all weights are zero")
 	cmd.Flags().StringSlice(optionNameBootnodes, []string{"/dnsaddr/mainnet.ethswarm.org"}, "initial nodes to connect to")
 	cmd.Flags().Uint64(optionNameNetworkID, chaincfg.Mainnet.NetworkID, "ID of the Swarm network")
 	cmd.Flags().StringSlice(optionCORSAllowedOrigins, []string{}, "origins with CORS headers enabled")
+	cmd.Flags().Bool(optionNameTopologyView, false, "enable the HTML view of the topology history served by the API")
 	cmd.Flags().Bool(optionNameTracingEnabled, false, "enable tracing")
 	cmd.Flags().String(optionNameTracingEndpoint, "127.0.0.1:6831", "endpoint to send tracing data")
 	cmd.Flags().String(optionNameTracingHost, "", "host to send tracing data")
@@ -268,6 +313,14 @@ func (c *command) setAllFlags(cmd *cobra.Command) {
 	cmd.Flags().Bool(optionNameFullNode, false, "cause the node to start in full mode")
 	cmd.Flags().String(optionNamePostageContractAddress, "", "postage stamp contract address")
 	cmd.Flags().Uint64(optionNamePostageContractStartBlock, 0, "postage stamp contract start block number")
+	cmd.Flags().String(optionNamePostageEventsFile, "", "postage events file exported by another node to bootstrap the batch store from")
+	cmd.Flags().String(optionNamePostageEventsFileHash, "", "SHA-256 digest of the postage events file, identifying the export of a trusted node, required without a blockchain endpoint")
+	cmd.Flags().String(optionNamePostageTopUpLimit, "0", "amount in PLUR the automatic postage batch top-ups may spend per day")
+	cmd.Flags().String(optionNamePostageSignerEndpoint, "", "URL of the remote service signing the postage stamps with the batch owner key")
+	cmd.Flags().String(optionNamePostageSignerToken, "", "bearer token authorizing the node at the remote postage stamp signer")
+	cmd.Flags().StringSlice(optionNamePostageWebhookURLs, []string{}, "URLs the events of the owned postage batches are posted to")
+	cmd.Flags().StringSlice(optionNamePostageNotifyTTLThresholds, []string{"168h", "24h"}, "postage batch TTLs the crossing of which is notified")
+	cmd.Flags().Uint(optionNamePostageNotifySaturation, 90, "postage batch bucket fill percentage the crossing of which is notified")
 	cmd.Flags().String(optionNamePriceOracleAddress, "", "price oracle contract address")
 	cmd.Flags().String(optionNameRedistributionAddress, "", "redistribution contract address")
 	cmd.Flags().String(optionNameStakingAddress, "", "staking contract address")
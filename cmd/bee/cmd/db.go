@@ -18,6 +18,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/ethersphere/bee/v2/pkg/crypto"
+	filekeystore "github.com/ethersphere/bee/v2/pkg/keystore/file"
 	"github.com/ethersphere/bee/v2/pkg/node"
 	"github.com/ethersphere/bee/v2/pkg/postage"
 	"github.com/ethersphere/bee/v2/pkg/puller"
@@ -34,6 +36,7 @@ const (
 	optionNameValidationPin  = "validate-pin"
 	optionNameCollectionPin  = "pin"
 	optionNameOutputLocation = "output"
+	optionNameDryRun         = "dry-run"
 )
 
 func (c *command) initDBCmd() {
@@ -46,7 +49,10 @@ func (c *command) initDBCmd() {
 	dbImportCmd(cmd)
 	dbNukeCmd(cmd)
 	dbInfoCmd(cmd)
+	dbUsageCmd(cmd)
 	dbCompactCmd(cmd)
+	dbMigrateCmd(cmd)
+	dbEncryptCmd(cmd)
 	dbValidateCmd(cmd)
 	dbValidatePinsCmd(cmd)
 	dbRepairReserve(cmd)
@@ -80,11 +86,17 @@ func dbInfoCmd(cmd *cobra.Command) {
 
 			logger.Info("getting db indices with data-dir", "path", dataDir)
 
+			key, err := localstoreEncryptionKey(cmd, dataDir, localstoreDataDir)
+			if err != nil {
+				return err
+			}
+
 			db, err := storer.New(cmd.Context(), dataDir, &storer.Options{
 				Logger:          logger,
 				RadiusSetter:    noopRadiusSetter{},
 				Batchstore:      new(postage.NoOpBatchStore),
 				ReserveCapacity: storer.DefaultReserveCapacity,
+				EncryptionKey:   key,
 				CacheCapacity:   1_000_000,
 			})
 			if err != nil {
@@ -111,9 +123,279 @@ func dbInfoCmd(cmd *cobra.Command) {
 	}
 	c.Flags().String(optionNameDataDir, "", "data directory")
 	c.Flags().String(optionNameVerbosity, "info", "verbosity level")
+	addLocalstoreEncryptionFlags(c)
+	cmd.AddCommand(c)
+}
+
+func dbUsageCmd(cmd *cobra.Command) {
+	c := &cobra.Command{
+		Use:   "usage",
+		Short: "Prints the storage usage breakdown of the localstore per logical store, batch and pin",
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			start := time.Now()
+			v, err := cmd.Flags().GetString(optionNameVerbosity)
+			if err != nil {
+				return fmt.Errorf("get verbosity: %w", err)
+			}
+			v = strings.ToLower(v)
+			logger, err := newLogger(cmd, v)
+			if err != nil {
+				return fmt.Errorf("new logger: %w", err)
+			}
+
+			dataDir, err := cmd.Flags().GetString(optionNameDataDir)
+			if err != nil {
+				return fmt.Errorf("get data-dir: %w", err)
+			}
+			if dataDir == "" {
+				return errors.New("no data-dir provided")
+			}
+
+			logger.Info("analyzing storage usage with data-dir", "path", dataDir)
+
+			key, err := localstoreEncryptionKey(cmd, dataDir, nodeDataDir)
+			if err != nil {
+				return err
+			}
+
+			db, err := storer.New(cmd.Context(), path.Join(dataDir, ioutil.DataPathLocalstore), &storer.Options{
+				Logger:          logger,
+				RadiusSetter:    noopRadiusSetter{},
+				Batchstore:      new(postage.NoOpBatchStore),
+				ReserveCapacity: storer.DefaultReserveCapacity,
+				EncryptionKey:   key,
+				CacheCapacity:   1_000_000,
+			})
+			if err != nil {
+				return fmt.Errorf("localstore: %w", err)
+			}
+			defer db.Close()
+
+			usage, err := db.Usage(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("analyzing storage usage: %w", err)
+			}
+
+			logger.Info("chunkstore", "chunks", usage.ChunkStore.Chunks, "bytes", usage.ChunkStore.Bytes)
+			logger.Info("reserve", "chunks", usage.Reserve.Chunks, "bytes", usage.Reserve.Bytes)
+			logger.Info("cache", "chunks", usage.Cache.Chunks, "bytes", usage.Cache.Bytes)
+			logger.Info("upload", "chunks", usage.Upload.Chunks, "bytes", usage.Upload.Bytes)
+			logger.Info("pinning", "chunks", usage.Pinning.Chunks, "bytes", usage.Pinning.Bytes, "shared_chunks", usage.PinShared.Chunks, "shared_bytes", usage.PinShared.Bytes)
+			logger.Info("stamp index", "entries", usage.StampIndex.Entries, "bytes", usage.StampIndex.Bytes)
+			for _, b := range usage.Batches {
+				logger.Info("batch", "batch_id", b.BatchID, "reserve_chunks", b.Reserve.Chunks, "reserve_bytes", b.Reserve.Bytes, "upload_chunks", b.Upload.Chunks, "upload_bytes", b.Upload.Bytes, "stamp_index_entries", b.StampIndex.Entries)
+			}
+			for _, p := range usage.Pins {
+				logger.Info("pin", "root", p.Root, "chunks", p.Total.Chunks, "bytes", p.Total.Bytes, "shared_chunks", p.Shared.Chunks, "shared_bytes", p.Shared.Bytes)
+			}
+			logger.Info("done", "elapsed", time.Since(start))
+
+			return nil
+		},
+	}
+	c.Flags().String(optionNameDataDir, "", "data directory")
+	c.Flags().String(optionNameVerbosity, "info", "verbosity level")
+	addLocalstoreEncryptionFlags(c)
+	cmd.AddCommand(c)
+}
+
+func dbMigrateCmd(cmd *cobra.Command) {
+	c := &cobra.Command{
+		Use:   "migrate",
+		Short: "Applies the pending localstore migrations or reports them with --dry-run",
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			start := time.Now()
+			v, err := cmd.Flags().GetString(optionNameVerbosity)
+			if err != nil {
+				return fmt.Errorf("get verbosity: %w", err)
+			}
+			v = strings.ToLower(v)
+			logger, err := newLogger(cmd, v)
+			if err != nil {
+				return fmt.Errorf("new logger: %w", err)
+			}
+
+			dataDir, err := cmd.Flags().GetString(optionNameDataDir)
+			if err != nil {
+				return fmt.Errorf("get data-dir: %w", err)
+			}
+			if dataDir == "" {
+				return errors.New("no data-dir provided")
+			}
+
+			dryRun, err := cmd.Flags().GetBool(optionNameDryRun)
+			if err != nil {
+				return fmt.Errorf("get dry-run: %w", err)
+			}
+
+			key, err := localstoreEncryptionKey(cmd, dataDir, nodeDataDir)
+			if err != nil {
+				return err
+			}
+
+			localstorePath := path.Join(dataDir, ioutil.DataPathLocalstore)
+			opts := &storer.Options{
+				Logger:          logger,
+				RadiusSetter:    noopRadiusSetter{},
+				Batchstore:      new(postage.NoOpBatchStore),
+				ReserveCapacity: storer.DefaultReserveCapacity,
+				EncryptionKey:   key,
+				CacheCapacity:   1_000_000,
+			}
+
+			steps, err := storer.PendingMigrations(cmd.Context(), localstorePath, opts)
+			if err != nil {
+				return fmt.Errorf("pending migrations: %w", err)
+			}
+			if len(steps) == 0 {
+				logger.Info("localstore is up to date")
+				return nil
+			}
+			for _, s := range steps {
+				logger.Info("pending migration step", "group", s.Group, "step", s.Version, "name", s.Name, "estimated_items", s.Estimate, "resumed", s.Resumed, "done_items", s.Done)
+			}
+			if dryRun {
+				return nil
+			}
+
+			logger.Info("applying migrations; an interrupted step continues from its last checkpoint or starts over on the next run")
+
+			db, err := storer.New(cmd.Context(), localstorePath, opts)
+			if err != nil {
+				return fmt.Errorf("localstore: %w", err)
+			}
+			if err := db.Close(); err != nil {
+				return fmt.Errorf("close localstore: %w", err)
+			}
+
+			logger.Info("done", "elapsed", time.Since(start))
+			return nil
+		},
+	}
+	c.Flags().String(optionNameDataDir, "", "data directory")
+	c.Flags().String(optionNameVerbosity, "info", "verbosity level")
+	c.Flags().Bool(optionNameDryRun, false, "report the pending migration steps and their estimated work without applying them")
+	addLocalstoreEncryptionFlags(c)
+	cmd.AddCommand(c)
+}
+
+func dbEncryptCmd(cmd *cobra.Command) {
+	c := &cobra.Command{
+		Use:   "encrypt",
+		Short: "Migrates the unencrypted localstore to one encrypted at rest with a key derived from the swarm key",
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			start := time.Now()
+			v, err := cmd.Flags().GetString(optionNameVerbosity)
+			if err != nil {
+				return fmt.Errorf("get verbosity: %w", err)
+			}
+			v = strings.ToLower(v)
+			logger, err := newLogger(cmd, v)
+			if err != nil {
+				return fmt.Errorf("new logger: %w", err)
+			}
+
+			dataDir, err := cmd.Flags().GetString(optionNameDataDir)
+			if err != nil {
+				return fmt.Errorf("get data-dir: %w", err)
+			}
+			if dataDir == "" {
+				return errors.New("no data-dir provided")
+			}
+
+			key, err := localstoreEncryptionKey(cmd, dataDir, nodeDataDir)
+			if err != nil {
+				return err
+			}
+			if key == nil {
+				return errors.New("no password or password-file provided")
+			}
+
+			plainPath, err := storer.Encrypt(cmd.Context(), path.Join(dataDir, ioutil.DataPathLocalstore), &storer.Options{
+				Logger:          logger,
+				RadiusSetter:    noopRadiusSetter{},
+				Batchstore:      new(postage.NoOpBatchStore),
+				ReserveCapacity: storer.DefaultReserveCapacity,
+				EncryptionKey:   key,
+			})
+			if err != nil {
+				return fmt.Errorf("localstore: %w", err)
+			}
+
+			logger.Info("localstore encrypted; start the node with the --db-encryption option from now on", "elapsed", time.Since(start))
+			logger.Warning("the unencrypted localstore is kept until it is removed manually", "path", plainPath)
+			return nil
+		},
+	}
+	c.Flags().String(optionNameDataDir, "", "data directory")
+	c.Flags().String(optionNameVerbosity, "info", "verbosity level")
+	addLocalstoreEncryptionFlags(c)
 	cmd.AddCommand(c)
 }
 
+// addLocalstoreEncryptionFlags adds the options with which the swarm key is
+// decrypted to derive the encryption key of an encrypted localstore.
+func addLocalstoreEncryptionFlags(c *cobra.Command) {
+	c.Flags().String(optionNamePassword, "", "password for decrypting keys, required for an encrypted localstore")
+	c.Flags().String(optionNamePasswordFile, "", "path to a file that contains password for decrypting keys, required for an encrypted localstore")
+}
+
+// dataDirLayout tells what the data-dir option of a db command points to.
+type dataDirLayout int
+
+const (
+	// nodeDataDir is the data directory of the node,
+	// holding the keys and the localstore directories.
+	nodeDataDir dataDirLayout = iota
+	// localstoreDataDir is the localstore directory itself,
+	// next to the keys directory of the node.
+	localstoreDataDir
+)
+
+// localstoreEncryptionKey derives the localstore encryption key from the swarm
+// key of the node, the same way the node does with the db-encryption option.
+// It returns a nil key if neither the password nor the password-file is set.
+func localstoreEncryptionKey(cmd *cobra.Command, dataDir string, layout dataDirLayout) ([]byte, error) {
+	password, err := cmd.Flags().GetString(optionNamePassword)
+	if err != nil {
+		return nil, fmt.Errorf("get password: %w", err)
+	}
+	if password == "" {
+		passwordFile, err := cmd.Flags().GetString(optionNamePasswordFile)
+		if err != nil {
+			return nil, fmt.Errorf("get password-file: %w", err)
+		}
+		if passwordFile == "" {
+			return nil, nil
+		}
+		b, err := os.ReadFile(passwordFile)
+		if err != nil {
+			return nil, err
+		}
+		password = string(bytes.Trim(b, "\n"))
+	}
+
+	keysDir := filepath.Join(dataDir, "keys")
+	if layout == localstoreDataDir {
+		keysDir = filepath.Join(dataDir, "..", "keys")
+	}
+	keystore := filekeystore.New(keysDir)
+	if exists, err := keystore.Exists("swarm"); err != nil {
+		return nil, err
+	} else if !exists {
+		return nil, fmt.Errorf("swarm key not found in %s", keysDir)
+	}
+	swarmPrivateKey, _, err := keystore.Key("swarm", password, crypto.EDGSecp256_K1)
+	if err != nil {
+		return nil, fmt.Errorf("swarm key: %w", err)
+	}
+	key, err := crypto.DeriveSymmetricKey(swarmPrivateKey, localstoreKeyPurpose)
+	if err != nil {
+		return nil, fmt.Errorf("localstore key: %w", err)
+	}
+	return key, nil
+}
+
 func dbCompactCmd(cmd *cobra.Command) {
 	c := &cobra.Command{
 		Use:   "compact",
@@ -160,6 +442,11 @@ func dbCompactCmd(cmd *cobra.Command) {
 			time.Sleep(10 * time.Second)
 			logger.Warning("proceeding with database compaction...")
 
+			key, err := localstoreEncryptionKey(cmd, dataDir, nodeDataDir)
+			if err != nil {
+				return err
+			}
+
 			localstorePath := path.Join(dataDir, ioutil.DataPathLocalstore)
 
 			err = storer.Compact(context.Background(), localstorePath, &storer.Options{
@@ -167,6 +454,7 @@ func dbCompactCmd(cmd *cobra.Command) {
 				RadiusSetter:    noopRadiusSetter{},
 				Batchstore:      new(postage.NoOpBatchStore),
 				ReserveCapacity: storer.DefaultReserveCapacity,
+				EncryptionKey:   key,
 			}, validation)
 			if err != nil {
 				return fmt.Errorf("localstore: %w", err)
@@ -179,6 +467,7 @@ func dbCompactCmd(cmd *cobra.Command) {
 	c.Flags().String(optionNameVerbosity, "info", "verbosity level")
 	c.Flags().Bool(optionNameValidation, false, "run chunk validation checks before and after the compaction")
 	c.Flags().Duration(optionNameSleepAfter, time.Duration(0), "time to sleep after the operation finished")
+	addLocalstoreEncryptionFlags(c)
 	cmd.AddCommand(c)
 }
 
@@ -215,6 +504,11 @@ func dbValidatePinsCmd(cmd *cobra.Command) {
 				return fmt.Errorf("read location option: %w", err)
 			}
 
+			key, err := localstoreEncryptionKey(cmd, dataDir, nodeDataDir)
+			if err != nil {
+				return err
+			}
+
 			localstorePath := path.Join(dataDir, ioutil.DataPathLocalstore)
 
 			err = storer.ValidatePinCollectionChunks(context.Background(), localstorePath, providedPin, outputLoc, &storer.Options{
@@ -222,6 +516,7 @@ func dbValidatePinsCmd(cmd *cobra.Command) {
 				RadiusSetter:    noopRadiusSetter{},
 				Batchstore:      new(postage.NoOpBatchStore),
 				ReserveCapacity: storer.DefaultReserveCapacity,
+				EncryptionKey:   key,
 			})
 			if err != nil {
 				return fmt.Errorf("localstore: %w", err)
@@ -234,6 +529,7 @@ func dbValidatePinsCmd(cmd *cobra.Command) {
 	c.Flags().String(optionNameVerbosity, "info", "verbosity level")
 	c.Flags().String(optionNameCollectionPin, "", "only validate given pin")
 	c.Flags().String(optionNameOutputLocation, "", "location and name of the output file")
+	addLocalstoreEncryptionFlags(c)
 	cmd.AddCommand(c)
 }
 
@@ -279,11 +575,17 @@ func dbRepairReserve(cmd *cobra.Command) {
 				}
 			}()
 
+			key, err := localstoreEncryptionKey(cmd, dataDir, nodeDataDir)
+			if err != nil {
+				return err
+			}
+
 			db, err := storer.New(cmd.Context(), path.Join(dataDir, "localstore"), &storer.Options{
 				Logger:          logger,
 				RadiusSetter:    noopRadiusSetter{},
 				Batchstore:      new(postage.NoOpBatchStore),
 				ReserveCapacity: storer.DefaultReserveCapacity,
+				EncryptionKey:   key,
 				CacheCapacity:   1_000_000,
 			})
 			if err != nil {
@@ -291,7 +593,7 @@ func dbRepairReserve(cmd *cobra.Command) {
 			}
 			defer db.Close()
 
-			err = migration.ReserveRepairer(db.Storage(), storage.ChunkType, logger)()
+			err = migration.ReserveRepairer(db.Storage(), storage.ChunkType, logger, nil)()
 			if err != nil {
 				return fmt.Errorf("repair: %w", err)
 			}
@@ -310,6 +612,7 @@ func dbRepairReserve(cmd *cobra.Command) {
 	c.Flags().String(optionNameDataDir, "", "data directory")
 	c.Flags().String(optionNameVerbosity, "info", "verbosity level")
 	c.Flags().Duration(optionNameSleepAfter, time.Duration(0), "time to sleep after the operation finished")
+	addLocalstoreEncryptionFlags(c)
 	cmd.AddCommand(c)
 }
 
@@ -341,6 +644,11 @@ func dbValidateCmd(cmd *cobra.Command) {
 			logger.Warning("    Progress logged at Info level.")
 			logger.Warning("    SOC chunks logged at Debug level.")
 
+			key, err := localstoreEncryptionKey(cmd, dataDir, nodeDataDir)
+			if err != nil {
+				return err
+			}
+
 			localstorePath := path.Join(dataDir, ioutil.DataPathLocalstore)
 
 			err = storer.ValidateRetrievalIndex(context.Background(), localstorePath, &storer.Options{
@@ -348,6 +656,7 @@ func dbValidateCmd(cmd *cobra.Command) {
 				RadiusSetter:    noopRadiusSetter{},
 				Batchstore:      new(postage.NoOpBatchStore),
 				ReserveCapacity: storer.DefaultReserveCapacity,
+				EncryptionKey:   key,
 			})
 			if err != nil {
 				return fmt.Errorf("localstore: %w", err)
@@ -358,6 +667,7 @@ func dbValidateCmd(cmd *cobra.Command) {
 	}
 	c.Flags().String(optionNameDataDir, "", "data directory")
 	c.Flags().String(optionNameVerbosity, "info", "verbosity level")
+	addLocalstoreEncryptionFlags(c)
 	cmd.AddCommand(c)
 }
 
@@ -406,11 +716,17 @@ func dbExportReserveCmd(cmd *cobra.Command) {
 
 			logger.Info("starting export process with data-dir", "path", dataDir)
 
+			key, err := localstoreEncryptionKey(cmd, dataDir, localstoreDataDir)
+			if err != nil {
+				return err
+			}
+
 			db, err := storer.New(cmd.Context(), dataDir, &storer.Options{
 				Logger:          logger,
 				RadiusSetter:    noopRadiusSetter{},
 				Batchstore:      new(postage.NoOpBatchStore),
 				ReserveCapacity: storer.DefaultReserveCapacity,
+				EncryptionKey:   key,
 				CacheCapacity:   1_000_000,
 			})
 			if err != nil {
@@ -459,6 +775,7 @@ func dbExportReserveCmd(cmd *cobra.Command) {
 			return nil
 		},
 	}
+	addLocalstoreEncryptionFlags(c)
 	cmd.AddCommand(c)
 }
 
@@ -489,11 +806,17 @@ func dbExportPinningCmd(cmd *cobra.Command) {
 			}
 
 			logger.Info("starting export process with data-dir", "path", dataDir)
+			key, err := localstoreEncryptionKey(cmd, dataDir, localstoreDataDir)
+			if err != nil {
+				return err
+			}
+
 			db, err := storer.New(cmd.Context(), dataDir, &storer.Options{
 				Logger:          logger,
 				RadiusSetter:    noopRadiusSetter{},
 				Batchstore:      new(postage.NoOpBatchStore),
 				ReserveCapacity: storer.DefaultReserveCapacity,
+				EncryptionKey:   key,
 				CacheCapacity:   1_000_000,
 			})
 			if err != nil {
@@ -556,6 +879,7 @@ func dbExportPinningCmd(cmd *cobra.Command) {
 			return nil
 		},
 	}
+	addLocalstoreEncryptionFlags(c)
 	cmd.AddCommand(c)
 }
 
@@ -569,6 +893,7 @@ func dbImportCmd(cmd *cobra.Command) {
 
 	dbImportReserveCmd(c)
 	dbImportPinningCmd(c)
+	dbImportBackupCmd(c)
 	cmd.AddCommand(c)
 }
 
@@ -599,11 +924,17 @@ func dbImportReserveCmd(cmd *cobra.Command) {
 
 			fmt.Printf("starting import process with data-dir at %s\n", dataDir)
 
+			key, err := localstoreEncryptionKey(cmd, dataDir, localstoreDataDir)
+			if err != nil {
+				return err
+			}
+
 			db, err := storer.New(cmd.Context(), dataDir, &storer.Options{
 				Logger:          logger,
 				RadiusSetter:    noopRadiusSetter{},
 				Batchstore:      new(postage.NoOpBatchStore),
 				ReserveCapacity: storer.DefaultReserveCapacity,
+				EncryptionKey:   key,
 				CacheCapacity:   1_000_000,
 			})
 			if err != nil {
@@ -653,6 +984,7 @@ func dbImportReserveCmd(cmd *cobra.Command) {
 		},
 	}
 
+	addLocalstoreEncryptionFlags(c)
 	cmd.AddCommand(c)
 }
 
@@ -683,11 +1015,17 @@ func dbImportPinningCmd(cmd *cobra.Command) {
 
 			fmt.Printf("starting import process with data-dir at %s\n", dataDir)
 
+			key, err := localstoreEncryptionKey(cmd, dataDir, localstoreDataDir)
+			if err != nil {
+				return err
+			}
+
 			db, err := storer.New(cmd.Context(), dataDir, &storer.Options{
 				Logger:          logger,
 				RadiusSetter:    noopRadiusSetter{},
 				Batchstore:      new(postage.NoOpBatchStore),
 				ReserveCapacity: storer.DefaultReserveCapacity,
+				EncryptionKey:   key,
 				CacheCapacity:   1_000_000,
 			})
 			if err != nil {
@@ -761,6 +1099,68 @@ func dbImportPinningCmd(cmd *cobra.Command) {
 			return nil
 		},
 	}
+	addLocalstoreEncryptionFlags(c)
+	cmd.AddCommand(c)
+}
+
+func dbImportBackupCmd(cmd *cobra.Command) {
+	c := &cobra.Command{
+		Use:   "backup <full-backup> [<incremental-backup>...]",
+		Short: "Restore the localstore from a full backup followed by its incremental backups, in the order they were taken",
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			if len(args) == 0 {
+				return cmd.Help()
+			}
+			v, err := cmd.Flags().GetString(optionNameVerbosity)
+			if err != nil {
+				return fmt.Errorf("get verbosity: %w", err)
+			}
+			v = strings.ToLower(v)
+			logger, err := newLogger(cmd, v)
+			if err != nil {
+				return fmt.Errorf("new logger: %w", err)
+			}
+			dataDir, err := cmd.Flags().GetString(optionNameDataDir)
+			if err != nil {
+				return fmt.Errorf("get data-dir: %w", err)
+			}
+			if dataDir == "" {
+				return errors.New("no data-dir provided")
+			}
+
+			logger.Info("starting backup restore with data-dir", "path", dataDir, "archives", len(args))
+
+			archives := make([]io.Reader, 0, len(args))
+			for _, name := range args {
+				f, err := os.Open(name)
+				if err != nil {
+					return fmt.Errorf("opening backup file: %w", err)
+				}
+				defer f.Close()
+				archives = append(archives, f)
+			}
+
+			key, err := localstoreEncryptionKey(cmd, dataDir, localstoreDataDir)
+			if err != nil {
+				return err
+			}
+
+			info, err := storer.RestoreBackup(cmd.Context(), dataDir, &storer.Options{
+				Logger:          logger,
+				RadiusSetter:    noopRadiusSetter{},
+				Batchstore:      new(postage.NoOpBatchStore),
+				ReserveCapacity: storer.DefaultReserveCapacity,
+				EncryptionKey:   key,
+			}, archives...)
+			if err != nil {
+				return fmt.Errorf("restore backup: %w", err)
+			}
+
+			logger.Info("localstore restored successfully", "timestamp", time.Unix(int64(info.Timestamp), 0), "next_backup_since", info.Timestamp)
+			return nil
+		},
+	}
+	addLocalstoreEncryptionFlags(c)
 	cmd.AddCommand(c)
 }
 
@@ -7,18 +7,23 @@ package cmd_test
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"os"
 	"path"
 	"strings"
 	"testing"
 
 	"github.com/ethersphere/bee/v2/cmd/bee/cmd"
+	"github.com/ethersphere/bee/v2/pkg/crypto"
+	filekeystore "github.com/ethersphere/bee/v2/pkg/keystore/file"
 	"github.com/ethersphere/bee/v2/pkg/log"
 	"github.com/ethersphere/bee/v2/pkg/postage"
 	storagetest "github.com/ethersphere/bee/v2/pkg/storage/testing"
 	"github.com/ethersphere/bee/v2/pkg/storer"
 	"github.com/ethersphere/bee/v2/pkg/swarm"
 	kademlia "github.com/ethersphere/bee/v2/pkg/topology/mock"
+	"github.com/ethersphere/bee/v2/pkg/util/ioutil"
 	"github.com/ethersphere/bee/v2/pkg/util/testutil"
 )
 
@@ -172,6 +177,69 @@ func TestDBExportImportPinning(t *testing.T) {
 	}
 }
 
+func TestDBImportBackup(t *testing.T) {
+	t.Parallel()
+
+	dir1 := t.TempDir()
+	dir2 := t.TempDir()
+	backup := t.TempDir() + "/localstore.backup"
+
+	ctx := context.Background()
+	db1 := newTestDB(t, ctx, &storer.Options{
+		Batchstore:      new(postage.NoOpBatchStore),
+		RadiusSetter:    kademlia.NewTopologyDriver(),
+		Logger:          testutil.NewLogger(t),
+		ReserveCapacity: storer.DefaultReserveCapacity,
+	}, dir1)
+
+	chunks := make(map[string]int)
+	for i := 0; i < 10; i++ {
+		ch := storagetest.GenerateTestRandomChunk()
+		err := db1.ReservePutter().Put(ctx, ch)
+		if err != nil {
+			t.Fatal(err)
+		}
+		chunks[ch.Address().String()] = 0
+	}
+
+	f, err := os.Create(backup)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db1.Backup(ctx, f, 0); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+	db1.Close()
+
+	err = newCommand(t, cmd.WithArgs("db", "import", "backup", backup, "--data-dir", dir2)).Execute()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db2 := newTestDB(t, ctx, &storer.Options{
+		Batchstore:      new(postage.NoOpBatchStore),
+		RadiusSetter:    kademlia.NewTopologyDriver(),
+		Logger:          testutil.NewLogger(t),
+		ReserveCapacity: storer.DefaultReserveCapacity,
+	}, dir2)
+
+	err = db2.ReserveIterateChunks(func(chunk swarm.Chunk) (bool, error) {
+		chunks[chunk.Address().String()]++
+		return false, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	db2.Close()
+
+	for k, v := range chunks {
+		if v != 1 {
+			t.Errorf("chunk %s missing", k)
+		}
+	}
+}
+
 // TestDBNuke_FLAKY is flaky on windows.
 func TestDBNuke_FLAKY(t *testing.T) {
 	t.Parallel()
@@ -269,6 +337,122 @@ func TestDBInfo(t *testing.T) {
 	}
 }
 
+func TestDBUsage(t *testing.T) {
+	t.Parallel()
+
+	dataDir := t.TempDir()
+	ctx := context.Background()
+	db := newTestDB(t, ctx, &storer.Options{
+		Batchstore:      new(postage.NoOpBatchStore),
+		RadiusSetter:    kademlia.NewTopologyDriver(),
+		Logger:          testutil.NewLogger(t),
+		ReserveCapacity: storer.DefaultReserveCapacity,
+	}, path.Join(dataDir, ioutil.DataPathLocalstore))
+
+	nChunks := 10
+	var nBytes int
+	for i := 0; i < nChunks; i++ {
+		ch := storagetest.GenerateTestRandomChunk()
+		err := db.ReservePutter().Put(ctx, ch)
+		if err != nil {
+			t.Fatal(err)
+		}
+		nBytes += len(ch.Data())
+	}
+	db.Close()
+
+	var buf bytes.Buffer
+	err := newCommand(t, cmd.WithArgs("db", "usage", "--data-dir", dataDir), cmd.WithOutput(&buf)).Execute()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(buf.String(), fmt.Sprintf("\"msg\"=\"reserve\" \"chunks\"=%d \"bytes\"=%d", nChunks, nBytes)) {
+		t.Fatal("reserve usage not correct")
+	}
+}
+
+func TestDBMigrate(t *testing.T) {
+	t.Parallel()
+
+	dataDir := t.TempDir()
+
+	var buf bytes.Buffer
+	err := newCommand(t, cmd.WithArgs("db", "migrate", "--dry-run", "--data-dir", dataDir), cmd.WithOutput(&buf)).Execute()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "\"msg\"=\"pending migration step\" \"group\"=\"migration\" \"step\"=7") {
+		t.Fatal("pending migration steps not reported")
+	}
+
+	buf.Reset()
+	err = newCommand(t, cmd.WithArgs("db", "migrate", "--data-dir", dataDir), cmd.WithOutput(&buf)).Execute()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buf.Reset()
+	err = newCommand(t, cmd.WithArgs("db", "migrate", "--dry-run", "--data-dir", dataDir), cmd.WithOutput(&buf)).Execute()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "localstore is up to date") {
+		t.Fatal("migrated localstore reported as outdated")
+	}
+}
+
+func TestDBEncrypt(t *testing.T) {
+	t.Parallel()
+
+	dataDir := t.TempDir()
+
+	err := newCommand(t, cmd.WithArgs("db", "migrate", "--data-dir", dataDir)).Execute()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = newCommand(t, cmd.WithArgs("db", "encrypt", "--data-dir", dataDir, "--password", "secret")).Execute()
+	if err == nil || !strings.Contains(err.Error(), "swarm key not found") {
+		t.Fatalf("want missing swarm key error, got %v", err)
+	}
+
+	if _, _, err := filekeystore.New(path.Join(dataDir, "keys")).Key("swarm", "secret", crypto.EDGSecp256_K1); err != nil {
+		t.Fatal(err)
+	}
+
+	err = newCommand(t, cmd.WithArgs("db", "encrypt", "--data-dir", dataDir, "--password", "wrong")).Execute()
+	if err == nil {
+		t.Fatal("localstore encrypted with a wrong password")
+	}
+
+	err = newCommand(t, cmd.WithArgs("db", "encrypt", "--data-dir", dataDir, "--password", "secret")).Execute()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = newCommand(t, cmd.WithArgs("db", "migrate", "--dry-run", "--data-dir", dataDir)).Execute()
+	if !errors.Is(err, storer.ErrEncryptionKeyRequired) {
+		t.Fatalf("want error %v, got %v", storer.ErrEncryptionKeyRequired, err)
+	}
+
+	err = newCommand(t, cmd.WithArgs("db", "migrate", "--dry-run", "--data-dir", dataDir, "--password", "secret")).Execute()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = newCommand(t, cmd.WithArgs("db", "usage", "--data-dir", dataDir, "--password", "secret")).Execute()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	export := t.TempDir() + "/export.tar"
+	err = newCommand(t, cmd.WithArgs("db", "export", "reserve", export, "--data-dir", path.Join(dataDir, ioutil.DataPathLocalstore), "--password", "secret")).Execute()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestMarshalChunk(t *testing.T) {
 	t.Parallel()
 	ch := storagetest.GenerateTestRandomChunk()
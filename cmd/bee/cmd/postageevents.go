@@ -0,0 +1,144 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethersphere/bee/v2/pkg/config"
+	"github.com/ethersphere/bee/v2/pkg/node"
+	"github.com/ethersphere/bee/v2/pkg/util/abiutil"
+	"github.com/spf13/cobra"
+)
+
+const (
+	optionNamePostageEventsBlock = "block"
+
+	// postageEventsConfirmations is the number of the blocks the
+	// default end block of the export is behind the chain tip.
+	postageEventsConfirmations = 4
+)
+
+func (c *command) initPostageEventsCmd() {
+	cmd := &cobra.Command{
+		Use:   "postage-events",
+		Short: "Export the postage contract events to bootstrap other nodes from",
+	}
+
+	postageEventsExportCmd(cmd)
+
+	c.root.AddCommand(cmd)
+}
+
+func postageEventsExportCmd(cmd *cobra.Command) {
+	c := &cobra.Command{
+		Use:   "export",
+		Short: "Exports the postage contract events to a file",
+		Long: `Exports the postage contract events to a file.
+
+The nodes started with the postage-events-file option set to the file
+bootstrap their batch store from the file and sync only the later blocks from
+the chain, or apply only the file when they run without a blockchain endpoint.
+The nodes with a blockchain endpoint check the hash of the last exported block
+on their chain, the others require the postage-events-file-hash option set to
+the printed file hash. The earlier events themselves are not verified against
+the chain, so the file must be exported by a trusted node.`,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			endpoint, err := cmd.Flags().GetString(optionNameBlockchainRpcEndpoint)
+			if err != nil {
+				return fmt.Errorf("get blockchain-rpc-endpoint: %w", err)
+			}
+			if endpoint == "" {
+				return errors.New("no blockchain-rpc-endpoint provided")
+			}
+			file, err := cmd.Flags().GetString(optionNameStampsFile)
+			if err != nil {
+				return fmt.Errorf("get file: %w", err)
+			}
+			if file == "" {
+				return errors.New("no file provided")
+			}
+			block, err := cmd.Flags().GetUint64(optionNamePostageEventsBlock)
+			if err != nil {
+				return fmt.Errorf("get block: %w", err)
+			}
+			contractAddress, err := cmd.Flags().GetString(optionNamePostageContractAddress)
+			if err != nil {
+				return fmt.Errorf("get postage-stamp-address: %w", err)
+			}
+			startBlock, err := cmd.Flags().GetUint64(optionNamePostageContractStartBlock)
+			if err != nil {
+				return fmt.Errorf("get postage-stamp-start-block: %w", err)
+			}
+
+			ctx := cmd.Context()
+			client, err := ethclient.DialContext(ctx, endpoint)
+			if err != nil {
+				return fmt.Errorf("dial blockchain rpc endpoint: %w", err)
+			}
+			defer client.Close()
+
+			chainID, err := client.ChainID(ctx)
+			if err != nil {
+				return fmt.Errorf("chain id: %w", err)
+			}
+			chainCfg, found := config.GetByChainID(chainID.Int64())
+			address, from := chainCfg.PostageStampAddress, chainCfg.PostageStampStartBlock
+			if contractAddress != "" {
+				if !common.IsHexAddress(contractAddress) {
+					return errors.New("malformed postage stamp address")
+				}
+				if startBlock == 0 {
+					return errors.New("postage contract start block option not provided")
+				}
+				address, from = common.HexToAddress(contractAddress), startBlock
+			} else if !found {
+				return errors.New("no known postage stamp addresses for this network")
+			}
+
+			if block == 0 {
+				latest, err := client.BlockNumber(ctx)
+				if err != nil {
+					return fmt.Errorf("block number: %w", err)
+				}
+				if latest < postageEventsConfirmations {
+					return errors.New("not enough blocks in the chain")
+				}
+				block = latest - postageEventsConfirmations
+			}
+
+			f, err := os.OpenFile(file, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+			if err != nil {
+				return fmt.Errorf("create events file: %w", err)
+			}
+			defer func() {
+				err = errors.Join(err, f.Close())
+			}()
+
+			h := sha256.New()
+			snapshot, err := node.ExportPostageEvents(ctx, client, address, abiutil.MustParseABI(chainCfg.PostageStampABI), from, block, io.MultiWriter(f, h))
+			if err != nil {
+				return fmt.Errorf("export postage events: %w", err)
+			}
+			cmd.Printf("exported %d postage events of blocks %d-%d to %s\n", len(snapshot.Events), snapshot.FirstBlockNumber, snapshot.LastBlockNumber, file)
+			cmd.Printf("block hash: %s\n", snapshot.LastBlockHash)
+			cmd.Printf("file hash: %x\n", h.Sum(nil))
+
+			return nil
+		},
+	}
+	c.Flags().String(optionNameBlockchainRpcEndpoint, "", "rpc blockchain endpoint")
+	c.Flags().String(optionNameStampsFile, "", "path of the created events file")
+	c.Flags().Uint64(optionNamePostageEventsBlock, 0, "last exported block number, defaults to a few blocks behind the chain tip")
+	c.Flags().String(optionNamePostageContractAddress, "", "postage stamp contract address")
+	c.Flags().Uint64(optionNamePostageContractStartBlock, 0, "postage stamp contract start block number")
+	cmd.AddCommand(c)
+}
@@ -0,0 +1,186 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethersphere/bee/v2/pkg/postage"
+	"github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/storage/leveldbstore"
+	"github.com/spf13/cobra"
+)
+
+const optionNameStampsFile = "file"
+
+func (c *command) initStampsCmd() {
+	cmd := &cobra.Command{
+		Use:   "stamps",
+		Short: "Move the stamp issuers of a stopped node to another node",
+	}
+
+	stampsExportCmd(cmd)
+	stampsImportCmd(cmd)
+	stampsRevertExportCmd(cmd)
+
+	c.root.AddCommand(cmd)
+}
+
+// openStamperStore opens the stamperstore of the data directory.
+func openStamperStore(cmd *cobra.Command) (storage.Store, error) {
+	v, err := cmd.Flags().GetString(optionNameVerbosity)
+	if err != nil {
+		return nil, fmt.Errorf("get verbosity: %w", err)
+	}
+	logger, err := newLogger(cmd, strings.ToLower(v))
+	if err != nil {
+		return nil, fmt.Errorf("new logger: %w", err)
+	}
+
+	dataDir, err := cmd.Flags().GetString(optionNameDataDir)
+	if err != nil {
+		return nil, fmt.Errorf("get data-dir: %w", err)
+	}
+	if dataDir == "" {
+		return nil, errors.New("no data-dir provided")
+	}
+
+	logger.Debug("opening stamperstore", "data_dir", dataDir)
+
+	store, err := leveldbstore.New(filepath.Join(dataDir, "stamperstore"), nil)
+	if err != nil {
+		return nil, fmt.Errorf("new stamperstore: %w", err)
+	}
+	return store, nil
+}
+
+func stampsExportCmd(cmd *cobra.Command) {
+	c := &cobra.Command{
+		Use:   "export <batch-id>",
+		Short: "Exports the stamp issuer of the batch and marks it read-only on this node",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			batchID, err := hex.DecodeString(strings.TrimPrefix(args[0], "0x"))
+			if err != nil {
+				return fmt.Errorf("invalid batch id %q: %w", args[0], err)
+			}
+			file, err := cmd.Flags().GetString(optionNameStampsFile)
+			if err != nil {
+				return fmt.Errorf("get file: %w", err)
+			}
+			if file == "" {
+				return errors.New("no file provided")
+			}
+
+			store, err := openStamperStore(cmd)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				err = errors.Join(err, store.Close())
+			}()
+
+			f, err := os.OpenFile(file, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+			if err != nil {
+				return fmt.Errorf("create export file: %w", err)
+			}
+			defer func() {
+				err = errors.Join(err, f.Close())
+			}()
+
+			if err := postage.ExportIssuer(cmd.Context(), store, batchID, f); err != nil {
+				return fmt.Errorf("export batch %x: %w", batchID, err)
+			}
+			cmd.Printf("exported batch %x to %s, the batch is read-only on this node\n", batchID, file)
+
+			return nil
+		},
+	}
+	c.Flags().String(optionNameDataDir, "", "data directory")
+	c.Flags().String(optionNameVerbosity, "info", "verbosity level")
+	c.Flags().String(optionNameStampsFile, "", "path of the created export file")
+	cmd.AddCommand(c)
+}
+
+func stampsImportCmd(cmd *cobra.Command) {
+	c := &cobra.Command{
+		Use:   "import",
+		Short: "Imports the stamp issuer exported by another node",
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			file, err := cmd.Flags().GetString(optionNameStampsFile)
+			if err != nil {
+				return fmt.Errorf("get file: %w", err)
+			}
+
+			var r io.Reader = cmd.InOrStdin()
+			if file != "" {
+				f, err := os.Open(file)
+				if err != nil {
+					return fmt.Errorf("open export file: %w", err)
+				}
+				defer f.Close()
+				r = f
+			}
+
+			store, err := openStamperStore(cmd)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				err = errors.Join(err, store.Close())
+			}()
+
+			issuer, err := postage.ImportIssuer(cmd.Context(), store, r)
+			if err != nil {
+				return fmt.Errorf("import: %w", err)
+			}
+			cmd.Printf("imported batch %x\n", issuer.ID())
+
+			return nil
+		},
+	}
+	c.Flags().String(optionNameDataDir, "", "data directory")
+	c.Flags().String(optionNameVerbosity, "info", "verbosity level")
+	c.Flags().String(optionNameStampsFile, "", "path of the export file, standard input if not set")
+	cmd.AddCommand(c)
+}
+
+func stampsRevertExportCmd(cmd *cobra.Command) {
+	c := &cobra.Command{
+		Use:   "revert-export <batch-id>",
+		Short: "Makes the exported stamp issuer of the batch writable again, when the export was not imported by another node",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			batchID, err := hex.DecodeString(strings.TrimPrefix(args[0], "0x"))
+			if err != nil {
+				return fmt.Errorf("invalid batch id %q: %w", args[0], err)
+			}
+
+			store, err := openStamperStore(cmd)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				err = errors.Join(err, store.Close())
+			}()
+
+			if err := postage.RevertExport(store, batchID); err != nil {
+				return fmt.Errorf("revert export of batch %x: %w", batchID, err)
+			}
+			cmd.Printf("reverted export of batch %x, the batch is writable on this node\n", batchID)
+
+			return nil
+		},
+	}
+	c.Flags().String(optionNameDataDir, "", "data directory")
+	c.Flags().String(optionNameVerbosity, "info", "verbosity level")
+	cmd.AddCommand(c)
+}
@@ -0,0 +1,86 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cmd_test
+
+import (
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/cmd/bee/cmd"
+	"github.com/ethersphere/bee/v2/pkg/postage"
+	"github.com/ethersphere/bee/v2/pkg/storage/leveldbstore"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+func TestStampsExportImport(t *testing.T) {
+	t.Parallel()
+
+	var (
+		sourceDir = t.TempDir()
+		targetDir = t.TempDir()
+		file      = filepath.Join(t.TempDir(), "batch.export")
+		batchID   = swarm.RandAddress(t).Bytes()
+	)
+
+	loadIssuer := func(t *testing.T, dataDir string) *postage.StampIssuer {
+		t.Helper()
+
+		store, err := leveldbstore.New(filepath.Join(dataDir, "stamperstore"), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer store.Close()
+
+		item := postage.NewStampIssuerItem(batchID)
+		if err := store.Get(item); err != nil {
+			t.Fatal(err)
+		}
+		return item.Issuer
+	}
+
+	store, err := leveldbstore.New(filepath.Join(sourceDir, "stamperstore"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	issuer := postage.NewStampIssuer("label", "", batchID, big.NewInt(3), 16, 8, 1000, true)
+	if err := store.Put(&postage.StampIssuerItem{Issuer: issuer}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	err = newCommand(t,
+		cmd.WithArgs("stamps", "export", swarm.NewAddress(batchID).String(), "--data-dir", sourceDir, "--file", file),
+	).Execute()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !loadIssuer(t, sourceDir).ReadOnly() {
+		t.Fatal("exported issuer is not read-only")
+	}
+
+	err = newCommand(t,
+		cmd.WithArgs("stamps", "import", "--data-dir", targetDir, "--file", file),
+	).Execute()
+	if err != nil {
+		t.Fatal(err)
+	}
+	imported := loadIssuer(t, targetDir)
+	if imported.ReadOnly() {
+		t.Fatal("imported issuer is read-only")
+	}
+	if imported.Label() != issuer.Label() {
+		t.Fatalf("label mismatch: want %q, got %q", issuer.Label(), imported.Label())
+	}
+
+	err = newCommand(t,
+		cmd.WithArgs("stamps", "import", "--data-dir", targetDir, "--file", file),
+	).Execute()
+	if err == nil {
+		t.Fatal("expected error importing an existing issuer")
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/crypto"
+	filekeystore "github.com/ethersphere/bee/v2/pkg/keystore/file"
+	"github.com/ethersphere/bee/v2/pkg/postage/stampsigner"
+	"github.com/spf13/cobra"
+)
+
+const (
+	optionNameStampSignerToken = "token"
+
+	// batchOwnerKeyName is the name of the key signing the stamps, kept apart
+	// from the swarm key of a node so that the signer holds no node identity.
+	batchOwnerKeyName = "batch-owner"
+)
+
+func (c *command) initStampSignerCmd() {
+	cmd := &cobra.Command{
+		Use:   "stamp-signer",
+		Short: "Serves the postage stamp signatures made with the batch owner key of the data directory",
+		Long: `Serves the postage stamp signatures made with the batch owner key of the data directory.
+
+It is a stand-in for a remote stamp signer service, such as an HSM backed one.
+The batch owner key is created on the first start. Point the postage-signer-endpoint
+option of the uploading nodes to its address and set their postage-signer-token
+option to its token. The batches are bought for the logged batch owner.`,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			v, err := cmd.Flags().GetString(optionNameVerbosity)
+			if err != nil {
+				return fmt.Errorf("get verbosity: %w", err)
+			}
+			logger, err := newLogger(cmd, strings.ToLower(v))
+			if err != nil {
+				return fmt.Errorf("new logger: %w", err)
+			}
+
+			dataDir, err := cmd.Flags().GetString(optionNameDataDir)
+			if err != nil {
+				return fmt.Errorf("get data-dir: %w", err)
+			}
+			if dataDir == "" {
+				return errors.New("no data-dir provided")
+			}
+			addr, err := cmd.Flags().GetString(optionNameAPIAddr)
+			if err != nil {
+				return fmt.Errorf("get api-addr: %w", err)
+			}
+			token, err := cmd.Flags().GetString(optionNameStampSignerToken)
+			if err != nil {
+				return fmt.Errorf("get token: %w", err)
+			}
+			if token == "" {
+				return errors.New("no token provided")
+			}
+
+			password, err := cmd.Flags().GetString(optionNamePassword)
+			if err != nil {
+				return fmt.Errorf("get password: %w", err)
+			}
+			if password == "" {
+				passwordFile, err := cmd.Flags().GetString(optionNamePasswordFile)
+				if err != nil {
+					return fmt.Errorf("get password-file: %w", err)
+				}
+				if passwordFile == "" {
+					return errors.New("no password or password-file provided")
+				}
+				b, err := os.ReadFile(passwordFile)
+				if err != nil {
+					return err
+				}
+				password = string(bytes.Trim(b, "\n"))
+			}
+
+			keystore := filekeystore.New(filepath.Join(dataDir, "keys"))
+			batchOwnerKey, created, err := keystore.Key(batchOwnerKeyName, password, crypto.EDGSecp256_K1)
+			if err != nil {
+				return fmt.Errorf("batch owner key: %w", err)
+			}
+			signer := crypto.NewDefaultSigner(batchOwnerKey)
+			owner, err := signer.EthereumAddress()
+			if err != nil {
+				return err
+			}
+			if created {
+				logger.Info("new batch owner key created", "batch_owner", owner)
+			}
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			server := &http.Server{
+				Addr:              addr,
+				Handler:           stampsigner.NewHandler(signer, token, logger),
+				ReadHeaderTimeout: 10 * time.Second,
+			}
+			errC := make(chan error, 1)
+			go func() {
+				errC <- server.ListenAndServe()
+			}()
+			logger.Info("serving stamp signatures", "address", addr, "batch_owner", owner)
+
+			select {
+			case err := <-errC:
+				return fmt.Errorf("serve: %w", err)
+			case <-ctx.Done():
+			}
+
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			return server.Shutdown(shutdownCtx)
+		},
+	}
+	cmd.Flags().String(optionNameDataDir, "", "data directory")
+	cmd.Flags().String(optionNameVerbosity, "info", "verbosity level")
+	cmd.Flags().String(optionNamePassword, "", "password for decrypting keys")
+	cmd.Flags().String(optionNamePasswordFile, "", "path to a file that contains password for decrypting keys")
+	cmd.Flags().String(optionNameAPIAddr, "127.0.0.1:1640", "HTTP listen address of the signer")
+	cmd.Flags().String(optionNameStampSignerToken, "", "bearer token the nodes are authorized with")
+
+	c.root.AddCommand(cmd)
+}
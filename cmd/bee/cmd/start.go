@@ -12,9 +12,11 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"net/url"
 	"os"
 	"os/signal"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"sync/atomic"
 	"syscall"
@@ -32,12 +34,16 @@ import (
 	"github.com/ethersphere/bee/v2/pkg/resolver/multiresolver"
 	"github.com/ethersphere/bee/v2/pkg/swarm"
 	"github.com/kardianos/service"
+	ma "github.com/multiformats/go-multiaddr"
 	"github.com/spf13/cobra"
 )
 
 const (
 	serviceName      = "SwarmBeeSvc"
 	libp2pPKFilename = "libp2p_v2"
+	// localstoreKeyPurpose separates the localstore
+	// encryption key from the other uses of the swarm key.
+	localstoreKeyPurpose = "localstore"
 )
 
 //go:embed bee-welcome-message.txt
@@ -272,6 +278,72 @@ func buildBeeNode(ctx context.Context, c *command, cmd *cobra.Command, logger lo
 		return nil, errors.New("static nodes can only be configured on bootnodes")
 	}
 
+	var privateNetworkKey []byte
+	if v := c.config.GetString(optionNameP2PPrivateNetworkKey); v != "" {
+		privateNetworkKey, err = hex.DecodeString(v)
+		if err != nil || len(privateNetworkKey) != 32 {
+			return nil, errors.New("private network key must be 32 hex encoded bytes")
+		}
+	}
+
+	allowlistOverlaysOpt := c.config.GetStringSlice(optionNameP2PAllowlistOverlays)
+	allowlistOverlays := make([]swarm.Address, 0, len(allowlistOverlaysOpt))
+	for _, p := range allowlistOverlaysOpt {
+		addr, err := swarm.ParseHexAddress(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid swarm address %q configured for allowlist", p)
+		}
+
+		allowlistOverlays = append(allowlistOverlays, addr)
+	}
+
+	allowlistUnderlaysOpt := c.config.GetStringSlice(optionNameP2PAllowlistUnderlays)
+	allowlistUnderlays := make([]ma.Multiaddr, 0, len(allowlistUnderlaysOpt))
+	for _, p := range allowlistUnderlaysOpt {
+		addr, err := ma.NewMultiaddr(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid underlay address %q configured for allowlist", p)
+		}
+
+		allowlistUnderlays = append(allowlistUnderlays, addr)
+	}
+
+	protocolBandwidthLimitsOpt := c.config.GetStringSlice(optionNameP2PProtocolBandwidthLimits)
+	protocolBandwidthLimits := make(map[string]int64, len(protocolBandwidthLimitsOpt))
+	for _, p := range protocolBandwidthLimitsOpt {
+		protocol, v, ok := strings.Cut(p, "=")
+		limit, err := strconv.ParseInt(v, 10, 64)
+		if !ok || err != nil || limit < 0 {
+			return nil, fmt.Errorf("invalid protocol bandwidth limit %q", p)
+		}
+
+		protocolBandwidthLimits[protocol] = limit
+	}
+
+	postageWebhookURLs := c.config.GetStringSlice(optionNamePostageWebhookURLs)
+	for _, v := range postageWebhookURLs {
+		u, err := url.Parse(v)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("invalid postage webhook url %q", v)
+		}
+	}
+
+	postageNotifyTTLThresholdsOpt := c.config.GetStringSlice(optionNamePostageNotifyTTLThresholds)
+	postageNotifyTTLThresholds := make([]time.Duration, 0, len(postageNotifyTTLThresholdsOpt))
+	for _, v := range postageNotifyTTLThresholdsOpt {
+		threshold, err := time.ParseDuration(v)
+		if err != nil || threshold <= 0 {
+			return nil, fmt.Errorf("invalid postage notify ttl threshold %q", v)
+		}
+
+		postageNotifyTTLThresholds = append(postageNotifyTTLThresholds, threshold)
+	}
+
+	postageNotifyBucketSaturation := c.config.GetUint(optionNamePostageNotifySaturation)
+	if postageNotifyBucketSaturation == 0 || postageNotifyBucketSaturation > 100 {
+		return nil, fmt.Errorf("invalid postage notify bucket saturation %d", postageNotifyBucketSaturation)
+	}
+
 	var neighborhoodSuggester string
 	if networkID == chaincfg.Mainnet.NetworkID {
 		neighborhoodSuggester = c.config.GetString(optionNameNeighborhoodSuggester)
@@ -290,13 +362,29 @@ func buildBeeNode(ctx context.Context, c *command, cmd *cobra.Command, logger lo
 		ChainID:                       networkConfig.chainID,
 		ChequebookEnable:              c.config.GetBool(optionNameChequebookEnable),
 		CORSAllowedOrigins:            c.config.GetStringSlice(optionCORSAllowedOrigins),
+		TopologyView:                  c.config.GetBool(optionNameTopologyView),
 		DataDir:                       c.config.GetString(optionNameDataDir),
 		DBBlockCacheCapacity:          c.config.GetUint64(optionNameDBBlockCacheCapacity),
 		DBDisableSeeksCompaction:      c.config.GetBool(optionNameDBDisableSeeksCompaction),
+		DBEncryptionKey:               signerConfig.localstoreKey,
 		DBOpenFilesLimit:              c.config.GetUint64(optionNameDBOpenFilesLimit),
+		DBScrubInterval:               c.config.GetDuration(optionNameDBScrubInterval),
+		DBScrubRate:                   c.config.GetInt(optionNameDBScrubRate),
 		DBWriteBufferSize:             c.config.GetUint64(optionNameDBWriteBufferSize),
 		EnableStorageIncentives:       c.config.GetBool(optionNameStorageIncentivesEnable),
 		EnableWS:                      c.config.GetBool(optionNameP2PWSEnable),
+		QUICAddr:                      c.config.GetString(optionNameP2PQUICAddr),
+		WebTransportAddr:              c.config.GetString(optionNameP2PWebTransportAddr),
+		EnableRelayService:            c.config.GetBool(optionNameP2PRelayEnable),
+		EnableHolePunching:            c.config.GetBool(optionNameP2PHolePunchingEnable),
+		PrivateNetworkKey:             privateNetworkKey,
+		AllowlistOverlays:             allowlistOverlays,
+		AllowlistUnderlays:            allowlistUnderlays,
+		BandwidthLimit:                c.config.GetInt64(optionNameP2PBandwidthLimit),
+		ProtocolBandwidthLimits:       protocolBandwidthLimits,
+		PeerScoreLatencyWeight:        c.config.GetFloat64(optionNamePeerScoreLatencyWeight),
+		PeerScoreHealthWeight:         c.config.GetFloat64(optionNamePeerScoreHealthWeight),
+		PeerScoreReliabilityWeight:    c.config.GetFloat64(optionNamePeerScoreReliabilityWeight),
 		FullNodeMode:                  fullNode,
 		Logger:                        logger,
 		MinimumStorageRadius:          c.config.GetUint(optionMinimumStorageRadius),
@@ -308,6 +396,14 @@ func buildBeeNode(ctx context.Context, c *command, cmd *cobra.Command, logger lo
 		PaymentTolerance:              c.config.GetInt64(optionNamePaymentTolerance),
 		PostageContractAddress:        c.config.GetString(optionNamePostageContractAddress),
 		PostageContractStartBlock:     c.config.GetUint64(optionNamePostageContractStartBlock),
+		PostageEventsFileHash:         c.config.GetString(optionNamePostageEventsFileHash),
+		PostageEventsFile:             c.config.GetString(optionNamePostageEventsFile),
+		PostageNotifyBucketSaturation: uint8(postageNotifyBucketSaturation),
+		PostageNotifyTTLThresholds:    postageNotifyTTLThresholds,
+		PostageTopUpLimit:             c.config.GetString(optionNamePostageTopUpLimit),
+		PostageSignerEndpoint:         c.config.GetString(optionNamePostageSignerEndpoint),
+		PostageSignerToken:            c.config.GetString(optionNamePostageSignerToken),
+		PostageWebhookURLs:            postageWebhookURLs,
 		PriceOracleAddress:            c.config.GetString(optionNamePriceOracleAddress),
 		RedistributionContractAddress: c.config.GetString(optionNameRedistributionAddress),
 		ReserveCapacityDoubling:       c.config.GetInt(optionReserveCapacityDoubling),
@@ -357,6 +453,7 @@ type signerConfig struct {
 	libp2pPrivateKey *ecdsa.PrivateKey
 	pssPrivateKey    *ecdsa.PrivateKey
 	session          accesscontrol.Session
+	localstoreKey    []byte
 }
 
 func (c *command) configureSigner(cmd *cobra.Command, logger log.Logger) (config *signerConfig, err error) {
@@ -411,6 +508,14 @@ func (c *command) configureSigner(cmd *cobra.Command, logger log.Logger) (config
 
 	logger.Info("swarm public key", "public_key", hex.EncodeToString(crypto.EncodeSecp256k1PublicKey(publicKey)))
 
+	var localstoreKey []byte
+	if c.config.GetBool(optionNameDBEncryption) {
+		localstoreKey, err = crypto.DeriveSymmetricKey(swarmPrivateKey, localstoreKeyPurpose)
+		if err != nil {
+			return nil, fmt.Errorf("localstore key: %w", err)
+		}
+	}
+
 	libp2pPrivateKey, created, err := keystore.Key(libp2pPKFilename, password, crypto.EDGSecp256_R1)
 	if err != nil {
 		return nil, fmt.Errorf("libp2p v2 key: %w", err)
@@ -446,6 +551,7 @@ func (c *command) configureSigner(cmd *cobra.Command, logger log.Logger) (config
 		libp2pPrivateKey: libp2pPrivateKey,
 		pssPrivateKey:    pssPrivateKey,
 		session:          session,
+		localstoreKey:    localstoreKey,
 	}, nil
 }
 
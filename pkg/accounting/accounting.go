@@ -18,6 +18,7 @@ import (
 	"github.com/ethersphere/bee/v2/pkg/log"
 	"github.com/ethersphere/bee/v2/pkg/p2p"
 	"github.com/ethersphere/bee/v2/pkg/pricing"
+	"github.com/ethersphere/bee/v2/pkg/reputation"
 	"github.com/ethersphere/bee/v2/pkg/settlement/pseudosettle"
 	"github.com/ethersphere/bee/v2/pkg/storage"
 	"github.com/ethersphere/bee/v2/pkg/swarm"
@@ -185,6 +186,8 @@ type Accounting struct {
 	lightDisconnectLimit     *big.Int
 	lightThresholdGrowStep   *big.Int
 	lightThresholdGrowChange *big.Int
+	// records the misbehavior of the peers and blocklists them
+	reputation reputation.Blocklister
 }
 
 var (
@@ -238,6 +241,7 @@ func NewAccounting(
 		lightDisconnectLimit:     percentOf(100+PaymentTolerance, lightPaymentThreshold),
 		lightThresholdGrowChange: new(big.Int).Mul(lightRefreshRate, big.NewInt(linearCheckpointNumber)),
 		lightThresholdGrowStep:   new(big.Int).Mul(lightRefreshRate, big.NewInt(linearCheckpointStep)),
+		reputation:               reputation.NewBlocklister(p2pService),
 	}, nil
 }
 
@@ -1130,7 +1134,7 @@ func (a *Accounting) NotifyRefreshmentSent(peer swarm.Address, attemptedAmount,
 		// if refreshment failed with connected peer, blocklist
 		if !errors.Is(receivedError, p2p.ErrPeerNotFound) {
 			a.metrics.AccountingDisconnectsEnforceRefreshCount.Inc()
-			_ = a.blocklist(peer, 1, "failed to refresh")
+			_ = a.blocklist(peer, 1, reputation.EventFailedRefreshment, "failed to refresh")
 		}
 		a.logger.Error(receivedError, "notifyrefreshmentsent failed to refresh")
 		return
@@ -1160,7 +1164,7 @@ func (a *Accounting) NotifyRefreshmentSent(peer swarm.Address, attemptedAmount,
 		// if expectation is not met, blocklist peer
 		a.logger.Error(nil, "accepted lower payment than expected", "pseudosettle peer", peer)
 		a.metrics.ErrRefreshmentBelowExpected.Inc()
-		_ = a.blocklist(peer, 1, "failed to meet expectation for allowance")
+		_ = a.blocklist(peer, 1, reputation.EventFailedRefreshment, "failed to meet expectation for allowance")
 		return
 	}
 
@@ -1370,6 +1374,7 @@ func (d *debitAction) Apply() error {
 	if nextBalance.Cmp(disconnectLimit) >= 0 {
 		// peer too much in debt
 		a.metrics.AccountingDisconnectsOverdrawCount.Inc()
+		a.reputation.Record(d.peer, reputation.EventAccountingDisconnect)
 
 		disconnectFor, err := a.blocklistUntil(d.peer, 1)
 		if err != nil {
@@ -1396,7 +1401,7 @@ func (d *debitAction) Cleanup() {
 	d.accountingPeer.ghostBalance = new(big.Int).Add(d.accountingPeer.ghostBalance, d.price)
 	if d.accountingPeer.ghostBalance.Cmp(d.accountingPeer.disconnectLimit) > 0 {
 		a.metrics.AccountingDisconnectsGhostOverdrawCount.Inc()
-		_ = a.blocklist(d.peer, 1, "ghost overdraw")
+		_ = a.blocklist(d.peer, 1, reputation.EventAccountingDisconnect, "ghost overdraw")
 	}
 }
 
@@ -1422,13 +1427,15 @@ func (a *Accounting) blocklistUntil(peer swarm.Address, multiplier int64) (int64
 	return kInt, nil
 }
 
-func (a *Accounting) blocklist(peer swarm.Address, multiplier int64, reason string) error {
+// blocklist records the event of the peer with the reputation,
+// which blocklists the peer for at least the time to repay its debt.
+func (a *Accounting) blocklist(peer swarm.Address, multiplier int64, event reputation.Event, reason string) error {
 	disconnectFor, err := a.blocklistUntil(peer, multiplier)
 	if err != nil {
-		return a.p2p.Blocklist(peer, 1*time.Minute, reason)
+		return a.reputation.Blocklist(peer, event, 1*time.Minute, reason)
 	}
 
-	return a.p2p.Blocklist(peer, time.Duration(disconnectFor)*time.Second, reason)
+	return a.reputation.Blocklist(peer, event, time.Duration(disconnectFor)*time.Second, reason)
 }
 
 func (a *Accounting) Connect(peer swarm.Address, fullNode bool) {
@@ -1536,6 +1543,12 @@ func (a *Accounting) Disconnect(peer swarm.Address) {
 	}
 }
 
+// SetReputation sets the reputation which records
+// the misbehaving peers and blocklists them.
+func (a *Accounting) SetReputation(r reputation.Blocklister) {
+	a.reputation = r
+}
+
 func (a *Accounting) SetRefreshFunc(f RefreshFunc) {
 	a.refreshFunction = f
 }
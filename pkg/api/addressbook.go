@@ -0,0 +1,264 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/addressbook"
+	"github.com/ethersphere/bee/v2/pkg/bzz"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"github.com/gorilla/mux"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+const addressBookMaxRequestSize = 1024 * 1024
+
+type addressBookPeer struct {
+	Overlay   swarm.Address `json:"overlay"`
+	Underlay  string        `json:"underlay"`
+	Signature string        `json:"signature"` // base64 encoded
+	Nonce     string        `json:"nonce"`     // hex encoded
+}
+
+type addressBookPeerResponse struct {
+	Overlay         swarm.Address `json:"overlay"`
+	Underlay        string        `json:"underlay"`
+	Signature       string        `json:"signature"`
+	Nonce           string        `json:"nonce"`
+	EthereumAddress string        `json:"ethereumAddress,omitempty"`
+	Connected       bool          `json:"connected"`
+	LastSeen        *time.Time    `json:"lastSeen,omitempty"`
+	FailedAttempts  int           `json:"failedAttempts"`
+}
+
+type addressBookPeersResponse struct {
+	Peers []addressBookPeerResponse `json:"peers"`
+}
+
+type addressBookImportRequest struct {
+	Peers []addressBookPeer `json:"peers"`
+}
+
+type addressBookImportResponse struct {
+	Imported int `json:"imported"`
+}
+
+type addressBookPruneResponse struct {
+	Removed int `json:"removed"`
+}
+
+func (s *Service) mapAddressBookPeer(a bzz.Address) addressBookPeerResponse {
+	resp := addressBookPeerResponse{
+		Overlay:   a.Overlay,
+		Underlay:  a.Underlay.String(),
+		Signature: base64.StdEncoding.EncodeToString(a.Signature),
+		Nonce:     hex.EncodeToString(a.Nonce),
+	}
+	if len(a.EthereumAddress) > 0 {
+		resp.EthereumAddress = hex.EncodeToString(a.EthereumAddress)
+	}
+
+	c := s.connectivity.PeerConnectivity(a.Overlay)
+	resp.Connected = c.Connected
+	resp.FailedAttempts = c.FailedAttempts
+	if !c.LastSeen.IsZero() {
+		resp.LastSeen = &c.LastSeen
+	}
+	return resp
+}
+
+// parseAddressBookPeer verifies the signature of the imported peer address.
+func (s *Service) parseAddressBookPeer(p addressBookPeer) (*bzz.Address, error) {
+	underlay, err := ma.NewMultiaddr(p.Underlay)
+	if err != nil {
+		return nil, fmt.Errorf("underlay: %w", err)
+	}
+	signature, err := base64.StdEncoding.DecodeString(p.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("signature: %w", err)
+	}
+	nonce, err := hex.DecodeString(p.Nonce)
+	if err != nil {
+		return nil, fmt.Errorf("nonce: %w", err)
+	}
+	return bzz.ParseAddress(underlay.Bytes(), p.Overlay.Bytes(), signature, nonce, true, s.networkID)
+}
+
+func (s *Service) addressBookPeersHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("get_addressbook").Build()
+
+	queries := struct {
+		Prefix string `map:"prefix" validate:"omitempty,hexadecimal"`
+	}{}
+	if response := s.mapStructure(r.URL.Query(), &queries); response != nil {
+		response("invalid query params", logger, w)
+		return
+	}
+	prefix := strings.ToLower(strings.TrimPrefix(queries.Prefix, "0x"))
+
+	addresses, err := s.addressBook.Addresses()
+	if err != nil {
+		logger.Debug("list addressbook failed", "error", err)
+		logger.Error(nil, "list addressbook failed")
+		jsonhttp.InternalServerError(w, "list addressbook failed")
+		return
+	}
+
+	resp := addressBookPeersResponse{Peers: make([]addressBookPeerResponse, 0, len(addresses))}
+	for _, a := range addresses {
+		if strings.HasPrefix(a.Overlay.String(), prefix) {
+			resp.Peers = append(resp.Peers, s.mapAddressBookPeer(a))
+		}
+	}
+
+	jsonhttp.OK(w, resp)
+}
+
+func (s *Service) addressBookPeerHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("get_addressbook_by_peer").Build()
+
+	paths := struct {
+		Address swarm.Address `map:"address" validate:"required"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	addr, err := s.addressBook.Get(paths.Address)
+	if err != nil {
+		if errors.Is(err, addressbook.ErrNotFound) {
+			jsonhttp.NotFound(w, "peer not found")
+			return
+		}
+		logger.Debug("get addressbook entry failed", "peer_address", paths.Address, "error", err)
+		logger.Error(nil, "get addressbook entry failed", "peer_address", paths.Address)
+		jsonhttp.InternalServerError(w, "get addressbook entry failed")
+		return
+	}
+
+	jsonhttp.OK(w, s.mapAddressBookPeer(*addr))
+}
+
+func (s *Service) addressBookRemoveHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("delete_addressbook_by_peer").Build()
+
+	paths := struct {
+		Address swarm.Address `map:"address" validate:"required"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	if _, err := s.addressBook.Get(paths.Address); err != nil {
+		if errors.Is(err, addressbook.ErrNotFound) {
+			jsonhttp.NotFound(w, "peer not found")
+			return
+		}
+		logger.Debug("get addressbook entry failed", "peer_address", paths.Address, "error", err)
+		logger.Error(nil, "get addressbook entry failed", "peer_address", paths.Address)
+		jsonhttp.InternalServerError(w, "get addressbook entry failed")
+		return
+	}
+
+	if err := s.addressBook.Remove(paths.Address); err != nil {
+		logger.Debug("remove addressbook entry failed", "peer_address", paths.Address, "error", err)
+		logger.Error(nil, "remove addressbook entry failed", "peer_address", paths.Address)
+		jsonhttp.InternalServerError(w, "remove addressbook entry failed")
+		return
+	}
+
+	jsonhttp.OK(w, nil)
+}
+
+// addressBookPruneHandler removes the entries of the peers which are not
+// connected and have not been connected to for the given duration.
+func (s *Service) addressBookPruneHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("delete_addressbook").Build()
+
+	queries := struct {
+		NotSeenFor uint64 `map:"notSeenFor" validate:"required"`
+	}{}
+	if response := s.mapStructure(r.URL.Query(), &queries); response != nil {
+		response("invalid query params", logger, w)
+		return
+	}
+	threshold := time.Now().Add(-time.Duration(queries.NotSeenFor) * time.Second)
+
+	overlays, err := s.addressBook.Overlays()
+	if err != nil {
+		logger.Debug("list addressbook failed", "error", err)
+		logger.Error(nil, "list addressbook failed")
+		jsonhttp.InternalServerError(w, "list addressbook failed")
+		return
+	}
+
+	resp := addressBookPruneResponse{}
+	for _, overlay := range overlays {
+		c := s.connectivity.PeerConnectivity(overlay)
+		if c.Connected || c.LastSeen.After(threshold) {
+			continue
+		}
+		if err := s.addressBook.Remove(overlay); err != nil {
+			logger.Debug("remove addressbook entry failed", "peer_address", overlay, "error", err)
+			logger.Error(nil, "remove addressbook entry failed", "peer_address", overlay)
+			jsonhttp.InternalServerError(w, "remove addressbook entry failed")
+			return
+		}
+		resp.Removed++
+	}
+
+	logger.Debug("addressbook pruned", "removed", resp.Removed, "not_seen_for", queries.NotSeenFor)
+	jsonhttp.OK(w, resp)
+}
+
+func (s *Service) addressBookImportHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("post_addressbook").Build()
+
+	var req addressBookImportRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Debug("failed to read body", "error", err)
+		jsonhttp.BadRequest(w, "invalid request body")
+		return
+	}
+
+	// all the addresses are verified before any of them is stored
+	addresses := make([]*bzz.Address, 0, len(req.Peers))
+	for _, p := range req.Peers {
+		addr, err := s.parseAddressBookPeer(p)
+		if err != nil {
+			logger.Debug("invalid peer address", "peer_address", p.Overlay, "error", err)
+			jsonhttp.BadRequest(w, fmt.Sprintf("invalid address of peer %s", p.Overlay))
+			return
+		}
+		addresses = append(addresses, addr)
+	}
+
+	overlays := make([]swarm.Address, 0, len(addresses))
+	for _, addr := range addresses {
+		if err := s.addressBook.Put(addr.Overlay, *addr); err != nil {
+			logger.Debug("store addressbook entry failed", "peer_address", addr.Overlay, "error", err)
+			logger.Error(nil, "store addressbook entry failed", "peer_address", addr.Overlay)
+			jsonhttp.InternalServerError(w, "store addressbook entry failed")
+			return
+		}
+		overlays = append(overlays, addr.Overlay)
+	}
+	if len(overlays) > 0 {
+		s.connectivity.AddPeers(overlays...)
+	}
+
+	jsonhttp.OK(w, addressBookImportResponse{Imported: len(overlays)})
+}
@@ -0,0 +1,268 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api_test
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/addressbook"
+	"github.com/ethersphere/bee/v2/pkg/api"
+	"github.com/ethersphere/bee/v2/pkg/bzz"
+	"github.com/ethersphere/bee/v2/pkg/crypto"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp/jsonhttptest"
+	statestore "github.com/ethersphere/bee/v2/pkg/statestore/mock"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"github.com/ethersphere/bee/v2/pkg/topology"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+const addressBookNetworkID = 1
+
+type connectivityMock struct {
+	mu    sync.Mutex
+	peers map[string]topology.PeerConnectivity
+	added []swarm.Address
+}
+
+func (m *connectivityMock) PeerConnectivity(addr swarm.Address) topology.PeerConnectivity {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.peers[addr.ByteString()]
+}
+
+func (m *connectivityMock) AddPeers(addrs ...swarm.Address) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.added = append(m.added, addrs...)
+}
+
+func newAddressBookAddress(t *testing.T, i int) bzz.Address {
+	t.Helper()
+
+	underlay, err := ma.NewMultiaddr("/ip4/127.0.0.1/tcp/" + strconv.Itoa(1634+i))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pk, err := crypto.GenerateSecp256k1Key()
+	if err != nil {
+		t.Fatal(err)
+	}
+	nonce := make([]byte, 32)
+	overlay, err := crypto.NewOverlayAddress(pk.PublicKey, addressBookNetworkID, nonce)
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr, err := bzz.NewAddress(crypto.NewDefaultSigner(pk), underlay, overlay, addressBookNetworkID, nonce)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return *addr
+}
+
+func newAddressBookPeer(a bzz.Address) api.AddressBookPeer {
+	return api.AddressBookPeer{
+		Overlay:   a.Overlay,
+		Underlay:  a.Underlay.String(),
+		Signature: base64.StdEncoding.EncodeToString(a.Signature),
+		Nonce:     hex.EncodeToString(a.Nonce),
+	}
+}
+
+func newAddressBookServer(t *testing.T, addresses []bzz.Address, conn *connectivityMock) (*http.Client, addressbook.Interface) {
+	t.Helper()
+
+	ab := addressbook.New(statestore.NewStateStore())
+	for _, a := range addresses {
+		if err := ab.Put(a.Overlay, a); err != nil {
+			t.Fatal(err)
+		}
+	}
+	client, _, _, _ := newTestServer(t, testServerOptions{
+		AddressBook:  ab,
+		Connectivity: conn,
+		NetworkID:    addressBookNetworkID,
+	})
+	return client, ab
+}
+
+func TestAddressBook(t *testing.T) {
+	t.Parallel()
+
+	var (
+		connected = newAddressBookAddress(t, 0)
+		seen      = newAddressBookAddress(t, 1)
+		stale     = newAddressBookAddress(t, 2)
+		lastSeen  = time.Unix(time.Now().Unix(), 0).UTC()
+	)
+	newConnectivity := func() *connectivityMock {
+		return &connectivityMock{peers: map[string]topology.PeerConnectivity{
+			connected.Overlay.ByteString(): {Connected: true, LastSeen: lastSeen},
+			seen.Overlay.ByteString():      {LastSeen: lastSeen, FailedAttempts: 1},
+			stale.Overlay.ByteString():     {LastSeen: lastSeen.Add(-48 * time.Hour), FailedAttempts: 3},
+		}}
+	}
+	all := []bzz.Address{connected, seen, stale}
+
+	t.Run("list", func(t *testing.T) {
+		t.Parallel()
+
+		client, _ := newAddressBookServer(t, all, newConnectivity())
+
+		var resp api.AddressBookPeersResponse
+		jsonhttptest.Request(t, client, http.MethodGet, "/addressbook", http.StatusOK,
+			jsonhttptest.WithUnmarshalJSONResponse(&resp),
+		)
+		if len(resp.Peers) != len(all) {
+			t.Fatalf("got %d peers, want %d", len(resp.Peers), len(all))
+		}
+		for _, p := range resp.Peers {
+			if !p.Overlay.Equal(stale.Overlay) {
+				continue
+			}
+			if p.Connected || p.FailedAttempts != 3 || p.LastSeen == nil || !p.LastSeen.Equal(lastSeen.Add(-48*time.Hour)) {
+				t.Fatalf("got stale peer %+v", p)
+			}
+			if p.Underlay != stale.Underlay.String() {
+				t.Fatalf("got underlay %s, want %s", p.Underlay, stale.Underlay)
+			}
+		}
+	})
+
+	t.Run("list with prefix", func(t *testing.T) {
+		t.Parallel()
+
+		client, _ := newAddressBookServer(t, all, newConnectivity())
+
+		var resp api.AddressBookPeersResponse
+		jsonhttptest.Request(t, client, http.MethodGet, "/addressbook?prefix="+seen.Overlay.String()[:8], http.StatusOK,
+			jsonhttptest.WithUnmarshalJSONResponse(&resp),
+		)
+		if len(resp.Peers) != 1 || !resp.Peers[0].Overlay.Equal(seen.Overlay) {
+			t.Fatalf("got peers %+v, want %s", resp.Peers, seen.Overlay)
+		}
+	})
+
+	t.Run("peer", func(t *testing.T) {
+		t.Parallel()
+
+		client, _ := newAddressBookServer(t, all, newConnectivity())
+
+		jsonhttptest.Request(t, client, http.MethodGet, "/addressbook/"+connected.Overlay.String(), http.StatusOK,
+			jsonhttptest.WithExpectedJSONResponse(api.AddressBookPeerResponse{
+				Overlay:         connected.Overlay,
+				Underlay:        connected.Underlay.String(),
+				Signature:       base64.StdEncoding.EncodeToString(connected.Signature),
+				Nonce:           hex.EncodeToString(connected.Nonce),
+				EthereumAddress: hex.EncodeToString(connected.EthereumAddress),
+				Connected:       true,
+				LastSeen:        &lastSeen,
+			}),
+		)
+
+		jsonhttptest.Request(t, client, http.MethodGet, "/addressbook/"+swarm.RandAddress(t).String(), http.StatusNotFound,
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Message: "peer not found",
+				Code:    http.StatusNotFound,
+			}),
+		)
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		t.Parallel()
+
+		client, ab := newAddressBookServer(t, all, newConnectivity())
+
+		jsonhttptest.Request(t, client, http.MethodDelete, "/addressbook/"+seen.Overlay.String(), http.StatusOK)
+		if _, err := ab.Get(seen.Overlay); err == nil {
+			t.Fatal("peer not removed")
+		}
+
+		jsonhttptest.Request(t, client, http.MethodDelete, "/addressbook/"+seen.Overlay.String(), http.StatusNotFound)
+	})
+
+	t.Run("prune", func(t *testing.T) {
+		t.Parallel()
+
+		client, ab := newAddressBookServer(t, all, newConnectivity())
+
+		jsonhttptest.Request(t, client, http.MethodDelete, "/addressbook", http.StatusBadRequest)
+
+		jsonhttptest.Request(t, client, http.MethodDelete, "/addressbook?notSeenFor=86400", http.StatusOK,
+			jsonhttptest.WithExpectedJSONResponse(api.AddressBookPruneResponse{Removed: 1}),
+		)
+		overlays, err := ab.Overlays()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(overlays) != 2 {
+			t.Fatalf("got %d peers, want 2", len(overlays))
+		}
+		if _, err := ab.Get(stale.Overlay); err == nil {
+			t.Fatal("stale peer not pruned")
+		}
+	})
+
+	t.Run("import", func(t *testing.T) {
+		t.Parallel()
+
+		conn := newConnectivity()
+		client, ab := newAddressBookServer(t, nil, conn)
+
+		jsonhttptest.Request(t, client, http.MethodPost, "/addressbook", http.StatusOK,
+			jsonhttptest.WithJSONRequestBody(api.AddressBookImportRequest{
+				Peers: []api.AddressBookPeer{newAddressBookPeer(connected), newAddressBookPeer(seen)},
+			}),
+			jsonhttptest.WithExpectedJSONResponse(api.AddressBookImportResponse{Imported: 2}),
+		)
+		for _, a := range []bzz.Address{connected, seen} {
+			got, err := ab.Get(a.Overlay)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !got.Equal(&a) {
+				t.Fatalf("got address %s, want %s", got, a)
+			}
+		}
+		if len(conn.added) != 2 {
+			t.Fatalf("got %d peers added to the topology, want 2", len(conn.added))
+		}
+	})
+
+	t.Run("import invalid", func(t *testing.T) {
+		t.Parallel()
+
+		conn := newConnectivity()
+		client, ab := newAddressBookServer(t, nil, conn)
+
+		forged := newAddressBookPeer(stale)
+		forged.Underlay = connected.Underlay.String()
+
+		jsonhttptest.Request(t, client, http.MethodPost, "/addressbook", http.StatusBadRequest,
+			jsonhttptest.WithJSONRequestBody(api.AddressBookImportRequest{
+				Peers: []api.AddressBookPeer{newAddressBookPeer(seen), forged},
+			}),
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Message: "invalid address of peer " + stale.Overlay.String(),
+				Code:    http.StatusBadRequest,
+			}),
+		)
+		overlays, err := ab.Overlays()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(overlays) != 0 || len(conn.added) != 0 {
+			t.Fatalf("got %d stored and %d added peers, want none", len(overlays), len(conn.added))
+		}
+	})
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethersphere/bee/v2/pkg/accesscontrol"
 	"github.com/ethersphere/bee/v2/pkg/accounting"
+	"github.com/ethersphere/bee/v2/pkg/addressbook"
 	"github.com/ethersphere/bee/v2/pkg/crypto"
 	"github.com/ethersphere/bee/v2/pkg/feeds"
 	"github.com/ethersphere/bee/v2/pkg/file/pipeline"
@@ -39,8 +40,12 @@ import (
 	"github.com/ethersphere/bee/v2/pkg/p2p"
 	"github.com/ethersphere/bee/v2/pkg/pingpong"
 	"github.com/ethersphere/bee/v2/pkg/postage"
+	"github.com/ethersphere/bee/v2/pkg/postage/migration"
+	"github.com/ethersphere/bee/v2/pkg/postage/notifier"
+	"github.com/ethersphere/bee/v2/pkg/postage/policy"
 	"github.com/ethersphere/bee/v2/pkg/postage/postagecontract"
 	"github.com/ethersphere/bee/v2/pkg/pss"
+	"github.com/ethersphere/bee/v2/pkg/reputation"
 	"github.com/ethersphere/bee/v2/pkg/resolver"
 	"github.com/ethersphere/bee/v2/pkg/resolver/client/ens"
 	"github.com/ethersphere/bee/v2/pkg/sctx"
@@ -81,7 +86,9 @@ const (
 	SwarmOnlyRootChunk                = "Swarm-Only-Root-Chunk"
 	SwarmCollectionHeader             = "Swarm-Collection"
 	SwarmPostageBatchIdHeader         = "Swarm-Postage-Batch-Id"
+	SwarmPostageBatchPoolHeader       = "Swarm-Postage-Batch-Pool"
 	SwarmPostageStampHeader           = "Swarm-Postage-Stamp"
+	SwarmPostageStampIndexHeader      = "Swarm-Postage-Stamp-Index"
 	SwarmDeferredUploadHeader         = "Swarm-Deferred-Upload"
 	SwarmRedundancyLevelHeader        = "Swarm-Redundancy-Level"
 	SwarmRedundancyStrategyHeader     = "Swarm-Redundancy-Strategy"
@@ -129,7 +136,8 @@ var (
 	errActUpload                        = errors.New("act upload failed")
 	errActGranteeList                   = errors.New("failed to create or update grantee list")
 
-	batchIdOrStampSig = fmt.Sprintf("Either '%s' or '%s' header must be set in the request", SwarmPostageStampHeader, SwarmPostageBatchIdHeader)
+	batchIdOrStampSig        = fmt.Sprintf("Either '%s' or '%s' header must be set in the request", SwarmPostageStampHeader, SwarmPostageBatchIdHeader)
+	stampIndexWithoutBatchID = fmt.Sprintf("'%s' header requires the '%s' header", SwarmPostageStampIndexHeader, SwarmPostageBatchIdHeader)
 )
 
 // Storer interface provides the functionality required from the local storage
@@ -143,6 +151,51 @@ type Storer interface {
 	storer.RadiusChecker
 	storer.Debugger
 	storer.NeighborhoodStats
+	storer.Backuper
+	storer.UsageAnalyzer
+}
+
+// Reputation reports and resets the reputation of peers.
+type Reputation interface {
+	Peers() []reputation.Peer
+	Peer(addr swarm.Address) reputation.Peer
+	Reset(addr swarm.Address) error
+}
+
+// PeerConnectivity reports the connectivity of the known peers
+// and adds the imported peers to the topology.
+type PeerConnectivity interface {
+	PeerConnectivity(addr swarm.Address) topology.PeerConnectivity
+	AddPeers(addrs ...swarm.Address)
+}
+
+// TopologyHistory reports the rolling history of the topology.
+type TopologyHistory interface {
+	History(since time.Time) topology.History
+}
+
+// PostagePolicy manages the top-up and dilution rules of the owned batches.
+type PostagePolicy interface {
+	SetRules(batchID []byte, r policy.Rules) error
+	Status(batchID []byte) (policy.Status, error)
+	RemoveRules(batchID []byte) error
+}
+
+// BatchMigrator re-stamps the content of the batches with other batches.
+type BatchMigrator interface {
+	Start(oldBatchID []byte, stamper postage.Stamper, save func() error) (migration.Status, error)
+	Status(oldBatchID []byte) (migration.Status, error)
+	Cancel(oldBatchID []byte) error
+}
+
+// PostageNotifier publishes the events of the owned batches.
+type PostageNotifier interface {
+	Subscribe() (<-chan notifier.Event, func())
+}
+
+// BandwidthReporter reports the bandwidth usage of the p2p protocols.
+type BandwidthReporter interface {
+	BandwidthUsage() []p2p.ProtocolBandwidth
 }
 
 type PinIntegrity interface {
@@ -160,9 +213,13 @@ type Service struct {
 	tracer          *tracing.Tracer
 	feedFactory     feeds.Factory
 	signer          crypto.Signer
+	stampSigner     postage.Signer
 	post            postage.Service
 	accesscontrol   accesscontrol.Controller
 	postageContract postagecontract.Interface
+	postagePolicy   PostagePolicy
+	batchMigrator   BatchMigrator
+	postageNotifier PostageNotifier
 	probe           *Probe
 	metricsRegistry *prometheus.Registry
 	stakingContract staking.Contract
@@ -184,16 +241,22 @@ type Service struct {
 	swapEnabled       bool
 	fullAPIEnabled    bool
 
-	topologyDriver topology.Driver
-	p2p            p2p.DebugService
-	accounting     accounting.Interface
-	chequebook     chequebook.Service
-	pseudosettle   settlement.Interface
-	pingpong       pingpong.Interface
+	topologyDriver  topology.Driver
+	topologyHistory TopologyHistory
+	addressBook     addressbook.Interface
+	connectivity    PeerConnectivity
+	networkID       uint64
+	p2p             p2p.DebugService
+	accounting      accounting.Interface
+	chequebook      chequebook.Service
+	pseudosettle    settlement.Interface
+	pingpong        pingpong.Interface
 
 	batchStore   postage.Storer
 	stamperStore storage.Store
 	pinIntegrity PinIntegrity
+	reputation   Reputation
+	bandwidth    BandwidthReporter
 
 	syncStatus func() (bool, error)
 
@@ -244,6 +307,7 @@ func (s *Service) SetRedistributionAgent(redistributionAgent *storageincentives.
 type Options struct {
 	CORSAllowedOrigins []string
 	WsPingPeriod       time.Duration
+	TopologyView       bool
 }
 
 type ExtraOptions struct {
@@ -263,11 +327,21 @@ type ExtraOptions struct {
 	Post            postage.Service
 	AccessControl   accesscontrol.Controller
 	PostageContract postagecontract.Interface
+	PostagePolicy   PostagePolicy
+	BatchMigrator   BatchMigrator
+	PostageNotifier PostageNotifier
+	StampSigner     postage.Signer
 	Staking         staking.Contract
 	Steward         steward.Interface
 	SyncStatus      func() (bool, error)
 	NodeStatus      *status.Service
 	PinIntegrity    PinIntegrity
+	Reputation      Reputation
+	Bandwidth       BandwidthReporter
+	TopologyHistory TopologyHistory
+	AddressBook     addressbook.Interface
+	Connectivity    PeerConnectivity
+	NetworkID       uint64
 }
 
 func New(
@@ -347,6 +421,13 @@ func (s *Service) Configure(signer crypto.Signer, tracer *tracing.Tracer, o Opti
 	s.post = e.Post
 	s.accesscontrol = e.AccessControl
 	s.postageContract = e.PostageContract
+	s.postagePolicy = e.PostagePolicy
+	s.batchMigrator = e.BatchMigrator
+	s.postageNotifier = e.PostageNotifier
+	s.stampSigner = signer
+	if e.StampSigner != nil {
+		s.stampSigner = e.StampSigner
+	}
 	s.steward = e.Steward
 	s.stakingContract = e.Staking
 
@@ -382,6 +463,12 @@ func (s *Service) Configure(signer crypto.Signer, tracer *tracing.Tracer, o Opti
 	}
 
 	s.pinIntegrity = e.PinIntegrity
+	s.reputation = e.Reputation
+	s.bandwidth = e.Bandwidth
+	s.topologyHistory = e.TopologyHistory
+	s.addressBook = e.AddressBook
+	s.connectivity = e.Connectivity
+	s.networkID = e.NetworkID
 }
 
 func (s *Service) SetProbe(probe *Probe) {
@@ -578,7 +665,7 @@ func (s *Service) corsHandler(h http.Handler) http.Handler {
 		"User-Agent", "Accept", "X-Requested-With", "Access-Control-Request-Headers", "Access-Control-Request-Method", "Accept-Ranges", "Content-Encoding",
 		AuthorizationHeader, AcceptEncodingHeader, ContentTypeHeader, ContentDispositionHeader, RangeHeader, OriginHeader,
 		SwarmTagHeader, SwarmPinHeader, SwarmEncryptHeader, SwarmIndexDocumentHeader, SwarmErrorDocumentHeader, SwarmCollectionHeader,
-		SwarmPostageBatchIdHeader, SwarmPostageStampHeader, SwarmDeferredUploadHeader, SwarmRedundancyLevelHeader,
+		SwarmPostageBatchIdHeader, SwarmPostageBatchPoolHeader, SwarmPostageStampHeader, SwarmPostageStampIndexHeader, SwarmDeferredUploadHeader, SwarmRedundancyLevelHeader,
 		SwarmRedundancyStrategyHeader, SwarmRedundancyFallbackModeHeader, SwarmChunkRetrievalTimeoutHeader, SwarmLookAheadBufferSizeHeader,
 		SwarmFeedIndexHeader, SwarmFeedIndexNextHeader, SwarmSocSignatureHeader, SwarmOnlyRootChunk, GasPriceHeader, GasLimitHeader, ImmutableHeader,
 		SwarmActHeader, SwarmActTimestampHeader, SwarmActPublisherHeader, SwarmActHistoryAddressHeader,
@@ -726,16 +813,23 @@ func equalASCIIFold(s, t string) bool {
 }
 
 type putterOptions struct {
-	BatchID  []byte
-	TagID    uint64
-	Deferred bool
-	Pin      bool
+	BatchID    []byte
+	BatchPool  string // label of the batches the stamps are issued from when BatchID is not set
+	TagID      uint64
+	Deferred   bool
+	Pin        bool
+	StampIndex *uint32 // within-bucket index overwritten by the stamps of the mutable batch
 }
 
 type putterSessionWrapper struct {
 	storer.PutterSession
 	stamper postage.Stamper
 	save    func() error
+
+	store   storage.Store // store the uploads of the batches are recorded in
+	tagID   uint64
+	mu      sync.Mutex
+	uploads map[string]*postage.Upload // stamped chunks per batch since the last Done
 }
 
 func (p *putterSessionWrapper) Put(ctx context.Context, chunk swarm.Chunk) error {
@@ -748,11 +842,55 @@ func (p *putterSessionWrapper) Put(ctx context.Context, chunk swarm.Chunk) error
 	if err != nil {
 		return err
 	}
-	return p.PutterSession.Put(ctx, chunk.WithStamp(stamp))
+	if err := p.PutterSession.Put(ctx, chunk.WithStamp(stamp)); err != nil {
+		return err
+	}
+	if p.uploads == nil {
+		// the presigned stamps are not of the batches of this node
+		return nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	upload, ok := p.uploads[string(stamp.BatchID())]
+	if !ok {
+		upload = new(postage.Upload)
+		p.uploads[string(stamp.BatchID())] = upload
+	}
+	upload.Chunks++
+	upload.Size += uint64(len(chunk.Data()))
+	return nil
 }
 
+// Done records the root reference of the upload with each batch
+// which stamped its chunks and saves the stamp issuers. The upload
+// is not recorded if the session failed to complete.
 func (p *putterSessionWrapper) Done(ref swarm.Address) error {
-	return errors.Join(p.PutterSession.Done(ref), p.save())
+	if err := p.PutterSession.Done(ref); err != nil {
+		return errors.Join(err, p.save())
+	}
+	return errors.Join(p.recordUploads(ref), p.save())
+}
+
+func (p *putterSessionWrapper) recordUploads(ref swarm.Address) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if ref.IsZero() || len(p.uploads) == 0 {
+		return nil
+	}
+
+	now := time.Now().UnixNano()
+	var errs []error
+	for batchID, upload := range p.uploads {
+		upload.Reference = ref
+		upload.TagID = p.tagID
+		upload.Timestamp = now
+		errs = append(errs, postage.RecordUpload(p.store, []byte(batchID), *upload))
+	}
+	clear(p.uploads)
+	return errors.Join(errs...)
 }
 
 func (p *putterSessionWrapper) Cleanup() error {
@@ -760,6 +898,24 @@ func (p *putterSessionWrapper) Cleanup() error {
 }
 
 func (s *Service) getStamper(batchID []byte) (postage.Stamper, func() error, error) {
+	issuer, save, err := s.getStampIssuer(batchID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return postage.NewStamper(s.stamperStore, issuer, s.stampSigner), save, nil
+}
+
+// getIndexStamper returns the stamper which stamps the chunks
+// with the given index of their bucket of the mutable batch.
+func (s *Service) getIndexStamper(batchID []byte, index uint32) (postage.Stamper, func() error, error) {
+	issuer, save, err := s.getStampIssuer(batchID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return postage.NewIndexStamper(s.stamperStore, issuer, s.stampSigner, index), save, nil
+}
+
+func (s *Service) getStampIssuer(batchID []byte) (*postage.StampIssuer, func() error, error) {
 	exists, err := s.batchStore.Exists(batchID)
 	if err != nil {
 		return nil, nil, fmt.Errorf("batch exists: %w", err)
@@ -770,11 +926,64 @@ func (s *Service) getStamper(batchID []byte) (postage.Stamper, func() error, err
 		return nil, nil, fmt.Errorf("stamp issuer: %w", err)
 	}
 
-	if usable := exists && s.post.IssuerUsable(issuer); !usable {
+	if usable := exists && s.post.IssuerUsable(issuer) && !issuer.ReadOnly(); !usable {
 		return nil, nil, errBatchUnusable
 	}
 
-	return postage.NewStamper(s.stamperStore, issuer, s.signer), save, nil
+	return issuer, save, nil
+}
+
+// getPoolStamper returns the stamper which issues the stamps from the
+// usable batches labeled with the given pool label.
+func (s *Service) getPoolStamper(pool string) (postage.Stamper, func() error, error) {
+	var (
+		issuers []*postage.StampIssuer
+		saves   []func() error
+		found   bool
+	)
+	for _, issuer := range s.post.StampIssuers() {
+		if issuer.Label() != pool {
+			continue
+		}
+		found = true
+
+		if issuer.ReadOnly() {
+			continue
+		}
+
+		exists, err := s.batchStore.Exists(issuer.ID())
+		if err != nil {
+			return nil, nil, fmt.Errorf("batch exists: %w", err)
+		}
+		if !exists {
+			continue
+		}
+		usable, save, err := s.post.GetStampIssuer(issuer.ID())
+		if errors.Is(err, postage.ErrNotUsable) {
+			continue
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("stamp issuer: %w", err)
+		}
+		issuers = append(issuers, usable)
+		saves = append(saves, save)
+	}
+
+	switch {
+	case !found:
+		return nil, nil, fmt.Errorf("batch pool %q: %w", pool, postage.ErrNotFound)
+	case len(issuers) == 0:
+		return nil, nil, errBatchUnusable
+	}
+
+	save := func() error {
+		var errs []error
+		for _, save := range saves {
+			errs = append(errs, save())
+		}
+		return errors.Join(errs...)
+	}
+	return postage.NewPoolStamper(s.stamperStore, issuers, s.stampSigner), save, nil
 }
 
 func (s *Service) newStamperPutter(ctx context.Context, opts putterOptions) (storer.PutterSession, error) {
@@ -782,7 +991,19 @@ func (s *Service) newStamperPutter(ctx context.Context, opts putterOptions) (sto
 		return nil, errUnsupportedDevNodeOperation
 	}
 
-	stamper, save, err := s.getStamper(opts.BatchID)
+	var (
+		stamper postage.Stamper
+		save    func() error
+		err     error
+	)
+	switch {
+	case len(opts.BatchID) == 0 && opts.BatchPool != "":
+		stamper, save, err = s.getPoolStamper(opts.BatchPool)
+	case opts.StampIndex != nil:
+		stamper, save, err = s.getIndexStamper(opts.BatchID, *opts.StampIndex)
+	default:
+		stamper, save, err = s.getStamper(opts.BatchID)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("get stamper: %w", err)
 	}
@@ -802,6 +1023,9 @@ func (s *Service) newStamperPutter(ctx context.Context, opts putterOptions) (sto
 		PutterSession: session,
 		stamper:       stamper,
 		save:          save,
+		store:         s.stamperStore,
+		tagID:         opts.TagID,
+		uploads:       make(map[string]*postage.Upload),
 	}, nil
 }
 
@@ -25,6 +25,7 @@ import (
 	"github.com/ethersphere/bee/v2/pkg/accesscontrol"
 	mockac "github.com/ethersphere/bee/v2/pkg/accesscontrol/mock"
 	accountingmock "github.com/ethersphere/bee/v2/pkg/accounting/mock"
+	"github.com/ethersphere/bee/v2/pkg/addressbook"
 	"github.com/ethersphere/bee/v2/pkg/api"
 	"github.com/ethersphere/bee/v2/pkg/crypto"
 	"github.com/ethersphere/bee/v2/pkg/feeds"
@@ -101,6 +102,10 @@ type testServerOptions struct {
 	Feeds              feeds.Factory
 	CORSAllowedOrigins []string
 	PostageContract    postagecontract.Interface
+	PostagePolicy      api.PostagePolicy
+	BatchMigrator      api.BatchMigrator
+	PostageNotifier    api.PostageNotifier
+	StampSigner        postage.Signer
 	StakingContract    staking.Contract
 	Post               postage.Service
 	AccessControl      accesscontrol.Controller
@@ -131,6 +136,13 @@ type testServerOptions struct {
 	RedistributionAgent *storageincentives.Agent
 	NodeStatus          *status.Service
 	PinIntegrity        api.PinIntegrity
+	Reputation          api.Reputation
+	Bandwidth           api.BandwidthReporter
+	TopologyHistory     api.TopologyHistory
+	AddressBook         addressbook.Interface
+	Connectivity        api.PeerConnectivity
+	NetworkID           uint64
+	TopologyView        bool
 	WhitelistedAddr     string
 	FullAPIDisabled     bool
 	ChequebookDisabled  bool
@@ -200,11 +212,21 @@ func newTestServer(t *testing.T, o testServerOptions) (*http.Client, *websocket.
 		Post:            o.Post,
 		AccessControl:   o.AccessControl,
 		PostageContract: o.PostageContract,
+		PostagePolicy:   o.PostagePolicy,
+		BatchMigrator:   o.BatchMigrator,
+		PostageNotifier: o.PostageNotifier,
+		StampSigner:     o.StampSigner,
 		Steward:         o.Steward,
 		SyncStatus:      o.SyncStatus,
 		Staking:         o.StakingContract,
 		NodeStatus:      o.NodeStatus,
 		PinIntegrity:    o.PinIntegrity,
+		Reputation:      o.Reputation,
+		Bandwidth:       o.Bandwidth,
+		TopologyHistory: o.TopologyHistory,
+		AddressBook:     o.AddressBook,
+		Connectivity:    o.Connectivity,
+		NetworkID:       o.NetworkID,
 	}
 
 	// By default bee mode is set to full mode.
@@ -234,6 +256,7 @@ func newTestServer(t *testing.T, o testServerOptions) (*http.Client, *websocket.
 	s.Configure(signer, noOpTracer, api.Options{
 		CORSAllowedOrigins: o.CORSAllowedOrigins,
 		WsPingPeriod:       o.WsPingPeriod,
+		TopologyView:       o.TopologyView,
 	}, extraOpts, 1, erc20)
 
 	s.Mount()
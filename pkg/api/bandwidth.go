@@ -0,0 +1,44 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+)
+
+type protocolBandwidthResponse struct {
+	Protocol string  `json:"protocol"`
+	Priority string  `json:"priority"`
+	Limit    int64   `json:"limit"`
+	In       uint64  `json:"in"`
+	Out      uint64  `json:"out"`
+	InRate   float64 `json:"inRate"`
+	OutRate  float64 `json:"outRate"`
+}
+
+type bandwidthResponse struct {
+	Protocols []protocolBandwidthResponse `json:"protocols"`
+}
+
+func (s *Service) bandwidthHandler(w http.ResponseWriter, _ *http.Request) {
+	usage := s.bandwidth.BandwidthUsage()
+
+	resp := bandwidthResponse{Protocols: make([]protocolBandwidthResponse, 0, len(usage))}
+	for _, u := range usage {
+		resp.Protocols = append(resp.Protocols, protocolBandwidthResponse{
+			Protocol: u.Protocol,
+			Priority: u.Priority,
+			Limit:    u.Limit,
+			In:       u.In,
+			Out:      u.Out,
+			InRate:   u.InRate,
+			OutRate:  u.OutRate,
+		})
+	}
+
+	jsonhttp.OK(w, resp)
+}
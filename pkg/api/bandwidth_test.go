@@ -0,0 +1,40 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/api"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp/jsonhttptest"
+	"github.com/ethersphere/bee/v2/pkg/p2p"
+)
+
+type bandwidthReporter []p2p.ProtocolBandwidth
+
+func (b bandwidthReporter) BandwidthUsage() []p2p.ProtocolBandwidth {
+	return b
+}
+
+func TestBandwidth(t *testing.T) {
+	t.Parallel()
+
+	client, _, _, _ := newTestServer(t, testServerOptions{
+		Bandwidth: bandwidthReporter{
+			{Protocol: "pullsync", Priority: "low", Limit: 1000, In: 10, Out: 20, InRate: 1.5, OutRate: 2.5},
+			{Protocol: "retrieval", Priority: "high", In: 30, Out: 40},
+		},
+	})
+
+	jsonhttptest.Request(t, client, http.MethodGet, "/bandwidth", http.StatusOK,
+		jsonhttptest.WithExpectedJSONResponse(api.BandwidthResponse{
+			Protocols: []api.ProtocolBandwidthResponse{
+				{Protocol: "pullsync", Priority: "low", Limit: 1000, In: 10, Out: 20, InRate: 1.5, OutRate: 2.5},
+				{Protocol: "retrieval", Priority: "high", In: 30, Out: 40},
+			},
+		}),
+	)
+}
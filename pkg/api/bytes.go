@@ -35,7 +35,8 @@ func (s *Service) bytesUploadHandler(w http.ResponseWriter, r *http.Request) {
 	defer span.Finish()
 
 	headers := struct {
-		BatchID        []byte           `map:"Swarm-Postage-Batch-Id" validate:"required"`
+		BatchID        []byte           `map:"Swarm-Postage-Batch-Id" validate:"required_without=BatchPool"`
+		BatchPool      string           `map:"Swarm-Postage-Batch-Pool"`
 		SwarmTag       uint64           `map:"Swarm-Tag"`
 		Pin            bool             `map:"Swarm-Pin"`
 		Deferred       *bool            `map:"Swarm-Deferred-Upload"`
@@ -75,10 +76,11 @@ func (s *Service) bytesUploadHandler(w http.ResponseWriter, r *http.Request) {
 	defer s.observeUploadSpeed(w, r, time.Now(), "bytes", deferred)
 
 	putter, err := s.newStamperPutter(ctx, putterOptions{
-		BatchID:  headers.BatchID,
-		TagID:    tag,
-		Pin:      headers.Pin,
-		Deferred: deferred,
+		BatchID:   headers.BatchID,
+		BatchPool: headers.BatchPool,
+		TagID:     tag,
+		Pin:       headers.Pin,
+		Deferred:  deferred,
 	})
 	if err != nil {
 		logger.Debug("get putter failed", "error", err)
@@ -8,6 +8,7 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"math/big"
 	"net/http"
 	"strconv"
 	"testing"
@@ -16,6 +17,7 @@ import (
 	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
 	"github.com/ethersphere/bee/v2/pkg/jsonhttp/jsonhttptest"
 	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/postage"
 	mockbatchstore "github.com/ethersphere/bee/v2/pkg/postage/batchstore/mock"
 	mockpost "github.com/ethersphere/bee/v2/pkg/postage/mock"
 	mockstorer "github.com/ethersphere/bee/v2/pkg/storer/mock"
@@ -275,6 +277,68 @@ func TestBytesInvalidStamp(t *testing.T) {
 	})
 }
 
+func TestBytesUploadBatchPool(t *testing.T) {
+	t.Parallel()
+
+	var (
+		first  = postage.NewStampIssuer("pool", "", swarm.RandAddress(t).Bytes(), big.NewInt(3), 24, 6, 1000, false)
+		second = postage.NewStampIssuer("pool", "", swarm.RandAddress(t).Bytes(), big.NewInt(3), 24, 6, 1000, false)
+		other  = postage.NewStampIssuer("other", "", swarm.RandAddress(t).Bytes(), big.NewInt(3), 24, 6, 1000, false)
+		mp     = mockpost.New()
+	)
+	for _, issuer := range []*postage.StampIssuer{first, second, other} {
+		if err := mp.Add(issuer); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	client, _, _, _ := newTestServer(t, testServerOptions{
+		Storer: mockstorer.New(),
+		Post:   mp,
+	})
+
+	g := mockbytes.New(0, mockbytes.MockTypeStandard).WithModulus(255)
+	content, err := g.SequentialBytes(swarm.ChunkSize * 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("upload", func(t *testing.T) {
+		jsonhttptest.Request(t, client, http.MethodPost, "/bytes", http.StatusCreated,
+			jsonhttptest.WithRequestHeader(api.SwarmDeferredUploadHeader, "true"),
+			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchPoolHeader, "pool"),
+			jsonhttptest.WithRequestBody(bytes.NewReader(content)),
+		)
+
+		var stamped uint32
+		for _, issuer := range []*postage.StampIssuer{first, second} {
+			for _, count := range issuer.Buckets() {
+				stamped += count
+			}
+		}
+		if stamped == 0 {
+			t.Fatal("expected chunks to be stamped by pool batches")
+		}
+		for _, count := range other.Buckets() {
+			if count != 0 {
+				t.Fatal("batch outside of the pool was used")
+			}
+		}
+	})
+
+	t.Run("unknown pool", func(t *testing.T) {
+		jsonhttptest.Request(t, client, http.MethodPost, "/bytes", http.StatusNotFound,
+			jsonhttptest.WithRequestHeader(api.SwarmDeferredUploadHeader, "true"),
+			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchPoolHeader, "missing"),
+			jsonhttptest.WithRequestBody(bytes.NewReader(content)),
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Message: "batch with id not found",
+				Code:    http.StatusNotFound,
+			}),
+		)
+	})
+}
+
 func TestBytesUploadHandlerInvalidInputs(t *testing.T) {
 	t.Parallel()
 
@@ -298,7 +362,7 @@ func TestBytesUploadHandlerInvalidInputs(t *testing.T) {
 				Reasons: []jsonhttp.Reason{
 					{
 						Field: "swarm-postage-batch-id",
-						Error: "want required:",
+						Error: "want required_without:BatchPool",
 					},
 				},
 			},
@@ -66,7 +66,8 @@ func (s *Service) bzzUploadHandler(w http.ResponseWriter, r *http.Request) {
 
 	headers := struct {
 		ContentType    string           `map:"Content-Type,mimeMediaType" validate:"required"`
-		BatchID        []byte           `map:"Swarm-Postage-Batch-Id" validate:"required"`
+		BatchID        []byte           `map:"Swarm-Postage-Batch-Id" validate:"required_without=BatchPool"`
+		BatchPool      string           `map:"Swarm-Postage-Batch-Pool"`
 		SwarmTag       uint64           `map:"Swarm-Tag"`
 		Pin            bool             `map:"Swarm-Pin"`
 		Deferred       *bool            `map:"Swarm-Deferred-Upload"`
@@ -107,10 +108,11 @@ func (s *Service) bzzUploadHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	putter, err := s.newStamperPutter(ctx, putterOptions{
-		BatchID:  headers.BatchID,
-		TagID:    tag,
-		Pin:      headers.Pin,
-		Deferred: deferred,
+		BatchID:   headers.BatchID,
+		BatchPool: headers.BatchPool,
+		TagID:     tag,
+		Pin:       headers.Pin,
+		Deferred:  deferred,
 	})
 	if err != nil {
 		logger.Debug("putter failed", "error", err)
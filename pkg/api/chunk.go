@@ -33,7 +33,9 @@ func (s *Service) chunkUploadHandler(w http.ResponseWriter, r *http.Request) {
 
 	headers := struct {
 		BatchID        []byte        `map:"Swarm-Postage-Batch-Id"`
+		BatchPool      string        `map:"Swarm-Postage-Batch-Pool"`
 		StampSig       []byte        `map:"Swarm-Postage-Stamp"`
+		StampIndex     *uint32       `map:"Swarm-Postage-Stamp-Index"`
 		SwarmTag       uint64        `map:"Swarm-Tag"`
 		Act            bool          `map:"Swarm-Act"`
 		HistoryAddress swarm.Address `map:"Swarm-Act-History-Address"`
@@ -62,12 +64,18 @@ func (s *Service) chunkUploadHandler(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	if len(headers.BatchID) == 0 && len(headers.StampSig) == 0 {
+	if len(headers.BatchID) == 0 && headers.BatchPool == "" && len(headers.StampSig) == 0 {
 		logger.Error(nil, batchIdOrStampSig)
 		jsonhttp.BadRequest(w, batchIdOrStampSig)
 		return
 	}
 
+	if headers.StampIndex != nil && (len(headers.BatchID) == 0 || len(headers.StampSig) != 0) {
+		logger.Error(nil, stampIndexWithoutBatchID)
+		jsonhttp.BadRequest(w, stampIndexWithoutBatchID)
+		return
+	}
+
 	// Currently the localstore supports session based uploads. We don't want to
 	// create new session for single chunk uploads. So if the chunk upload is not
 	// part of a session already, then we directly push the chunk. This way we dont
@@ -92,9 +100,11 @@ func (s *Service) chunkUploadHandler(w http.ResponseWriter, r *http.Request) {
 		}, &stamp)
 	} else {
 		putter, err = s.newStamperPutter(r.Context(), putterOptions{
-			BatchID:  headers.BatchID,
-			TagID:    tag,
-			Deferred: deferred,
+			BatchID:    headers.BatchID,
+			BatchPool:  headers.BatchPool,
+			TagID:      tag,
+			Deferred:   deferred,
+			StampIndex: headers.StampIndex,
 		})
 	}
 	if err != nil {
@@ -178,6 +188,10 @@ func (s *Service) chunkUploadHandler(w http.ResponseWriter, r *http.Request) {
 			jsonhttp.PaymentRequired(ow, "batch is overissued")
 		case errors.Is(err, postage.ErrInvalidBatchSignature):
 			jsonhttp.BadRequest(ow, "stamp signature is invalid")
+		case errors.Is(err, postage.ErrIndexImmutable):
+			jsonhttp.BadRequest(ow, "stamp index of immutable batch")
+		case errors.Is(err, postage.ErrIndexOutOfRange):
+			jsonhttp.BadRequest(ow, "stamp index out of range")
 		default:
 			jsonhttp.InternalServerError(ow, "chunk write error")
 		}
@@ -28,8 +28,9 @@ func (s *Service) chunkUploadStreamHandler(w http.ResponseWriter, r *http.Reques
 	logger := s.logger.WithName("chunks_stream").Build()
 
 	headers := struct {
-		BatchID  []byte `map:"Swarm-Postage-Batch-Id" validate:"required"`
-		SwarmTag uint64 `map:"Swarm-Tag"`
+		BatchID   []byte `map:"Swarm-Postage-Batch-Id" validate:"required_without=BatchPool"`
+		BatchPool string `map:"Swarm-Postage-Batch-Pool"`
+		SwarmTag  uint64 `map:"Swarm-Tag"`
 	}{}
 	if response := s.mapStructure(r.Header, &headers); response != nil {
 		response("invalid header params", logger, w)
@@ -58,9 +59,10 @@ func (s *Service) chunkUploadStreamHandler(w http.ResponseWriter, r *http.Reques
 	// if tag not specified use direct upload
 	// Using context.Background here because the putter's lifetime extends beyond that of the HTTP request.
 	putter, err := s.newStamperPutter(context.Background(), putterOptions{
-		BatchID:  headers.BatchID,
-		TagID:    tag,
-		Deferred: tag != 0,
+		BatchID:   headers.BatchID,
+		BatchPool: headers.BatchPool,
+		TagID:     tag,
+		Deferred:  tag != 0,
 	})
 	if err != nil {
 		logger.Debug("get putter failed", "error", err)
@@ -99,6 +99,15 @@ func TestChunkUploadDownload(t *testing.T) {
 			t.Fatal(err)
 		}
 	})
+
+	t.Run("stamp index ok", func(t *testing.T) {
+		jsonhttptest.Request(t, client, http.MethodPost, chunksEndpoint, http.StatusCreated,
+			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+			jsonhttptest.WithRequestHeader(api.SwarmPostageStampIndexHeader, "5"),
+			jsonhttptest.WithRequestBody(bytes.NewReader(chunk.Data())),
+			jsonhttptest.WithExpectedJSONResponse(api.ChunkAddressResponse{Reference: chunk.Address()}),
+		)
+	})
 }
 
 // nolint:paralleltest,tparallel
@@ -246,6 +255,25 @@ func TestChunkInvalidParams(t *testing.T) {
 			jsonhttptest.WithRequestBody(bytes.NewReader(chunk.Data())),
 		)
 	})
+
+	t.Run("stamp index without batch id", func(t *testing.T) {
+		t.Parallel()
+
+		client, _, _, _ := newTestServer(t, testServerOptions{
+			Storer: storerMock,
+			Logger: logger,
+			Post:   mockpost.New(mockpost.WithAcceptAll()),
+		})
+		jsonhttptest.Request(t, client, http.MethodPost, chunksEndpoint, http.StatusBadRequest,
+			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchPoolHeader, "logs"),
+			jsonhttptest.WithRequestHeader(api.SwarmPostageStampIndexHeader, "0"),
+			jsonhttptest.WithRequestBody(bytes.NewReader(chunk.Data())),
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Message: "'Swarm-Postage-Stamp-Index' header requires the 'Swarm-Postage-Batch-Id' header",
+				Code:    http.StatusBadRequest,
+			}),
+		)
+	})
 }
 
 // TestDirectChunkUpload tests that the direct upload endpoint give correct error message in dev mode
@@ -5,6 +5,7 @@
 package api
 
 import (
+	"fmt"
 	"net/http"
 
 	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
@@ -24,3 +25,44 @@ func (s *Service) debugStorage(w http.ResponseWriter, r *http.Request) {
 
 	jsonhttp.OK(w, info)
 }
+
+func (s *Service) debugStorageUsage(w http.ResponseWriter, r *http.Request) {
+	logger := tracing.NewLoggerWithTraceID(r.Context(), s.logger.WithName("get_debugstore_usage").Build())
+
+	usage, err := s.storer.Usage(r.Context())
+	if err != nil {
+		logger.Debug("get storage usage failed", "error", err)
+		logger.Error(nil, "get storage usage failed")
+		jsonhttp.InternalServerError(w, "storage usage not available")
+		return
+	}
+
+	jsonhttp.OK(w, usage)
+}
+
+func (s *Service) debugStorageBackup(w http.ResponseWriter, r *http.Request) {
+	logger := tracing.NewLoggerWithTraceID(r.Context(), s.logger.WithName("get_debugstore_backup").Build())
+
+	queries := struct {
+		Since uint64 `map:"since"`
+	}{}
+	if response := s.mapStructure(r.URL.Query(), &queries); response != nil {
+		response("invalid query params", logger, w)
+		return
+	}
+
+	w.Header().Set(ContentTypeHeader, "application/octet-stream")
+	w.Header().Set(ContentDispositionHeader, fmt.Sprintf("attachment; filename=\"localstore-%d.backup\"", queries.Since))
+
+	info, err := s.storer.Backup(r.Context(), w, queries.Since)
+	if err != nil {
+		// The archive might already be partially written, so the status
+		// can't be changed anymore. An archive without the end record is
+		// rejected when restored.
+		logger.Debug("localstore backup failed", "since", queries.Since, "error", err)
+		logger.Error(nil, "localstore backup failed")
+		return
+	}
+
+	logger.Debug("localstore backup written", "since", info.Since, "timestamp", info.Timestamp, "chunks", info.Chunks)
+}
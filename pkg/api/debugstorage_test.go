@@ -8,9 +8,12 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/ethersphere/bee/v2/pkg/api"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
 	"github.com/ethersphere/bee/v2/pkg/jsonhttp/jsonhttptest"
 	"github.com/ethersphere/bee/v2/pkg/storer"
 	mockstorer "github.com/ethersphere/bee/v2/pkg/storer/mock"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
 )
 
 func TestDebugStorage(t *testing.T) {
@@ -42,5 +45,65 @@ func TestDebugStorage(t *testing.T) {
 			jsonhttptest.WithExpectedJSONResponse(want),
 		)
 	})
+}
+
+func TestDebugStorageUsage(t *testing.T) {
+	t.Parallel()
+
+	want := storer.Usage{
+		ChunkStore: storer.UsageStat{Chunks: 30, Bytes: 30 * swarm.ChunkWithSpanSize},
+		Reserve:    storer.UsageStat{Chunks: 20, Bytes: 20 * swarm.ChunkWithSpanSize},
+		Pinning:    storer.UsageStat{Chunks: 10, Bytes: 10 * swarm.ChunkWithSpanSize},
+		StampIndex: storer.IndexStat{Entries: 40, Bytes: 4000},
+		Batches: []storer.BatchUsage{{
+			BatchID: "a1b2",
+			Reserve: storer.UsageStat{Chunks: 20, Bytes: 20 * swarm.ChunkWithSpanSize},
+		}},
+		Pins: []storer.PinUsage{{
+			Root:  swarm.MustParseHexAddress("ca8d2d29466e017cba46d383e7e0794d99a141185ec525086037f25fc2093155"),
+			Total: storer.UsageStat{Chunks: 10, Bytes: 10 * swarm.ChunkWithSpanSize},
+		}},
+	}
+
+	ts, _, _, _ := newTestServer(t, testServerOptions{
+		Storer: mockstorer.NewWithUsage(want),
+	})
+
+	jsonhttptest.Request(t, ts, http.MethodGet, "/debugstore/usage", http.StatusOK,
+		jsonhttptest.WithExpectedJSONResponse(want),
+	)
+}
+
+func TestDebugStorageBackup(t *testing.T) {
+	t.Parallel()
+
+	ts, _, _, _ := newTestServer(t, testServerOptions{
+		Storer: mockstorer.New(),
+	})
+
+	t.Run("ok", func(t *testing.T) {
+		t.Parallel()
 
+		jsonhttptest.Request(t, ts, http.MethodGet, "/debugstore/backup?since=1700000000", http.StatusOK,
+			jsonhttptest.WithExpectedResponseHeader(api.ContentTypeHeader, "application/octet-stream"),
+			jsonhttptest.WithExpectedResponseHeader(api.ContentDispositionHeader, `attachment; filename="localstore-1700000000.backup"`),
+		)
+	})
+
+	t.Run("invalid since", func(t *testing.T) {
+		t.Parallel()
+
+		jsonhttptest.Request(t, ts, http.MethodGet, "/debugstore/backup?since=yesterday", http.StatusBadRequest,
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Code:    http.StatusBadRequest,
+				Message: "invalid query params",
+				Reasons: []jsonhttp.Reason{
+					{
+						Field: "since",
+						Error: "invalid syntax",
+					},
+				},
+			}),
+		)
+	})
 }
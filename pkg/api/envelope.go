@@ -27,7 +27,8 @@ func (s *Service) envelopePostHandler(w http.ResponseWriter, r *http.Request) {
 	logger := s.logger.WithName("post_envelope").Build()
 
 	headers := struct {
-		BatchID []byte `map:"Swarm-Postage-Batch-Id" validate:"required"`
+		BatchID    []byte  `map:"Swarm-Postage-Batch-Id" validate:"required"`
+		StampIndex *uint32 `map:"Swarm-Postage-Stamp-Index"`
 	}{}
 	if response := s.mapStructure(r.Header, &headers); response != nil {
 		response("invalid header params", logger, w)
@@ -42,7 +43,16 @@ func (s *Service) envelopePostHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	stamper, save, err := s.getStamper(headers.BatchID)
+	var (
+		stamper postage.Stamper
+		save    func() error
+		err     error
+	)
+	if headers.StampIndex != nil {
+		stamper, save, err = s.getIndexStamper(headers.BatchID, *headers.StampIndex)
+	} else {
+		stamper, save, err = s.getStamper(headers.BatchID)
+	}
 	if err != nil {
 		logger.Debug("get stamper failed", "error", err)
 		logger.Error(err, "get stamper failed")
@@ -66,6 +76,10 @@ func (s *Service) envelopePostHandler(w http.ResponseWriter, r *http.Request) {
 		switch {
 		case errors.Is(err, postage.ErrBucketFull):
 			jsonhttp.PaymentRequired(w, "batch is overissued")
+		case errors.Is(err, postage.ErrIndexImmutable):
+			jsonhttp.BadRequest(w, "stamp index of immutable batch")
+		case errors.Is(err, postage.ErrIndexOutOfRange):
+			jsonhttp.BadRequest(w, "stamp index out of range")
 		default:
 			jsonhttp.InternalServerError(w, "stamping failed")
 		}
@@ -77,7 +91,7 @@ func (s *Service) envelopePostHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	issuer, err := s.signer.EthereumAddress()
+	issuer, err := s.stampSigner.EthereumAddress()
 	if err != nil {
 		jsonhttp.InternalServerError(w, "signer ethereum address")
 		return
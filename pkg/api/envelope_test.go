@@ -7,13 +7,17 @@ package api_test
 import (
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/ethersphere/bee/v2/pkg/api"
+	"github.com/ethersphere/bee/v2/pkg/crypto"
 	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
 	"github.com/ethersphere/bee/v2/pkg/jsonhttp/jsonhttptest"
+	"github.com/ethersphere/bee/v2/pkg/log"
 	mockbatchstore "github.com/ethersphere/bee/v2/pkg/postage/batchstore/mock"
 	mockpost "github.com/ethersphere/bee/v2/pkg/postage/mock"
+	"github.com/ethersphere/bee/v2/pkg/postage/stampsigner"
 )
 
 func TestPostEnvelope(t *testing.T) {
@@ -33,6 +37,36 @@ func TestPostEnvelope(t *testing.T) {
 		)
 	})
 
+	t.Run("remote signer", func(t *testing.T) {
+		t.Parallel()
+
+		key, err := crypto.GenerateSecp256k1Key()
+		if err != nil {
+			t.Fatal(err)
+		}
+		signer := crypto.NewDefaultSigner(key)
+		owner, err := signer.EthereumAddress()
+		if err != nil {
+			t.Fatal(err)
+		}
+		ts := httptest.NewServer(stampsigner.NewHandler(signer, "token", log.Noop))
+		t.Cleanup(ts.Close)
+
+		client, _, _, _ := newTestServer(t, testServerOptions{
+			Post:        mockpost.New(mockpost.WithAcceptAll()),
+			StampSigner: stampsigner.New(ts.URL, "token", ts.Client()),
+		})
+
+		var resp api.PostEnvelopeResponse
+		jsonhttptest.Request(t, client, http.MethodPost, envelopeEndpoint(zeroHex), http.StatusCreated,
+			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+			jsonhttptest.WithUnmarshalJSONResponse(&resp),
+		)
+		if resp.Issuer != owner.Hex() {
+			t.Fatalf("issuer mismatch: have %s, want %s", resp.Issuer, owner.Hex())
+		}
+	})
+
 	t.Run("wrong chunk address", func(t *testing.T) {
 		t.Parallel()
 
@@ -62,6 +62,16 @@ type (
 	PeerConnectResponse               = peerConnectResponse
 	PeersResponse                     = peersResponse
 	BlockedListedPeersResponse        = blockListedPeersResponse
+	ReputationPeerResponse            = reputationPeerResponse
+	ReputationPeersResponse           = reputationPeersResponse
+	BandwidthResponse                 = bandwidthResponse
+	ProtocolBandwidthResponse         = protocolBandwidthResponse
+	AddressBookPeer                   = addressBookPeer
+	AddressBookPeerResponse           = addressBookPeerResponse
+	AddressBookPeersResponse          = addressBookPeersResponse
+	AddressBookImportRequest          = addressBookImportRequest
+	AddressBookImportResponse         = addressBookImportResponse
+	AddressBookPruneResponse          = addressBookPruneResponse
 	AddressesResponse                 = addressesResponse
 	WelcomeMessageRequest             = welcomeMessageRequest
 	WelcomeMessageResponse            = welcomeMessageResponse
@@ -91,7 +101,19 @@ type (
 	PostageStampsResponse             = postageStampsResponse
 	PostageBatchResponse              = postageBatchResponse
 	PostageStampBucketsResponse       = postageStampBucketsResponse
+	PostagePolicyRules                = postagePolicyRules
+	PostagePolicyResponse             = postagePolicyResponse
+	PostageMigrationResponse          = postageMigrationResponse
+	PostageImportResponse             = postageImportResponse
+	PostEnvelopeResponse              = postEnvelopeResponse
+	PostageEstimateRequest            = postageEstimateRequest
+	PostageEstimateFile               = postageEstimateFile
+	PostageEstimateResponse           = postageEstimateResponse
 	BucketData                        = bucketData
+	PostageStampBucketSlotsResponse   = postageStampBucketSlotsResponse
+	BucketSlotData                    = bucketSlotData
+	PostageUploadResponse             = postageUploadResponse
+	PostageUploadsResponse            = postageUploadsResponse
 	WalletResponse                    = walletResponse
 	WalletTxResponse                  = walletTxResponse
 	GetStakeResponse                  = getStakeResponse
@@ -169,7 +169,8 @@ func (s *Service) feedPostHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	headers := struct {
-		BatchID        []byte        `map:"Swarm-Postage-Batch-Id" validate:"required"`
+		BatchID        []byte        `map:"Swarm-Postage-Batch-Id" validate:"required_without=BatchPool"`
+		BatchPool      string        `map:"Swarm-Postage-Batch-Pool"`
 		Pin            bool          `map:"Swarm-Pin"`
 		Deferred       *bool         `map:"Swarm-Deferred-Upload"`
 		Act            bool          `map:"Swarm-Act"`
@@ -201,10 +202,11 @@ func (s *Service) feedPostHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	putter, err := s.newStamperPutter(r.Context(), putterOptions{
-		BatchID:  headers.BatchID,
-		TagID:    tag.TagID,
-		Pin:      headers.Pin,
-		Deferred: deferred,
+		BatchID:   headers.BatchID,
+		BatchPool: headers.BatchPool,
+		TagID:     tag.TagID,
+		Pin:       headers.Pin,
+		Deferred:  deferred,
 	})
 	if err != nil {
 		logger.Debug("get putter failed", "error", err)
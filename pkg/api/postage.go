@@ -18,6 +18,7 @@ import (
 	"github.com/ethersphere/bee/v2/pkg/postage"
 	"github.com/ethersphere/bee/v2/pkg/postage/postagecontract"
 	"github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
 	"github.com/ethersphere/bee/v2/pkg/tracing"
 	"github.com/gorilla/mux"
 )
@@ -179,6 +180,18 @@ type bucketData struct {
 	Collisions uint32 `json:"collisions"`
 }
 
+type postageStampBucketSlotsResponse struct {
+	BucketID   uint32           `json:"bucketID"`
+	Collisions uint32           `json:"collisions"`
+	Slots      []bucketSlotData `json:"slots"`
+}
+
+type bucketSlotData struct {
+	Index        uint32        `json:"index"`
+	ChunkAddress swarm.Address `json:"chunkAddress"`
+	Timestamp    uint64        `json:"timestamp"`
+}
+
 func (s *Service) postageGetStampsHandler(w http.ResponseWriter, r *http.Request) {
 	logger := s.logger.WithName("get_stamps").Build()
 
@@ -302,6 +315,67 @@ func (s *Service) postageGetStampBucketsHandler(w http.ResponseWriter, r *http.R
 	jsonhttp.OK(w, resp)
 }
 
+// postageGetStampBucketSlotsHandler lists the chunks which hold
+// the used stamp indices of the bucket of the batch.
+func (s *Service) postageGetStampBucketSlotsHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("get_stamp_bucket_slots").Build()
+
+	paths := struct {
+		BatchID  []byte `map:"batch_id" validate:"required,len=32"`
+		BucketID uint32 `map:"bucket_id"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+	hexBatchID := hex.EncodeToString(paths.BatchID)
+
+	issuer, _, err := s.post.GetStampIssuer(paths.BatchID)
+	if err != nil {
+		logger.Debug("get stamp issuer: get issuer failed", "batch_id", hexBatchID, "error", err)
+		logger.Error(nil, "get stamp issuer: get issuer failed")
+		switch {
+		case errors.Is(err, postage.ErrNotUsable):
+			jsonhttp.BadRequest(w, "batch not usable")
+		case errors.Is(err, postage.ErrNotFound):
+			jsonhttp.NotFound(w, "issuer does not exist")
+		default:
+			jsonhttp.InternalServerError(w, "get issuer failed")
+		}
+		return
+	}
+
+	buckets := issuer.Buckets()
+	if int(paths.BucketID) >= len(buckets) {
+		logger.Debug("bucket out of range", "batch_id", hexBatchID, "bucket_id", paths.BucketID)
+		jsonhttp.BadRequest(w, "bucket out of range")
+		return
+	}
+
+	slots, err := postage.BucketSlots(r.Context(), s.stamperStore, paths.BatchID, paths.BucketID)
+	if err != nil {
+		logger.Debug("get bucket slots failed", "batch_id", hexBatchID, "bucket_id", paths.BucketID, "error", err)
+		logger.Error(nil, "get bucket slots failed")
+		jsonhttp.InternalServerError(w, "get bucket slots failed")
+		return
+	}
+
+	resp := postageStampBucketSlotsResponse{
+		BucketID:   paths.BucketID,
+		Collisions: buckets[paths.BucketID],
+		Slots:      make([]bucketSlotData, len(slots)),
+	}
+	for i, slot := range slots {
+		resp.Slots[i] = bucketSlotData{
+			Index:        slot.Index,
+			ChunkAddress: slot.Address,
+			Timestamp:    slot.Timestamp,
+		}
+	}
+
+	jsonhttp.OK(w, resp)
+}
+
 func (s *Service) postageGetStampHandler(w http.ResponseWriter, r *http.Request) {
 	logger := s.logger.WithName("get_stamp").Build()
 
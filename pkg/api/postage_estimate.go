@@ -0,0 +1,117 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/bigint"
+	"github.com/ethersphere/bee/v2/pkg/file/redundancy"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/postage/planner"
+)
+
+// estimateRounds is the number of the bucket saturation simulation rounds.
+const estimateRounds = 5
+
+// plurPerBZZ is the number of PLUR in one BZZ.
+var plurPerBZZ = new(big.Int).Exp(big.NewInt(10), big.NewInt(16), nil)
+
+type postageEstimateFile struct {
+	Size            int64            `json:"size" validate:"min=0"`
+	RedundancyLevel redundancy.Level `json:"redundancyLevel" validate:"max=4"`
+	Encrypt         bool             `json:"encrypt"`
+}
+
+type postageEstimateRequest struct {
+	Files []postageEstimateFile `json:"files" validate:"required,min=1,max=10000,dive"`
+	TTL   int64                 `json:"ttl" validate:"min=1,max=3153600000"`
+}
+
+type postageEstimateResponse struct {
+	Chunks        int64          `json:"chunks"`
+	MaxBucketFill uint64         `json:"maxBucketFill"`
+	Depth         uint8          `json:"depth"`
+	Utilization   float64        `json:"utilization"`
+	Amount        *bigint.BigInt `json:"amount"`
+	Cost          *bigint.BigInt `json:"cost"`
+	CostBZZ       string         `json:"costBZZ"`
+}
+
+// postageEstimateHandler estimates the batch for the data of the size
+// given in the query, or for the set of files given in the request body.
+func (s *Service) postageEstimateHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("stamp_estimate").Build()
+
+	var req postageEstimateRequest
+	if r.Method == http.MethodGet {
+		queries := struct {
+			Size            int64            `map:"size" validate:"min=0"`
+			TTL             int64            `map:"ttl" validate:"min=1,max=3153600000"`
+			RedundancyLevel redundancy.Level `map:"redundancyLevel" validate:"max=4"`
+			Encrypt         bool             `map:"encrypt"`
+		}{}
+		if response := s.mapStructure(r.URL.Query(), &queries); response != nil {
+			response("invalid query params", logger, w)
+			return
+		}
+		req.TTL = queries.TTL
+		req.Files = []postageEstimateFile{{
+			Size:            queries.Size,
+			RedundancyLevel: queries.RedundancyLevel,
+			Encrypt:         queries.Encrypt,
+		}}
+	} else {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			logger.Debug("failed to read body", "error", err)
+			jsonhttp.BadRequest(w, "invalid request body")
+			return
+		}
+		if err := s.validate.Struct(req); err != nil {
+			logger.Debug("invalid request body", "error", err)
+			jsonhttp.BadRequest(w, "invalid request body")
+			return
+		}
+	}
+
+	files := make([]planner.File, len(req.Files))
+	for i, f := range req.Files {
+		files[i] = planner.File{Size: f.Size, Redundancy: f.RedundancyLevel, Encrypt: f.Encrypt}
+	}
+
+	plan, err := planner.Estimate(files, planner.Options{
+		TTL:       time.Duration(req.TTL) * time.Second,
+		Price:     s.batchStore.GetChainState().CurrentPrice,
+		BlockTime: s.blockTime,
+		Rounds:    estimateRounds,
+	})
+	if err != nil {
+		logger.Debug("estimate failed", "error", err)
+		switch {
+		case errors.Is(err, planner.ErrNoPrice):
+			jsonhttp.ServiceUnavailable(w, "price not available")
+		case errors.Is(err, planner.ErrTooLarge):
+			jsonhttp.BadRequest(w, "files too large")
+		default:
+			logger.Error(nil, "estimate failed")
+			jsonhttp.InternalServerError(w, "estimate failed")
+		}
+		return
+	}
+
+	jsonhttp.OK(w, postageEstimateResponse{
+		Chunks:        plan.Chunks,
+		MaxBucketFill: plan.MaxBucketFill,
+		Depth:         plan.Depth,
+		Utilization:   plan.Utilization,
+		Amount:        bigint.Wrap(plan.Amount),
+		Cost:          bigint.Wrap(plan.Cost),
+		CostBZZ:       new(big.Rat).SetFrac(plan.Cost, plurPerBZZ).FloatString(16),
+	})
+}
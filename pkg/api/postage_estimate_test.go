@@ -0,0 +1,113 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api_test
+
+import (
+	"math/big"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/api"
+	"github.com/ethersphere/bee/v2/pkg/file/redundancy"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp/jsonhttptest"
+	"github.com/ethersphere/bee/v2/pkg/postage"
+	mockbatchstore "github.com/ethersphere/bee/v2/pkg/postage/batchstore/mock"
+	"github.com/ethersphere/bee/v2/pkg/postage/planner"
+)
+
+func TestPostageEstimate(t *testing.T) {
+	t.Parallel()
+
+	client, _, _, _ := newTestServer(t, testServerOptions{
+		BatchStore: mockbatchstore.New(mockbatchstore.WithChainState(&postage.ChainState{
+			CurrentPrice: big.NewInt(24000),
+		})),
+		BlockTime: 5 * time.Second,
+	})
+
+	t.Run("size", func(t *testing.T) {
+		t.Parallel()
+
+		var resp api.PostageEstimateResponse
+		jsonhttptest.Request(t, client, http.MethodGet, "/stamps/estimate?size=1048576&ttl=86400", http.StatusOK,
+			jsonhttptest.WithUnmarshalJSONResponse(&resp),
+		)
+
+		// one day of 24000 PLUR blocks every 5 seconds
+		amount := big.NewInt(86400 / 5 * 24000)
+		cost := new(big.Int).Lsh(amount, planner.MinDepth)
+		if resp.Chunks != 259 {
+			t.Fatalf("have %d chunks, want 259", resp.Chunks)
+		}
+		if resp.Depth != planner.MinDepth {
+			t.Fatalf("have depth %d, want %d", resp.Depth, planner.MinDepth)
+		}
+		if resp.Amount.Cmp(amount) != 0 {
+			t.Fatalf("have amount %s, want %s", resp.Amount, amount)
+		}
+		if resp.Cost.Cmp(cost) != 0 {
+			t.Fatalf("have cost %s, want %s", resp.Cost, cost)
+		}
+		if resp.CostBZZ != "0.0054358179840000" {
+			t.Fatalf("have cost %s BZZ, want 0.0054358179840000", resp.CostBZZ)
+		}
+	})
+
+	t.Run("file set", func(t *testing.T) {
+		t.Parallel()
+
+		var resp api.PostageEstimateResponse
+		jsonhttptest.Request(t, client, http.MethodPost, "/stamps/estimate", http.StatusOK,
+			jsonhttptest.WithJSONRequestBody(api.PostageEstimateRequest{
+				Files: []api.PostageEstimateFile{
+					{Size: 1 << 30},
+					{Size: 1 << 30, RedundancyLevel: redundancy.PARANOID},
+				},
+				TTL: 86400,
+			}),
+			jsonhttptest.WithUnmarshalJSONResponse(&resp),
+		)
+		if resp.MaxBucketFill > 1<<(resp.Depth-postage.BucketDepth) {
+			t.Fatalf("depth %d does not hold the bucket fill %d", resp.Depth, resp.MaxBucketFill)
+		}
+		if resp.Depth <= planner.MinDepth {
+			t.Fatalf("have depth %d, want above %d", resp.Depth, planner.MinDepth)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+
+		jsonhttptest.Request(t, client, http.MethodGet, "/stamps/estimate?size=1&ttl=0", http.StatusBadRequest)
+		jsonhttptest.Request(t, client, http.MethodGet, "/stamps/estimate?size=1&ttl=1&redundancyLevel=5", http.StatusBadRequest)
+		jsonhttptest.Request(t, client, http.MethodGet, "/stamps/estimate?size=9223372036854775807&ttl=1", http.StatusBadRequest,
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Message: "files too large",
+				Code:    http.StatusBadRequest,
+			}),
+		)
+		jsonhttptest.Request(t, client, http.MethodPost, "/stamps/estimate", http.StatusBadRequest,
+			jsonhttptest.WithJSONRequestBody(api.PostageEstimateRequest{TTL: 1}),
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Message: "invalid request body",
+				Code:    http.StatusBadRequest,
+			}),
+		)
+	})
+
+	t.Run("no price", func(t *testing.T) {
+		t.Parallel()
+
+		client, _, _, _ := newTestServer(t, testServerOptions{
+			BatchStore: mockbatchstore.New(mockbatchstore.WithChainState(&postage.ChainState{
+				CurrentPrice: big.NewInt(0),
+			})),
+			BlockTime: 5 * time.Second,
+		})
+		jsonhttptest.Request(t, client, http.MethodGet, "/stamps/estimate?size=1&ttl=1", http.StatusServiceUnavailable)
+	})
+}
@@ -0,0 +1,61 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api_test
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/postage"
+	mockbatchstore "github.com/ethersphere/bee/v2/pkg/postage/batchstore/mock"
+	mockpost "github.com/ethersphere/bee/v2/pkg/postage/mock"
+	"github.com/ethersphere/bee/v2/pkg/postage/notifier"
+	"github.com/ethersphere/bee/v2/pkg/util/testutil"
+)
+
+func TestPostageEvents(t *testing.T) {
+	t.Parallel()
+
+	issuer := postage.NewStampIssuer("", "", batchOk, big.NewInt(3), 11, 10, 1000, true)
+	post := mockpost.New(mockpost.WithIssuer(issuer))
+	n := notifier.New(post, mockbatchstore.New(), log.Noop, notifier.Options{})
+	testutil.CleanupCloser(t, n)
+
+	_, cl, _, _ := newTestServer(t, testServerOptions{
+		PostageNotifier: n,
+		WsPath:          "/stamps/events",
+		Logger:          log.Noop,
+	})
+
+	received := make(chan notifier.Event, 1)
+	go func() {
+		var e notifier.Event
+		if err := cl.ReadJSON(&e); err != nil {
+			return
+		}
+		received <- e
+	}()
+
+	// the events are published until the subscription of the connection is registered
+	listener := n.BatchEventListener(post)
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
+	timeout := time.After(5 * time.Second)
+	for {
+		listener.HandleDepthIncrease(batchOk, 12)
+		select {
+		case e := <-received:
+			if e.Type != notifier.EventDilution || e.BatchID != batchOkStr || e.Depth != 12 {
+				t.Fatalf("got event %q of batch %s with depth %d", e.Type, e.BatchID, e.Depth)
+			}
+			return
+		case <-timeout:
+			t.Fatal("event not received")
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/postage"
+	"github.com/ethersphere/bee/v2/pkg/postage/migration"
+	storage "github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/gorilla/mux"
+)
+
+type postageMigrationResponse struct {
+	OldBatchID hexByte         `json:"oldBatchID"`
+	NewBatchID hexByte         `json:"newBatchID"`
+	State      migration.State `json:"state"`
+	Total      uint64          `json:"total"`
+	Migrated   uint64          `json:"migrated"`
+	Failed     uint64          `json:"failed"`
+	Error      string          `json:"error,omitempty"`
+	StartedAt  time.Time       `json:"startedAt"`
+	FinishedAt *time.Time      `json:"finishedAt,omitempty"`
+}
+
+func newPostageMigrationResponse(status migration.Status) postageMigrationResponse {
+	resp := postageMigrationResponse{
+		OldBatchID: status.OldBatchID,
+		NewBatchID: status.NewBatchID,
+		State:      status.State,
+		Total:      status.Total,
+		Migrated:   status.Migrated,
+		Failed:     status.Failed,
+		Error:      status.Error,
+		StartedAt:  status.StartedAt,
+	}
+	if !status.FinishedAt.IsZero() {
+		resp.FinishedAt = &status.FinishedAt
+	}
+	return resp
+}
+
+func (s *Service) postageStartMigrationHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("post_stamp_migration").Build()
+
+	paths := struct {
+		BatchID []byte `map:"batch_id" validate:"required,len=32"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	headers := struct {
+		BatchID []byte `map:"Swarm-Postage-Batch-Id" validate:"required"`
+	}{}
+	if response := s.mapStructure(r.Header, &headers); response != nil {
+		response("invalid header params", logger, w)
+		return
+	}
+
+	stamper, save, err := s.getStamper(headers.BatchID)
+	if err != nil {
+		switch {
+		case errors.Is(err, errBatchUnusable) || errors.Is(err, postage.ErrNotUsable):
+			jsonhttp.UnprocessableEntity(w, "batch not usable yet or does not exist")
+		case errors.Is(err, postage.ErrNotFound) || errors.Is(err, storage.ErrNotFound):
+			jsonhttp.NotFound(w, "batch with id not found")
+		case errors.Is(err, errInvalidPostageBatch):
+			jsonhttp.BadRequest(w, "invalid batch id")
+		default:
+			jsonhttp.BadRequest(w, nil)
+		}
+		return
+	}
+
+	status, err := s.batchMigrator.Start(paths.BatchID, stamper, save)
+	if err != nil {
+		switch {
+		case errors.Is(err, migration.ErrSameBatch):
+			jsonhttp.BadRequest(w, "batch migrated to itself")
+		case errors.Is(err, migration.ErrRunning):
+			jsonhttp.Conflict(w, "migration already running")
+		default:
+			logger.Debug("start migration failed", "batch_id", hex.EncodeToString(paths.BatchID), "error", err)
+			logger.Error(nil, "start migration failed")
+			jsonhttp.InternalServerError(w, "start migration failed")
+		}
+		return
+	}
+
+	jsonhttp.Accepted(w, newPostageMigrationResponse(status))
+}
+
+func (s *Service) postageGetMigrationHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("get_stamp_migration").Build()
+
+	paths := struct {
+		BatchID []byte `map:"batch_id" validate:"required,len=32"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	status, err := s.batchMigrator.Status(paths.BatchID)
+	if err != nil {
+		if errors.Is(err, migration.ErrNotFound) {
+			jsonhttp.NotFound(w, "migration does not exist")
+			return
+		}
+		logger.Debug("get migration failed", "batch_id", hex.EncodeToString(paths.BatchID), "error", err)
+		logger.Error(nil, "get migration failed")
+		jsonhttp.InternalServerError(w, "get migration failed")
+		return
+	}
+
+	jsonhttp.OK(w, newPostageMigrationResponse(status))
+}
+
+func (s *Service) postageCancelMigrationHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("delete_stamp_migration").Build()
+
+	paths := struct {
+		BatchID []byte `map:"batch_id" validate:"required,len=32"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	if err := s.batchMigrator.Cancel(paths.BatchID); err != nil {
+		if errors.Is(err, migration.ErrNotFound) {
+			jsonhttp.NotFound(w, "migration not running")
+			return
+		}
+		logger.Debug("cancel migration failed", "batch_id", hex.EncodeToString(paths.BatchID), "error", err)
+		logger.Error(nil, "cancel migration failed")
+		jsonhttp.InternalServerError(w, "cancel migration failed")
+		return
+	}
+
+	jsonhttp.OK(w, nil)
+}
@@ -0,0 +1,127 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/api"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp/jsonhttptest"
+	"github.com/ethersphere/bee/v2/pkg/postage"
+	"github.com/ethersphere/bee/v2/pkg/postage/migration"
+	mockpost "github.com/ethersphere/bee/v2/pkg/postage/mock"
+	mockstorer "github.com/ethersphere/bee/v2/pkg/storer/mock"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+type batchMigratorMock struct {
+	mu     sync.Mutex
+	status map[string]migration.Status
+}
+
+func (m *batchMigratorMock) Start(oldBatchID []byte, stamper postage.Stamper, _ func() error) (migration.Status, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if bytes.Equal(oldBatchID, stamper.BatchId()) {
+		return migration.Status{}, migration.ErrSameBatch
+	}
+	if s, ok := m.status[string(oldBatchID)]; ok && s.State == migration.StateRunning {
+		return migration.Status{}, migration.ErrRunning
+	}
+	s := migration.Status{
+		OldBatchID: oldBatchID,
+		NewBatchID: stamper.BatchId(),
+		State:      migration.StateRunning,
+		StartedAt:  time.Unix(1700000000, 0).UTC(),
+	}
+	m.status[string(oldBatchID)] = s
+	return s, nil
+}
+
+func (m *batchMigratorMock) Status(oldBatchID []byte) (migration.Status, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s, ok := m.status[string(oldBatchID)]
+	if !ok {
+		return migration.Status{}, migration.ErrNotFound
+	}
+	return s, nil
+}
+
+func (m *batchMigratorMock) Cancel(oldBatchID []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s, ok := m.status[string(oldBatchID)]
+	if !ok || s.State != migration.StateRunning {
+		return migration.ErrNotFound
+	}
+	s.State = migration.StateCancelled
+	s.FinishedAt = s.StartedAt.Add(time.Minute)
+	m.status[string(oldBatchID)] = s
+	return nil
+}
+
+func TestPostageMigration(t *testing.T) {
+	t.Parallel()
+
+	client, _, _, _ := newTestServer(t, testServerOptions{
+		Storer:        mockstorer.New(),
+		Post:          mockpost.New(mockpost.WithAcceptAll()),
+		BatchMigrator: &batchMigratorMock{status: make(map[string]migration.Status)},
+	})
+	oldBatchID := swarm.RandAddress(t).Bytes()
+	migrationURL := "/stamps/" + hex.EncodeToString(oldBatchID) + "/migration"
+	startedAt := time.Unix(1700000000, 0).UTC()
+	finishedAt := startedAt.Add(time.Minute)
+
+	jsonhttptest.Request(t, client, http.MethodGet, migrationURL, http.StatusNotFound,
+		jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+			Message: "migration does not exist",
+			Code:    http.StatusNotFound,
+		}),
+	)
+	jsonhttptest.Request(t, client, http.MethodPost, migrationURL, http.StatusBadRequest)
+
+	jsonhttptest.Request(t, client, http.MethodPost, migrationURL, http.StatusAccepted,
+		jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+		jsonhttptest.WithExpectedJSONResponse(api.PostageMigrationResponse{
+			OldBatchID: oldBatchID,
+			NewBatchID: batchOk,
+			State:      migration.StateRunning,
+			StartedAt:  startedAt,
+		}),
+	)
+	jsonhttptest.Request(t, client, http.MethodPost, migrationURL, http.StatusConflict,
+		jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+	)
+	jsonhttptest.Request(t, client, http.MethodPost, "/stamps/"+batchOkStr+"/migration", http.StatusBadRequest,
+		jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+		jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+			Message: "batch migrated to itself",
+			Code:    http.StatusBadRequest,
+		}),
+	)
+
+	jsonhttptest.Request(t, client, http.MethodDelete, migrationURL, http.StatusOK)
+	jsonhttptest.Request(t, client, http.MethodDelete, migrationURL, http.StatusNotFound)
+	jsonhttptest.Request(t, client, http.MethodGet, migrationURL, http.StatusOK,
+		jsonhttptest.WithExpectedJSONResponse(api.PostageMigrationResponse{
+			OldBatchID: oldBatchID,
+			NewBatchID: batchOk,
+			State:      migration.StateCancelled,
+			StartedAt:  startedAt,
+			FinishedAt: &finishedAt,
+		}),
+	)
+}
@@ -0,0 +1,157 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/bigint"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/postage/policy"
+	"github.com/gorilla/mux"
+)
+
+// postagePolicyRules are the rules of the batch with the TTLs in seconds.
+type postagePolicyRules struct {
+	MinTTL         int64 `json:"minTTL"`
+	TopUpTTL       int64 `json:"topUpTTL"`
+	MaxUtilization uint8 `json:"maxUtilization"`
+	MaxDepth       uint8 `json:"maxDepth"`
+}
+
+type postagePolicyAction struct {
+	Type      policy.ActionType `json:"type"`
+	Timestamp time.Time         `json:"timestamp"`
+	Amount    *bigint.BigInt    `json:"amount,omitempty"`
+	Depth     uint8             `json:"depth,omitempty"`
+	TxHash    string            `json:"txHash"`
+}
+
+type postagePolicyResponse struct {
+	BatchID    hexByte              `json:"batchID"`
+	Rules      postagePolicyRules   `json:"rules"`
+	LastAction *postagePolicyAction `json:"lastAction,omitempty"`
+	LastError  string               `json:"lastError,omitempty"`
+}
+
+func (s *Service) postageGetPolicyHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("get_stamp_policy").Build()
+
+	paths := struct {
+		BatchID []byte `map:"batch_id" validate:"required,len=32"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	status, err := s.postagePolicy.Status(paths.BatchID)
+	if err != nil {
+		if errors.Is(err, policy.ErrNotFound) {
+			jsonhttp.NotFound(w, "policy does not exist")
+			return
+		}
+		logger.Debug("get policy failed", "batch_id", hex.EncodeToString(paths.BatchID), "error", err)
+		logger.Error(nil, "get policy failed")
+		jsonhttp.InternalServerError(w, "get policy failed")
+		return
+	}
+
+	resp := postagePolicyResponse{
+		BatchID: paths.BatchID,
+		Rules: postagePolicyRules{
+			MinTTL:         int64(status.Rules.MinTTL / time.Second),
+			TopUpTTL:       int64(status.Rules.TopUpTTL / time.Second),
+			MaxUtilization: status.Rules.MaxUtilization,
+			MaxDepth:       status.Rules.MaxDepth,
+		},
+		LastError: status.LastError,
+	}
+	if a := status.LastAction; a != nil {
+		resp.LastAction = &postagePolicyAction{
+			Type:      a.Type,
+			Timestamp: a.Timestamp,
+			Depth:     a.Depth,
+			TxHash:    a.TxHash.String(),
+		}
+		if a.Amount != nil {
+			resp.LastAction.Amount = bigint.Wrap(a.Amount)
+		}
+	}
+
+	jsonhttp.OK(w, resp)
+}
+
+func (s *Service) postageSetPolicyHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("put_stamp_policy").Build()
+
+	paths := struct {
+		BatchID []byte `map:"batch_id" validate:"required,len=32"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+	hexBatchID := hex.EncodeToString(paths.BatchID)
+
+	var req postagePolicyRules
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Debug("failed to read body", "error", err)
+		jsonhttp.BadRequest(w, "invalid request body")
+		return
+	}
+
+	err := s.postagePolicy.SetRules(paths.BatchID, policy.Rules{
+		MinTTL:         time.Duration(req.MinTTL) * time.Second,
+		TopUpTTL:       time.Duration(req.TopUpTTL) * time.Second,
+		MaxUtilization: req.MaxUtilization,
+		MaxDepth:       req.MaxDepth,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, policy.ErrInvalidRules):
+			logger.Debug("set policy: invalid rules", "batch_id", hexBatchID, "error", err)
+			jsonhttp.BadRequest(w, err.Error())
+		case errors.Is(err, policy.ErrNotFound):
+			jsonhttp.NotFound(w, "issuer does not exist")
+		default:
+			logger.Debug("set policy failed", "batch_id", hexBatchID, "error", err)
+			logger.Error(nil, "set policy failed")
+			jsonhttp.InternalServerError(w, "set policy failed")
+		}
+		return
+	}
+
+	jsonhttp.OK(w, nil)
+}
+
+func (s *Service) postageRemovePolicyHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("delete_stamp_policy").Build()
+
+	paths := struct {
+		BatchID []byte `map:"batch_id" validate:"required,len=32"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	if err := s.postagePolicy.RemoveRules(paths.BatchID); err != nil {
+		if errors.Is(err, policy.ErrNotFound) {
+			jsonhttp.NotFound(w, "policy does not exist")
+			return
+		}
+		logger.Debug("remove policy failed", "batch_id", hex.EncodeToString(paths.BatchID), "error", err)
+		logger.Error(nil, "remove policy failed")
+		jsonhttp.InternalServerError(w, "remove policy failed")
+		return
+	}
+
+	jsonhttp.OK(w, nil)
+}
@@ -0,0 +1,71 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api_test
+
+import (
+	"encoding/hex"
+	"math/big"
+	"net/http"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/api"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp/jsonhttptest"
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/postage"
+	mockbatchstore "github.com/ethersphere/bee/v2/pkg/postage/batchstore/mock"
+	mockpost "github.com/ethersphere/bee/v2/pkg/postage/mock"
+	"github.com/ethersphere/bee/v2/pkg/postage/policy"
+	contractMock "github.com/ethersphere/bee/v2/pkg/postage/postagecontract/mock"
+	statestore "github.com/ethersphere/bee/v2/pkg/statestore/mock"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+func TestPostagePolicy(t *testing.T) {
+	t.Parallel()
+
+	si := postage.NewStampIssuer("", "", batchOk, big.NewInt(3), 11, 10, 1000, true)
+	pp, err := policy.New(statestore.NewStateStore(), mockpost.New(mockpost.WithIssuer(si)), mockbatchstore.New(), contractMock.New(), log.Noop, policy.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = pp.Close() })
+
+	client, _, _, _ := newTestServer(t, testServerOptions{PostagePolicy: pp})
+	policyURL := "/stamps/" + batchOkStr + "/policy"
+	unknown := swarm.RandAddress(t).Bytes()
+
+	jsonhttptest.Request(t, client, http.MethodGet, policyURL, http.StatusNotFound,
+		jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+			Message: "policy does not exist",
+			Code:    http.StatusNotFound,
+		}),
+	)
+
+	rules := api.PostagePolicyRules{MinTTL: 30 * 24 * 3600, TopUpTTL: 60 * 24 * 3600, MaxUtilization: 80}
+	jsonhttptest.Request(t, client, http.MethodPut, policyURL, http.StatusOK,
+		jsonhttptest.WithJSONRequestBody(rules),
+	)
+	jsonhttptest.Request(t, client, http.MethodGet, policyURL, http.StatusOK,
+		jsonhttptest.WithExpectedJSONResponse(api.PostagePolicyResponse{
+			BatchID: batchOk,
+			Rules:   rules,
+		}),
+	)
+
+	jsonhttptest.Request(t, client, http.MethodPut, policyURL, http.StatusBadRequest,
+		jsonhttptest.WithJSONRequestBody(api.PostagePolicyRules{MaxUtilization: 120}),
+	)
+	jsonhttptest.Request(t, client, http.MethodPut, "/stamps/"+hex.EncodeToString(unknown)+"/policy", http.StatusNotFound,
+		jsonhttptest.WithJSONRequestBody(rules),
+		jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+			Message: "issuer does not exist",
+			Code:    http.StatusNotFound,
+		}),
+	)
+
+	jsonhttptest.Request(t, client, http.MethodDelete, policyURL, http.StatusOK)
+	jsonhttptest.Request(t, client, http.MethodDelete, policyURL, http.StatusNotFound)
+}
@@ -368,6 +368,112 @@ func TestPostageGetBuckets(t *testing.T) {
 	})
 }
 
+func TestPostageGetBucketSlots(t *testing.T) {
+	t.Parallel()
+
+	si := postage.NewStampIssuer("", "", batchOk, big.NewInt(3), 17, 16, 1000, false)
+	mp := mockpost.New(mockpost.WithIssuer(si))
+	ts, _, _, _ := newTestServer(t, testServerOptions{Post: mp})
+
+	addrs := []string{
+		"0000000000000000000000000000000000000000000000000000000000000001",
+		"0000000000000000000000000000000000000000000000000000000000000002",
+		"0000000000000000000000000000000000000000000000000000000000000003",
+	}
+	jsonhttptest.Request(t, ts, http.MethodPost, "/envelope/"+addrs[0], http.StatusCreated,
+		jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+	)
+	jsonhttptest.Request(t, ts, http.MethodPost, "/envelope/"+addrs[1], http.StatusCreated,
+		jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+	)
+
+	// the third chunk overwrites the first one instead of the next index
+	var envelope api.PostEnvelopeResponse
+	jsonhttptest.Request(t, ts, http.MethodPost, "/envelope/"+addrs[2], http.StatusCreated,
+		jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+		jsonhttptest.WithRequestHeader(api.SwarmPostageStampIndexHeader, "0"),
+		jsonhttptest.WithUnmarshalJSONResponse(&envelope),
+	)
+	if want := "0000000000000000"; envelope.Index != want {
+		t.Fatalf("got index %s, want %s", envelope.Index, want)
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		t.Parallel()
+
+		var resp api.PostageStampBucketSlotsResponse
+		jsonhttptest.Request(t, ts, http.MethodGet, "/stamps/"+batchOkStr+"/buckets/0", http.StatusOK,
+			jsonhttptest.WithUnmarshalJSONResponse(&resp),
+		)
+		if resp.BucketID != 0 || resp.Collisions != 2 || len(resp.Slots) != 2 {
+			t.Fatalf("unexpected response %+v", resp)
+		}
+		for i, want := range []string{addrs[2], addrs[1]} {
+			if slot := resp.Slots[i]; slot.Index != uint32(i) || slot.ChunkAddress.String() != want {
+				t.Fatalf("slot %d: got index %d address %s, want %s", i, slot.Index, slot.ChunkAddress, want)
+			}
+		}
+	})
+
+	t.Run("empty bucket", func(t *testing.T) {
+		t.Parallel()
+
+		jsonhttptest.Request(t, ts, http.MethodGet, "/stamps/"+batchOkStr+"/buckets/1", http.StatusOK,
+			jsonhttptest.WithExpectedJSONResponse(&api.PostageStampBucketSlotsResponse{
+				BucketID: 1,
+				Slots:    []api.BucketSlotData{},
+			}),
+		)
+	})
+
+	t.Run("bucket out of range", func(t *testing.T) {
+		t.Parallel()
+
+		jsonhttptest.Request(t, ts, http.MethodGet, "/stamps/"+batchOkStr+"/buckets/65536", http.StatusBadRequest,
+			jsonhttptest.WithExpectedJSONResponse(&jsonhttp.StatusResponse{
+				Code:    http.StatusBadRequest,
+				Message: "bucket out of range",
+			}),
+		)
+	})
+
+	t.Run("index out of range", func(t *testing.T) {
+		t.Parallel()
+
+		jsonhttptest.Request(t, ts, http.MethodPost, "/envelope/"+addrs[0], http.StatusBadRequest,
+			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+			jsonhttptest.WithRequestHeader(api.SwarmPostageStampIndexHeader, "2"),
+			jsonhttptest.WithExpectedJSONResponse(&jsonhttp.StatusResponse{
+				Code:    http.StatusBadRequest,
+				Message: "stamp index out of range",
+			}),
+		)
+	})
+
+	t.Run("immutable batch", func(t *testing.T) {
+		t.Parallel()
+
+		si := postage.NewStampIssuer("", "", batchOk, big.NewInt(3), 17, 16, 1000, true)
+		ts, _, _, _ := newTestServer(t, testServerOptions{Post: mockpost.New(mockpost.WithIssuer(si))})
+
+		jsonhttptest.Request(t, ts, http.MethodPost, "/envelope/"+addrs[0], http.StatusBadRequest,
+			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+			jsonhttptest.WithRequestHeader(api.SwarmPostageStampIndexHeader, "0"),
+			jsonhttptest.WithExpectedJSONResponse(&jsonhttp.StatusResponse{
+				Code:    http.StatusBadRequest,
+				Message: "stamp index of immutable batch",
+			}),
+		)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+
+		tsNotFound, _, _, _ := newTestServer(t, testServerOptions{Post: mockpost.New()})
+		jsonhttptest.Request(t, tsNotFound, http.MethodGet, "/stamps/"+batchOkStr+"/buckets/0", http.StatusNotFound)
+	})
+}
+
 func TestReserveState(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,123 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"encoding/hex"
+	"errors"
+	"net/http"
+
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/postage"
+	"github.com/gorilla/mux"
+)
+
+type postageImportResponse struct {
+	BatchID hexByte `json:"batchID"`
+}
+
+// exportWriter sends the response headers on the first write,
+// so the errors before any data is exported can still be reported.
+type exportWriter struct {
+	w       http.ResponseWriter
+	written bool
+}
+
+func (e *exportWriter) Write(p []byte) (int, error) {
+	if !e.written {
+		e.w.Header().Set(ContentTypeHeader, "application/octet-stream")
+		e.w.WriteHeader(http.StatusOK)
+		e.written = true
+	}
+	return e.w.Write(p)
+}
+
+func (s *Service) postageExportHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("post_stamp_export").Build()
+
+	paths := struct {
+		BatchID []byte `map:"batch_id" validate:"required,len=32"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+	hexBatchID := hex.EncodeToString(paths.BatchID)
+
+	ew := &exportWriter{w: w}
+	if err := s.post.Export(r.Context(), paths.BatchID, ew); err != nil {
+		logger.Debug("export stamp issuer failed", "batch_id", hexBatchID, "error", err)
+		logger.Error(nil, "export stamp issuer failed")
+		switch {
+		case ew.written:
+			// the response is already partially sent
+		case errors.Is(err, postage.ErrNotFound):
+			jsonhttp.NotFound(w, "issuer does not exist")
+		case errors.Is(err, postage.ErrReadOnly):
+			jsonhttp.Conflict(w, "issuer already exported")
+		default:
+			jsonhttp.InternalServerError(w, "export stamp issuer failed")
+		}
+		return
+	}
+	logger.Info("stamp issuer exported, batch is read-only on this node", "batch_id", hexBatchID)
+}
+
+func (s *Service) postageRevertExportHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("delete_stamp_export").Build()
+
+	paths := struct {
+		BatchID []byte `map:"batch_id" validate:"required,len=32"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+	hexBatchID := hex.EncodeToString(paths.BatchID)
+
+	if err := s.post.RevertExport(paths.BatchID); err != nil {
+		logger.Debug("revert stamp issuer export failed", "batch_id", hexBatchID, "error", err)
+		logger.Error(nil, "revert stamp issuer export failed")
+		switch {
+		case errors.Is(err, postage.ErrNotFound):
+			jsonhttp.NotFound(w, "issuer does not exist")
+		case errors.Is(err, postage.ErrNotExported):
+			jsonhttp.Conflict(w, "issuer not exported")
+		default:
+			jsonhttp.InternalServerError(w, "revert stamp issuer export failed")
+		}
+		return
+	}
+	logger.Info("stamp issuer export reverted, batch is writable on this node", "batch_id", hexBatchID)
+
+	jsonhttp.OK(w, nil)
+}
+
+func (s *Service) postageImportHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("post_stamp_import").Build()
+
+	issuer, err := s.post.Import(r.Context(), r.Body)
+	if err != nil {
+		logger.Debug("import stamp issuer failed", "error", err)
+		logger.Error(nil, "import stamp issuer failed")
+		switch {
+		case errors.Is(err, postage.ErrInvalidExport):
+			jsonhttp.BadRequest(w, "invalid stamp issuer export")
+		case errors.Is(err, postage.ErrIssuerExists):
+			jsonhttp.Conflict(w, "issuer already exists")
+		case errors.Is(err, postage.ErrNotBatchOwner):
+			jsonhttp.BadRequest(w, "batch not owned by the stamp signer of the node")
+		case errors.Is(err, postage.ErrNotFound):
+			jsonhttp.NotFound(w, "batch not found")
+		default:
+			jsonhttp.InternalServerError(w, "import stamp issuer failed")
+		}
+		return
+	}
+
+	jsonhttp.Created(w, postageImportResponse{
+		BatchID: issuer.ID(),
+	})
+}
@@ -0,0 +1,105 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api_test
+
+import (
+	"bytes"
+	"math/big"
+	"net/http"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/api"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp/jsonhttptest"
+	"github.com/ethersphere/bee/v2/pkg/postage"
+	mockpost "github.com/ethersphere/bee/v2/pkg/postage/mock"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+func TestPostageExportImport(t *testing.T) {
+	t.Parallel()
+
+	si := postage.NewStampIssuer("label", "", batchOk, big.NewInt(3), 11, 10, 1000, true)
+	source := mockpost.New(mockpost.WithIssuer(si))
+	sourceClient, _, _, _ := newTestServer(t, testServerOptions{Post: source})
+	targetClient, _, _, _ := newTestServer(t, testServerOptions{Post: mockpost.New()})
+
+	var export []byte
+	jsonhttptest.Request(t, sourceClient, http.MethodPost, "/stamps/"+batchOkStr+"/export", http.StatusOK,
+		jsonhttptest.WithPutResponseBody(&export),
+	)
+
+	issuer, _, err := source.GetStampIssuer(batchOk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !issuer.ReadOnly() {
+		t.Fatal("exported issuer is not read-only")
+	}
+
+	jsonhttptest.Request(t, targetClient, http.MethodPost, "/stamps/import", http.StatusCreated,
+		jsonhttptest.WithRequestBody(bytes.NewReader(export)),
+		jsonhttptest.WithExpectedJSONResponse(api.PostageImportResponse{
+			BatchID: batchOk,
+		}),
+	)
+
+	t.Run("import twice", func(t *testing.T) {
+		jsonhttptest.Request(t, targetClient, http.MethodPost, "/stamps/import", http.StatusConflict,
+			jsonhttptest.WithRequestBody(bytes.NewReader(export)),
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Message: "issuer already exists",
+				Code:    http.StatusConflict,
+			}),
+		)
+	})
+
+	t.Run("invalid export", func(t *testing.T) {
+		jsonhttptest.Request(t, targetClient, http.MethodPost, "/stamps/import", http.StatusBadRequest,
+			jsonhttptest.WithRequestBody(bytes.NewReader(export[:len(export)/2])),
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Message: "invalid stamp issuer export",
+				Code:    http.StatusBadRequest,
+			}),
+		)
+	})
+
+	t.Run("export twice", func(t *testing.T) {
+		jsonhttptest.Request(t, sourceClient, http.MethodPost, "/stamps/"+batchOkStr+"/export", http.StatusConflict,
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Message: "issuer already exported",
+				Code:    http.StatusConflict,
+			}),
+		)
+	})
+
+	t.Run("revert export", func(t *testing.T) {
+		jsonhttptest.Request(t, sourceClient, http.MethodDelete, "/stamps/"+batchOkStr+"/export", http.StatusOK)
+
+		issuer, _, err := source.GetStampIssuer(batchOk)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if issuer.ReadOnly() {
+			t.Fatal("reverted issuer is read-only")
+		}
+
+		jsonhttptest.Request(t, sourceClient, http.MethodDelete, "/stamps/"+batchOkStr+"/export", http.StatusConflict,
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Message: "issuer not exported",
+				Code:    http.StatusConflict,
+			}),
+		)
+	})
+
+	t.Run("unknown batch", func(t *testing.T) {
+		jsonhttptest.Request(t, sourceClient, http.MethodPost, "/stamps/"+swarm.RandAddress(t).String()+"/export", http.StatusNotFound,
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Message: "issuer does not exist",
+				Code:    http.StatusNotFound,
+			}),
+		)
+	})
+}
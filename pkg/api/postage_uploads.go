@@ -0,0 +1,89 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"bytes"
+	"encoding/hex"
+	"net/http"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/postage"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"github.com/gorilla/mux"
+)
+
+type postageUploadResponse struct {
+	Reference swarm.Address `json:"reference"`
+	TagID     uint64        `json:"tagID"`
+	Timestamp int64         `json:"timestamp"`
+	Chunks    uint64        `json:"chunks"`
+	Size      uint64        `json:"size"`
+}
+
+type postageUploadsResponse struct {
+	Uploads []postageUploadResponse `json:"uploads"`
+}
+
+// postageGetUploadsHandler lists the root references
+// uploaded with the stamps of the batch.
+func (s *Service) postageGetUploadsHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("get_stamp_uploads").Build()
+
+	paths := struct {
+		BatchID []byte `map:"batch_id" validate:"required,len=32"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	queries := struct {
+		Offset int `map:"offset" validate:"min=0"`
+		Limit  int `map:"limit" validate:"min=1,max=1000"`
+	}{
+		Limit: 100, // Default limit.
+	}
+	if response := s.mapStructure(r.URL.Query(), &queries); response != nil {
+		response("invalid query params", logger, w)
+		return
+	}
+	hexBatchID := hex.EncodeToString(paths.BatchID)
+
+	found := false
+	for _, issuer := range s.post.StampIssuers() {
+		if bytes.Equal(issuer.ID(), paths.BatchID) {
+			found = true
+			break
+		}
+	}
+	if !found {
+		logger.Debug("issuer does not exist", "batch_id", hexBatchID)
+		jsonhttp.NotFound(w, "issuer does not exist")
+		return
+	}
+
+	uploads, err := postage.BatchUploads(r.Context(), s.stamperStore, paths.BatchID, queries.Offset, queries.Limit)
+	if err != nil {
+		logger.Debug("get uploads failed", "batch_id", hexBatchID, "error", err)
+		logger.Error(nil, "get uploads failed")
+		jsonhttp.InternalServerError(w, "get uploads failed")
+		return
+	}
+
+	resp := postageUploadsResponse{Uploads: make([]postageUploadResponse, len(uploads))}
+	for i, u := range uploads {
+		resp.Uploads[i] = postageUploadResponse{
+			Reference: u.Reference,
+			TagID:     u.TagID,
+			Timestamp: time.Unix(0, u.Timestamp).Unix(),
+			Chunks:    u.Chunks,
+			Size:      u.Size,
+		}
+	}
+
+	jsonhttp.OK(w, resp)
+}
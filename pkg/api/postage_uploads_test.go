@@ -0,0 +1,119 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"net/http"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/api"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp/jsonhttptest"
+	"github.com/ethersphere/bee/v2/pkg/postage"
+	mockpost "github.com/ethersphere/bee/v2/pkg/postage/mock"
+	mockstorer "github.com/ethersphere/bee/v2/pkg/storer/mock"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"gitlab.com/nolash/go-mockbytes"
+)
+
+func TestPostageGetUploads(t *testing.T) {
+	t.Parallel()
+
+	var (
+		issuer = postage.NewStampIssuer("", "", batchOk, big.NewInt(3), 24, 6, 1000, true)
+		client = newTestServerWithIssuer(t, issuer)
+	)
+	content, err := mockbytes.New(0, mockbytes.MockTypeStandard).WithModulus(255).SequentialBytes(swarm.ChunkSize * 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var upload api.BytesPostResponse
+	jsonhttptest.Request(t, client, http.MethodPost, "/bytes", http.StatusCreated,
+		jsonhttptest.WithRequestHeader(api.SwarmDeferredUploadHeader, "true"),
+		jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+		jsonhttptest.WithRequestBody(bytes.NewReader(content)),
+		jsonhttptest.WithUnmarshalJSONResponse(&upload),
+	)
+
+	t.Run("ok", func(t *testing.T) {
+		t.Parallel()
+
+		var resp api.PostageUploadsResponse
+		jsonhttptest.Request(t, client, http.MethodGet, "/stamps/"+batchOkStr+"/uploads", http.StatusOK,
+			jsonhttptest.WithUnmarshalJSONResponse(&resp),
+		)
+		if len(resp.Uploads) != 1 {
+			t.Fatalf("got %d uploads, want 1", len(resp.Uploads))
+		}
+		// eight data chunks and the root chunk with their references
+		got := resp.Uploads[0]
+		if !got.Reference.Equal(upload.Reference) || got.Chunks != 9 || got.Size != 8*(swarm.ChunkWithSpanSize)+swarm.SpanSize+8*swarm.HashSize {
+			t.Fatalf("unexpected upload %+v", got)
+		}
+		if got.Timestamp == 0 {
+			t.Fatal("upload timestamp not set")
+		}
+	})
+
+	t.Run("offset past the uploads", func(t *testing.T) {
+		t.Parallel()
+
+		jsonhttptest.Request(t, client, http.MethodGet, "/stamps/"+batchOkStr+"/uploads?offset=1", http.StatusOK,
+			jsonhttptest.WithExpectedJSONResponse(api.PostageUploadsResponse{Uploads: []api.PostageUploadResponse{}}),
+		)
+	})
+
+	t.Run("invalid limit", func(t *testing.T) {
+		t.Parallel()
+
+		jsonhttptest.Request(t, client, http.MethodGet, "/stamps/"+batchOkStr+"/uploads?limit=0", http.StatusBadRequest)
+	})
+
+	t.Run("issuer not found", func(t *testing.T) {
+		t.Parallel()
+
+		jsonhttptest.Request(t, client, http.MethodGet, "/stamps/"+hex.EncodeToString(swarm.RandAddress(t).Bytes())+"/uploads", http.StatusNotFound,
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Message: "issuer does not exist",
+				Code:    http.StatusNotFound,
+			}),
+		)
+	})
+}
+
+func TestPostageUploadsFailedUpload(t *testing.T) {
+	t.Parallel()
+
+	issuer := postage.NewStampIssuer("", "", batchOk, big.NewInt(3), 24, 6, 1000, true)
+	client, _, _, _ := newTestServer(t, testServerOptions{
+		Storer: mockstorer.NewWithDoneErr(errors.New("done failed")),
+		Post:   mockpost.New(mockpost.WithIssuer(issuer)),
+	})
+
+	jsonhttptest.Request(t, client, http.MethodPost, "/bytes", http.StatusInternalServerError,
+		jsonhttptest.WithRequestHeader(api.SwarmDeferredUploadHeader, "true"),
+		jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+		jsonhttptest.WithRequestBody(bytes.NewReader([]byte("data"))),
+	)
+
+	jsonhttptest.Request(t, client, http.MethodGet, "/stamps/"+batchOkStr+"/uploads", http.StatusOK,
+		jsonhttptest.WithExpectedJSONResponse(api.PostageUploadsResponse{Uploads: []api.PostageUploadResponse{}}),
+	)
+}
+
+func newTestServerWithIssuer(t *testing.T, issuer *postage.StampIssuer) *http.Client {
+	t.Helper()
+
+	client, _, _, _ := newTestServer(t, testServerOptions{
+		Storer: mockstorer.New(),
+		Post:   mockpost.New(mockpost.WithIssuer(issuer)),
+	})
+	return client
+}
@@ -0,0 +1,83 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/reputation"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"github.com/gorilla/mux"
+)
+
+type reputationPeerResponse struct {
+	Address swarm.Address               `json:"address"`
+	Score   float64                     `json:"score"`
+	Events  map[reputation.Event]uint64 `json:"events"`
+	Updated time.Time                   `json:"updated"`
+	Bad     bool                        `json:"bad"`
+}
+
+type reputationPeersResponse struct {
+	Peers []reputationPeerResponse `json:"peers"`
+}
+
+func mapReputationPeer(p reputation.Peer) reputationPeerResponse {
+	return reputationPeerResponse{
+		Address: p.Address,
+		Score:   p.Score,
+		Events:  p.Events,
+		Updated: p.Updated,
+		Bad:     p.Bad,
+	}
+}
+
+func (s *Service) reputationPeersHandler(w http.ResponseWriter, _ *http.Request) {
+	peers := s.reputation.Peers()
+
+	resp := reputationPeersResponse{Peers: make([]reputationPeerResponse, 0, len(peers))}
+	for _, p := range peers {
+		resp.Peers = append(resp.Peers, mapReputationPeer(p))
+	}
+
+	jsonhttp.OK(w, resp)
+}
+
+func (s *Service) reputationPeerHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithValues("get_reputation_by_peer").Build()
+
+	paths := struct {
+		Address swarm.Address `map:"address" validate:"required"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	jsonhttp.OK(w, mapReputationPeer(s.reputation.Peer(paths.Address)))
+}
+
+func (s *Service) reputationResetHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithValues("delete_reputation_by_peer").Build()
+
+	paths := struct {
+		Address swarm.Address `map:"address" validate:"required"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	if err := s.reputation.Reset(paths.Address); err != nil {
+		logger.Debug("reset peer reputation failed", "peer_address", paths.Address, "error", err)
+		logger.Error(nil, "reset peer reputation failed", "peer_address", paths.Address)
+		jsonhttp.InternalServerError(w, "reset peer reputation failed")
+		return
+	}
+
+	jsonhttp.OK(w, nil)
+}
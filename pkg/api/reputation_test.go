@@ -0,0 +1,82 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/api"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp/jsonhttptest"
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/reputation"
+	statestore "github.com/ethersphere/bee/v2/pkg/statestore/mock"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+func TestReputation(t *testing.T) {
+	t.Parallel()
+
+	rep, err := reputation.New(statestore.NewStateStore(), nil, log.Noop, reputation.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = rep.Close() })
+
+	var (
+		bad  = swarm.RandAddress(t)
+		good = swarm.RandAddress(t)
+	)
+	for i := 0; i < 3; i++ {
+		rep.Record(bad, reputation.EventInvalidStamp)
+	}
+	rep.Record(good, reputation.EventTimeout)
+
+	client, _, _, _ := newTestServer(t, testServerOptions{
+		Reputation: rep,
+	})
+
+	t.Run("list", func(t *testing.T) {
+		var resp api.ReputationPeersResponse
+		jsonhttptest.Request(t, client, http.MethodGet, "/reputation", http.StatusOK,
+			jsonhttptest.WithUnmarshalJSONResponse(&resp),
+		)
+		if len(resp.Peers) != 2 {
+			t.Fatalf("got %d peers, want 2", len(resp.Peers))
+		}
+		if !resp.Peers[0].Address.Equal(bad) || !resp.Peers[0].Bad {
+			t.Fatalf("got first peer %+v, want bad peer %s", resp.Peers[0], bad)
+		}
+		if got := resp.Peers[0].Events[reputation.EventInvalidStamp]; got != 3 {
+			t.Fatalf("got %d invalid stamp events, want 3", got)
+		}
+		if !resp.Peers[1].Address.Equal(good) || resp.Peers[1].Bad {
+			t.Fatalf("got second peer %+v, want good peer %s", resp.Peers[1], good)
+		}
+	})
+
+	t.Run("peer", func(t *testing.T) {
+		var resp api.ReputationPeerResponse
+		jsonhttptest.Request(t, client, http.MethodGet, "/reputation/"+good.String(), http.StatusOK,
+			jsonhttptest.WithUnmarshalJSONResponse(&resp),
+		)
+		if resp.Score >= 0 || resp.Events[reputation.EventTimeout] != 1 || resp.Updated.After(time.Now()) {
+			t.Fatalf("unexpected peer reputation %+v", resp)
+		}
+	})
+
+	t.Run("reset", func(t *testing.T) {
+		jsonhttptest.Request(t, client, http.MethodDelete, "/reputation/"+bad.String(), http.StatusOK)
+
+		if rep.IsBad(bad) {
+			t.Fatal("peer is bad after the reputation reset")
+		}
+	})
+
+	t.Run("invalid address", func(t *testing.T) {
+		jsonhttptest.Request(t, client, http.MethodGet, "/reputation/invalid", http.StatusBadRequest)
+	})
+}
@@ -124,6 +124,20 @@ func (s *Service) mountTechnicalDebug() {
 		),
 	})
 
+	s.router.Handle("/debugstore/usage", jsonhttp.MethodHandler{
+		"GET": web.ChainHandlers(
+			httpaccess.NewHTTPAccessSuppressLogHandler(),
+			web.FinalHandlerFunc(s.debugStorageUsage),
+		),
+	})
+
+	s.router.Handle("/debugstore/backup", jsonhttp.MethodHandler{
+		"GET": web.ChainHandlers(
+			httpaccess.NewHTTPAccessSuppressLogHandler(),
+			web.FinalHandlerFunc(s.debugStorageBackup),
+		),
+	})
+
 	s.router.Path("/metrics").Handler(web.ChainHandlers(
 		httpaccess.NewHTTPAccessSuppressLogHandler(),
 		web.FinalHandler(promhttp.InstrumentMetricHandler(
@@ -429,10 +443,45 @@ func (s *Service) mountBusinessDebug() {
 		"DELETE": http.HandlerFunc(s.peerDisconnectHandler),
 	})
 
+	handle("/bandwidth", jsonhttp.MethodHandler{
+		"GET": http.HandlerFunc(s.bandwidthHandler),
+	})
+
+	handle("/reputation", jsonhttp.MethodHandler{
+		"GET": http.HandlerFunc(s.reputationPeersHandler),
+	})
+
+	handle("/reputation/{address}", jsonhttp.MethodHandler{
+		"GET":    http.HandlerFunc(s.reputationPeerHandler),
+		"DELETE": http.HandlerFunc(s.reputationResetHandler),
+	})
+
+	handle("/addressbook", jsonhttp.MethodHandler{
+		"GET": http.HandlerFunc(s.addressBookPeersHandler),
+		"POST": web.ChainHandlers(
+			jsonhttp.NewMaxBodyBytesHandler(addressBookMaxRequestSize),
+			web.FinalHandlerFunc(s.addressBookImportHandler),
+		),
+		"DELETE": http.HandlerFunc(s.addressBookPruneHandler),
+	})
+
+	handle("/addressbook/{address}", jsonhttp.MethodHandler{
+		"GET":    http.HandlerFunc(s.addressBookPeerHandler),
+		"DELETE": http.HandlerFunc(s.addressBookRemoveHandler),
+	})
+
 	handle("/topology", jsonhttp.MethodHandler{
 		"GET": http.HandlerFunc(s.topologyHandler),
 	})
 
+	handle("/topology/history", jsonhttp.MethodHandler{
+		"GET": http.HandlerFunc(s.topologyHistoryHandler),
+	})
+
+	handle("/topology/view", jsonhttp.MethodHandler{
+		"GET": http.HandlerFunc(s.topologyViewHandler),
+	})
+
 	handle("/welcome-message", jsonhttp.MethodHandler{
 		"GET": http.HandlerFunc(s.getWelcomeMessageHandler),
 		"POST": web.ChainHandlers(
@@ -560,6 +609,23 @@ func (s *Service) mountBusinessDebug() {
 		})),
 	)
 
+	handle("/stamps/estimate", web.ChainHandlers(
+		s.postageSyncStatusCheckHandler,
+		web.FinalHandler(jsonhttp.MethodHandler{
+			"GET":  http.HandlerFunc(s.postageEstimateHandler),
+			"POST": http.HandlerFunc(s.postageEstimateHandler),
+		})),
+	)
+
+	handle("/stamps/import", web.ChainHandlers(
+		s.postageSyncStatusCheckHandler,
+		web.FinalHandler(jsonhttp.MethodHandler{
+			"POST": http.HandlerFunc(s.postageImportHandler),
+		})),
+	)
+
+	handle("/stamps/events", http.HandlerFunc(s.postageEventsWsHandler))
+
 	handle("/stamps/{batch_id}", web.ChainHandlers(
 		s.postageSyncStatusCheckHandler,
 		web.FinalHandler(jsonhttp.MethodHandler{
@@ -574,6 +640,46 @@ func (s *Service) mountBusinessDebug() {
 		})),
 	)
 
+	handle("/stamps/{batch_id}/buckets/{bucket_id}", web.ChainHandlers(
+		s.postageSyncStatusCheckHandler,
+		web.FinalHandler(jsonhttp.MethodHandler{
+			"GET": http.HandlerFunc(s.postageGetStampBucketSlotsHandler),
+		})),
+	)
+
+	handle("/stamps/{batch_id}/uploads", web.ChainHandlers(
+		s.postageSyncStatusCheckHandler,
+		web.FinalHandler(jsonhttp.MethodHandler{
+			"GET": http.HandlerFunc(s.postageGetUploadsHandler),
+		})),
+	)
+
+	handle("/stamps/{batch_id}/migration", web.ChainHandlers(
+		s.postageSyncStatusCheckHandler,
+		web.FinalHandler(jsonhttp.MethodHandler{
+			"GET":    http.HandlerFunc(s.postageGetMigrationHandler),
+			"POST":   http.HandlerFunc(s.postageStartMigrationHandler),
+			"DELETE": http.HandlerFunc(s.postageCancelMigrationHandler),
+		})),
+	)
+
+	handle("/stamps/{batch_id}/policy", web.ChainHandlers(
+		s.postageSyncStatusCheckHandler,
+		web.FinalHandler(jsonhttp.MethodHandler{
+			"GET":    http.HandlerFunc(s.postageGetPolicyHandler),
+			"PUT":    http.HandlerFunc(s.postageSetPolicyHandler),
+			"DELETE": http.HandlerFunc(s.postageRemovePolicyHandler),
+		})),
+	)
+
+	handle("/stamps/{batch_id}/export", web.ChainHandlers(
+		s.postageSyncStatusCheckHandler,
+		web.FinalHandler(jsonhttp.MethodHandler{
+			"POST":   http.HandlerFunc(s.postageExportHandler),
+			"DELETE": http.HandlerFunc(s.postageRevertExportHandler),
+		})),
+	)
+
 	handle("/stamps/{amount}/{depth}", web.ChainHandlers(
 		s.postageAccessHandler,
 		s.postageSyncStatusCheckHandler,
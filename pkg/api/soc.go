@@ -48,6 +48,7 @@ func (s *Service) socUploadHandler(w http.ResponseWriter, r *http.Request) {
 
 	headers := struct {
 		BatchID        []byte        `map:"Swarm-Postage-Batch-Id"`
+		BatchPool      string        `map:"Swarm-Postage-Batch-Pool"`
 		StampSig       []byte        `map:"Swarm-Postage-Stamp"`
 		Act            bool          `map:"Swarm-Act"`
 		HistoryAddress swarm.Address `map:"Swarm-Act-History-Address"`
@@ -57,7 +58,7 @@ func (s *Service) socUploadHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if len(headers.BatchID) == 0 && len(headers.StampSig) == 0 {
+	if len(headers.BatchID) == 0 && headers.BatchPool == "" && len(headers.StampSig) == 0 {
 		logger.Error(nil, batchIdOrStampSig)
 		jsonhttp.BadRequest(w, batchIdOrStampSig)
 		return
@@ -86,10 +87,11 @@ func (s *Service) socUploadHandler(w http.ResponseWriter, r *http.Request) {
 		}, &stamp)
 	} else {
 		putter, err = s.newStamperPutter(r.Context(), putterOptions{
-			BatchID:  headers.BatchID,
-			TagID:    0,
-			Pin:      false,
-			Deferred: false,
+			BatchID:   headers.BatchID,
+			BatchPool: headers.BatchPool,
+			TagID:     0,
+			Pin:       false,
+			Deferred:  false,
 		})
 	}
 	if err != nil {
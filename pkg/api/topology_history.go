@@ -0,0 +1,225 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"github.com/ethersphere/bee/v2/pkg/topology"
+)
+
+const (
+	topologyViewEvents = 50 // number of the most recent events shown by the view
+
+	topologyViewBinWidth    = 24
+	topologyViewBinsHeight  = 200
+	topologyViewDepthWidth  = topologyViewBinWidth * int(swarm.MaxBins)
+	topologyViewDepthHeight = 120
+)
+
+func (s *Service) topologyHistoryHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("get_topology_history").Build()
+
+	queries := struct {
+		Since int64 `map:"since"`
+	}{}
+	if response := s.mapStructure(r.URL.Query(), &queries); response != nil {
+		response("invalid query params", logger, w)
+		return
+	}
+
+	jsonhttp.OK(w, s.topologyHistory.History(time.Unix(queries.Since, 0)))
+}
+
+type topologyViewBin struct {
+	Bin                int
+	X                  int
+	PopulationY        int
+	PopulationHeight   int
+	Connected          int
+	ConnectedY         int
+	ConnectedHeight    int
+	Population         int
+	AboveStorageRadius bool
+}
+
+type topologyViewData struct {
+	Updated     time.Time
+	Depth       uint8
+	Radius      uint8
+	Connected   int
+	Population  int
+	Bins        []topologyViewBin
+	BinsWidth   int
+	BinsHeight  int
+	LabelY      int
+	RadiusX     int
+	DepthPoints string
+	DepthWidth  int
+	DepthHeight int
+	DepthFrom   time.Time
+	Events      []topology.HistoryEvent
+}
+
+var topologyViewTemplate = template.Must(template.New("topology").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Bee topology</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; font-size: 0.9em; }
+td, th { padding: 0.2em 0.8em; text-align: left; }
+tr:nth-child(even) { background: #f0f0f0; }
+.population { fill: #c6dbef; }
+.connected { fill: #2171b5; }
+.neighborhood { fill: #fd8d3c; }
+.depth { stroke: #e6550d; stroke-width: 2; fill: none; }
+.axis { stroke: #888; }
+</style>
+</head>
+<body>
+<h1>Topology</h1>
+{{if .Bins}}
+<p>Updated {{.Updated.Format "2006-01-02 15:04:05"}}: depth {{.Depth}}, storage radius {{.Radius}}, {{.Connected}} connected of {{.Population}} known peers.</p>
+<h2>Bins</h2>
+<svg width="{{.BinsWidth}}" height="{{.BinsHeight}}" viewBox="0 -10 {{.BinsWidth}} {{.BinsHeight}}">
+{{range .Bins}}<g><title>bin {{.Bin}}: {{.Connected}} connected of {{.Population}} known</title>
+<rect class="population" x="{{.X}}" y="{{.PopulationY}}" width="20" height="{{.PopulationHeight}}"/>
+<rect class="{{if .AboveStorageRadius}}neighborhood{{else}}connected{{end}}" x="{{.X}}" y="{{.ConnectedY}}" width="20" height="{{.ConnectedHeight}}"/>
+<text x="{{.X}}" y="{{$.LabelY}}" font-size="9">{{.Bin}}</text>
+</g>
+{{end}}<line class="depth" x1="{{.RadiusX}}" y1="-10" x2="{{.RadiusX}}" y2="{{.DepthHeight}}"/>
+</svg>
+<h2>Depth since {{.DepthFrom.Format "2006-01-02 15:04:05"}}</h2>
+<svg width="{{.DepthWidth}}" height="{{.DepthHeight}}">
+<line class="axis" x1="0" y1="{{.DepthHeight}}" x2="{{.DepthWidth}}" y2="{{.DepthHeight}}"/>
+<polyline class="depth" points="{{.DepthPoints}}"/>
+</svg>
+{{else}}
+<p>No topology samples recorded yet.</p>
+{{end}}
+<h2>Recent events</h2>
+<table>
+<tr><th>Time</th><th>Event</th><th>Peer</th><th>Bin</th><th>Direction</th><th>Reason</th><th>Depth</th><th>Storage radius</th></tr>
+{{range .Events}}<tr><td>{{.Timestamp.Format "2006-01-02 15:04:05"}}</td><td>{{.Type}}</td><td>{{with .Peer}}{{.}}{{end}}</td><td>{{if .Peer}}{{.Bin}}{{end}}</td><td>{{.Direction}}</td><td>{{.Reason}}</td><td>{{.Depth}}</td><td>{{.Radius}}</td></tr>
+{{end}}</table>
+</body>
+</html>
+`))
+
+// newTopologyViewData lays out the charts of the topology history.
+func newTopologyViewData(h topology.History) topologyViewData {
+	d := topologyViewData{
+		BinsWidth:   topologyViewBinWidth * int(swarm.MaxBins),
+		BinsHeight:  topologyViewBinsHeight,
+		LabelY:      topologyViewBinsHeight - 15,
+		DepthWidth:  topologyViewDepthWidth,
+		DepthHeight: topologyViewDepthHeight,
+	}
+
+	events := h.Events[max(len(h.Events)-topologyViewEvents, 0):]
+	d.Events = make([]topology.HistoryEvent, 0, len(events))
+	for i := len(events) - 1; i >= 0; i-- {
+		d.Events = append(d.Events, events[i])
+	}
+
+	if len(h.Samples) == 0 {
+		return d
+	}
+
+	last := h.Samples[len(h.Samples)-1]
+	d.Updated = last.Timestamp
+	d.Depth = last.Depth
+	d.Radius = last.Radius
+	d.Connected = last.Connected
+	d.Population = last.Population
+	d.RadiusX = int(last.Radius) * topologyViewBinWidth
+
+	// the bars are scaled to the most populated bin, leaving the
+	// room for the bin numbers at the bottom of the chart
+	height := topologyViewBinsHeight - 30
+	maxPopulation := 1
+	for _, p := range last.BinPopulation {
+		maxPopulation = max(maxPopulation, p)
+	}
+	for bin := range last.BinPopulation {
+		population := last.BinPopulation[bin]
+		connected := 0
+		if bin < len(last.BinConnected) {
+			connected = last.BinConnected[bin]
+		}
+		b := topologyViewBin{
+			Bin:                bin,
+			X:                  bin * topologyViewBinWidth,
+			Population:         population,
+			PopulationHeight:   population * height / maxPopulation,
+			Connected:          connected,
+			ConnectedHeight:    connected * height / maxPopulation,
+			AboveStorageRadius: bin >= int(last.Radius),
+		}
+		b.PopulationY = height - b.PopulationHeight
+		b.ConnectedY = height - b.ConnectedHeight
+		d.Bins = append(d.Bins, b)
+	}
+
+	// the chart of the kademlia depth spans the time of the samples
+	// with the full height for the deepest of them
+	d.DepthFrom = h.Samples[0].Timestamp
+	span := last.Timestamp.Sub(d.DepthFrom)
+	maxDepth := uint8(1)
+	for _, s := range h.Samples {
+		maxDepth = max(maxDepth, s.Depth)
+	}
+	points := make([]string, 0, len(h.Samples))
+	for _, s := range h.Samples {
+		x := 0
+		if span > 0 {
+			x = int(int64(s.Timestamp.Sub(d.DepthFrom)) * int64(topologyViewDepthWidth) / int64(span))
+		}
+		y := topologyViewDepthHeight - int(s.Depth)*(topologyViewDepthHeight-10)/int(maxDepth)
+		points = append(points, fmt.Sprintf("%d,%d", x, y))
+	}
+	d.DepthPoints = strings.Join(points, " ")
+
+	return d
+}
+
+func (s *Service) topologyViewHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("get_topology_view").Build()
+
+	if !s.TopologyView {
+		jsonhttp.NotFound(w, "topology view is disabled")
+		return
+	}
+
+	queries := struct {
+		Since int64 `map:"since"`
+	}{}
+	if response := s.mapStructure(r.URL.Query(), &queries); response != nil {
+		response("invalid query params", logger, w)
+		return
+	}
+
+	data := newTopologyViewData(s.topologyHistory.History(time.Unix(queries.Since, 0)))
+
+	var b bytes.Buffer
+	if err := topologyViewTemplate.Execute(&b, data); err != nil {
+		logger.Debug("render topology view failed", "error", err)
+		logger.Error(nil, "render topology view failed")
+		jsonhttp.InternalServerError(w, "render topology view failed")
+		return
+	}
+	w.Header().Set(ContentTypeHeader, "text/html; charset=utf-8")
+	_, _ = io.Copy(w, &b)
+}
@@ -6,9 +6,15 @@ package api_test
 
 import (
 	"net/http"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/ethersphere/bee/v2/pkg/api"
 	"github.com/ethersphere/bee/v2/pkg/jsonhttp/jsonhttptest"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"github.com/ethersphere/bee/v2/pkg/topology"
 )
 
 func TestTopologyOK(t *testing.T) {
@@ -24,3 +30,89 @@ func TestTopologyOK(t *testing.T) {
 		t.Error("empty response")
 	}
 }
+
+type topologyHistoryMock struct {
+	history topology.History
+	since   time.Time
+}
+
+func (m *topologyHistoryMock) History(since time.Time) topology.History {
+	m.since = since
+	return m.history
+}
+
+func TestTopologyHistory(t *testing.T) {
+	t.Parallel()
+
+	peer := swarm.RandAddress(t)
+	now := time.Unix(time.Now().Unix(), 0).UTC()
+	history := topology.History{
+		Samples: []topology.HistorySample{{
+			Timestamp:     now.Add(-time.Minute),
+			Depth:         2,
+			Radius:        1,
+			Connected:     3,
+			Population:    5,
+			BinConnected:  []int{1, 1, 1},
+			BinPopulation: []int{2, 2, 1},
+		}, {
+			Timestamp:     now,
+			Depth:         3,
+			Radius:        2,
+			Connected:     4,
+			Population:    6,
+			BinConnected:  []int{1, 1, 1, 1},
+			BinPopulation: []int{2, 2, 1, 1},
+		}},
+		Events: []topology.HistoryEvent{{
+			Timestamp: now,
+			Type:      topology.HistoryEventDisconnect,
+			Peer:      &peer,
+			Bin:       1,
+			Reason:    "pruned",
+			Depth:     3,
+			Radius:    2,
+		}},
+	}
+
+	t.Run("history", func(t *testing.T) {
+		t.Parallel()
+
+		mock := &topologyHistoryMock{history: history}
+		client, _, _, _ := newTestServer(t, testServerOptions{TopologyHistory: mock})
+
+		jsonhttptest.Request(t, client, http.MethodGet, "/topology/history?since="+strconv.FormatInt(now.Unix(), 10), http.StatusOK,
+			jsonhttptest.WithExpectedJSONResponse(history),
+		)
+		if !mock.since.Equal(now) {
+			t.Fatalf("got since %v, want %v", mock.since, now)
+		}
+
+		jsonhttptest.Request(t, client, http.MethodGet, "/topology/history?since=invalid", http.StatusBadRequest)
+	})
+
+	t.Run("view disabled", func(t *testing.T) {
+		t.Parallel()
+
+		client, _, _, _ := newTestServer(t, testServerOptions{TopologyHistory: &topologyHistoryMock{history: history}})
+
+		jsonhttptest.Request(t, client, http.MethodGet, "/topology/view", http.StatusNotFound)
+	})
+
+	t.Run("view", func(t *testing.T) {
+		t.Parallel()
+
+		client, _, _, _ := newTestServer(t, testServerOptions{TopologyHistory: &topologyHistoryMock{history: history}, TopologyView: true})
+
+		var body []byte
+		jsonhttptest.Request(t, client, http.MethodGet, "/topology/view", http.StatusOK,
+			jsonhttptest.WithExpectedResponseHeader(api.ContentTypeHeader, "text/html; charset=utf-8"),
+			jsonhttptest.WithPutResponseBody(&body),
+		)
+		for _, want := range []string{"<svg", "<polyline", peer.String(), "pruned", "depth 3, storage radius 2"} {
+			if !strings.Contains(string(body), want) {
+				t.Fatalf("view does not contain %q", want)
+			}
+		}
+	})
+}
@@ -213,6 +213,14 @@ func mapStructure(input, output interface{}, hooks map[string]func(v string) (st
 			if value == "" {
 				return nil // Nil slice.
 			}
+			if field.Type() == reflect.TypeOf(multiaddr.Multiaddr(nil)) {
+				val, err := multiaddr.NewMultiaddr(value)
+				if err != nil {
+					return err
+				}
+				field.Set(reflect.ValueOf(val))
+				return nil
+			}
 			val, err := hex.DecodeString(value)
 			if err != nil {
 				return err
@@ -251,15 +259,6 @@ func mapStructure(input, output interface{}, hooks map[string]func(v string) (st
 				}
 				field.Set(reflect.ValueOf(*val))
 			}
-		case reflect.Interface:
-			switch field.Type() {
-			case reflect.TypeOf((*multiaddr.Multiaddr)(nil)).Elem():
-				val, err := multiaddr.NewMultiaddr(value)
-				if err != nil {
-					return err
-				}
-				field.Set(reflect.ValueOf(val))
-			}
 		default:
 			return fmt.Errorf("unsupported type %T", field.Interface())
 		}
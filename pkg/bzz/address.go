@@ -114,7 +114,7 @@ func (a *Address) Equal(b *Address) bool {
 
 func multiaddrEqual(a, b ma.Multiaddr) bool {
 	if a == nil || b == nil {
-		return a == b
+		return a == nil && b == nil
 	}
 
 	return a.Equal(b)
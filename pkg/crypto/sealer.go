@@ -0,0 +1,69 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package crypto
+
+import (
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+// SealOverhead is the number of bytes the sealed data is longer than the plaintext.
+const SealOverhead = chacha20poly1305.NonceSizeX + chacha20poly1305.Overhead
+
+// ErrSealedDataInvalid is returned by Open if the sealed data is
+// malformed, was tampered with or was sealed with a different key.
+var ErrSealedDataInvalid = errors.New("sealed data invalid")
+
+// Sealer encrypts and authenticates data at rest with XChaCha20-Poly1305.
+// Every sealing uses a random nonce which is prepended to the ciphertext,
+// so the same plaintext never results in the same sealed data.
+type Sealer struct {
+	aead cipher.AEAD
+}
+
+// NewSealer returns a new Sealer for the given 32 bytes long key.
+func NewSealer(key []byte) (*Sealer, error) {
+	aead, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		return nil, fmt.Errorf("sealer: %w", err)
+	}
+	return &Sealer{aead: aead}, nil
+}
+
+// Seal appends the sealed plaintext to dst and returns the resulting slice.
+func (s *Sealer) Seal(dst, plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, chacha20poly1305.NonceSizeX)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("sealer: nonce: %w", err)
+	}
+	dst = append(dst, nonce...)
+	return s.aead.Seal(dst, nonce, plaintext, nil), nil
+}
+
+// Open appends the plaintext of the sealed data to dst and returns the resulting slice.
+func (s *Sealer) Open(dst, sealed []byte) ([]byte, error) {
+	if len(sealed) < SealOverhead {
+		return nil, ErrSealedDataInvalid
+	}
+	nonce, ciphertext := sealed[:chacha20poly1305.NonceSizeX], sealed[chacha20poly1305.NonceSizeX:]
+	plaintext, err := s.aead.Open(dst, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, ErrSealedDataInvalid
+	}
+	return plaintext, nil
+}
+
+// DeriveSymmetricKey derives a 32 bytes long symmetric key for the given
+// purpose from the private key, so that different purposes get unrelated keys.
+func DeriveSymmetricKey(key *ecdsa.PrivateKey, purpose string) ([]byte, error) {
+	d := make([]byte, 32)
+	key.D.FillBytes(d)
+	return LegacyKeccak256(append([]byte(purpose), d...))
+}
@@ -0,0 +1,75 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package crypto_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/crypto"
+)
+
+func TestSealer(t *testing.T) {
+	t.Parallel()
+
+	pk, err := crypto.GenerateSecp256k1Key()
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := crypto.DeriveSymmetricKey(pk, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, err := crypto.DeriveSymmetricKey(pk, "other")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(key, otherKey) {
+		t.Fatal("keys for different purposes must differ")
+	}
+
+	s, err := crypto.NewSealer(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	plaintext := []byte("swarm")
+	sealed1, err := s.Seal(nil, plaintext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sealed2, err := s.Seal(nil, plaintext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sealed1) != len(plaintext)+crypto.SealOverhead {
+		t.Fatalf("want sealed length %d, got %d", len(plaintext)+crypto.SealOverhead, len(sealed1))
+	}
+	if bytes.Equal(sealed1, sealed2) {
+		t.Fatal("sealing the same plaintext twice must give different results")
+	}
+
+	opened, err := s.Open(nil, sealed1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(opened, plaintext) {
+		t.Fatalf("want %q, got %q", plaintext, opened)
+	}
+
+	other, err := crypto.NewSealer(otherKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := other.Open(nil, sealed1); !errors.Is(err, crypto.ErrSealedDataInvalid) {
+		t.Fatalf("want error %v, got %v", crypto.ErrSealedDataInvalid, err)
+	}
+
+	sealed1[len(sealed1)-1] ^= 1
+	if _, err := s.Open(nil, sealed1); !errors.Is(err, crypto.ErrSealedDataInvalid) {
+		t.Fatalf("want error %v, got %v", crypto.ErrSealedDataInvalid, err)
+	}
+}
@@ -4,5 +4,21 @@
 
 package hive
 
+import (
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/ratelimit"
+)
+
 var MaxBatchSize = maxBatchSize
 var LimitBurst = limitBurst
+
+const UnderlayTTL = underlayTTL
+
+func (s *Service) SetContributionBurst(burst int) {
+	s.contributionLimiter = ratelimit.New(contributionRate, burst)
+}
+
+func (s *Service) ExpireUnderlays(now time.Time) {
+	s.expireUnderlays(now)
+}
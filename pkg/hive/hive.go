@@ -7,7 +7,11 @@
 // informed about other peers in the network. It gossips
 // about all peers by default and performs no specific
 // prioritization about which peers are gossipped to
-// others.
+// others. The received peer addresses are verified by
+// their signatures and reachability before they are
+// stored, the contributions of each gossiper are limited
+// and the underlays which are not confirmed for a while
+// are checked again and expired.
 package hive
 
 import (
@@ -27,6 +31,7 @@ import (
 	"github.com/ethersphere/bee/v2/pkg/p2p"
 	"github.com/ethersphere/bee/v2/pkg/p2p/protobuf"
 	"github.com/ethersphere/bee/v2/pkg/ratelimit"
+	"github.com/ethersphere/bee/v2/pkg/reputation"
 	"github.com/ethersphere/bee/v2/pkg/swarm"
 	ma "github.com/multiformats/go-multiaddr"
 	manet "github.com/multiformats/go-multiaddr/net"
@@ -43,50 +48,75 @@ const (
 	maxBatchSize           = 30
 	pingTimeout            = time.Second * 15 // time to wait for ping to succeed
 	batchValidationTimeout = 5 * time.Minute  // prevent lock contention on peer validation
+	underlayTTL            = 24 * time.Hour   // time after which the unconfirmed underlay of a gossiped peer is checked again
+	expiryInterval         = time.Hour        // interval of the checks of the stale underlays
 )
 
 var (
 	limitBurst = 4 * int(swarm.MaxBins)
 	limitRate  = time.Minute
 
+	// contributionBurst is the number of the new peers which a single
+	// gossiper can add to the addressbook before being limited to one
+	// new peer per contributionRate.
+	contributionBurst = 32 * int(swarm.MaxBins)
+	contributionRate  = 10 * time.Minute
+
 	ErrRateLimitExceeded = errors.New("rate limit exceeded")
 )
 
+// gossip is the message of the peers received from the gossiper.
+type gossip struct {
+	gossiper swarm.Address
+	peers    pb.Peers
+}
+
 type Service struct {
-	streamer          p2p.StreamerPinger
-	addressBook       addressbook.GetPutter
-	addPeersHandler   func(...swarm.Address)
-	networkID         uint64
-	logger            log.Logger
-	metrics           metrics
-	inLimiter         *ratelimit.Limiter
-	outLimiter        *ratelimit.Limiter
-	quit              chan struct{}
-	wg                sync.WaitGroup
-	peersChan         chan pb.Peers
-	sem               *semaphore.Weighted
-	bootnode          bool
-	allowPrivateCIDRs bool
+	streamer            p2p.StreamerPinger
+	addressBook         addressbook.Interface
+	addPeersHandler     func(...swarm.Address)
+	networkID           uint64
+	logger              log.Logger
+	metrics             metrics
+	inLimiter           *ratelimit.Limiter
+	outLimiter          *ratelimit.Limiter
+	contributionLimiter *ratelimit.Limiter
+	reputation          reputation.Recorder
+	quit                chan struct{}
+	wg                  sync.WaitGroup
+	peersChan           chan gossip
+	sem                 *semaphore.Weighted
+	bootnode            bool
+	allowPrivateCIDRs   bool
+	allowlist           *p2p.Allowlist
+
+	unconfirmedMu sync.Mutex
+	unconfirmed   map[string]time.Time // last verification of the gossiped peers not connected to since
 }
 
-func New(streamer p2p.StreamerPinger, addressbook addressbook.GetPutter, networkID uint64, bootnode bool, allowPrivateCIDRs bool, logger log.Logger) *Service {
+func New(streamer p2p.StreamerPinger, addressbook addressbook.Interface, networkID uint64, bootnode bool, allowPrivateCIDRs bool, logger log.Logger) *Service {
 	svc := &Service{
-		streamer:          streamer,
-		logger:            logger.WithName(loggerName).Register(),
-		addressBook:       addressbook,
-		networkID:         networkID,
-		metrics:           newMetrics(),
-		inLimiter:         ratelimit.New(limitRate, limitBurst),
-		outLimiter:        ratelimit.New(limitRate, limitBurst),
-		quit:              make(chan struct{}),
-		peersChan:         make(chan pb.Peers),
-		sem:               semaphore.NewWeighted(int64(swarm.MaxBins)),
-		bootnode:          bootnode,
-		allowPrivateCIDRs: allowPrivateCIDRs,
+		streamer:            streamer,
+		logger:              logger.WithName(loggerName).Register(),
+		addressBook:         addressbook,
+		networkID:           networkID,
+		metrics:             newMetrics(),
+		inLimiter:           ratelimit.New(limitRate, limitBurst),
+		outLimiter:          ratelimit.New(limitRate, limitBurst),
+		contributionLimiter: ratelimit.New(contributionRate, contributionBurst),
+		reputation:          reputation.NopRecorder,
+		quit:                make(chan struct{}),
+		peersChan:           make(chan gossip),
+		sem:                 semaphore.NewWeighted(int64(swarm.MaxBins)),
+		bootnode:            bootnode,
+		allowPrivateCIDRs:   allowPrivateCIDRs,
+		unconfirmed:         make(map[string]time.Time),
 	}
 
 	if !bootnode {
+		svc.seedUnconfirmed()
 		svc.startCheckPeersHandler()
+		svc.startExpireUnderlays()
 	}
 
 	return svc
@@ -102,6 +132,8 @@ func (s *Service) Protocol() p2p.ProtocolSpec {
 				Handler: s.peersHandler,
 			},
 		},
+		ConnectIn:     s.connect,
+		ConnectOut:    s.connect,
 		DisconnectIn:  s.disconnect,
 		DisconnectOut: s.disconnect,
 	}
@@ -144,6 +176,16 @@ func (s *Service) SetAddPeersHandler(h func(addr ...swarm.Address)) {
 	s.addPeersHandler = h
 }
 
+// SetAllowlist restricts the gossiped peers to the peers of the private network.
+func (s *Service) SetAllowlist(a *p2p.Allowlist) {
+	s.allowlist = a
+}
+
+// SetReputationRecorder sets the recorder of the gossipers misbehavior.
+func (s *Service) SetReputationRecorder(r reputation.Recorder) {
+	s.reputation = r
+}
+
 func (s *Service) Close() error {
 	close(s.quit)
 
@@ -190,6 +232,10 @@ func (s *Service) sendPeers(ctx context.Context, peer swarm.Address, peers []swa
 			continue // Don't advertise private CIDRs to the public network.
 		}
 
+		if !s.allowlist.Allowed(addr.Overlay, addr.Underlay) {
+			continue // Don't advertise peers outside of the private network.
+		}
+
 		peersRequest.Peers = append(peersRequest.Peers, &pb.BzzAddress{
 			Overlay:   addr.Overlay.Bytes(),
 			Underlay:  addr.Underlay.Bytes(),
@@ -220,6 +266,8 @@ func (s *Service) peersHandler(ctx context.Context, peer p2p.Peer, stream p2p.St
 
 	if !s.inLimiter.Allow(peer.Address.ByteString(), len(peersReq.Peers)) {
 		_ = stream.Reset()
+		s.metrics.RateLimitExceeded.Inc()
+		s.reputation.Record(peer.Address, reputation.EventGossipFlood)
 		return ErrRateLimitExceeded
 	}
 
@@ -233,7 +281,7 @@ func (s *Service) peersHandler(ctx context.Context, peer p2p.Peer, stream p2p.St
 	}
 
 	select {
-	case s.peersChan <- peersReq:
+	case s.peersChan <- gossip{gossiper: peer.Address, peers: peersReq}:
 	case <-s.quit:
 		return errors.New("failed to process peers, shutting down hive")
 	}
@@ -241,9 +289,19 @@ func (s *Service) peersHandler(ctx context.Context, peer p2p.Peer, stream p2p.St
 	return nil
 }
 
+// connect marks the underlay of the peer as confirmed by the connection.
+func (s *Service) connect(_ context.Context, peer p2p.Peer) error {
+	s.unconfirmedMu.Lock()
+	defer s.unconfirmedMu.Unlock()
+
+	delete(s.unconfirmed, peer.Address.ByteString())
+	return nil
+}
+
 func (s *Service) disconnect(peer p2p.Peer) error {
 	s.inLimiter.Clear(peer.Address.ByteString())
 	s.outLimiter.Clear(peer.Address.ByteString())
+	s.contributionLimiter.Clear(peer.Address.ByteString())
 	return nil
 }
 
@@ -263,25 +321,25 @@ func (s *Service) startCheckPeersHandler() {
 			select {
 			case <-ctx.Done():
 				return
-			case newPeers := <-s.peersChan:
+			case g := <-s.peersChan:
 				s.wg.Add(1)
 				go func() {
 					defer s.wg.Done()
 					cctx, cancel := context.WithTimeout(ctx, batchValidationTimeout)
 					defer cancel()
-					s.checkAndAddPeers(cctx, newPeers)
+					s.checkAndAddPeers(cctx, g.gossiper, g.peers)
 				}()
 			}
 		}
 	}()
 }
 
-func (s *Service) checkAndAddPeers(ctx context.Context, peers pb.Peers) {
+func (s *Service) checkAndAddPeers(ctx context.Context, gossiper swarm.Address, peers pb.Peers) {
 	var peersToAdd []swarm.Address
 	mtx := sync.Mutex{}
 	wg := sync.WaitGroup{}
 
-	addPeer := func(newPeer *pb.BzzAddress, multiUnderlay ma.Multiaddr) {
+	addPeer := func(bzzAddress *bzz.Address) {
 		err := s.sem.Acquire(ctx, 1)
 		if err != nil {
 			return
@@ -302,29 +360,23 @@ func (s *Service) checkAndAddPeers(ctx context.Context, peers pb.Peers) {
 			start := time.Now()
 
 			// check if the underlay is usable by doing a raw ping using libp2p
-			if _, err := s.streamer.Ping(ctx, multiUnderlay); err != nil {
+			if _, err := s.streamer.Ping(ctx, bzzAddress.Underlay); err != nil {
 				s.metrics.PingFailureTime.Observe(time.Since(start).Seconds())
 				s.metrics.UnreachablePeers.Inc()
-				s.logger.Debug("unreachable peer underlay", "peer_address", hex.EncodeToString(newPeer.Overlay), "underlay", multiUnderlay)
+				s.logger.Debug("unreachable peer underlay", "peer_address", bzzAddress.Overlay, "underlay", bzzAddress.Underlay)
 				return
 			}
 			s.metrics.PingTime.Observe(time.Since(start).Seconds())
 
 			s.metrics.ReachablePeers.Inc()
 
-			bzzAddress := bzz.Address{
-				Overlay:   swarm.NewAddress(newPeer.Overlay),
-				Underlay:  multiUnderlay,
-				Signature: newPeer.Signature,
-				Nonce:     newPeer.Nonce,
-			}
-
-			err := s.addressBook.Put(bzzAddress.Overlay, bzzAddress)
+			err := s.addressBook.Put(bzzAddress.Overlay, *bzzAddress)
 			if err != nil {
 				s.metrics.StorePeerErr.Inc()
-				s.logger.Warning("skipping peer in response", "peer_address", newPeer.String(), "error", err)
+				s.logger.Warning("skipping peer in response", "peer_address", bzzAddress.Overlay, "error", err)
 				return
 			}
+			s.setUnconfirmed(bzzAddress.Overlay)
 
 			mtx.Lock()
 			peersToAdd = append(peersToAdd, bzzAddress.Overlay)
@@ -332,6 +384,7 @@ func (s *Service) checkAndAddPeers(ctx context.Context, peers pb.Peers) {
 		}()
 	}
 
+	invalid := 0
 	for _, p := range peers.Peers {
 
 		multiUnderlay, err := ma.NewMultiaddrBytes(p.Underlay)
@@ -341,19 +394,167 @@ func (s *Service) checkAndAddPeers(ctx context.Context, peers pb.Peers) {
 			continue
 		}
 
+		if !s.allowlist.Allowed(swarm.NewAddress(p.Overlay), multiUnderlay) {
+			s.logger.Debug("skipping peer outside of the private network", "peer_address", hex.EncodeToString(p.Overlay), "underlay", multiUnderlay)
+			continue
+		}
+
+		// the address must be signed by the owner of the overlay
+		bzzAddress, err := bzz.ParseAddress(p.Underlay, p.Overlay, p.Signature, p.Nonce, true, s.networkID)
+		if err != nil {
+			invalid++
+			s.metrics.InvalidPeerAddress.Inc()
+			s.logger.Debug("invalid peer address", "peer_address", hex.EncodeToString(p.Overlay), "gossiper", gossiper, "error", err)
+			continue
+		}
+
 		// if peer exists already in the addressBook
 		// and if the underlays match, skip
-		addr, err := s.addressBook.Get(swarm.NewAddress(p.Overlay))
+		addr, err := s.addressBook.Get(bzzAddress.Overlay)
 		if err == nil && addr.Underlay.Equal(multiUnderlay) {
 			continue
 		}
 
+		if !s.contributionLimiter.Allow(gossiper.ByteString(), 1) {
+			s.metrics.ContributionLimitExceeded.Inc()
+			s.logger.Debug("skipping peer over the gossiper contribution limit", "peer_address", bzzAddress.Overlay, "gossiper", gossiper)
+			continue
+		}
+
 		// add peer does not exist in the addressbook
-		addPeer(p, multiUnderlay)
+		addPeer(bzzAddress)
 	}
 	wg.Wait()
 
+	if invalid > 0 {
+		s.reputation.Record(gossiper, reputation.EventInvalidAddress)
+	}
+
 	if s.addPeersHandler != nil && len(peersToAdd) > 0 {
 		s.addPeersHandler(peersToAdd...)
 	}
 }
+
+// seedUnconfirmed tracks the peers of the addressbook as verified now, so
+// that the underlays of the peers which are not connected to after a restart
+// are checked again and expire like the ones gossiped since the start.
+func (s *Service) seedUnconfirmed() {
+	now := time.Now()
+
+	s.unconfirmedMu.Lock()
+	defer s.unconfirmedMu.Unlock()
+
+	err := s.addressBook.IterateOverlays(func(overlay swarm.Address) (bool, error) {
+		s.unconfirmed[overlay.ByteString()] = now
+		return false, nil
+	})
+	if err != nil {
+		s.logger.Debug("seed unconfirmed peers failed", "error", err)
+	}
+}
+
+// setUnconfirmed records the verification of the underlay of the gossiped
+// peer, which is not yet confirmed by a connection to the peer.
+func (s *Service) setUnconfirmed(overlay swarm.Address) {
+	s.unconfirmedMu.Lock()
+	defer s.unconfirmedMu.Unlock()
+
+	s.unconfirmed[overlay.ByteString()] = time.Now()
+}
+
+func (s *Service) startExpireUnderlays() {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		ticker := time.NewTicker(expiryInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-s.quit:
+				return
+			case <-ticker.C:
+				s.expireUnderlays(time.Now())
+			}
+		}
+	}()
+}
+
+// expireUnderlays checks again the underlays of the gossiped peers which are
+// not confirmed by a connection for longer than the underlayTTL before now,
+// and removes the peers with unreachable underlays from the addressbook.
+func (s *Service) expireUnderlays(now time.Time) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-s.quit:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	var stale []swarm.Address
+	s.unconfirmedMu.Lock()
+	for k, verified := range s.unconfirmed {
+		if now.Sub(verified) > underlayTTL {
+			stale = append(stale, swarm.NewAddress([]byte(k)))
+		}
+	}
+	s.unconfirmedMu.Unlock()
+
+	var wg sync.WaitGroup
+	for _, overlay := range stale {
+		if err := s.sem.Acquire(ctx, 1); err != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func() {
+			defer func() {
+				s.sem.Release(1)
+				wg.Done()
+			}()
+
+			addr, err := s.addressBook.Get(overlay)
+			if err != nil {
+				s.forgetUnconfirmed(overlay)
+				return
+			}
+
+			pctx, cancel := context.WithTimeout(ctx, pingTimeout)
+			defer cancel()
+
+			if _, err := s.streamer.Ping(pctx, addr.Underlay); err == nil {
+				s.setUnconfirmed(overlay)
+				return
+			}
+			if ctx.Err() != nil {
+				return
+			}
+
+			if !s.forgetUnconfirmed(overlay) {
+				return // connected to in the meantime
+			}
+			if err := s.addressBook.Remove(overlay); err != nil {
+				s.logger.Debug("remove expired peer failed", "peer_address", overlay, "error", err)
+				return
+			}
+			s.metrics.ExpiredPeers.Inc()
+			s.logger.Debug("expired unreachable peer underlay", "peer_address", overlay, "underlay", addr.Underlay)
+		}()
+	}
+	wg.Wait()
+}
+
+// forgetUnconfirmed stops tracking the underlay of the gossiped
+// peer and reports whether it has been still tracked.
+func (s *Service) forgetUnconfirmed(overlay swarm.Address) bool {
+	s.unconfirmedMu.Lock()
+	defer s.unconfirmedMu.Unlock()
+
+	_, ok := s.unconfirmed[overlay.ByteString()]
+	delete(s.unconfirmed, overlay.ByteString())
+	return ok
+}
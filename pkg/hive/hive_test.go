@@ -10,6 +10,8 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -22,18 +24,17 @@ import (
 	"github.com/ethersphere/bee/v2/pkg/hive"
 	"github.com/ethersphere/bee/v2/pkg/hive/pb"
 	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/p2p"
 	"github.com/ethersphere/bee/v2/pkg/p2p/protobuf"
 	"github.com/ethersphere/bee/v2/pkg/p2p/streamtest"
+	"github.com/ethersphere/bee/v2/pkg/reputation"
 	"github.com/ethersphere/bee/v2/pkg/spinlock"
 	"github.com/ethersphere/bee/v2/pkg/statestore/mock"
 	"github.com/ethersphere/bee/v2/pkg/swarm"
 	"github.com/ethersphere/bee/v2/pkg/util/testutil"
 )
 
-var (
-	nonce = common.HexToHash("0x2").Bytes()
-	block = common.HexToHash("0x1").Bytes()
-)
+var nonce = common.HexToHash("0x2").Bytes()
 
 const spinTimeout = time.Second * 5
 
@@ -73,7 +74,7 @@ func TestHandlerRateLimit(t *testing.T) {
 			t.Fatal(err)
 		}
 		signer := crypto.NewDefaultSigner(pk)
-		overlay, err := crypto.NewOverlayAddress(pk.PublicKey, networkID, block)
+		overlay, err := crypto.NewOverlayAddress(pk.PublicKey, networkID, nonce)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -141,7 +142,7 @@ func TestBroadcastPeers(t *testing.T) {
 			t.Fatal(err)
 		}
 		signer := crypto.NewDefaultSigner(pk)
-		overlay, err := crypto.NewOverlayAddress(pk.PublicKey, networkID, block)
+		overlay, err := crypto.NewOverlayAddress(pk.PublicKey, networkID, nonce)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -173,6 +174,8 @@ func TestBroadcastPeers(t *testing.T) {
 		wantBzzAddresses  []bzz.Address
 		allowPrivateCIDRs bool
 		pingErr           func(addr ma.Multiaddr) (time.Duration, error)
+		clientAllowlist   *p2p.Allowlist
+		serverAllowlist   *p2p.Allowlist
 	}{
 		"OK - single record": {
 			addresee:          swarm.MustParseHexAddress("ca1e9f3938cc1425c6061b96ad9eb93e134dfe8734ad490164ef20af9d1cf59c"),
@@ -230,6 +233,24 @@ func TestBroadcastPeers(t *testing.T) {
 				return rtt, nil
 			},
 		},
+		"OK - don't advertise peers outside of the private network": {
+			addresee:          swarm.MustParseHexAddress("ca1e9f3938cc1425c6061b96ad9eb93e134dfe8734ad490164ef20af9d1cf59c"),
+			peers:             overlays[:15],
+			wantMsgs:          []pb.Peers{{Peers: wantMsgs[0].Peers[:5]}},
+			wantOverlays:      overlays[:5],
+			wantBzzAddresses:  bzzAddresses[:5],
+			allowPrivateCIDRs: true,
+			clientAllowlist:   p2p.NewAllowlist(overlays[:5], nil),
+		},
+		"OK - skip received peers outside of the private network": {
+			addresee:          swarm.MustParseHexAddress("ca1e9f3938cc1425c6061b96ad9eb93e134dfe8734ad490164ef20af9d1cf59c"),
+			peers:             overlays[:15],
+			wantMsgs:          []pb.Peers{{Peers: wantMsgs[0].Peers[:15]}},
+			wantOverlays:      overlays[:5],
+			wantBzzAddresses:  bzzAddresses[:5],
+			allowPrivateCIDRs: true,
+			serverAllowlist:   p2p.NewAllowlist(overlays[:5], nil),
+		},
 		"Ok - don't advertise private CIDRs": {
 			addresee:          overlays[len(overlays)-1],
 			peers:             overlays[:15],
@@ -255,6 +276,7 @@ func TestBroadcastPeers(t *testing.T) {
 			}
 			// create a hive server that handles the incoming stream
 			server := hive.New(streamer, addressbookclean, networkID, false, true, logger)
+			server.SetAllowlist(tc.serverAllowlist)
 			testutil.CleanupCloser(t, server)
 
 			// setup the stream recorder to record stream data
@@ -264,6 +286,7 @@ func TestBroadcastPeers(t *testing.T) {
 
 			// create a hive client that will do broadcast
 			client := hive.New(recorder, addressbook, networkID, false, tc.allowPrivateCIDRs, logger)
+			client.SetAllowlist(tc.clientAllowlist)
 			if err := client.BroadcastPeers(context.Background(), tc.addresee, tc.peers...); err != nil {
 				t.Fatal(err)
 			}
@@ -296,6 +319,178 @@ func TestBroadcastPeers(t *testing.T) {
 	}
 }
 
+func TestVerifyPeers(t *testing.T) {
+	t.Parallel()
+
+	logger := log.Noop
+	networkID := uint64(1)
+	addressbook := ab.New(mock.NewStateStore())
+
+	var valid []bzz.Address
+	for i := 0; i < 3; i++ {
+		valid = append(valid, newBzzAddress(t, networkID, i))
+	}
+	// the overlay is not derived from the key of the signer
+	foreign := newBzzAddress(t, networkID, 3)
+	foreign.Overlay = swarm.RandAddress(t)
+	// the underlay is not the one signed
+	forged := newBzzAddress(t, networkID, 4)
+	forged.Underlay = valid[0].Underlay
+
+	var peers []swarm.Address
+	for _, a := range append(valid, foreign, forged) {
+		if err := addressbook.Put(a.Overlay, a); err != nil {
+			t.Fatal(err)
+		}
+		peers = append(peers, a.Overlay)
+	}
+
+	addressbookclean := ab.New(mock.NewStateStore())
+	rep := new(reputationRecorder)
+	server := hive.New(streamtest.New(), addressbookclean, networkID, false, true, logger)
+	server.SetReputationRecorder(rep)
+	testutil.CleanupCloser(t, server)
+
+	gossiper := swarm.RandAddress(t)
+	recorder := streamtest.New(
+		streamtest.WithProtocols(server.Protocol()),
+		streamtest.WithBaseAddr(gossiper),
+	)
+
+	client := hive.New(recorder, addressbook, networkID, false, true, logger)
+	testutil.CleanupCloser(t, client)
+	if err := client.BroadcastPeers(context.Background(), swarm.RandAddress(t), peers...); err != nil {
+		t.Fatal(err)
+	}
+
+	expectBzzAddresessEventually(t, addressbookclean, valid)
+
+	err := spinlock.Wait(spinTimeout, func() bool {
+		return rep.count(gossiper, reputation.EventInvalidAddress) == 1
+	})
+	if err != nil {
+		t.Fatal("invalid address event not recorded for the gossiper")
+	}
+}
+
+func TestContributionLimit(t *testing.T) {
+	t.Parallel()
+
+	logger := log.Noop
+	networkID := uint64(1)
+	addressbook := ab.New(mock.NewStateStore())
+
+	var addresses []bzz.Address
+	var peers []swarm.Address
+	for i := 0; i < 10; i++ {
+		a := newBzzAddress(t, networkID, i)
+		if err := addressbook.Put(a.Overlay, a); err != nil {
+			t.Fatal(err)
+		}
+		addresses = append(addresses, a)
+		peers = append(peers, a.Overlay)
+	}
+
+	addressbookclean := ab.New(mock.NewStateStore())
+	server := hive.New(streamtest.New(), addressbookclean, networkID, false, true, logger)
+	server.SetContributionBurst(4)
+	testutil.CleanupCloser(t, server)
+
+	broadcast := func(gossiper swarm.Address, peers []swarm.Address) {
+		t.Helper()
+
+		recorder := streamtest.New(
+			streamtest.WithProtocols(server.Protocol()),
+			streamtest.WithBaseAddr(gossiper),
+		)
+		client := hive.New(recorder, addressbook, networkID, false, true, logger)
+		testutil.CleanupCloser(t, client)
+		if err := client.BroadcastPeers(context.Background(), swarm.RandAddress(t), peers...); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// a single gossiper contributes only up to the limit
+	gossiper := swarm.RandAddress(t)
+	broadcast(gossiper, peers[:6])
+	expectOverlaysEventually(t, addressbookclean, peers[:4])
+
+	broadcast(gossiper, peers[6:])
+	time.Sleep(100 * time.Millisecond)
+	expectOverlaysEventually(t, addressbookclean, peers[:4])
+
+	// the other gossipers are not limited by the first one
+	broadcast(swarm.RandAddress(t), peers[4:8])
+	expectBzzAddresessEventually(t, addressbookclean, addresses[:8])
+
+	// the contribution of the gossiper is forgotten on disconnect
+	if err := server.Protocol().DisconnectIn(p2p.Peer{Address: gossiper}); err != nil {
+		t.Fatal(err)
+	}
+	broadcast(gossiper, peers[8:])
+	expectBzzAddresessEventually(t, addressbookclean, addresses)
+}
+
+func TestExpireUnderlays(t *testing.T) {
+	t.Parallel()
+
+	logger := log.Noop
+	networkID := uint64(1)
+	addressbook := ab.New(mock.NewStateStore())
+
+	var addresses []bzz.Address
+	var peers []swarm.Address
+	for i := 0; i < 3; i++ {
+		a := newBzzAddress(t, networkID, i)
+		if err := addressbook.Put(a.Overlay, a); err != nil {
+			t.Fatal(err)
+		}
+		addresses = append(addresses, a)
+		peers = append(peers, a.Overlay)
+	}
+
+	var unreachable atomic.Bool
+	streamer := streamtest.New(streamtest.WithPingErr(func(addr ma.Multiaddr) (time.Duration, error) {
+		// the first peer stays reachable
+		if unreachable.Load() && !addr.Equal(addresses[0].Underlay) {
+			return 0, errors.New("ping failure")
+		}
+		return 0, nil
+	}))
+
+	addressbookclean := ab.New(mock.NewStateStore())
+	server := hive.New(streamer, addressbookclean, networkID, false, true, logger)
+	testutil.CleanupCloser(t, server)
+
+	recorder := streamtest.New(streamtest.WithProtocols(server.Protocol()))
+	client := hive.New(recorder, addressbook, networkID, false, true, logger)
+	testutil.CleanupCloser(t, client)
+	if err := client.BroadcastPeers(context.Background(), swarm.RandAddress(t), peers...); err != nil {
+		t.Fatal(err)
+	}
+	expectOverlaysEventually(t, addressbookclean, peers)
+
+	// the second peer is confirmed by the connection
+	if err := server.Protocol().ConnectOut(context.Background(), p2p.Peer{Address: peers[1]}); err != nil {
+		t.Fatal(err)
+	}
+
+	unreachable.Store(true)
+
+	server.ExpireUnderlays(time.Now())
+	expectOverlaysEventually(t, addressbookclean, peers)
+
+	server.ExpireUnderlays(time.Now().Add(hive.UnderlayTTL + time.Minute))
+	expectOverlaysEventually(t, addressbookclean, peers[:2])
+
+	// the peers of the addressbook are checked again after a restart
+	restarted := hive.New(streamer, addressbook, networkID, false, true, logger)
+	testutil.CleanupCloser(t, restarted)
+
+	restarted.ExpireUnderlays(time.Now().Add(hive.UnderlayTTL + time.Minute))
+	expectOverlaysEventually(t, addressbook, peers[:1])
+}
+
 func expectOverlaysEventually(t *testing.T, exporter ab.Interface, wantOverlays []swarm.Address) {
 	t.Helper()
 
@@ -380,3 +575,54 @@ func readAndAssertPeersMsgs(in []byte, expectedLen int) ([]pb.Peers, error) {
 
 	return peers, nil
 }
+
+// newBzzAddress returns a valid address of a new peer with the i-th underlay.
+func newBzzAddress(t *testing.T, networkID uint64, i int) bzz.Address {
+	t.Helper()
+
+	underlay, err := ma.NewMultiaddr("/ip4/127.0.0.1/udp/" + strconv.Itoa(i))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pk, err := crypto.GenerateSecp256k1Key()
+	if err != nil {
+		t.Fatal(err)
+	}
+	overlay, err := crypto.NewOverlayAddress(pk.PublicKey, networkID, nonce)
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr, err := bzz.NewAddress(crypto.NewDefaultSigner(pk), underlay, overlay, networkID, nonce)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return *addr
+}
+
+type reputationRecorder struct {
+	mu     sync.Mutex
+	events map[string][]reputation.Event
+}
+
+func (r *reputationRecorder) Record(peer swarm.Address, event reputation.Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.events == nil {
+		r.events = make(map[string][]reputation.Event)
+	}
+	r.events[peer.ByteString()] = append(r.events[peer.ByteString()], event)
+}
+
+func (r *reputationRecorder) count(peer swarm.Address, event reputation.Event) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	n := 0
+	for _, e := range r.events[peer.ByteString()] {
+		if e == event {
+			n++
+		}
+	}
+	return n
+}
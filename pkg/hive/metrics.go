@@ -25,6 +25,11 @@ type metrics struct {
 	PeerUnderlayErr     prometheus.Counter
 	StorePeerErr        prometheus.Counter
 	ReachablePeers      prometheus.Counter
+
+	InvalidPeerAddress        prometheus.Counter
+	RateLimitExceeded         prometheus.Counter
+	ContributionLimitExceeded prometheus.Counter
+	ExpiredPeers              prometheus.Counter
 }
 
 func newMetrics() metrics {
@@ -103,6 +108,30 @@ func newMetrics() metrics {
 			Name:      "reachable_peers_count",
 			Help:      "Number of peers that are reachable.",
 		}),
+		InvalidPeerAddress: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "invalid_peer_address_count",
+			Help:      "Number of received peer addresses with invalid signatures.",
+		}),
+		RateLimitExceeded: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "rate_limit_exceeded_count",
+			Help:      "Number of peer messages rejected by the rate limit.",
+		}),
+		ContributionLimitExceeded: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "contribution_limit_exceeded_count",
+			Help:      "Number of received peers skipped over the gossiper contribution limit.",
+		}),
+		ExpiredPeers: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "expired_peers_count",
+			Help:      "Number of peers removed from the addressbook with the expired underlays.",
+		}),
 	}
 }
 
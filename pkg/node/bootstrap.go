@@ -28,6 +28,7 @@ import (
 	"github.com/ethersphere/bee/v2/pkg/hive"
 	"github.com/ethersphere/bee/v2/pkg/log"
 	"github.com/ethersphere/bee/v2/pkg/manifest"
+	"github.com/ethersphere/bee/v2/pkg/p2p"
 	"github.com/ethersphere/bee/v2/pkg/p2p/libp2p"
 	"github.com/ethersphere/bee/v2/pkg/postage"
 	"github.com/ethersphere/bee/v2/pkg/pricer"
@@ -96,13 +97,25 @@ func bootstrapNode(
 		retErr = multierror.Append(new(multierror.Error), retErr, b.Shutdown()).ErrorOrNil()
 	}()
 
+	var allowlist *p2p.Allowlist
+	if len(o.AllowlistOverlays) > 0 || len(o.AllowlistUnderlays) > 0 {
+		allowlist = p2p.NewAllowlist(o.AllowlistOverlays, o.AllowlistUnderlays)
+	}
+
 	p2ps, err := libp2p.New(p2pCtx, signer, networkID, swarmAddress, addr, addressbook, stateStore, lightNodes, logger, tracer, libp2p.Options{
-		PrivateKey:     libp2pPrivateKey,
-		NATAddr:        o.NATAddr,
-		EnableWS:       o.EnableWS,
-		WelcomeMessage: o.WelcomeMessage,
-		FullNode:       false,
-		Nonce:          nonce,
+		PrivateKey:              libp2pPrivateKey,
+		NATAddr:                 o.NATAddr,
+		EnableWS:                o.EnableWS,
+		QUICAddr:                o.QUICAddr,
+		WebTransportAddr:        o.WebTransportAddr,
+		EnableHolePunching:      o.EnableHolePunching,
+		PrivateNetworkKey:       o.PrivateNetworkKey,
+		Allowlist:               allowlist,
+		BandwidthLimit:          o.BandwidthLimit,
+		ProtocolBandwidthLimits: o.ProtocolBandwidthLimits,
+		WelcomeMessage:          o.WelcomeMessage,
+		FullNode:                false,
+		Nonce:                   nonce,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("p2p service: %w", err)
@@ -111,6 +124,7 @@ func bootstrapNode(
 	b.p2pHalter = p2ps
 
 	hive := hive.New(p2ps, addressbook, networkID, o.BootnodeMode, o.AllowPrivateCIDRs, logger)
+	hive.SetAllowlist(allowlist)
 
 	if err = p2ps.AddProtocol(hive.Protocol()); err != nil {
 		return nil, fmt.Errorf("hive service: %w", err)
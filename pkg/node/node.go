@@ -43,7 +43,11 @@ import (
 	"github.com/ethersphere/bee/v2/pkg/postage/batchservice"
 	"github.com/ethersphere/bee/v2/pkg/postage/batchstore"
 	"github.com/ethersphere/bee/v2/pkg/postage/listener"
+	"github.com/ethersphere/bee/v2/pkg/postage/migration"
+	"github.com/ethersphere/bee/v2/pkg/postage/notifier"
+	"github.com/ethersphere/bee/v2/pkg/postage/policy"
 	"github.com/ethersphere/bee/v2/pkg/postage/postagecontract"
+	"github.com/ethersphere/bee/v2/pkg/postage/stampsigner"
 	"github.com/ethersphere/bee/v2/pkg/pricer"
 	"github.com/ethersphere/bee/v2/pkg/pricing"
 	"github.com/ethersphere/bee/v2/pkg/pss"
@@ -51,6 +55,7 @@ import (
 	"github.com/ethersphere/bee/v2/pkg/pullsync"
 	"github.com/ethersphere/bee/v2/pkg/pusher"
 	"github.com/ethersphere/bee/v2/pkg/pushsync"
+	"github.com/ethersphere/bee/v2/pkg/reputation"
 	"github.com/ethersphere/bee/v2/pkg/resolver/multiresolver"
 	"github.com/ethersphere/bee/v2/pkg/retrieval"
 	"github.com/ethersphere/bee/v2/pkg/salud"
@@ -70,6 +75,7 @@ import (
 	"github.com/ethersphere/bee/v2/pkg/topology"
 	"github.com/ethersphere/bee/v2/pkg/topology/kademlia"
 	"github.com/ethersphere/bee/v2/pkg/topology/lightnode"
+	"github.com/ethersphere/bee/v2/pkg/topology/peerscore"
 	"github.com/ethersphere/bee/v2/pkg/tracing"
 	"github.com/ethersphere/bee/v2/pkg/transaction"
 	"github.com/ethersphere/bee/v2/pkg/util/abiutil"
@@ -111,12 +117,16 @@ type Bee struct {
 	transactionCloser        io.Closer
 	listenerCloser           io.Closer
 	postageServiceCloser     io.Closer
+	postagePolicyCloser      io.Closer
+	batchMigrationCloser     io.Closer
+	postageNotifierCloser    io.Closer
 	priceOracleCloser        io.Closer
 	hiveCloser               io.Closer
 	saludCloser              io.Closer
 	storageIncetivesCloser   io.Closer
 	pushSyncCloser           io.Closer
 	retrievalCloser          io.Closer
+	reputationCloser         io.Closer
 	shutdownInProgress       bool
 	shutdownMutex            sync.Mutex
 	syncingStopped           *syncutil.Signaler
@@ -136,13 +146,29 @@ type Options struct {
 	ChainID                       int64
 	ChequebookEnable              bool
 	CORSAllowedOrigins            []string
+	TopologyView                  bool
 	DataDir                       string
 	DBBlockCacheCapacity          uint64
 	DBDisableSeeksCompaction      bool
+	DBEncryptionKey               []byte
 	DBOpenFilesLimit              uint64
+	DBScrubInterval               time.Duration
+	DBScrubRate                   int
 	DBWriteBufferSize             uint64
 	EnableStorageIncentives       bool
 	EnableWS                      bool
+	QUICAddr                      string
+	WebTransportAddr              string
+	EnableRelayService            bool
+	EnableHolePunching            bool
+	PrivateNetworkKey             []byte
+	AllowlistOverlays             []swarm.Address
+	AllowlistUnderlays            []ma.Multiaddr
+	BandwidthLimit                int64
+	ProtocolBandwidthLimits       map[string]int64
+	PeerScoreLatencyWeight        float64
+	PeerScoreHealthWeight         float64
+	PeerScoreReliabilityWeight    float64
 	FullNodeMode                  bool
 	Logger                        log.Logger
 	MinimumStorageRadius          uint
@@ -154,6 +180,14 @@ type Options struct {
 	PaymentTolerance              int64
 	PostageContractAddress        string
 	PostageContractStartBlock     uint64
+	PostageEventsFileHash         string
+	PostageEventsFile             string
+	PostageNotifyBucketSaturation uint8
+	PostageNotifyTTLThresholds    []time.Duration
+	PostageTopUpLimit             string
+	PostageSignerEndpoint         string
+	PostageSignerToken            string
+	PostageWebhookURLs            []string
 	PriceOracleAddress            string
 	RedistributionContractAddress string
 	ReserveCapacityDoubling       int
@@ -368,7 +402,9 @@ func NewBee(
 	var batchStore postage.Storer = new(postage.NoOpBatchStore)
 	var evictFn func([]byte) error
 
-	if chainEnabled {
+	// the nodes without chain access keep a batch store
+	// only when it is bootstrapped from a postage events file
+	if chainEnabled || o.PostageEventsFile != "" {
 		batchStore, err = batchstore.New(
 			stateStore,
 			func(id []byte) error {
@@ -603,10 +639,39 @@ func NewBee(
 		logger.Debug("node warmup check: period complete.", "periodEndTime", t, "eventsInPeriod", periodCount, "rateStdDev", stDev)
 	}
 
+	chainCfg, found := config.GetByChainID(chainID)
+	postageStampContractAddress, postageSyncStart := chainCfg.PostageStampAddress, chainCfg.PostageStampStartBlock
+	if o.PostageContractAddress != "" {
+		if !common.IsHexAddress(o.PostageContractAddress) {
+			return nil, errors.New("malformed postage stamp address")
+		}
+		postageStampContractAddress = common.HexToAddress(o.PostageContractAddress)
+		if o.PostageContractStartBlock == 0 {
+			return nil, errors.New("postage contract start block option not provided")
+		}
+		postageSyncStart = o.PostageContractStartBlock
+	} else if !found {
+		return nil, errors.New("no known postage stamp addresses for this network")
+	}
+
+	postageStampContractABI := abiutil.MustParseABI(chainCfg.PostageStampABI)
+
 	var initBatchState *postage.ChainSnapshot
 	// Bootstrap node with postage snapshot only if it is running on mainnet, is a fresh
 	// install or explicitly asked by user to resync
-	if networkID == mainnetNetworkID && o.UsePostageSnapshot && (!batchStoreExists || o.Resync) {
+	if o.PostageEventsFile != "" && (!batchStoreExists || o.Resync) {
+		logger.Info("cold postage start detected. reading postage events from file", "file", o.PostageEventsFile)
+		initBatchState, err = readPostageEventsFile(o.PostageEventsFile, o.PostageEventsFileHash, chainEnabled, postageSyncStart)
+		if err != nil {
+			return nil, fmt.Errorf("postage events file: %w", err)
+		}
+		if chainEnabled {
+			if err := VerifyPostageEvents(ctx, chainBackend, initBatchState); err != nil {
+				return nil, fmt.Errorf("postage events file: %w", err)
+			}
+		}
+		logger.Info("postage events read", "events", len(initBatchState.Events), "last_block", initBatchState.LastBlockNumber)
+	} else if networkID == mainnetNetworkID && o.UsePostageSnapshot && (!batchStoreExists || o.Resync) {
 		start := time.Now()
 		logger.Info("cold postage start detected. fetching postage stamp snapshot from swarm")
 		initBatchState, err = bootstrapNode(
@@ -637,15 +702,28 @@ func NewBee(
 		registry = apiService.MetricsRegistry()
 	}
 
+	var allowlist *p2p.Allowlist
+	if len(o.AllowlistOverlays) > 0 || len(o.AllowlistUnderlays) > 0 {
+		allowlist = p2p.NewAllowlist(o.AllowlistOverlays, o.AllowlistUnderlays)
+	}
+
 	p2ps, err := libp2p.New(ctx, signer, networkID, swarmAddress, addr, addressbook, stateStore, lightNodes, logger, tracer, libp2p.Options{
-		PrivateKey:      libp2pPrivateKey,
-		NATAddr:         o.NATAddr,
-		EnableWS:        o.EnableWS,
-		WelcomeMessage:  o.WelcomeMessage,
-		FullNode:        o.FullNodeMode,
-		Nonce:           nonce,
-		ValidateOverlay: chainEnabled,
-		Registry:        registry,
+		PrivateKey:              libp2pPrivateKey,
+		NATAddr:                 o.NATAddr,
+		EnableWS:                o.EnableWS,
+		QUICAddr:                o.QUICAddr,
+		WebTransportAddr:        o.WebTransportAddr,
+		EnableRelayService:      o.EnableRelayService,
+		EnableHolePunching:      o.EnableHolePunching,
+		PrivateNetworkKey:       o.PrivateNetworkKey,
+		Allowlist:               allowlist,
+		BandwidthLimit:          o.BandwidthLimit,
+		ProtocolBandwidthLimits: o.ProtocolBandwidthLimits,
+		WelcomeMessage:          o.WelcomeMessage,
+		FullNode:                o.FullNodeMode,
+		Nonce:                   nonce,
+		ValidateOverlay:         chainEnabled,
+		Registry:                registry,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("p2p service: %w", err)
@@ -656,12 +734,50 @@ func NewBee(
 	b.p2pService = p2ps
 	b.p2pHalter = p2ps
 
-	post, err := postage.NewService(logger, stamperStore, batchStore, chainID)
+	// the stamps are signed by the node key unless a remote signer
+	// holds the key of the batch owner
+	batchOwner := overlayEthAddress
+	var stampSigner postage.Signer
+	if o.PostageSignerEndpoint != "" {
+		remoteSigner := stampsigner.New(o.PostageSignerEndpoint, o.PostageSignerToken, nil)
+		batchOwner, err = remoteSigner.EthereumAddress()
+		if err != nil {
+			return nil, fmt.Errorf("postage signer: %w", err)
+		}
+		stampSigner = remoteSigner
+		logger.Info("using remote postage stamp signer", "endpoint", o.PostageSignerEndpoint, "batch_owner", batchOwner)
+	}
+
+	post, err := postage.NewService(logger, stamperStore, batchStore, chainID, batchOwner.Bytes())
 	if err != nil {
 		return nil, fmt.Errorf("postage service: %w", err)
 	}
 	b.postageServiceCloser = post
-	batchStore.SetBatchExpiryHandler(post)
+
+	var (
+		syncErr    atomic.Value
+		syncStatus atomic.Value
+
+		syncStatusFn = func() (isDone bool, err error) {
+			iErr := syncErr.Load()
+			if iErr != nil {
+				err = iErr.(error)
+			}
+			isDone = syncStatus.Load() != nil
+			return isDone, err
+		}
+	)
+
+	postageNotifier := notifier.New(post, batchStore, logger, notifier.Options{
+		WebhookURLs:         o.PostageWebhookURLs,
+		TTLThresholds:       o.PostageNotifyTTLThresholds,
+		SaturationThreshold: o.PostageNotifyBucketSaturation,
+		BlockTime:           o.BlockTime,
+		Synced:              syncStatusFn,
+	})
+	postageNotifier.Start()
+	b.postageNotifierCloser = postageNotifier
+	batchStore.SetBatchExpiryHandler(postageNotifier.BatchExpiryHandler(post))
 
 	var (
 		postageStampContractService postagecontract.Interface
@@ -669,23 +785,6 @@ func NewBee(
 		eventListener               postage.Listener
 	)
 
-	chainCfg, found := config.GetByChainID(chainID)
-	postageStampContractAddress, postageSyncStart := chainCfg.PostageStampAddress, chainCfg.PostageStampStartBlock
-	if o.PostageContractAddress != "" {
-		if !common.IsHexAddress(o.PostageContractAddress) {
-			return nil, errors.New("malformed postage stamp address")
-		}
-		postageStampContractAddress = common.HexToAddress(o.PostageContractAddress)
-		if o.PostageContractStartBlock == 0 {
-			return nil, errors.New("postage contract start block option not provided")
-		}
-		postageSyncStart = o.PostageContractStartBlock
-	} else if !found {
-		return nil, errors.New("no known postage stamp addresses for this network")
-	}
-
-	postageStampContractABI := abiutil.MustParseABI(chainCfg.PostageStampABI)
-
 	bzzTokenAddress, err := postagecontract.LookupERC20Address(ctx, transactionService, postageStampContractAddress, postageStampContractABI, chainEnabled)
 	if err != nil {
 		return nil, fmt.Errorf("lookup erc20 postage address: %w", err)
@@ -693,6 +792,7 @@ func NewBee(
 
 	postageStampContractService = postagecontract.New(
 		overlayEthAddress,
+		batchOwner,
 		postageStampContractAddress,
 		postageStampContractABI,
 		bzzTokenAddress,
@@ -706,7 +806,7 @@ func NewBee(
 	eventListener = listener.New(b.syncingStopped, logger, chainBackend, postageStampContractAddress, postageStampContractABI, o.BlockTime, postageSyncingStallingTimeout, postageSyncingBackoffTimeout)
 	b.listenerCloser = eventListener
 
-	batchSvc, err = batchservice.New(stateStore, batchStore, logger, eventListener, overlayEthAddress.Bytes(), post, sha3.New256, o.Resync)
+	batchSvc, err = batchservice.New(stateStore, batchStore, logger, eventListener, batchOwner.Bytes(), postageNotifier.BatchEventListener(post), sha3.New256, o.Resync)
 	if err != nil {
 		return nil, fmt.Errorf("init batch service: %w", err)
 	}
@@ -719,6 +819,7 @@ func NewBee(
 	}
 
 	hive := hive.New(p2ps, addressbook, networkID, o.BootnodeMode, o.AllowPrivateCIDRs, logger)
+	hive.SetAllowlist(allowlist)
 
 	if err = p2ps.AddProtocol(hive.Protocol()); err != nil {
 		return nil, fmt.Errorf("hive service: %w", err)
@@ -727,8 +828,27 @@ func NewBee(
 
 	var swapService *swap.Service
 
-	kad, err := kademlia.New(swarmAddress, addressbook, hive, p2ps, detector, logger,
-		kademlia.Options{Bootnodes: bootnodes, BootnodeMode: o.BootnodeMode, StaticNodes: o.StaticNodes, DataDir: o.DataDir})
+	reputationService, err := reputation.New(stateStore, p2ps, logger, reputation.Options{})
+	if err != nil {
+		return nil, fmt.Errorf("reputation service: %w", err)
+	}
+	b.reputationCloser = reputationService
+	hive.SetReputationRecorder(reputationService)
+
+	kadOpts := kademlia.Options{Bootnodes: bootnodes, BootnodeMode: o.BootnodeMode, StaticNodes: o.StaticNodes, DataDir: o.DataDir, Reputation: reputationService}
+
+	var peerScorer *peerscore.Service
+	if weights := (peerscore.Weights{
+		Latency:     o.PeerScoreLatencyWeight,
+		Health:      o.PeerScoreHealthWeight,
+		Reliability: o.PeerScoreReliabilityWeight,
+	}); weights.Enabled() {
+		peerScorer = peerscore.New(weights)
+		kadOpts.PeerScorer = peerScorer
+		pingPong.SetPeerScoreRecorder(peerScorer)
+	}
+
+	kad, err := kademlia.New(swarmAddress, addressbook, hive, p2ps, detector, logger, kadOpts)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create kademlia: %w", err)
 	}
@@ -759,6 +879,9 @@ func NewBee(
 		Tracer:                    tracer,
 		CacheMinEvictCount:        cacheMinEvictCount,
 		MinimumStorageRadius:      o.MinimumStorageRadius,
+		ScrubRate:                 o.DBScrubRate,
+		ScrubInterval:             o.DBScrubInterval,
+		EncryptionKey:             o.DBEncryptionKey,
 	}
 
 	if o.FullNodeMode && !o.BootnodeMode {
@@ -789,26 +912,12 @@ func NewBee(
 	accesscontrol := accesscontrol.NewController(actLogic)
 	b.accesscontrolCloser = accesscontrol
 
-	var (
-		syncErr    atomic.Value
-		syncStatus atomic.Value
-
-		syncStatusFn = func() (isDone bool, err error) {
-			iErr := syncErr.Load()
-			if iErr != nil {
-				err = iErr.(error)
-			}
-			isDone = syncStatus.Load() != nil
-			return isDone, err
-		}
-	)
-
-	if !o.SkipPostageSnapshot && !batchStoreExists && (networkID == mainnetNetworkID) {
+	if !o.SkipPostageSnapshot && o.PostageEventsFile == "" && !batchStoreExists && (networkID == mainnetNetworkID) {
 		chainBackend := NewSnapshotLogFilterer(logger, archiveSnapshotGetter{})
 
 		snapshotEventListener := listener.New(b.syncingStopped, logger, chainBackend, postageStampContractAddress, postageStampContractABI, o.BlockTime, postageSyncingStallingTimeout, postageSyncingBackoffTimeout)
 
-		snapshotBatchSvc, err := batchservice.New(stateStore, batchStore, logger, snapshotEventListener, overlayEthAddress.Bytes(), post, sha3.New256, o.Resync)
+		snapshotBatchSvc, err := batchservice.New(stateStore, batchStore, logger, snapshotEventListener, batchOwner.Bytes(), post, sha3.New256, o.Resync)
 		if err != nil {
 			logger.Error(err, "failed to initialize batch service from snapshot, continuing outside snapshot block...")
 		} else {
@@ -859,7 +968,32 @@ func NewBee(
 			}()
 		}
 
+	} else if initBatchState != nil {
+		logger.Info("applying postage events without chain access", "last_block", initBatchState.LastBlockNumber)
+		err = ApplyPostageEvents(ctx, logger, stateStore, batchStore, batchOwner.Bytes(), post, postageStampContractAddress, postageStampContractABI, initBatchState)
+		syncStatus.Store(true)
+		if err != nil {
+			syncErr.Store(err)
+			return nil, fmt.Errorf("apply postage events: %w", err)
+		}
+	}
+
+	postageTopUpLimit, ok := new(big.Int).SetString(o.PostageTopUpLimit, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid postage top-up limit: %s", o.PostageTopUpLimit)
+	}
+	postagePolicy, err := policy.New(stateStore, post, batchStore, postageStampContractService, logger, policy.Options{
+		BlockTime:  o.BlockTime,
+		SpendLimit: postageTopUpLimit,
+		Synced:     syncStatusFn,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("postage policy: %w", err)
+	}
+	if chainEnabled {
+		postagePolicy.Start()
 	}
+	b.postagePolicyCloser = postagePolicy
 
 	minThreshold := big.NewInt(2 * refreshRate)
 	maxThreshold := big.NewInt(24 * refreshRate)
@@ -925,6 +1059,7 @@ func NewBee(
 	}
 
 	acc.SetRefreshFunc(pseudosettleService.Pay)
+	acc.SetReputation(reputationService)
 
 	if o.SwapEnable && chainEnabled {
 		var priceOracle priceoracle.Service
@@ -1026,7 +1161,17 @@ func NewBee(
 	pssService.SetPushSyncer(pushSyncProtocol)
 
 	retrieval := retrieval.New(swarmAddress, waitNetworkRFunc, localStore, p2ps, kad, logger, acc, pricer, tracer, o.RetrievalCaching)
+
+	pushSyncProtocol.SetReputationRecorder(reputationService)
+	retrieval.SetReputationRecorder(reputationService)
+
+	if peerScorer != nil {
+		pushSyncProtocol.SetPeerScoreRecorder(peerScorer)
+		retrieval.SetPeerScoreRecorder(peerScorer)
+	}
+
 	localStore.SetRetrievalService(retrieval)
+	localStore.StartScrubber(ctx)
 
 	statusMetricsRegistry.MustRegister(retrieval.StatusMetrics()...)
 
@@ -1037,6 +1182,7 @@ func NewBee(
 
 	pullSyncProtocol := pullsync.New(p2ps, localStore, pssService.TryUnwrap, gsocService.Handle, validStamp, logger, pullsync.DefaultMaxPage)
 	b.pullSyncCloser = pullSyncProtocol
+	pullSyncProtocol.SetReputationRecorder(reputationService)
 
 	retrieveProtocolSpec := retrieval.Protocol()
 	pushSyncProtocolSpec := pushSyncProtocol.Protocol()
@@ -1184,6 +1330,9 @@ func NewBee(
 	feedFactory := factory.New(localStore.Download(true))
 	steward := steward.New(localStore, retrieval, localStore.Cache())
 
+	batchMigration := migration.New(localStore, stamperStore, logger)
+	b.batchMigrationCloser = batchMigration
+
 	extraOpts := api.ExtraOptions{
 		Pingpong:        pingPong,
 		TopologyDriver:  kad,
@@ -1201,11 +1350,21 @@ func NewBee(
 		Post:            post,
 		AccessControl:   accesscontrol,
 		PostageContract: postageStampContractService,
+		PostagePolicy:   postagePolicy,
+		BatchMigrator:   batchMigration,
+		PostageNotifier: postageNotifier,
+		StampSigner:     stampSigner,
 		Staking:         stakingContract,
 		Steward:         steward,
 		SyncStatus:      syncStatusFn,
 		NodeStatus:      nodeStatus,
 		PinIntegrity:    localStore.PinIntegrity(),
+		Reputation:      reputationService,
+		Bandwidth:       p2ps,
+		TopologyHistory: kad,
+		AddressBook:     addressbook,
+		Connectivity:    kad,
+		NetworkID:       networkID,
 	}
 
 	if o.APIAddr != "" {
@@ -1215,6 +1374,7 @@ func NewBee(
 		apiService.MustRegisterMetrics(acc.Metrics()...)
 		apiService.MustRegisterMetrics(localStore.Metrics()...)
 		apiService.MustRegisterMetrics(kad.Metrics()...)
+		apiService.MustRegisterMetrics(reputationService.Metrics()...)
 		apiService.MustRegisterMetrics(saludService.Metrics()...)
 		apiService.MustRegisterMetrics(stateStoreMetrics.Metrics()...)
 
@@ -1257,6 +1417,7 @@ func NewBee(
 		apiService.Configure(signer, tracer, api.Options{
 			CORSAllowedOrigins: o.CORSAllowedOrigins,
 			WsPingPeriod:       60 * time.Second,
+			TopologyView:       o.TopologyView,
 		}, extraOpts, chainID, erc20Service)
 
 		apiService.EnableFullAPI()
@@ -1366,6 +1527,9 @@ func (b *Bee) Shutdown() error {
 
 	tryClose(b.p2pService, "p2p server")
 	tryClose(b.priceOracleCloser, "price oracle service")
+	tryClose(b.postagePolicyCloser, "postage policy")
+	tryClose(b.batchMigrationCloser, "batch migration")
+	tryClose(b.postageNotifierCloser, "postage notifier")
 
 	wg.Add(3)
 	go func() {
@@ -1388,6 +1552,7 @@ func (b *Bee) Shutdown() error {
 	tryClose(b.accesscontrolCloser, "accesscontrol")
 	tryClose(b.tracerCloser, "tracer")
 	tryClose(b.topologyCloser, "topology driver")
+	tryClose(b.reputationCloser, "reputation")
 	tryClose(b.storageIncetivesCloser, "storage incentives agent")
 	tryClose(b.stateStoreCloser, "statestore")
 	tryClose(b.stamperStoreCloser, "stamperstore")
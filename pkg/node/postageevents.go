@@ -0,0 +1,218 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package node
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/postage"
+	"github.com/ethersphere/bee/v2/pkg/postage/batchservice"
+	"github.com/ethersphere/bee/v2/pkg/postage/listener"
+	"github.com/ethersphere/bee/v2/pkg/storage"
+	"golang.org/x/crypto/sha3"
+)
+
+// postageEventsPage is the number of the blocks the events are filtered in at once.
+const postageEventsPage = 5000
+
+var (
+	// ErrPostageEventsBlockHash is returned when the postage events
+	// were not exported at the block with the hash on the chain.
+	ErrPostageEventsBlockHash = errors.New("postage events: block hash mismatch")
+	// ErrPostageEventsFileHash is returned when the postage
+	// events file does not have the expected digest.
+	ErrPostageEventsFileHash = errors.New("postage events: file hash mismatch")
+	// ErrPostageEventsInvalid is returned when the postage events file is malformed.
+	ErrPostageEventsInvalid = errors.New("postage events: invalid file")
+	// ErrPostageEventsStart is returned when the postage events file starts
+	// after the start block of the contract and misses the earlier events.
+	ErrPostageEventsStart = errors.New("postage events: file starts after the contract start block")
+)
+
+// PostageEventsBackend is the chain backend the postage events are exported from.
+type PostageEventsBackend interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error)
+}
+
+// ExportPostageEvents writes the events of the postage stamp contract emitted
+// between the from and to blocks to w as a gzip compressed postage.ChainSnapshot.
+// The snapshot records the hash of the to block, which the importing
+// node compares with the hash of the block on its chain, if it has one.
+func ExportPostageEvents(
+	ctx context.Context,
+	backend PostageEventsBackend,
+	contractAddress common.Address,
+	contractABI abi.ABI,
+	from, to uint64,
+	w io.Writer,
+) (*postage.ChainSnapshot, error) {
+	if from > to {
+		return nil, fmt.Errorf("start block %d after end block %d", from, to)
+	}
+
+	header, err := backend.HeaderByNumber(ctx, new(big.Int).SetUint64(to))
+	if err != nil {
+		return nil, fmt.Errorf("header of block %d: %w", to, err)
+	}
+
+	topics := make([]common.Hash, 0, 5)
+	for _, name := range []string{"BatchCreated", "BatchTopUp", "BatchDepthIncrease", "PriceUpdate", "Paused"} {
+		topics = append(topics, contractABI.Events[name].ID)
+	}
+
+	snapshot := &postage.ChainSnapshot{
+		Events:           make([]types.Log, 0),
+		FirstBlockNumber: from,
+		LastBlockNumber:  to,
+		LastBlockHash:    header.Hash(),
+		Timestamp:        time.Now().Unix(),
+	}
+	for start := from; start <= to; start += postageEventsPage {
+		end := min(start+postageEventsPage-1, to)
+		events, err := backend.FilterLogs(ctx, ethereum.FilterQuery{
+			FromBlock: new(big.Int).SetUint64(start),
+			ToBlock:   new(big.Int).SetUint64(end),
+			Addresses: []common.Address{contractAddress},
+			Topics:    [][]common.Hash{topics},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("filter logs of blocks %d-%d: %w", start, end, err)
+		}
+		snapshot.Events = append(snapshot.Events, events...)
+	}
+
+	gw := gzip.NewWriter(w)
+	if err := json.NewEncoder(gw).Encode(snapshot); err != nil {
+		return nil, fmt.Errorf("write postage events: %w", err)
+	}
+	if err := gw.Close(); err != nil {
+		return nil, fmt.Errorf("write postage events: %w", err)
+	}
+	return snapshot, nil
+}
+
+// ReadPostageEvents reads the postage events exported by ExportPostageEvents
+// and checks that the SHA-256 digest of the file is the given one, unless it
+// is nil, and that the export starts no later than the start block of the
+// contract. The digest identifies the file of a trusted node for the nodes
+// without chain access, the others check the export with VerifyPostageEvents.
+func ReadPostageEvents(r io.Reader, fileHash []byte, startBlock uint64) (*postage.ChainSnapshot, error) {
+	h := sha256.New()
+	r = io.TeeReader(r, h)
+
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrPostageEventsInvalid, err)
+	}
+	defer gr.Close()
+
+	snapshot := new(postage.ChainSnapshot)
+	if err := json.NewDecoder(gr).Decode(snapshot); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrPostageEventsInvalid, err)
+	}
+
+	if fileHash != nil {
+		if _, err := io.Copy(io.Discard, r); err != nil {
+			return nil, fmt.Errorf("read postage events: %w", err)
+		}
+		if sum := h.Sum(nil); !bytes.Equal(sum, fileHash) {
+			return nil, fmt.Errorf("%w: have %x, want %x", ErrPostageEventsFileHash, sum, fileHash)
+		}
+	}
+	if snapshot.FirstBlockNumber > startBlock {
+		return nil, fmt.Errorf("%w: first block %d, start block %d", ErrPostageEventsStart, snapshot.FirstBlockNumber, startBlock)
+	}
+
+	prev := snapshot.FirstBlockNumber
+	for i, e := range snapshot.Events {
+		switch {
+		case e.Removed:
+			return nil, fmt.Errorf("%w: event %d is removed", ErrPostageEventsInvalid, i)
+		case e.BlockNumber < prev || e.BlockNumber > snapshot.LastBlockNumber:
+			return nil, fmt.Errorf("%w: event %d of block %d out of order", ErrPostageEventsInvalid, i, e.BlockNumber)
+		case e.BlockNumber == snapshot.LastBlockNumber && e.BlockHash != snapshot.LastBlockHash:
+			return nil, fmt.Errorf("%w: event %d of block %d", ErrPostageEventsBlockHash, i, e.BlockNumber)
+		case len(e.Topics) == 0:
+			return nil, fmt.Errorf("%w: event %d has no topics", ErrPostageEventsInvalid, i)
+		}
+		prev = e.BlockNumber
+	}
+	return snapshot, nil
+}
+
+// VerifyPostageEvents checks that the postage events are exported
+// at the block which is on the chain of the given backend.
+func VerifyPostageEvents(ctx context.Context, backend PostageEventsBackend, snapshot *postage.ChainSnapshot) error {
+	header, err := backend.HeaderByNumber(ctx, new(big.Int).SetUint64(snapshot.LastBlockNumber))
+	if err != nil {
+		return fmt.Errorf("header of block %d: %w", snapshot.LastBlockNumber, err)
+	}
+	if hash := header.Hash(); hash != snapshot.LastBlockHash {
+		return fmt.Errorf("%w: block %d has hash %s on chain, %s in file", ErrPostageEventsBlockHash, snapshot.LastBlockNumber, hash, snapshot.LastBlockHash)
+	}
+	return nil
+}
+
+// readPostageEventsFile reads the postage events file with the given SHA-256
+// digest. The digest may be left out only by the nodes with chain access.
+func readPostageEventsFile(path, fileHash string, chainEnabled bool, startBlock uint64) (*postage.ChainSnapshot, error) {
+	var hash []byte
+	if fileHash != "" || !chainEnabled {
+		var err error
+		hash, err = hex.DecodeString(strings.TrimPrefix(fileHash, "0x"))
+		if err != nil || len(hash) != sha256.Size {
+			return nil, errors.New("postage events file hash option not provided or malformed")
+		}
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ReadPostageEvents(f, hash, startBlock)
+}
+
+// ApplyPostageEvents applies the postage events to the batch store without
+// chain access, for the nodes running without a blockchain endpoint.
+func ApplyPostageEvents(
+	ctx context.Context,
+	logger log.Logger,
+	stateStore storage.StateStorer,
+	batchStore postage.Storer,
+	owner []byte,
+	batchListener postage.BatchEventListener,
+	contractAddress common.Address,
+	contractABI abi.ABI,
+	snapshot *postage.ChainSnapshot,
+) error {
+	eventListener := listener.New(nil, logger, nil, contractAddress, contractABI, 0, 0, 0)
+	defer eventListener.Close()
+
+	batchSvc, err := batchservice.New(stateStore, batchStore, logger, eventListener, owner, batchListener, sha3.New256, false)
+	if err != nil {
+		return fmt.Errorf("init batch service: %w", err)
+	}
+	return batchSvc.Start(ctx, snapshot.FirstBlockNumber, snapshot)
+}
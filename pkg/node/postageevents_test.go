@@ -0,0 +1,235 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package node_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethersphere/bee/v2/pkg/config"
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/node"
+	"github.com/ethersphere/bee/v2/pkg/postage"
+	"github.com/ethersphere/bee/v2/pkg/postage/batchstore"
+	postagemock "github.com/ethersphere/bee/v2/pkg/postage/mock"
+	mockstate "github.com/ethersphere/bee/v2/pkg/statestore/mock"
+	"github.com/ethersphere/bee/v2/pkg/util/abiutil"
+)
+
+type postageEventsBackend struct {
+	logs    []types.Log
+	queries int
+}
+
+func (b *postageEventsBackend) HeaderByNumber(_ context.Context, number *big.Int) (*types.Header, error) {
+	return &types.Header{Number: number}, nil
+}
+
+func (b *postageEventsBackend) FilterLogs(_ context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	b.queries++
+	var logs []types.Log
+	for _, l := range b.logs {
+		if l.BlockNumber >= query.FromBlock.Uint64() && l.BlockNumber <= query.ToBlock.Uint64() {
+			logs = append(logs, l)
+		}
+	}
+	return logs, nil
+}
+
+func blockHash(n uint64) common.Hash {
+	return (&types.Header{Number: new(big.Int).SetUint64(n)}).Hash()
+}
+
+func TestPostageEventsExportRead(t *testing.T) {
+	t.Parallel()
+
+	var (
+		contractABI = abiutil.MustParseABI(config.Mainnet.PostageStampABI)
+		contract    = common.HexToAddress("0x1")
+		topic       = contractABI.Events["BatchCreated"].ID
+		backend     = &postageEventsBackend{logs: []types.Log{
+			{BlockNumber: 100, Topics: []common.Hash{topic}, Address: contract},
+			{BlockNumber: 6000, Topics: []common.Hash{topic}, Address: contract},
+			{BlockNumber: 12000, Topics: []common.Hash{topic}, Address: contract, BlockHash: blockHash(12000)},
+			{BlockNumber: 13000, Topics: []common.Hash{topic}, Address: contract},
+		}}
+	)
+
+	var buf bytes.Buffer
+	exported, err := node.ExportPostageEvents(context.Background(), backend, contract, contractABI, 50, 12000, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if backend.queries != 3 {
+		t.Fatalf("got %d filter queries, want 3", backend.queries)
+	}
+	if exported.LastBlockHash != blockHash(12000) {
+		t.Fatalf("got block hash %s, want %s", exported.LastBlockHash, blockHash(12000))
+	}
+
+	fileHash := sha256.Sum256(buf.Bytes())
+
+	t.Run("read", func(t *testing.T) {
+		t.Parallel()
+
+		snapshot, err := node.ReadPostageEvents(bytes.NewReader(buf.Bytes()), fileHash[:], 50)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(snapshot.Events) != 3 {
+			t.Fatalf("got %d events, want 3", len(snapshot.Events))
+		}
+		if snapshot.FirstBlockNumber != 50 || snapshot.LastBlockNumber != 12000 {
+			t.Fatalf("got blocks %d-%d, want 50-12000", snapshot.FirstBlockNumber, snapshot.LastBlockNumber)
+		}
+	})
+
+	t.Run("file hash mismatch", func(t *testing.T) {
+		t.Parallel()
+
+		// the trailing garbage is not read by the decoder
+		data := append(bytes.Clone(buf.Bytes()), 0)
+		_, err := node.ReadPostageEvents(bytes.NewReader(data), fileHash[:], 50)
+		if !errors.Is(err, node.ErrPostageEventsFileHash) {
+			t.Fatalf("got error %v, want %v", err, node.ErrPostageEventsFileHash)
+		}
+	})
+
+	t.Run("verify on chain", func(t *testing.T) {
+		t.Parallel()
+
+		snapshot, err := node.ReadPostageEvents(bytes.NewReader(buf.Bytes()), nil, 50)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := node.VerifyPostageEvents(context.Background(), backend, snapshot); err != nil {
+			t.Fatal(err)
+		}
+
+		// an export of a forked chain with the events of
+		// the last block rewritten to match its hash
+		forked := writeChainSnapshot(t, &postage.ChainSnapshot{
+			Events:          []types.Log{{BlockNumber: 12000, Topics: []common.Hash{topic}, BlockHash: blockHash(12001)}},
+			LastBlockNumber: 12000,
+			LastBlockHash:   blockHash(12001),
+		})
+		snapshot, err = node.ReadPostageEvents(bytes.NewReader(forked), nil, 50)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := node.VerifyPostageEvents(context.Background(), backend, snapshot); !errors.Is(err, node.ErrPostageEventsBlockHash) {
+			t.Fatalf("got error %v, want %v", err, node.ErrPostageEventsBlockHash)
+		}
+	})
+
+	t.Run("starts after the contract start block", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := node.ReadPostageEvents(bytes.NewReader(buf.Bytes()), fileHash[:], 49)
+		if !errors.Is(err, node.ErrPostageEventsStart) {
+			t.Fatalf("got error %v, want %v", err, node.ErrPostageEventsStart)
+		}
+	})
+
+	t.Run("events out of order", func(t *testing.T) {
+		t.Parallel()
+
+		data := writeChainSnapshot(t, &postage.ChainSnapshot{
+			Events: []types.Log{
+				{BlockNumber: 20, Topics: []common.Hash{topic}},
+				{BlockNumber: 10, Topics: []common.Hash{topic}},
+			},
+			LastBlockNumber: 30,
+			LastBlockHash:   blockHash(30),
+		})
+		_, err := node.ReadPostageEvents(bytes.NewReader(data), nil, 0)
+		if !errors.Is(err, node.ErrPostageEventsInvalid) {
+			t.Fatalf("got error %v, want %v", err, node.ErrPostageEventsInvalid)
+		}
+	})
+
+	t.Run("not gzip", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := node.ReadPostageEvents(bytes.NewReader([]byte("{}")), nil, 0)
+		if !errors.Is(err, node.ErrPostageEventsInvalid) {
+			t.Fatalf("got error %v, want %v", err, node.ErrPostageEventsInvalid)
+		}
+	})
+}
+
+// TestApplyPostageEvents checks that the nodes without
+// chain access bootstrap their batch store from the file.
+func TestApplyPostageEvents(t *testing.T) {
+	t.Parallel()
+
+	var (
+		contractABI = abiutil.MustParseABI(config.Mainnet.PostageStampABI)
+		contract    = common.HexToAddress("0x1")
+		event       = contractABI.Events["BatchCreated"]
+		batchID     = common.HexToHash("0xbeef")
+		owner       = common.HexToAddress("0xabcd")
+	)
+	data, err := event.Inputs.NonIndexed().Pack(big.NewInt(1000), big.NewInt(10), owner, uint8(20), uint8(16), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	backend := &postageEventsBackend{logs: []types.Log{
+		{BlockNumber: 100, Topics: []common.Hash{event.ID, batchID}, Address: contract, Data: data},
+	}}
+
+	var buf bytes.Buffer
+	if _, err := node.ExportPostageEvents(context.Background(), backend, contract, contractABI, 50, 200, &buf); err != nil {
+		t.Fatal(err)
+	}
+	snapshot, err := node.ReadPostageEvents(&buf, nil, 50)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stateStore := mockstate.NewStateStore()
+	batchStore, err := batchstore.New(stateStore, func([]byte) error { return nil }, 1000, log.Noop)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = node.ApplyPostageEvents(context.Background(), log.Noop, stateStore, batchStore, owner.Bytes(), postagemock.New(), contract, contractABI, snapshot)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := batchStore.Get(batchID.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(b.Owner, owner.Bytes()) || b.Depth != 20 {
+		t.Fatalf("got batch owner %x depth %d, want owner %x depth 20", b.Owner, b.Depth, owner)
+	}
+	if cs := batchStore.GetChainState(); cs.Block != 201 {
+		t.Fatalf("got chain state block %d, want 201", cs.Block)
+	}
+}
+
+func writeChainSnapshot(t *testing.T, snapshot *postage.ChainSnapshot) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	if err := json.NewEncoder(gw).Encode(snapshot); err != nil {
+		t.Fatal(err)
+	}
+	if err := gw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
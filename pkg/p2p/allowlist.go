@@ -0,0 +1,76 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package p2p
+
+import (
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+// allowlistProtocols are the underlay protocols whose values are matched
+// against the allowlist, the transport ports are not taken into account.
+var allowlistProtocols = []int{ma.P_IP4, ma.P_IP6, ma.P_DNS, ma.P_DNS4, ma.P_DNS6, ma.P_P2P}
+
+// Allowlist is the set of peers of a private network. The nil Allowlist
+// allows all peers, as does the Allowlist without overlays or underlays
+// for the overlay or underlay respectively.
+type Allowlist struct {
+	overlays  map[string]struct{}
+	underlays []ma.Multiaddr
+}
+
+// NewAllowlist returns the allowlist of the given overlays and underlays. An
+// underlay matches the allowed one if it has the same host address and peer
+// ID, where only the components present in the allowed underlay are compared.
+func NewAllowlist(overlays []swarm.Address, underlays []ma.Multiaddr) *Allowlist {
+	a := &Allowlist{
+		overlays:  make(map[string]struct{}, len(overlays)),
+		underlays: underlays,
+	}
+	for _, o := range overlays {
+		a.overlays[o.ByteString()] = struct{}{}
+	}
+	return a
+}
+
+// AllowedOverlay reports whether the peer with the given overlay is allowed.
+func (a *Allowlist) AllowedOverlay(overlay swarm.Address) bool {
+	if a == nil || len(a.overlays) == 0 {
+		return true
+	}
+	_, ok := a.overlays[overlay.ByteString()]
+	return ok
+}
+
+// AllowedUnderlay reports whether the peer with the given underlay is allowed.
+func (a *Allowlist) AllowedUnderlay(underlay ma.Multiaddr) bool {
+	if a == nil || len(a.underlays) == 0 {
+		return true
+	}
+	for _, allowed := range a.underlays {
+		if matchUnderlay(allowed, underlay) {
+			return true
+		}
+	}
+	return false
+}
+
+// Allowed reports whether the peer with the given overlay and underlay is allowed.
+func (a *Allowlist) Allowed(overlay swarm.Address, underlay ma.Multiaddr) bool {
+	return a.AllowedOverlay(overlay) && a.AllowedUnderlay(underlay)
+}
+
+func matchUnderlay(allowed, underlay ma.Multiaddr) bool {
+	for _, p := range allowlistProtocols {
+		want, err := allowed.ValueForProtocol(p)
+		if err != nil {
+			continue
+		}
+		if got, err := underlay.ValueForProtocol(p); err != nil || got != want {
+			return false
+		}
+	}
+	return true
+}
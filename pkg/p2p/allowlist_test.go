@@ -0,0 +1,97 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package p2p_test
+
+import (
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/p2p"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+func TestAllowlist(t *testing.T) {
+	t.Parallel()
+
+	var (
+		overlay = swarm.RandAddress(t)
+		other   = swarm.RandAddress(t)
+		peerID  = "16Uiu2HAkx8ULY8cTXhdVAcMmLcH9AsTKz6uBQ7DPLKRjMLgBVYkS"
+	)
+
+	testCases := []struct {
+		name      string
+		allowlist *p2p.Allowlist
+		overlay   swarm.Address
+		underlay  string
+		want      bool
+	}{
+		{
+			name:     "nil allowlist",
+			overlay:  overlay,
+			underlay: "/ip4/1.2.3.4/tcp/1634",
+			want:     true,
+		},
+		{
+			name:      "allowed overlay",
+			allowlist: p2p.NewAllowlist([]swarm.Address{overlay}, nil),
+			overlay:   overlay,
+			underlay:  "/ip4/1.2.3.4/tcp/1634",
+			want:      true,
+		},
+		{
+			name:      "not allowed overlay",
+			allowlist: p2p.NewAllowlist([]swarm.Address{overlay}, nil),
+			overlay:   other,
+			underlay:  "/ip4/1.2.3.4/tcp/1634",
+			want:      false,
+		},
+		{
+			name:      "allowed host",
+			allowlist: p2p.NewAllowlist(nil, []ma.Multiaddr{ma.StringCast("/ip4/1.2.3.4")}),
+			overlay:   other,
+			underlay:  "/ip4/1.2.3.4/tcp/1634/p2p/" + peerID,
+			want:      true,
+		},
+		{
+			name:      "not allowed host",
+			allowlist: p2p.NewAllowlist(nil, []ma.Multiaddr{ma.StringCast("/ip4/1.2.3.4")}),
+			overlay:   other,
+			underlay:  "/ip4/1.2.3.5/tcp/1634/p2p/" + peerID,
+			want:      false,
+		},
+		{
+			name:      "allowed peer id",
+			allowlist: p2p.NewAllowlist(nil, []ma.Multiaddr{ma.StringCast("/ip4/1.2.3.4/tcp/1634/p2p/" + peerID)}),
+			overlay:   other,
+			underlay:  "/ip4/1.2.3.4/tcp/1635/p2p/" + peerID,
+			want:      true,
+		},
+		{
+			name:      "not allowed peer id",
+			allowlist: p2p.NewAllowlist(nil, []ma.Multiaddr{ma.StringCast("/ip4/1.2.3.4/tcp/1634/p2p/" + peerID)}),
+			overlay:   other,
+			underlay:  "/ip4/1.2.3.4/tcp/1634",
+			want:      false,
+		},
+		{
+			name:      "allowed underlay of not allowed overlay",
+			allowlist: p2p.NewAllowlist([]swarm.Address{overlay}, []ma.Multiaddr{ma.StringCast("/ip4/1.2.3.4")}),
+			overlay:   other,
+			underlay:  "/ip4/1.2.3.4/tcp/1634",
+			want:      false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tc.allowlist.Allowed(tc.overlay, ma.StringCast(tc.underlay)); got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,282 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package libp2p
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/p2p"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// minBandwidthBurst is the minimal burst of the bandwidth limiters
+	// which allows a chunk with its stamp and framing to pass at once.
+	minBandwidthBurst = 64 * 1024
+	// bandwidthRateInterval is the interval of the bandwidth rates update.
+	bandwidthRateInterval = time.Second
+)
+
+// bandwidthPriority is the priority class of the protocol streams. The streams
+// of a lower priority wait for the global bandwidth limit while the streams of
+// a higher priority are waiting.
+type bandwidthPriority int
+
+const (
+	priorityLow bandwidthPriority = iota
+	priorityNormal
+	priorityHigh
+	priorityClasses
+)
+
+func (p bandwidthPriority) String() string {
+	switch p {
+	case priorityLow:
+		return "low"
+	case priorityHigh:
+		return "high"
+	default:
+		return "normal"
+	}
+}
+
+// protocolPriorities are the priority classes of the protocols, the user
+// originated retrieval and push take precedence over the background syncing.
+var protocolPriorities = map[string]bandwidthPriority{
+	"retrieval": priorityHigh,
+	"pushsync":  priorityHigh,
+	"pullsync":  priorityLow,
+}
+
+// newBandwidthLimiter returns the limiter of the given rate in
+// bytes per second, or nil if the rate is not limited.
+func newBandwidthLimiter(limit int64) *rate.Limiter {
+	if limit <= 0 {
+		return nil
+	}
+	return rate.NewLimiter(rate.Limit(limit), int(max(limit, minBandwidthBurst)))
+}
+
+// waitBandwidth waits for n bytes of the limiter, in the portions of its burst.
+func waitBandwidth(ctx context.Context, limiter *rate.Limiter, n int) error {
+	for n > 0 {
+		c := min(n, limiter.Burst())
+		if err := limiter.WaitN(ctx, c); err != nil {
+			return err
+		}
+		n -= c
+	}
+	return nil
+}
+
+// bandwidth limits and accounts the bandwidth of the protocol streams.
+type bandwidth struct {
+	ctx     context.Context
+	global  *rate.Limiter // nil if unlimited
+	limits  map[string]int64
+	metrics metrics
+
+	waitingMu sync.Mutex
+	waiting   [priorityClasses]int
+	released  chan struct{} // closed and replaced when a waiting stream gets the bandwidth
+
+	mu        sync.Mutex
+	protocols map[string]*protocolBandwidth
+}
+
+func newBandwidth(ctx context.Context, limit int64, protocolLimits map[string]int64, metrics metrics) *bandwidth {
+	b := &bandwidth{
+		ctx:       ctx,
+		global:    newBandwidthLimiter(limit),
+		limits:    protocolLimits,
+		metrics:   metrics,
+		released:  make(chan struct{}),
+		protocols: make(map[string]*protocolBandwidth),
+	}
+	go b.updateRates()
+	return b
+}
+
+// protocol returns the bandwidth of the protocol.
+func (b *bandwidth) protocol(name string) *protocolBandwidth {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	p, ok := b.protocols[name]
+	if !ok {
+		priority, ok := protocolPriorities[name]
+		if !ok {
+			priority = priorityNormal
+		}
+		p = &protocolBandwidth{
+			b:        b,
+			name:     name,
+			priority: priority,
+			limit:    b.limits[name],
+			limiter:  newBandwidthLimiter(b.limits[name]),
+		}
+		b.protocols[name] = p
+	}
+	return p
+}
+
+// enterWaiting counts the stream of the priority as waiting for the bandwidth.
+func (b *bandwidth) enterWaiting(priority bandwidthPriority) {
+	b.waitingMu.Lock()
+	b.waiting[priority]++
+	b.waitingMu.Unlock()
+}
+
+// leaveWaiting counts the stream of the priority as no longer waiting and
+// wakes up the streams of lower priorities to check whether they can go on.
+func (b *bandwidth) leaveWaiting(priority bandwidthPriority) {
+	b.waitingMu.Lock()
+	b.waiting[priority]--
+	close(b.released)
+	b.released = make(chan struct{})
+	b.waitingMu.Unlock()
+}
+
+// waitHigher waits until no streams of a higher priority wait for the bandwidth.
+func (b *bandwidth) waitHigher(ctx context.Context, priority bandwidthPriority) error {
+	for {
+		b.waitingMu.Lock()
+		higher := false
+		for p := priority + 1; p < priorityClasses; p++ {
+			if b.waiting[p] > 0 {
+				higher = true
+				break
+			}
+		}
+		released := b.released
+		b.waitingMu.Unlock()
+
+		if !higher {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-released:
+		}
+	}
+}
+
+// wait waits for n bytes of both the protocol and the global bandwidth
+// until the context of the stream is done.
+func (b *bandwidth) wait(ctx context.Context, p *protocolBandwidth, n int) error {
+	if p.limiter != nil {
+		if err := waitBandwidth(ctx, p.limiter, n); err != nil {
+			return err
+		}
+	}
+
+	if b.global == nil {
+		return nil
+	}
+
+	b.enterWaiting(p.priority)
+	defer b.leaveWaiting(p.priority)
+
+	if err := b.waitHigher(ctx, p.priority); err != nil {
+		return err
+	}
+
+	return waitBandwidth(ctx, b.global, n)
+}
+
+// updateRates periodically updates the bandwidth rates of the protocols.
+func (b *bandwidth) updateRates() {
+	ticker := time.NewTicker(bandwidthRateInterval)
+	defer ticker.Stop()
+
+	last := time.Now()
+	for {
+		select {
+		case <-b.ctx.Done():
+			return
+		case now := <-ticker.C:
+			elapsed := now.Sub(last).Seconds()
+			last = now
+
+			b.mu.Lock()
+			for _, p := range b.protocols {
+				p.updateRates(elapsed)
+			}
+			b.mu.Unlock()
+		}
+	}
+}
+
+// usage returns the bandwidth usage of the protocols ordered by the name.
+func (b *bandwidth) usage() []p2p.ProtocolBandwidth {
+	b.mu.Lock()
+	usage := make([]p2p.ProtocolBandwidth, 0, len(b.protocols))
+	for _, p := range b.protocols {
+		usage = append(usage, p2p.ProtocolBandwidth{
+			Protocol: p.name,
+			Priority: p.priority.String(),
+			Limit:    p.limit,
+			In:       p.in.Load(),
+			Out:      p.out.Load(),
+			InRate:   math.Float64frombits(p.inRate.Load()),
+			OutRate:  math.Float64frombits(p.outRate.Load()),
+		})
+	}
+	b.mu.Unlock()
+
+	sort.Slice(usage, func(i, j int) bool {
+		return usage[i].Protocol < usage[j].Protocol
+	})
+	return usage
+}
+
+// protocolBandwidth limits and accounts the bandwidth of a protocol.
+type protocolBandwidth struct {
+	b        *bandwidth
+	name     string
+	priority bandwidthPriority
+	limit    int64
+	limiter  *rate.Limiter // nil if unlimited
+
+	in, out         atomic.Uint64
+	inRate, outRate atomic.Uint64 // float64 bits
+	lastIn, lastOut uint64        // guarded by the bandwidth mutex
+}
+
+func (p *protocolBandwidth) updateRates(elapsed float64) {
+	in, out := p.in.Load(), p.out.Load()
+	p.inRate.Store(math.Float64bits(float64(in-p.lastIn) / elapsed))
+	p.outRate.Store(math.Float64bits(float64(out-p.lastOut) / elapsed))
+	p.lastIn, p.lastOut = in, out
+}
+
+// read accounts and waits for the n bytes read from the stream.
+func (p *protocolBandwidth) read(ctx context.Context, n int) error {
+	p.in.Add(uint64(n))
+	p.b.metrics.ProtocolBandwidth.WithLabelValues(p.name, "in").Add(float64(n))
+	return p.b.wait(ctx, p, n)
+}
+
+// write waits for and accounts the n bytes to be written to the stream.
+func (p *protocolBandwidth) write(ctx context.Context, n int) error {
+	if err := p.b.wait(ctx, p, n); err != nil {
+		return err
+	}
+	p.out.Add(uint64(n))
+	p.b.metrics.ProtocolBandwidth.WithLabelValues(p.name, "out").Add(float64(n))
+	return nil
+}
+
+// BandwidthUsage returns the bandwidth usage of the protocols.
+func (s *Service) BandwidthUsage() []p2p.ProtocolBandwidth {
+	return s.bandwidth.usage()
+}
@@ -0,0 +1,140 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package libp2p_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/p2p/libp2p"
+)
+
+func TestBandwidthProtocolLimit(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	const limit = 320 * 1024
+
+	b := libp2p.NewBandwidth(ctx, 0, map[string]int64{"pullsync": limit})
+
+	// exhaust the burst of the protocol limiter
+	if err := b.Write(ctx, "pullsync", limit); err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+	if err := b.Write(ctx, "retrieval", limit); err != nil {
+		t.Fatal(err)
+	}
+	if d := time.Since(start); d > 100*time.Millisecond {
+		t.Fatalf("unlimited protocol waited for %v", d)
+	}
+
+	start = time.Now()
+	if err := b.Write(ctx, "pullsync", limit/5); err != nil {
+		t.Fatal(err)
+	}
+	if d := time.Since(start); d < 150*time.Millisecond {
+		t.Fatalf("limited protocol waited only for %v", d)
+	}
+
+	usage := b.Usage()
+	if len(usage) != 2 {
+		t.Fatalf("got usage of %d protocols, want 2", len(usage))
+	}
+	if u := usage[0]; u.Protocol != "pullsync" || u.Priority != "low" || u.Limit != limit || u.Out != limit+limit/5 {
+		t.Fatalf("unexpected pullsync usage %+v", u)
+	}
+	if u := usage[1]; u.Protocol != "retrieval" || u.Priority != "high" || u.Limit != 0 || u.Out != limit {
+		t.Fatalf("unexpected retrieval usage %+v", u)
+	}
+}
+
+func TestBandwidthPriority(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	const limit = 320 * 1024
+
+	b := libp2p.NewBandwidth(ctx, limit, nil)
+
+	// exhaust the burst of the global limiter
+	if err := b.Write(ctx, "hive", limit); err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan string, 3)
+	write := func(protocol, name string) {
+		go func() {
+			if err := b.Write(ctx, protocol, limit/5); err != nil {
+				t.Error(err)
+			}
+			done <- name
+		}()
+	}
+
+	// the background syncing waits for both of the retrievals, even
+	// for the one that started waiting after the syncing did
+	write("retrieval", "first retrieval")
+	time.Sleep(50 * time.Millisecond)
+	write("pullsync", "pullsync")
+	time.Sleep(50 * time.Millisecond)
+	write("retrieval", "second retrieval")
+
+	for _, want := range []string{"first retrieval", "second retrieval", "pullsync"} {
+		select {
+		case got := <-done:
+			if got != want {
+				t.Fatalf("got %s done, want %s", got, want)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out")
+		}
+	}
+}
+
+func TestBandwidthWaitContext(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	const limit = 320 * 1024
+
+	b := libp2p.NewBandwidth(ctx, limit, nil)
+
+	// exhaust the burst of the global limiter
+	if err := b.Write(ctx, "hive", limit); err != nil {
+		t.Fatal(err)
+	}
+
+	// the waits of both the higher and the lower priority
+	// end with the stream while the service is running
+	streamCtx, streamCancel := context.WithCancel(ctx)
+
+	errs := make(chan error, 2)
+	go func() { errs <- b.Write(streamCtx, "retrieval", 10*limit) }()
+	time.Sleep(50 * time.Millisecond)
+	go func() { errs <- b.Write(streamCtx, "pullsync", limit) }()
+	time.Sleep(50 * time.Millisecond)
+	streamCancel()
+
+	for range 2 {
+		select {
+		case err := <-errs:
+			if !errors.Is(err, context.Canceled) {
+				t.Fatalf("got error %v, want %v", err, context.Canceled)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("wait did not end with the stream context")
+		}
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"math/rand"
 	"reflect"
@@ -17,6 +18,7 @@ import (
 	"time"
 
 	"github.com/ethersphere/bee/v2/pkg/addressbook"
+	"github.com/ethersphere/bee/v2/pkg/crypto"
 	"github.com/ethersphere/bee/v2/pkg/log"
 	"github.com/ethersphere/bee/v2/pkg/p2p"
 	"github.com/ethersphere/bee/v2/pkg/p2p/libp2p"
@@ -517,6 +519,179 @@ func TestConnectWithEnabledWSTransports(t *testing.T) {
 	expectPeersEventually(t, s1, overlay2)
 }
 
+func TestConnectWithEnabledRelayAndHolePunching(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s1, overlay1 := newService(t, 1, libp2pServiceOpts{
+		libp2pOpts: libp2p.Options{
+			EnableRelayService: true,
+			EnableHolePunching: true,
+			FullNode:           true,
+		},
+	})
+
+	s2, overlay2 := newService(t, 1, libp2pServiceOpts{
+		libp2pOpts: libp2p.Options{
+			EnableHolePunching: true,
+			FullNode:           true,
+		},
+	})
+
+	addr := serviceUnderlayAddress(t, s1)
+
+	if _, err := s2.Connect(ctx, addr); err != nil {
+		t.Fatal(err)
+	}
+
+	expectPeers(t, s2, overlay1)
+	expectPeersEventually(t, s1, overlay2)
+}
+
+func TestConnectPrivateNetwork(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	key := bytes.Repeat([]byte{0x1}, 32)
+
+	s1, overlay1 := newService(t, 1, libp2pServiceOpts{
+		libp2pOpts: libp2p.Options{
+			PrivateNetworkKey: key,
+			FullNode:          true,
+		},
+	})
+
+	allowed, _ := newService(t, 1, libp2pServiceOpts{
+		libp2pOpts: libp2p.Options{
+			PrivateNetworkKey: key,
+			Allowlist:         p2p.NewAllowlist([]swarm.Address{overlay1}, nil),
+			FullNode:          true,
+		},
+	})
+
+	if _, err := allowed.Connect(ctx, serviceUnderlayAddress(t, s1)); err != nil {
+		t.Fatal(err)
+	}
+	expectPeers(t, allowed, overlay1)
+
+	s2, _ := newService(t, 1, libp2pServiceOpts{
+		libp2pOpts: libp2p.Options{
+			PrivateNetworkKey: key,
+			FullNode:          true,
+		},
+	})
+
+	if _, err := allowed.Connect(ctx, serviceUnderlayAddress(t, s2)); !errors.Is(err, p2p.ErrPeerNotAllowed) {
+		t.Fatalf("got error %v, want %v", err, p2p.ErrPeerNotAllowed)
+	}
+	expectPeers(t, allowed, overlay1)
+
+	public, _ := newService(t, 1, libp2pServiceOpts{libp2pOpts: libp2p.Options{
+		FullNode: true,
+	}})
+
+	if _, err := public.Connect(ctx, serviceUnderlayAddress(t, s1)); err == nil {
+		t.Fatal("connected to private network without the key")
+	}
+	expectPeers(t, public)
+}
+
+func TestPrivateNetworkQUICTransport(t *testing.T) {
+	t.Parallel()
+
+	swarmKey, err := crypto.GenerateSecp256k1Key()
+	if err != nil {
+		t.Fatal(err)
+	}
+	overlay := swarm.RandAddress(t)
+	statestore := mock.NewStateStore()
+
+	_, err = libp2p.New(context.Background(), crypto.NewDefaultSigner(swarmKey), 1, overlay, ":0", addressbook.New(statestore), statestore, lightnode.NewContainer(overlay), log.Noop, nil, libp2p.Options{
+		PrivateNetworkKey: bytes.Repeat([]byte{0x1}, 32),
+		QUICAddr:          "127.0.0.1:0",
+	})
+	if !errors.Is(err, libp2p.ErrPrivateNetworkTransport) {
+		t.Fatalf("got error %v, want %v", err, libp2p.ErrPrivateNetworkTransport)
+	}
+}
+
+func TestConnectWithEnabledQUICTransports(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s1, overlay1 := newService(t, 1, libp2pServiceOpts{
+		libp2pOpts: libp2p.Options{
+			QUICAddr:         "127.0.0.1:0",
+			WebTransportAddr: "127.0.0.1:0",
+			FullNode:         true,
+		},
+	})
+
+	s2, overlay2 := newService(t, 1, libp2pServiceOpts{
+		libp2pOpts: libp2p.Options{
+			QUICAddr: "127.0.0.1:0",
+			FullNode: true,
+		},
+	})
+
+	headers := p2p.Headers{"test-header-key": []byte("header-value")}
+	handled := make(chan p2p.Headers, 1)
+	if err := s1.AddProtocol(newTestProtocol(func(_ context.Context, _ p2p.Peer, stream p2p.Stream) error {
+		handled <- stream.Headers()
+		return nil
+	})); err != nil {
+		t.Fatal(err)
+	}
+
+	addrs, err := s1.Addresses()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var quicAddr, webTransportAddr ma.Multiaddr
+	for _, a := range addrs {
+		if _, err := a.ValueForProtocol(ma.P_WEBTRANSPORT); err == nil {
+			webTransportAddr = a
+		} else if _, err := a.ValueForProtocol(ma.P_QUIC_V1); err == nil {
+			quicAddr = a
+		}
+	}
+	if quicAddr == nil || webTransportAddr == nil {
+		t.Fatalf("quic and webtransport addresses not found in %v", addrs)
+	}
+
+	if _, err := s2.Connect(ctx, quicAddr); err != nil {
+		t.Fatal(err)
+	}
+
+	expectPeers(t, s2, overlay1)
+	expectPeersEventually(t, s1, overlay2)
+
+	stream, err := s2.NewStream(ctx, overlay1, headers, testProtocolName, testProtocolVersion, testStreamName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stream.Close()
+
+	select {
+	case got := <-handled:
+		if fmt.Sprint(got) != fmt.Sprint(headers) {
+			t.Errorf("got headers %+v, want %+v", got, headers)
+		}
+	case <-time.After(30 * time.Second):
+		t.Fatal("timeout waiting for handler")
+	}
+
+	if _, err := s2.Ping(ctx, quicAddr); err != nil {
+		t.Fatalf("ping over quic: %v", err)
+	}
+}
+
 // TestConnectRepeatHandshake tests if handshake was attempted more then once by the same peer
 func TestConnectRepeatHandshake(t *testing.T) {
 	t.Parallel()
@@ -7,11 +7,14 @@ package libp2p
 import (
 	"context"
 
+	"github.com/ethersphere/bee/v2/pkg/p2p"
 	handshake "github.com/ethersphere/bee/v2/pkg/p2p/libp2p/internal/handshake"
 	libp2pm "github.com/libp2p/go-libp2p"
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/network"
 	libp2ppeer "github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/p2p/host/autorelay"
+	ma "github.com/multiformats/go-multiaddr"
 )
 
 func (s *Service) HandshakeService() *handshake.Service {
@@ -32,9 +35,16 @@ func (s *Service) Host() host.Host {
 
 type StaticAddressResolver = staticAddressResolver
 
+func NewRelayAddressResolver(addrs func() []ma.Multiaddr, resolver handshake.AdvertisableAddressResolver) handshake.AdvertisableAddressResolver {
+	return &relayAddressResolver{addrs: addrs, resolver: resolver}
+}
+
 var (
 	NewStaticAddressResolver = newStaticAddressResolver
 	UserAgent                = userAgent
+
+	ErrPrivateNetworkTransport = errPrivateNetworkTransport
+	ErrNoDirectConnection      = errNoDirectConnection
 )
 
 func WithHostFactory(factory func(...libp2pm.Option) (host.Host, error)) Options {
@@ -42,3 +52,25 @@ func WithHostFactory(factory func(...libp2pm.Option) (host.Host, error)) Options
 		hostFactory: factory,
 	}
 }
+
+func SetHostFactory(o *Options, factory func(...libp2pm.Option) (host.Host, error)) {
+	o.hostFactory = factory
+}
+
+func SetAutoRelayOptions(o *Options, opts ...autorelay.Option) {
+	o.autoRelayOptions = opts
+}
+
+type Bandwidth = bandwidth
+
+func NewBandwidth(ctx context.Context, limit int64, protocolLimits map[string]int64) *Bandwidth {
+	return newBandwidth(ctx, limit, protocolLimits, newMetrics())
+}
+
+func (b *bandwidth) Write(ctx context.Context, protocol string, n int) error {
+	return b.protocol(protocol).write(ctx, n)
+}
+
+func (b *bandwidth) Usage() []p2p.ProtocolBandwidth {
+	return b.usage()
+}
@@ -186,7 +186,12 @@ func (r *reacher) tryAcquirePeer() (*peer, time.Duration) {
 }
 
 // Connected adds a new peer to the queue for testing reachability.
+// The peers with addresses that can not be dialed back are ignored.
 func (r *reacher) Connected(overlay swarm.Address, addr ma.Multiaddr) {
+	if !dialable(addr) {
+		return
+	}
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -220,3 +225,17 @@ func (r *reacher) Close() error {
 	r.wg.Wait()
 	return nil
 }
+
+// dialable reports whether the address can be dialed. The WebTransport
+// addresses observed from the browser clients lack the certificate hashes
+// which are required to dial them.
+func dialable(addr ma.Multiaddr) bool {
+	if addr == nil {
+		return true
+	}
+	if _, err := addr.ValueForProtocol(ma.P_WEBTRANSPORT); err != nil {
+		return true
+	}
+	_, err := addr.ValueForProtocol(ma.P_CERTHASH)
+	return err == nil
+}
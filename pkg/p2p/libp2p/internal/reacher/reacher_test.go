@@ -121,6 +121,44 @@ func TestDisconnected(t *testing.T) {
 	r.Disconnected(disconnectedOverlay)
 }
 
+func TestNotDialable(t *testing.T) {
+	t.Parallel()
+
+	var (
+		quicMa, _         = ma.NewMultiaddr("/ip4/127.0.0.1/udp/7071/quic-v1/p2p/16Uiu2HAmTBuJT9LvNmBiQiNoTsxE5mtNy6YG3paw79m94CRa9sRb")
+		webTransportMa, _ = ma.NewMultiaddr("/ip4/127.0.0.1/udp/7072/quic-v1/webtransport/p2p/16Uiu2HAmTBuJT9LvNmBiQiNoTsxE5mtNy6YG3paw79m94CRa9sRb")
+	)
+
+	pinged := make(chan ma.Multiaddr, 2)
+	pingFunc := func(_ context.Context, a ma.Multiaddr) (time.Duration, error) {
+		pinged <- a
+		return 0, nil
+	}
+
+	mock := newMock(pingFunc, func(swarm.Address, p2p.ReachabilityStatus) {})
+
+	r := reacher.New(mock, mock, &defaultOptions)
+	testutil.CleanupCloser(t, r)
+
+	r.Connected(swarm.RandAddress(t), webTransportMa)
+	r.Connected(swarm.RandAddress(t), quicMa)
+
+	select {
+	case <-time.After(time.Second * 5):
+		t.Fatalf("test timed out")
+	case a := <-pinged:
+		if !a.Equal(quicMa) {
+			t.Fatalf("got pinged address %s, want %s", a, quicMa)
+		}
+	}
+
+	select {
+	case a := <-pinged:
+		t.Fatalf("unexpected ping of %s", a)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
 type mock struct {
 	pingFunc      func(context.Context, ma.Multiaddr) (time.Duration, error)
 	reachableFunc func(swarm.Address, p2p.ReachabilityStatus)
@@ -41,13 +41,17 @@ import (
 	"github.com/libp2p/go-libp2p/core/peerstore"
 	"github.com/libp2p/go-libp2p/core/protocol"
 	"github.com/libp2p/go-libp2p/p2p/host/autonat"
+	"github.com/libp2p/go-libp2p/p2p/host/autorelay"
 	basichost "github.com/libp2p/go-libp2p/p2p/host/basic"
 	"github.com/libp2p/go-libp2p/p2p/host/peerstore/pstoremem"
 	rcmgr "github.com/libp2p/go-libp2p/p2p/host/resource-manager"
 	lp2pswarm "github.com/libp2p/go-libp2p/p2p/net/swarm"
+	relayv2 "github.com/libp2p/go-libp2p/p2p/protocol/circuitv2/relay"
 	libp2pping "github.com/libp2p/go-libp2p/p2p/protocol/ping"
+	libp2pquic "github.com/libp2p/go-libp2p/p2p/transport/quic"
 	"github.com/libp2p/go-libp2p/p2p/transport/tcp"
 	ws "github.com/libp2p/go-libp2p/p2p/transport/websocket"
+	libp2pwebtransport "github.com/libp2p/go-libp2p/p2p/transport/webtransport"
 
 	ma "github.com/multiformats/go-multiaddr"
 	"github.com/multiformats/go-multistream"
@@ -69,6 +73,10 @@ var (
 	// reachabilityOverridePublic overrides autonat to simply report
 	// public reachability status, it is set in the makefile.
 	reachabilityOverridePublic = "false"
+
+	// errPrivateNetworkTransport is returned when QUIC or WebTransport is
+	// enabled together with the pre-shared key of a private network.
+	errPrivateNetworkTransport = errors.New("quic and webtransport transports are not supported in a private network")
 )
 
 const (
@@ -96,6 +104,8 @@ type Service struct {
 	peers             *peerRegistry
 	connectionBreaker breaker.Interface
 	blocklist         *blocklist.Blocklist
+	allowlist         *p2p.Allowlist
+	bandwidth         *bandwidth
 	protocols         []p2p.ProtocolSpec
 	notifier          p2p.PickyNotifier
 	logger            log.Logger
@@ -120,23 +130,35 @@ type lightnodes interface {
 }
 
 type Options struct {
-	PrivateKey       *ecdsa.PrivateKey
-	NATAddr          string
-	EnableWS         bool
-	FullNode         bool
-	LightNodeLimit   int
-	WelcomeMessage   string
-	Nonce            []byte
-	ValidateOverlay  bool
-	hostFactory      func(...libp2p.Option) (host.Host, error)
-	HeadersRWTimeout time.Duration
-	Registry         *prometheus.Registry
+	PrivateKey              *ecdsa.PrivateKey
+	NATAddr                 string
+	EnableWS                bool
+	QUICAddr                string           // UDP listen address of the QUIC transport, disabled if empty
+	WebTransportAddr        string           // UDP listen address of the WebTransport transport, disabled if empty
+	EnableRelayService      bool             // full node acts as a circuit relay for peers behind NAT when publicly reachable
+	EnableHolePunching      bool             // node behind NAT is reachable through relays with connections upgraded by hole punching
+	PrivateNetworkKey       []byte           // pre-shared key of the private network, required from all the peers if set
+	Allowlist               *p2p.Allowlist   // peers of the private network, all the peers are allowed if nil
+	BandwidthLimit          int64            // global limit of the protocol streams in bytes per second, unlimited if zero
+	ProtocolBandwidthLimits map[string]int64 // limits of the protocol streams in bytes per second by the protocol name
+	FullNode                bool
+	LightNodeLimit          int
+	WelcomeMessage          string
+	Nonce                   []byte
+	ValidateOverlay         bool
+	hostFactory             func(...libp2p.Option) (host.Host, error)
+	autoRelayOptions        []autorelay.Option
+	HeadersRWTimeout        time.Duration
+	Registry                *prometheus.Registry
 }
 
-func New(ctx context.Context, signer beecrypto.Signer, networkID uint64, overlay swarm.Address, addr string, ab addressbook.Putter, storer storage.StateStorer, lightNodes *lightnode.Container, logger log.Logger, tracer *tracing.Tracer, o Options) (*Service, error) {
+// listenAddresses returns the listen multiaddrs of the host:port address for
+// each of the protocol formats, such as "tcp/%s", where the verb is replaced
+// with the port. An empty host listens on all the IPv4 and IPv6 interfaces.
+func listenAddresses(addr string, protocols ...string) ([]string, error) {
 	host, port, err := net.SplitHostPort(addr)
 	if err != nil {
-		return nil, fmt.Errorf("address: %w", err)
+		return nil, err
 	}
 
 	ip4Addr := "0.0.0.0"
@@ -154,21 +176,56 @@ func New(ctx context.Context, signer beecrypto.Signer, networkID uint64, overlay
 	}
 
 	var listenAddrs []string
-	if ip4Addr != "" {
-		listenAddrs = append(listenAddrs, fmt.Sprintf("/ip4/%s/tcp/%s", ip4Addr, port))
-		if o.EnableWS {
-			listenAddrs = append(listenAddrs, fmt.Sprintf("/ip4/%s/tcp/%s/ws", ip4Addr, port))
+	for _, p := range protocols {
+		if ip4Addr != "" {
+			listenAddrs = append(listenAddrs, fmt.Sprintf("/ip4/%s/"+p, ip4Addr, port))
+		}
+	}
+	for _, p := range protocols {
+		if ip6Addr != "" {
+			listenAddrs = append(listenAddrs, fmt.Sprintf("/ip6/%s/"+p, ip6Addr, port))
+		}
+	}
+	return listenAddrs, nil
+}
+
+func New(ctx context.Context, signer beecrypto.Signer, networkID uint64, overlay swarm.Address, addr string, ab addressbook.Putter, storer storage.StateStorer, lightNodes *lightnode.Container, logger log.Logger, tracer *tracing.Tracer, o Options) (*Service, error) {
+	tcpProtocols := []string{"tcp/%s"}
+	if o.EnableWS {
+		tcpProtocols = append(tcpProtocols, "tcp/%s/ws")
+	}
+	listenAddrs, err := listenAddresses(addr, tcpProtocols...)
+	if err != nil {
+		return nil, fmt.Errorf("address: %w", err)
+	}
+
+	if o.QUICAddr != "" {
+		quicAddrs, err := listenAddresses(o.QUICAddr, "udp/%s/quic-v1")
+		if err != nil {
+			return nil, fmt.Errorf("quic address: %w", err)
 		}
+		listenAddrs = append(listenAddrs, quicAddrs...)
 	}
 
-	if ip6Addr != "" {
-		listenAddrs = append(listenAddrs, fmt.Sprintf("/ip6/%s/tcp/%s", ip6Addr, port))
-		if o.EnableWS {
-			listenAddrs = append(listenAddrs, fmt.Sprintf("/ip6/%s/tcp/%s/ws", ip6Addr, port))
+	if o.WebTransportAddr != "" {
+		webTransportAddrs, err := listenAddresses(o.WebTransportAddr, "udp/%s/quic-v1/webtransport")
+		if err != nil {
+			return nil, fmt.Errorf("webtransport address: %w", err)
 		}
+		listenAddrs = append(listenAddrs, webTransportAddrs...)
 	}
 
 	security := libp2p.DefaultSecurity
+
+	// The pre-shared key protects the connections of the dialers too, so
+	// it is a part of the security option. QUIC and WebTransport connections
+	// cannot be protected by it and are not allowed in a private network.
+	if len(o.PrivateNetworkKey) > 0 {
+		if o.QUICAddr != "" || o.WebTransportAddr != "" {
+			return nil, errPrivateNetworkTransport
+		}
+		security = libp2p.ChainOptions(security, libp2p.PrivateNetwork(o.PrivateNetworkKey))
+	}
 	libp2pPeerstore, err := pstoremem.NewPeerstore()
 	if err != nil {
 		return nil, err
@@ -213,6 +270,8 @@ func New(ctx context.Context, signer beecrypto.Signer, networkID uint64, overlay
 
 	var natManager basichost.NATManager
 
+	peerRegistry := newPeerRegistry()
+
 	opts := []libp2p.Option{
 		libp2p.ListenAddrStrings(listenAddrs...),
 		security,
@@ -241,6 +300,17 @@ func New(ctx context.Context, signer beecrypto.Signer, networkID uint64, overlay
 		)
 	}
 
+	if o.EnableRelayService && o.FullNode {
+		opts = append(opts, libp2p.EnableRelayService(relayv2.WithACL(&relayACL{peers: peerRegistry})))
+	}
+
+	if o.EnableHolePunching {
+		opts = append(opts,
+			libp2p.EnableAutoRelayWithPeerSource(relayPeerSource(peerRegistry, libp2pPeerstore), o.autoRelayOptions...),
+			libp2p.EnableHolePunching(),
+		)
+	}
+
 	transports := []libp2p.Option{
 		libp2p.Transport(tcp.NewTCPTransport, tcp.DisableReuseport()),
 	}
@@ -249,6 +319,16 @@ func New(ctx context.Context, signer beecrypto.Signer, networkID uint64, overlay
 		transports = append(transports, libp2p.Transport(ws.New))
 	}
 
+	// QUIC and WebTransport bring their own encryption and stream
+	// multiplexing, so the security option does not apply to them.
+	if o.QUICAddr != "" {
+		transports = append(transports, libp2p.Transport(libp2pquic.NewTransport))
+	}
+
+	if o.WebTransportAddr != "" {
+		transports = append(transports, libp2p.Transport(libp2pwebtransport.New))
+	}
+
 	opts = append(opts, transports...)
 
 	if o.hostFactory == nil {
@@ -304,6 +384,13 @@ func New(ctx context.Context, signer beecrypto.Signer, networkID uint64, overlay
 		advertisableAddresser = natAddrResolver
 	}
 
+	if o.EnableHolePunching {
+		advertisableAddresser = &relayAddressResolver{
+			addrs:    h.Addrs,
+			resolver: advertisableAddresser,
+		}
+	}
+
 	handshakeService, err := handshake.New(signer, advertisableAddresser, overlay, networkID, o.FullNode, o.Nonce, o.WelcomeMessage, o.ValidateOverlay, h.ID(), logger)
 	if err != nil {
 		return nil, fmt.Errorf("handshake service: %w", err)
@@ -319,7 +406,6 @@ func New(ctx context.Context, signer beecrypto.Signer, networkID uint64, overlay
 		return nil, err
 	}
 
-	peerRegistry := newPeerRegistry()
 	s := &Service{
 		ctx:               ctx,
 		host:              h,
@@ -334,6 +420,7 @@ func New(ctx context.Context, signer beecrypto.Signer, networkID uint64, overlay
 		peers:             peerRegistry,
 		addressbook:       ab,
 		blocklist:         blocklist.NewBlocklist(storer),
+		allowlist:         o.Allowlist,
 		logger:            logger.WithName(loggerName).Register(),
 		tracer:            tracer,
 		connectionBreaker: breaker.NewBreaker(breaker.Options{}), // use default options
@@ -346,6 +433,8 @@ func New(ctx context.Context, signer beecrypto.Signer, networkID uint64, overlay
 
 	peerRegistry.setDisconnecter(s)
 
+	s.bandwidth = newBandwidth(ctx, o.BandwidthLimit, o.ProtocolBandwidthLimits, s.metrics)
+
 	s.lightNodeLimit = defaultLightNodeLimit
 	if o.LightNodeLimit > 0 {
 		s.lightNodeLimit = o.LightNodeLimit
@@ -437,6 +526,13 @@ func (s *Service) handleIncoming(stream network.Stream) {
 		return
 	}
 
+	if !s.allowed(overlay, stream.Conn()) {
+		s.logger.Error(nil, "stream handler: blocked connection from peer outside of the private network", "peer_address", overlay)
+		_ = handshakeStream.Reset()
+		_ = s.host.Network().ClosePeer(peerID)
+		return
+	}
+
 	if exists := s.peers.addIfNotExists(stream.Conn(), overlay, i.FullNode); exists {
 		s.logger.Debug("stream handler: peer already exists", "peer_address", overlay)
 		if err = handshakeStream.FullClose(); err != nil {
@@ -578,6 +674,7 @@ func (s *Service) AddProtocol(p p2p.ProtocolSpec) (err error) {
 			}
 
 			stream := newStream(streamlibp2p, s.metrics)
+			stream.setBandwidth(s.ctx, s.bandwidth.protocol(p.Name))
 
 			// exchange headers
 			headersStartTime := time.Now()
@@ -708,6 +805,16 @@ func buildUnderlayAddress(addr ma.Multiaddr, peerID libp2ppeer.ID) (ma.Multiaddr
 	return addr.Encapsulate(hostAddr), nil
 }
 
+// allowed reports whether the peer with the overlay connected
+// over the connection is on the allowlist of the private network.
+func (s *Service) allowed(overlay swarm.Address, conn network.Conn) bool {
+	underlay, err := buildUnderlayAddress(conn.RemoteMultiaddr(), conn.RemotePeer())
+	if err != nil {
+		return false
+	}
+	return s.allowlist.Allowed(overlay, underlay)
+}
+
 func (s *Service) Connect(ctx context.Context, addr ma.Multiaddr) (address *bzz.Address, err error) {
 	loggerV1 := s.logger.V(1).Register()
 
@@ -736,6 +843,13 @@ func (s *Service) Connect(ctx context.Context, addr ma.Multiaddr) (address *bzz.
 		return address, p2p.ErrAlreadyConnected
 	}
 
+	// The relay serves only the connected swarm peers.
+	if isRelayAddress(remoteAddr) {
+		if err := s.connectRelay(ctx, remoteAddr); err != nil {
+			return nil, fmt.Errorf("connect relay: %w", err)
+		}
+	}
+
 	if err := s.connectionBreaker.Execute(func() error { return s.host.Connect(ctx, *info) }); err != nil {
 		if errors.Is(err, breaker.ErrClosed) {
 			s.metrics.ConnectBreakerCount.Inc()
@@ -744,6 +858,15 @@ func (s *Service) Connect(ctx context.Context, addr ma.Multiaddr) (address *bzz.
 		return nil, err
 	}
 
+	// A peer behind NAT is dialed through a circuit relay and the relayed
+	// connection has to be upgraded to a direct one by the hole punching.
+	if isRelayAddress(remoteAddr) {
+		if err := s.waitDirectConnection(ctx, info.ID); err != nil {
+			_ = s.host.Network().ClosePeer(info.ID)
+			return nil, err
+		}
+	}
+
 	stream, err := s.newStreamForPeerID(ctx, info.ID, handshake.ProtocolName, handshake.ProtocolVersion, handshake.StreamName)
 	if err != nil {
 		_ = s.host.Network().ClosePeer(info.ID)
@@ -782,6 +905,13 @@ func (s *Service) Connect(ctx context.Context, addr ma.Multiaddr) (address *bzz.
 		return nil, p2p.ErrPeerBlocklisted
 	}
 
+	if !s.allowed(overlay, stream.Conn()) {
+		s.logger.Error(nil, "blocked connection to peer outside of the private network", "peer_id", info.ID)
+		_ = handshakeStream.Reset()
+		_ = s.host.Network().ClosePeer(info.ID)
+		return nil, p2p.ErrPeerNotAllowed
+	}
+
 	if exists := s.peers.addIfNotExists(stream.Conn(), overlay, i.FullNode); exists {
 		if err := handshakeStream.FullClose(); err != nil {
 			_ = s.Disconnect(overlay, "failed closing handshake stream after connect")
@@ -858,6 +988,9 @@ func (s *Service) Disconnect(overlay swarm.Address, reason string) (err error) {
 	s.protocolsmu.RUnlock()
 
 	if s.notifier != nil {
+		if r, ok := s.notifier.(p2p.DisconnectReasoner); ok {
+			r.DisconnectReason(overlay, reason)
+		}
 		s.notifier.Disconnected(peer)
 	}
 	if s.lightNodes != nil {
@@ -938,6 +1071,7 @@ func (s *Service) NewStream(ctx context.Context, overlay swarm.Address, headers
 	}
 
 	stream := newStream(streamlibp2p, s.metrics)
+	stream.setBandwidth(ctx, s.bandwidth.protocol(protocolName))
 
 	// tracing: add span context header
 	if headers == nil {
@@ -1033,6 +1167,12 @@ func (s *Service) Halt() {
 }
 
 func (s *Service) Ping(ctx context.Context, addr ma.Multiaddr) (rtt time.Duration, err error) {
+	// A peer behind NAT is reachable only through its circuit relay,
+	// which does not relay the connections of the ping dialer.
+	if isRelayAddress(addr) {
+		return s.pingRelayed(ctx, addr)
+	}
+
 	info, err := libp2ppeer.AddrInfoFromP2pAddr(addr)
 	if err != nil {
 		return rtt, fmt.Errorf("unable to parse underlay address: %w", err)
@@ -27,6 +27,7 @@ type metrics struct {
 	KickedOutPeersCount        prometheus.Counter
 	StreamHandlerErrResetCount prometheus.Counter
 	HeadersExchangeDuration    prometheus.Histogram
+	ProtocolBandwidth          *prometheus.CounterVec
 }
 
 func newMetrics() metrics {
@@ -117,6 +118,15 @@ func newMetrics() metrics {
 			Name:      "headers_exchange_duration",
 			Help:      "The duration spent exchanging the headers.",
 		}),
+		ProtocolBandwidth: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "protocol_bandwidth_bytes",
+				Help:      "Number of bytes transferred over the protocol streams.",
+			},
+			[]string{"protocol", "direction"},
+		),
 	}
 }
 
@@ -176,6 +176,20 @@ func (r *peerRegistry) fullnode(peerID libp2ppeer.ID) (bool, bool) {
 	return full, found
 }
 
+// fullNodePeerIDs returns the libp2p peer IDs of all connected full nodes.
+func (r *peerRegistry) fullNodePeerIDs() []libp2ppeer.ID {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	ids := make([]libp2ppeer.ID, 0, len(r.full))
+	for id, full := range r.full {
+		if full {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
 func (r *peerRegistry) isConnected(peerID libp2ppeer.ID, remoteAddr ma.Multiaddr) (swarm.Address, bool) {
 	if remoteAddr == nil {
 		return swarm.ZeroAddress, false
@@ -0,0 +1,199 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package libp2p
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/p2p"
+	"github.com/ethersphere/bee/v2/pkg/p2p/libp2p/internal/handshake"
+	"github.com/libp2p/go-libp2p/core/network"
+	libp2ppeer "github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/peerstore"
+	"github.com/libp2p/go-libp2p/p2p/host/autorelay"
+	"github.com/libp2p/go-libp2p/p2p/protocol/circuitv2/proto"
+	libp2pping "github.com/libp2p/go-libp2p/p2p/protocol/ping"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+const (
+	// directConnectionTimeout is the time to wait for the hole punching to
+	// replace a relayed connection with a direct one.
+	directConnectionTimeout       = 30 * time.Second
+	directConnectionCheckInterval = 100 * time.Millisecond
+)
+
+// errNoDirectConnection is returned when a peer dialed through a circuit relay
+// could not be connected to directly. Relayed connections are limited in
+// duration and data, so they are not used for the swarm protocols.
+var errNoDirectConnection = errors.New("no direct connection established with relayed peer")
+
+// isRelayAddress reports whether the multiaddr is a circuit relay address.
+func isRelayAddress(a ma.Multiaddr) bool {
+	_, err := a.ValueForProtocol(ma.P_CIRCUIT)
+	return err == nil
+}
+
+// relayAddress returns the address of the relay from the circuit relay address.
+func relayAddress(a ma.Multiaddr) ma.Multiaddr {
+	relay, _ := ma.SplitFunc(a, func(c ma.Component) bool {
+		return c.Code() == ma.P_CIRCUIT
+	})
+	return relay
+}
+
+// relayPeerSource returns the autorelay peer source which provides the
+// connected full nodes that run the circuit relay service as relay candidates.
+func relayPeerSource(peers *peerRegistry, ps peerstore.Peerstore) autorelay.PeerSource {
+	return func(ctx context.Context, num int) <-chan libp2ppeer.AddrInfo {
+		c := make(chan libp2ppeer.AddrInfo, num)
+		defer close(c)
+
+		for _, id := range peers.fullNodePeerIDs() {
+			if len(c) == num {
+				break
+			}
+			if supported, err := ps.SupportsProtocols(id, proto.ProtoIDv2Hop); err != nil || len(supported) == 0 {
+				continue
+			}
+			c <- libp2ppeer.AddrInfo{ID: id, Addrs: ps.Addrs(id)}
+		}
+		return c
+	}
+}
+
+// relayACL allows only the connected swarm peers, which have their overlay
+// verified by the handshake, to make relay reservations and to be relayed,
+// so that the relay service is not available to arbitrary libp2p nodes.
+type relayACL struct {
+	peers *peerRegistry
+}
+
+func (a *relayACL) AllowReserve(p libp2ppeer.ID, _ ma.Multiaddr) bool {
+	_, found := a.peers.overlay(p)
+	return found
+}
+
+func (a *relayACL) AllowConnect(src libp2ppeer.ID, _ ma.Multiaddr, dest libp2ppeer.ID) bool {
+	_, srcFound := a.peers.overlay(src)
+	_, destFound := a.peers.overlay(dest)
+	return srcFound && destFound
+}
+
+// relayAddressResolver advertises the circuit relay address of the node once
+// it has a relay reservation, which is the case only when the node is not
+// publicly reachable. Otherwise, the resolving is left to the wrapped resolver.
+type relayAddressResolver struct {
+	addrs    func() []ma.Multiaddr
+	resolver handshake.AdvertisableAddressResolver
+}
+
+func (r *relayAddressResolver) Resolve(observedAddress ma.Multiaddr) (ma.Multiaddr, error) {
+	observableAddrInfo, err := libp2ppeer.AddrInfoFromP2pAddr(observedAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, a := range r.addrs() {
+		if isRelayAddress(a) {
+			return buildUnderlayAddress(a, observableAddrInfo.ID)
+		}
+	}
+
+	return r.resolver.Resolve(observedAddress)
+}
+
+// connectRelay connects the relay of the circuit relay address as a swarm
+// peer, unless it is connected already, as the relay does not serve others.
+// The connection is signaled to the notifier as a forced one, so that the
+// relay is not dropped from a saturated bin while the peers behind it are used.
+func (s *Service) connectRelay(ctx context.Context, addr ma.Multiaddr) error {
+	relayAddr := relayAddress(addr)
+	info, err := libp2ppeer.AddrInfoFromP2pAddr(relayAddr)
+	if err != nil {
+		return err
+	}
+	if _, found := s.peers.overlay(info.ID); found {
+		return nil
+	}
+
+	bzzAddr, err := s.Connect(ctx, relayAddr)
+	if errors.Is(err, p2p.ErrAlreadyConnected) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if s.notifier == nil {
+		return nil
+	}
+	peer := p2p.Peer{Address: bzzAddr.Overlay, FullNode: true, EthereumAddress: bzzAddr.EthereumAddress}
+	if err := s.notifier.Connected(ctx, peer, true); err != nil {
+		_ = s.Disconnect(bzzAddr.Overlay, "unable to signal connection notifier")
+		return err
+	}
+	return nil
+}
+
+// pingRelayed pings the peer behind NAT through its circuit relay, so that
+// the address does not pass for a reachable one with only the relay behind it.
+// The relay serves only the connected swarm peers, so it is connected first
+// and the limited relayed connection opened for the ping is closed after it.
+func (s *Service) pingRelayed(ctx context.Context, addr ma.Multiaddr) (rtt time.Duration, err error) {
+	info, err := libp2ppeer.AddrInfoFromP2pAddr(addr)
+	if err != nil {
+		return rtt, fmt.Errorf("unable to parse underlay address: %w", err)
+	}
+
+	if err := s.connectRelay(ctx, addr); err != nil {
+		return rtt, fmt.Errorf("connect relay: %w", err)
+	}
+
+	if s.host.Network().Connectedness(info.ID) == network.NotConnected {
+		defer func() {
+			for _, c := range s.host.Network().ConnsToPeer(info.ID) {
+				if c.Stat().Limited {
+					_ = c.Close()
+				}
+			}
+		}()
+	}
+	s.host.Peerstore().AddAddrs(info.ID, info.Addrs, peerstore.TempAddrTTL)
+
+	select {
+	case <-ctx.Done():
+		return rtt, ctx.Err()
+	case res := <-libp2pping.Ping(ctx, s.host, info.ID):
+		return res.RTT, res.Error
+	}
+}
+
+// waitDirectConnection waits for the hole punching to establish a direct
+// connection with a peer that is connected only through a circuit relay.
+func (s *Service) waitDirectConnection(ctx context.Context, peerID libp2ppeer.ID) error {
+	ctx, cancel := context.WithTimeout(ctx, directConnectionTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(directConnectionCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		for _, c := range s.host.Network().ConnsToPeer(peerID) {
+			if !c.Stat().Limited {
+				return nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return errNoDirectConnection
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package libp2p_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/p2p"
+	"github.com/ethersphere/bee/v2/pkg/p2p/libp2p"
+	"github.com/ethersphere/bee/v2/pkg/spinlock"
+	libp2pm "github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p/core/host"
+	libp2ppeer "github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/p2p/host/autorelay"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+func TestRelayAddressResolver(t *testing.T) {
+	t.Parallel()
+
+	const observableAddress = "/ip4/127.0.0.1/tcp/7071/p2p/16Uiu2HAkyyGKpjBiCkVqCKoJa6RzzZw9Nr7hGogsMPcdad1KyMmd"
+
+	for _, tc := range []struct {
+		name  string
+		addrs []string
+		want  string
+	}{
+		{
+			name:  "no relay address",
+			addrs: []string{"/ip4/127.0.0.1/tcp/1634", "/ip4/192.168.1.34/tcp/1634"},
+			want:  "/ip4/192.168.1.34/tcp/30123/p2p/16Uiu2HAkyyGKpjBiCkVqCKoJa6RzzZw9Nr7hGogsMPcdad1KyMmd",
+		},
+		{
+			name: "relay address",
+			addrs: []string{
+				"/ip4/127.0.0.1/tcp/1634",
+				"/ip4/1.2.3.4/tcp/1634/p2p/16Uiu2HAm8PwTUbwBsEtC9BLBk7XkGAyHRpTFSBqkYX9DRLYfiBV4/p2p-circuit",
+			},
+			want: "/ip4/1.2.3.4/tcp/1634/p2p/16Uiu2HAm8PwTUbwBsEtC9BLBk7XkGAyHRpTFSBqkYX9DRLYfiBV4/p2p-circuit/p2p/16Uiu2HAkyyGKpjBiCkVqCKoJa6RzzZw9Nr7hGogsMPcdad1KyMmd",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			natResolver, err := libp2p.NewStaticAddressResolver("192.168.1.34:30123", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			r := libp2p.NewRelayAddressResolver(func() []ma.Multiaddr {
+				addrs := make([]ma.Multiaddr, 0, len(tc.addrs))
+				for _, a := range tc.addrs {
+					addrs = append(addrs, ma.StringCast(a))
+				}
+				return addrs
+			}, natResolver)
+
+			got, err := r.Resolve(ma.StringCast(observableAddress))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got.String() != tc.want {
+				t.Errorf("got %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+// TestConnectThroughRelay dials a peer behind NAT which is reachable only
+// through the circuit relay address. The hole punching needs
+// public addresses, so the relayed connection is not upgraded on loopback.
+func TestConnectThroughRelay(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	relayOpts := libp2p.Options{EnableRelayService: true, EnableHolePunching: true, FullNode: true}
+	libp2p.SetHostFactory(&relayOpts, hostFactory(libp2pm.ForceReachabilityPublic()))
+	relay, relayOverlay := newService(t, 1, libp2pServiceOpts{libp2pOpts: relayOpts, notifier: mockNotifier(noopCf, noopDf, true)})
+
+	natOpts := libp2p.Options{EnableHolePunching: true, FullNode: true}
+	libp2p.SetHostFactory(&natOpts, hostFactory(libp2pm.ForceReachabilityPrivate()))
+	libp2p.SetAutoRelayOptions(&natOpts,
+		autorelay.WithBootDelay(0),
+		autorelay.WithMinCandidates(1),
+		autorelay.WithMinInterval(100*time.Millisecond),
+		autorelay.WithBackoff(100*time.Millisecond),
+	)
+	nat, natOverlay := newService(t, 1, libp2pServiceOpts{libp2pOpts: natOpts, notifier: mockNotifier(noopCf, noopDf, true)})
+
+	relayAddr := serviceUnderlayAddress(t, relay)
+	if _, err := nat.Connect(ctx, relayAddr); err != nil {
+		t.Fatal(err)
+	}
+	expectPeersEventually(t, relay, natOverlay)
+
+	// the relay addresses are advertised only for the public relays,
+	// so the circuit relay address of the peer on loopback is built here
+	natAddr := relayAddr.Encapsulate(ma.StringCast("/p2p-circuit/p2p/" + nat.Host().ID().String()))
+	natInfo, err := libp2ppeer.AddrInfoFromP2pAddr(natAddr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// wait for the relay reservation of the peer behind NAT
+	probe, _ := newService(t, 1, libp2pServiceOpts{notifier: mockNotifier(noopCf, noopDf, true)})
+	if _, err := probe.Connect(ctx, relayAddr); err != nil {
+		t.Fatal(err)
+	}
+	err = spinlock.Wait(10*time.Second, func() bool {
+		return probe.Host().Connect(ctx, *natInfo) == nil
+	})
+	if err != nil {
+		t.Fatal("no relay reservation")
+	}
+
+	t.Run("not a swarm peer", func(t *testing.T) {
+		h, err := libp2pm.New(libp2pm.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer h.Close()
+
+		if err := h.Connect(ctx, *natInfo); err == nil {
+			t.Fatal("relayed connection of a libp2p node which is not a swarm peer")
+		}
+	})
+
+	t.Run("swarm peer", func(t *testing.T) {
+		var forcedRelay atomic.Bool
+		dialer, _ := newService(t, 1, libp2pServiceOpts{
+			libp2pOpts: libp2p.Options{EnableHolePunching: true, FullNode: true},
+			notifier: mockNotifier(func(_ context.Context, p p2p.Peer, forced bool) error {
+				if p.Address.Equal(relayOverlay) {
+					forcedRelay.Store(forced)
+				}
+				return nil
+			}, noopDf, true),
+		})
+
+		connectCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+		defer cancel()
+
+		// the relayed connection is established, but it is not used
+		// for the swarm protocols without a direct one
+		_, err := dialer.Connect(connectCtx, natAddr)
+		if !errors.Is(err, libp2p.ErrNoDirectConnection) {
+			t.Fatalf("got error %v, want %v", err, libp2p.ErrNoDirectConnection)
+		}
+		expectPeers(t, dialer, relayOverlay)
+		if !forcedRelay.Load() {
+			t.Fatal("relay connection not forced in the topology")
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+
+		// the peer behind NAT is pinged through its relay
+		if _, err := dialer.Ping(ctx, natAddr); err != nil {
+			t.Fatal(err)
+		}
+
+		// a circuit relay address of a peer without
+		// a reservation is not reachable through the relay
+		forged := relayAddr.Encapsulate(ma.StringCast("/p2p-circuit/p2p/" + probe.Host().ID().String()))
+		if _, err := dialer.Ping(ctx, forged); err == nil {
+			t.Fatal("ping of a peer not reachable through the relay")
+		}
+	})
+}
+
+func hostFactory(opts ...libp2pm.Option) func(...libp2pm.Option) (host.Host, error) {
+	return func(o ...libp2pm.Option) (host.Host, error) {
+		return libp2pm.New(append(o, opts...)...)
+	}
+}
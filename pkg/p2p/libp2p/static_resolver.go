@@ -63,13 +63,21 @@ func (r *staticAddressResolver) Resolve(observedAddress ma.Multiaddr) (ma.Multia
 		multiProto = strings.Join(observedAddrSplit[:3], "/")
 	}
 
-	var port string
+	var port, suffix string
 	if r.port != "" {
 		port = r.port
 	} else {
 		port = observedAddrSplit[4]
 	}
-	a, err := ma.NewMultiaddr(multiProto + "/" + observedAddrSplit[3] + "/" + port)
+	// The NAT port is the one of the TCP transport, so the UDP based
+	// transports, like QUIC, keep the observed port and their protocols.
+	if observedAddrSplit[3] == "udp" {
+		port = observedAddrSplit[4]
+		if len(observedAddrSplit) > 5 {
+			suffix = "/" + strings.Join(observedAddrSplit[5:], "/")
+		}
+	}
+	a, err := ma.NewMultiaddr(multiProto + "/" + observedAddrSplit[3] + "/" + port + suffix)
 	if err != nil {
 		return nil, err
 	}
@@ -5,8 +5,10 @@
 package libp2p
 
 import (
+	"context"
 	"errors"
 	"io"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethersphere/bee/v2/pkg/p2p"
@@ -24,11 +26,91 @@ type stream struct {
 	headers         map[string][]byte
 	responseHeaders map[string][]byte
 	metrics         metrics
+	bandwidth       *protocolBandwidth // nil if the stream bandwidth is not limited
+
+	// the bandwidth waits end with the context, which is
+	// cancelled when the stream is closed or reset, or with the deadlines
+	ctx                         context.Context
+	cancel                      context.CancelFunc
+	readDeadline, writeDeadline atomic.Int64 // unix nanoseconds, zero if not set
 }
 
 func newStream(s network.Stream, metrics metrics) *stream {
 	return &stream{Stream: s, metrics: metrics}
 }
+
+// setBandwidth limits the bandwidth of the stream until the context is done.
+func (s *stream) setBandwidth(ctx context.Context, bandwidth *protocolBandwidth) {
+	s.bandwidth = bandwidth
+	s.ctx, s.cancel = context.WithCancel(ctx)
+}
+
+// waitContext returns the context of the bandwidth wait which ends at the deadline.
+func (s *stream) waitContext(deadline int64) (context.Context, context.CancelFunc) {
+	if deadline == 0 {
+		return s.ctx, func() {}
+	}
+	return context.WithDeadline(s.ctx, time.Unix(0, deadline))
+}
+
+func (s *stream) Read(p []byte) (int, error) {
+	n, err := s.Stream.Read(p)
+	if s.bandwidth != nil && n > 0 {
+		ctx, cancel := s.waitContext(s.readDeadline.Load())
+		defer cancel()
+		if werr := s.bandwidth.read(ctx, n); werr != nil && err == nil {
+			err = werr
+		}
+	}
+	return n, err
+}
+
+func (s *stream) Write(p []byte) (int, error) {
+	if s.bandwidth != nil {
+		ctx, cancel := s.waitContext(s.writeDeadline.Load())
+		defer cancel()
+		if err := s.bandwidth.write(ctx, len(p)); err != nil {
+			return 0, err
+		}
+	}
+	return s.Stream.Write(p)
+}
+
+func (s *stream) SetDeadline(t time.Time) error {
+	s.readDeadline.Store(unixNano(t))
+	s.writeDeadline.Store(unixNano(t))
+	return s.Stream.SetDeadline(t)
+}
+
+func (s *stream) SetReadDeadline(t time.Time) error {
+	s.readDeadline.Store(unixNano(t))
+	return s.Stream.SetReadDeadline(t)
+}
+
+func (s *stream) SetWriteDeadline(t time.Time) error {
+	s.writeDeadline.Store(unixNano(t))
+	return s.Stream.SetWriteDeadline(t)
+}
+
+// unixNano returns the deadline in unix nanoseconds, zero for no deadline.
+func unixNano(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano()
+}
+
+// stopBandwidth ends the bandwidth waits of the stream.
+func (s *stream) stopBandwidth() {
+	if s.cancel != nil {
+		s.cancel()
+	}
+}
+
+func (s *stream) Close() error {
+	s.stopBandwidth()
+	return s.Stream.Close()
+}
 func (s *stream) Headers() p2p.Headers {
 	return s.headers
 }
@@ -39,6 +121,7 @@ func (s *stream) ResponseHeaders() p2p.Headers {
 
 func (s *stream) Reset() error {
 	defer s.metrics.StreamResetCount.Inc()
+	s.stopBandwidth()
 	return s.Stream.Reset()
 }
 
@@ -129,6 +129,12 @@ type Notifier interface {
 	AnnounceTo(ctx context.Context, addressee, peer swarm.Address, fullnode bool) error
 }
 
+// DisconnectReasoner is implemented by the Notifier which is told the reason
+// of a disconnection initiated by the node, before it is notified about it.
+type DisconnectReasoner interface {
+	DisconnectReason(peer swarm.Address, reason string)
+}
+
 // DebugService extends the Service with method used for debugging.
 type DebugService interface {
 	Service
@@ -200,6 +206,17 @@ type BlockListedPeer struct {
 	Duration time.Duration
 }
 
+// ProtocolBandwidth holds the bandwidth usage and limit of a protocol.
+type ProtocolBandwidth struct {
+	Protocol string
+	Priority string
+	Limit    int64   // bytes per second, unlimited if zero
+	In       uint64  // total received bytes
+	Out      uint64  // total sent bytes
+	InRate   float64 // received bytes per second
+	OutRate  float64 // sent bytes per second
+}
+
 // HandlerFunc handles a received Stream from a Peer.
 type HandlerFunc func(context.Context, Peer, Stream) error
 
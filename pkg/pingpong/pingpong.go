@@ -18,6 +18,7 @@ import (
 	"github.com/ethersphere/bee/v2/pkg/p2p/protobuf"
 	"github.com/ethersphere/bee/v2/pkg/pingpong/pb"
 	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"github.com/ethersphere/bee/v2/pkg/topology/peerscore"
 	"github.com/ethersphere/bee/v2/pkg/tracing"
 )
 
@@ -39,6 +40,7 @@ type Service struct {
 	logger   log.Logger
 	tracer   *tracing.Tracer
 	metrics  metrics
+	scores   peerscore.Recorder
 }
 
 func New(streamer p2p.Streamer, logger log.Logger, tracer *tracing.Tracer) *Service {
@@ -47,9 +49,15 @@ func New(streamer p2p.Streamer, logger log.Logger, tracer *tracing.Tracer) *Serv
 		logger:   logger.WithName(loggerName).Register(),
 		tracer:   tracer,
 		metrics:  newMetrics(),
+		scores:   peerscore.NopRecorder,
 	}
 }
 
+// SetPeerScoreRecorder sets the recorder of the measured round-trip times.
+func (s *Service) SetPeerScoreRecorder(r peerscore.Recorder) {
+	s.scores = r
+}
+
 func (s *Service) Protocol() p2p.ProtocolSpec {
 	return p2p.ProtocolSpec{
 		Name:    protocolName,
@@ -96,7 +104,9 @@ func (s *Service) Ping(ctx context.Context, address swarm.Address, msgs ...strin
 
 		s.metrics.PongReceivedCount.Inc()
 	}
-	return time.Since(start), nil
+	rtt = time.Since(start)
+	s.scores.RecordLatency(address, rtt)
+	return rtt, nil
 }
 
 func (s *Service) handler(ctx context.Context, p p2p.Peer, stream p2p.Stream) error {
@@ -4,7 +4,11 @@
 
 package postage
 
-import "math/big"
+import (
+	"math"
+	"math/big"
+	"time"
+)
 
 // ChainState contains data the batch service reads from the chain.
 type ChainState struct {
@@ -12,3 +16,29 @@ type ChainState struct {
 	TotalAmount  *big.Int // Cumulative amount paid per stamp.
 	CurrentPrice *big.Int // Bzz/chunk/block normalised price.
 }
+
+// Balance returns the per chunk balance left on the batch,
+// zero if the batch is already expired.
+func (cs *ChainState) Balance(batch *Batch) *big.Int {
+	balance := new(big.Int).Sub(batch.Value, cs.TotalAmount)
+	if balance.Sign() < 0 {
+		balance.SetInt64(0)
+	}
+	return balance
+}
+
+// TTL returns the remaining time to live of the batch at the current price
+// with the given block time. It is not known if the price is not set yet or
+// the block time is not positive.
+func (cs *ChainState) TTL(batch *Batch, blockTime time.Duration) (ttl time.Duration, ok bool) {
+	if cs.CurrentPrice == nil || cs.CurrentPrice.Sign() == 0 || blockTime <= 0 {
+		return 0, false
+	}
+
+	blocks := cs.Balance(batch)
+	blocks.Div(blocks, cs.CurrentPrice)
+	if !blocks.IsInt64() || blocks.Int64() > int64(math.MaxInt64/blockTime) {
+		return math.MaxInt64, true
+	}
+	return time.Duration(blocks.Int64()) * blockTime, true
+}
@@ -0,0 +1,68 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package postage_test
+
+import (
+	"math"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/postage"
+	postagetesting "github.com/ethersphere/bee/v2/pkg/postage/testing"
+)
+
+func TestChainStateTTL(t *testing.T) {
+	t.Parallel()
+
+	batch := postagetesting.MustNewBatch(postagetesting.WithValue(1000))
+
+	for _, tc := range []struct {
+		name      string
+		cs        *postage.ChainState
+		blockTime time.Duration
+		ttl       time.Duration
+		ok        bool
+	}{
+		{
+			name:      "balance left",
+			cs:        &postage.ChainState{TotalAmount: big.NewInt(400), CurrentPrice: big.NewInt(10)},
+			blockTime: 5 * time.Second,
+			ttl:       60 * 5 * time.Second,
+			ok:        true,
+		},
+		{
+			name:      "expired",
+			cs:        &postage.ChainState{TotalAmount: big.NewInt(2000), CurrentPrice: big.NewInt(10)},
+			blockTime: 5 * time.Second,
+			ok:        true,
+		},
+		{
+			name:      "overflow",
+			cs:        &postage.ChainState{TotalAmount: big.NewInt(0), CurrentPrice: big.NewInt(1)},
+			blockTime: math.MaxInt64 / 100,
+			ttl:       math.MaxInt64,
+			ok:        true,
+		},
+		{
+			name:      "no price",
+			cs:        &postage.ChainState{TotalAmount: big.NewInt(0), CurrentPrice: big.NewInt(0)},
+			blockTime: 5 * time.Second,
+		},
+		{
+			name: "no block time",
+			cs:   &postage.ChainState{TotalAmount: big.NewInt(0), CurrentPrice: big.NewInt(10)},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ttl, ok := tc.cs.TTL(batch, tc.blockTime)
+			if ttl != tc.ttl || ok != tc.ok {
+				t.Fatalf("got ttl %s and %t, want %s and %t", ttl, ok, tc.ttl, tc.ok)
+			}
+		})
+	}
+}
@@ -30,10 +30,12 @@ type EventUpdater interface {
 // ChainSnapshot represents the snapshot of all the postage events between the
 // FirstBlockNumber and LastBlockNumber. The timestamp stores the time at which the
 // snapshot was generated. This snapshot can be used to sync the postage package
-// to prevent large no. of chain backend calls.
+// to prevent large no. of chain backend calls. The LastBlockHash, when set, is
+// the hash of the block with the LastBlockNumber the snapshot is verified against.
 type ChainSnapshot struct {
 	Events           []types.Log `json:"events"`
 	LastBlockNumber  uint64      `json:"lastBlockNumber"`
+	LastBlockHash    common.Hash `json:"lastBlockHash"`
 	FirstBlockNumber uint64      `json:"firstBlockNumber"`
 	Timestamp        int64       `json:"timestamp"`
 }
@@ -71,6 +71,9 @@ type listener struct {
 	pausedTopic             common.Hash
 }
 
+// New constructs the listener of the postage stamp contract events. The
+// listener without the ev backend only applies the initial state passed to
+// Listen, for the nodes bootstrapped from a file without chain access.
 func New(
 	syncingStopped *syncutil.Signaler,
 	logger log.Logger,
@@ -226,6 +229,17 @@ func (l *listener) Listen(ctx context.Context, from uint64, updater postage.Even
 		return nil
 	}
 
+	if l.ev == nil {
+		// without a chain backend only the initial state is applied
+		synced := make(chan error, 1)
+		if initState != nil {
+			synced <- processEvents(initState.Events, initState.LastBlockNumber+1)
+		} else {
+			synced <- nil
+		}
+		return synced
+	}
+
 	if initState != nil {
 		err := processEvents(initState.Events, initState.LastBlockNumber+1)
 		if err != nil {
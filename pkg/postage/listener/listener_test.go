@@ -534,6 +534,66 @@ func TestListenerBatchState(t *testing.T) {
 	}
 }
 
+func TestListenerWithoutBackend(t *testing.T) {
+	t.Parallel()
+
+	ev := newEventUpdaterMock()
+	create := createArgs{
+		id:               hash[:],
+		owner:            addr[:],
+		amount:           big.NewInt(42),
+		normalisedAmount: big.NewInt(43),
+		depth:            100,
+	}
+	snapshot := &postage.ChainSnapshot{
+		Events:           []types.Log{create.toLog(496)},
+		FirstBlockNumber: 496,
+		LastBlockNumber:  499,
+	}
+
+	events := make(chan []interface{})
+	go func() {
+		var got []interface{}
+		for e := range ev.eventC {
+			got = append(got, e)
+		}
+		events <- got
+	}()
+
+	l := listener.New(
+		nil,
+		log.Noop,
+		nil,
+		postageStampContractAddress,
+		postageStampContractABI,
+		1,
+		stallingTimeout,
+		backoffTime,
+	)
+	testutil.CleanupCloser(t, l)
+
+	select {
+	case err := <-l.Listen(context.Background(), snapshot.LastBlockNumber+1, ev, snapshot):
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the initial state")
+	}
+	close(ev.eventC)
+
+	got := <-events
+	if len(got) != 3 {
+		t.Fatalf("got %d updates, want 3: %v", len(got), got)
+	}
+	if err := got[1].(createArgs).compare(create); err != nil {
+		t.Fatal(err)
+	}
+	if b := got[2].(blockNumberCall).blockNumber; b != 500 {
+		t.Fatalf("got block number %d, want 500", b)
+	}
+}
+
 func newEventUpdaterMock() *updater {
 	return &updater{
 		eventC: make(chan interface{}, 1),
@@ -0,0 +1,322 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package migration re-stamps the content of a postage batch with another
+// batch and pushes it to the network, so that the content outlives the
+// expiry of the original batch.
+//
+// The chunks of the batch are the ones of the upload store and the reserve
+// stamped by it, and the ones of the pinned collections whose root was
+// uploaded with it, as recorded in the upload history of the batch.
+package migration
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/postage"
+	"github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/storer"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+// loggerName is the tree path name of the logger for this package.
+const loggerName = "batchmigration"
+
+var (
+	// ErrNotFound is returned when no migration of the batch was started.
+	ErrNotFound = errors.New("migration not found")
+	// ErrRunning is returned when the migration of the batch is already running.
+	ErrRunning = errors.New("migration already running")
+	// ErrSameBatch is returned when the batch would be migrated to itself.
+	ErrSameBatch = errors.New("migration to the same batch")
+)
+
+// Storer is the local store the chunks of the batch are read from
+// and pushed to the network with.
+type Storer interface {
+	IterateBatchChunks(ctx context.Context, batchID []byte, fn func(swarm.Address) (bool, error)) error
+	HasPin(root swarm.Address) (bool, error)
+	IteratePinCollection(root swarm.Address, fn func(swarm.Address) (bool, error)) error
+	ChunkStore() storage.ReadOnlyChunkStore
+	DirectUpload() storer.PutterSession
+}
+
+// State is the state of a migration.
+type State string
+
+const (
+	StateRunning   State = "running"
+	StateDone      State = "done"
+	StateFailed    State = "failed"
+	StateCancelled State = "cancelled"
+)
+
+// Status is the progress of the migration of a batch.
+type Status struct {
+	OldBatchID []byte
+	NewBatchID []byte
+	State      State
+	// Total is the number of the chunks of the old batch,
+	// zero until all of them are enumerated.
+	Total uint64
+	// Migrated is the number of the chunks re-stamped
+	// with the new batch and pushed to the network.
+	Migrated uint64
+	// Failed is the number of the chunks which
+	// could not be read from the local store.
+	Failed     uint64
+	Error      string
+	StartedAt  time.Time
+	FinishedAt time.Time
+}
+
+type job struct {
+	status Status
+	cancel context.CancelFunc
+}
+
+// Service runs the migrations of the batches in the background.
+// The state of the migrations is kept in memory only, an interrupted
+// migration has to be started again, the chunks which were already
+// re-stamped keep their stamps of the new batch.
+type Service struct {
+	storer Storer
+	store  storage.Store
+	logger log.Logger
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu   sync.Mutex
+	jobs map[string]*job
+}
+
+// New constructs a new migration service. The store is the
+// stamper store holding the upload history of the batches.
+func New(st Storer, store storage.Store, logger log.Logger) *Service {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Service{
+		storer: st,
+		store:  store,
+		logger: logger.WithName(loggerName).Register(),
+		ctx:    ctx,
+		cancel: cancel,
+		jobs:   make(map[string]*job),
+	}
+}
+
+// Start starts the migration of the content of the old batch to the new
+// batch the stamper issues the stamps from. The save function persists
+// the stamp issuer of the new batch once the migration finishes.
+func (s *Service) Start(oldBatchID []byte, stamper postage.Stamper, save func() error) (Status, error) {
+	newBatchID := stamper.BatchId()
+	if string(oldBatchID) == string(newBatchID) {
+		return Status{}, ErrSameBatch
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := string(oldBatchID)
+	if j, ok := s.jobs[key]; ok && j.status.State == StateRunning {
+		return Status{}, ErrRunning
+	}
+	if err := s.ctx.Err(); err != nil {
+		return Status{}, err
+	}
+
+	ctx, cancel := context.WithCancel(s.ctx)
+	j := &job{
+		status: Status{
+			OldBatchID: append([]byte(nil), oldBatchID...),
+			NewBatchID: append([]byte(nil), newBatchID...),
+			State:      StateRunning,
+			StartedAt:  time.Now(),
+		},
+		cancel: cancel,
+	}
+	s.jobs[key] = j
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer cancel()
+
+		err := s.migrate(ctx, j, stamper, save)
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		j.status.FinishedAt = time.Now()
+		switch {
+		case err == nil:
+			j.status.State = StateDone
+		case errors.Is(err, context.Canceled):
+			j.status.State = StateCancelled
+		default:
+			j.status.State = StateFailed
+			j.status.Error = err.Error()
+		}
+		s.logger.Info("batch migration finished",
+			"old_batch_id", hex.EncodeToString(j.status.OldBatchID),
+			"new_batch_id", hex.EncodeToString(j.status.NewBatchID),
+			"state", j.status.State,
+			"migrated", j.status.Migrated,
+			"failed", j.status.Failed,
+		)
+	}()
+
+	return j.status, nil
+}
+
+// Status returns the status of the last migration of the old batch.
+func (s *Service) Status(oldBatchID []byte) (Status, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	j, ok := s.jobs[string(oldBatchID)]
+	if !ok {
+		return Status{}, ErrNotFound
+	}
+	return j.status, nil
+}
+
+// Cancel cancels the running migration of the old batch.
+func (s *Service) Cancel(oldBatchID []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	j, ok := s.jobs[string(oldBatchID)]
+	if !ok || j.status.State != StateRunning {
+		return ErrNotFound
+	}
+	j.cancel()
+	return nil
+}
+
+// Close cancels the running migrations and waits for them to stop.
+func (s *Service) Close() error {
+	s.cancel()
+	s.wg.Wait()
+	return nil
+}
+
+// migrate re-stamps the chunks of the old batch and pushes them to the network.
+func (s *Service) migrate(ctx context.Context, j *job, stamper postage.Stamper, save func() error) (err error) {
+	oldBatchID := j.status.OldBatchID
+
+	addrs, uploads, err := s.batchChunks(ctx, oldBatchID)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	j.status.Total = uint64(len(addrs))
+	s.mu.Unlock()
+
+	session := s.storer.DirectUpload()
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, session.Cleanup())
+		}
+		// the stamps issued so far are persisted even if the migration fails
+		if serr := save(); serr != nil {
+			err = errors.Join(err, fmt.Errorf("save stamp issuer: %w", serr))
+		}
+	}()
+
+	getter := s.storer.ChunkStore()
+	for _, addr := range addrs {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		ch, err := getter.Get(ctx, addr)
+		if err != nil {
+			s.logger.Debug("read chunk failed", "chunk_address", addr, "error", err)
+			s.mu.Lock()
+			j.status.Failed++
+			s.mu.Unlock()
+			continue
+		}
+
+		idAddr, err := storage.IdentityAddress(ch)
+		if err != nil {
+			return fmt.Errorf("identity address of chunk %s: %w", addr, err)
+		}
+		stamp, err := stamper.Stamp(ch.Address(), idAddr)
+		if err != nil {
+			return fmt.Errorf("stamp chunk %s: %w", addr, err)
+		}
+		if err := session.Put(ctx, ch.WithStamp(stamp)); err != nil {
+			return fmt.Errorf("push chunk %s: %w", addr, err)
+		}
+
+		s.mu.Lock()
+		j.status.Migrated++
+		s.mu.Unlock()
+	}
+
+	if err := session.Done(swarm.ZeroAddress); err != nil {
+		return fmt.Errorf("push chunks: %w", err)
+	}
+
+	// the uploads are listed in the history of the new batch so
+	// that the pinned content is found by its next migration
+	for _, upload := range uploads {
+		if err := postage.RecordUpload(s.store, j.status.NewBatchID, upload); err != nil {
+			return fmt.Errorf("record upload %s: %w", upload.Reference, err)
+		}
+	}
+	return nil
+}
+
+// batchChunks returns the addresses of the chunks of the batch
+// and the uploads recorded in the history of the batch.
+func (s *Service) batchChunks(ctx context.Context, batchID []byte) ([]swarm.Address, []postage.Upload, error) {
+	var (
+		addrs []swarm.Address
+		seen  = make(map[string]struct{})
+	)
+	add := func(addr swarm.Address) (bool, error) {
+		if err := ctx.Err(); err != nil {
+			return true, err
+		}
+		if _, ok := seen[addr.ByteString()]; !ok {
+			seen[addr.ByteString()] = struct{}{}
+			addrs = append(addrs, addr)
+		}
+		return false, nil
+	}
+
+	if err := s.storer.IterateBatchChunks(ctx, batchID, add); err != nil {
+		return nil, nil, fmt.Errorf("iterate batch chunks: %w", err)
+	}
+
+	uploads, err := postage.BatchUploads(ctx, s.store, batchID, 0, math.MaxInt)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, upload := range uploads {
+		pinned, err := s.storer.HasPin(upload.Reference)
+		if err != nil {
+			return nil, nil, fmt.Errorf("has pin %s: %w", upload.Reference, err)
+		}
+		if !pinned {
+			continue
+		}
+		if err := s.storer.IteratePinCollection(upload.Reference, add); err != nil {
+			return nil, nil, fmt.Errorf("iterate pin collection %s: %w", upload.Reference, err)
+		}
+	}
+
+	return addrs, uploads, nil
+}
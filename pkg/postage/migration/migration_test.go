@@ -0,0 +1,228 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package migration_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/crypto"
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/postage"
+	"github.com/ethersphere/bee/v2/pkg/postage/migration"
+	postagetesting "github.com/ethersphere/bee/v2/pkg/postage/testing"
+	"github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/storage/inmemchunkstore"
+	"github.com/ethersphere/bee/v2/pkg/storage/inmemstore"
+	chunktest "github.com/ethersphere/bee/v2/pkg/storage/testing"
+	"github.com/ethersphere/bee/v2/pkg/storer"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+type testStorer struct {
+	chunks *inmemchunkstore.ChunkStore
+	batch  []swarm.Address
+	pins   map[string][]swarm.Address
+	block  bool
+
+	mu     sync.Mutex
+	pushed []swarm.Chunk
+}
+
+func (s *testStorer) IterateBatchChunks(_ context.Context, _ []byte, fn func(swarm.Address) (bool, error)) error {
+	for _, addr := range s.batch {
+		if stop, err := fn(addr); stop || err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *testStorer) HasPin(root swarm.Address) (bool, error) {
+	_, ok := s.pins[root.ByteString()]
+	return ok, nil
+}
+
+func (s *testStorer) IteratePinCollection(root swarm.Address, fn func(swarm.Address) (bool, error)) error {
+	for _, addr := range s.pins[root.ByteString()] {
+		if stop, err := fn(addr); stop || err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *testStorer) ChunkStore() storage.ReadOnlyChunkStore { return s.chunks }
+
+func (s *testStorer) DirectUpload() storer.PutterSession { return &testSession{s} }
+
+type testSession struct{ s *testStorer }
+
+func (ts *testSession) Put(ctx context.Context, ch swarm.Chunk) error {
+	if ts.s.block {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	ts.s.mu.Lock()
+	defer ts.s.mu.Unlock()
+	ts.s.pushed = append(ts.s.pushed, ch)
+	return nil
+}
+
+func (ts *testSession) Done(swarm.Address) error { return nil }
+
+func (ts *testSession) Cleanup() error { return nil }
+
+func newTestStamper(t *testing.T, store storage.Store) postage.Stamper {
+	t.Helper()
+
+	pk, err := crypto.GenerateSecp256k1Key()
+	if err != nil {
+		t.Fatal(err)
+	}
+	issuer := postage.NewStampIssuer("label", "keyID", postagetesting.MustNewID(), big.NewInt(3), 16, 8, 1000, true)
+	return postage.NewStamper(store, issuer, crypto.NewDefaultSigner(pk))
+}
+
+func waitState(t *testing.T, s *migration.Service, batchID []byte, state migration.State) migration.Status {
+	t.Helper()
+
+	for range 100 {
+		status, err := s.Status(batchID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if status.State == state {
+			return status
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	t.Fatalf("migration did not reach state %q", state)
+	return migration.Status{}
+}
+
+func TestMigration(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx        = context.Background()
+		store      = inmemstore.New()
+		chunks     = chunktest.GenerateTestRandomChunks(5)
+		missing    = swarm.RandAddress(t)
+		oldBatchID = postagetesting.MustNewID()
+		root       = chunks[3].Address()
+		st         = &testStorer{
+			chunks: inmemchunkstore.New(),
+			// the chunk held both in the upload store and the reserve is reported twice
+			batch: []swarm.Address{chunks[0].Address(), chunks[1].Address(), chunks[1].Address(), missing},
+			pins:  map[string][]swarm.Address{root.ByteString(): {chunks[1].Address(), chunks[3].Address(), chunks[4].Address()}},
+		}
+	)
+	for _, ch := range chunks {
+		if err := st.chunks.Put(ctx, ch); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, ref := range []swarm.Address{root, swarm.RandAddress(t)} {
+		if err := postage.RecordUpload(store, oldBatchID, postage.Upload{Reference: ref, Timestamp: time.Now().UnixNano()}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s := migration.New(st, store, log.Noop)
+	t.Cleanup(func() { _ = s.Close() })
+
+	stamper := newTestStamper(t, store)
+	saved := make(chan struct{}, 1)
+	if _, err := s.Start(oldBatchID, stamper, func() error { saved <- struct{}{}; return nil }); err != nil {
+		t.Fatal(err)
+	}
+
+	status := waitState(t, s, oldBatchID, migration.StateDone)
+	if status.Total != 5 || status.Migrated != 4 || status.Failed != 1 {
+		t.Fatalf("got total %d, migrated %d, failed %d, want 5, 4, 1", status.Total, status.Migrated, status.Failed)
+	}
+	if !bytes.Equal(status.NewBatchID, stamper.BatchId()) {
+		t.Fatalf("got new batch %x, want %x", status.NewBatchID, stamper.BatchId())
+	}
+	select {
+	case <-saved:
+	default:
+		t.Fatal("stamp issuer not saved")
+	}
+
+	st.mu.Lock()
+	pushed := st.pushed
+	st.mu.Unlock()
+	if len(pushed) != 4 {
+		t.Fatalf("got %d pushed chunks, want 4", len(pushed))
+	}
+	for _, ch := range pushed {
+		if !bytes.Equal(ch.Stamp().BatchID(), stamper.BatchId()) {
+			t.Fatalf("chunk %s stamped by batch %x, want %x", ch.Address(), ch.Stamp().BatchID(), stamper.BatchId())
+		}
+	}
+
+	uploads, err := postage.BatchUploads(ctx, store, stamper.BatchId(), 0, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(uploads) != 2 {
+		t.Fatalf("got %d uploads of the new batch, want 2", len(uploads))
+	}
+}
+
+func TestMigrationCancel(t *testing.T) {
+	t.Parallel()
+
+	store := inmemstore.New()
+	ch := chunktest.GenerateTestRandomChunk()
+	st := &testStorer{chunks: inmemchunkstore.New(), batch: []swarm.Address{ch.Address()}, block: true}
+	if err := st.chunks.Put(context.Background(), ch); err != nil {
+		t.Fatal(err)
+	}
+	oldBatchID := postagetesting.MustNewID()
+
+	s := migration.New(st, store, log.Noop)
+	t.Cleanup(func() { _ = s.Close() })
+
+	if _, err := s.Status(oldBatchID); !errors.Is(err, migration.ErrNotFound) {
+		t.Fatalf("got error %v, want %v", err, migration.ErrNotFound)
+	}
+
+	stamper := newTestStamper(t, store)
+	save := func() error { return nil }
+	if _, err := s.Start(stamper.BatchId(), stamper, save); !errors.Is(err, migration.ErrSameBatch) {
+		t.Fatalf("got error %v, want %v", err, migration.ErrSameBatch)
+	}
+
+	status, err := s.Start(oldBatchID, stamper, save)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.State != migration.StateRunning {
+		t.Fatalf("got state %q, want %q", status.State, migration.StateRunning)
+	}
+	if _, err := s.Start(oldBatchID, stamper, save); !errors.Is(err, migration.ErrRunning) {
+		t.Fatalf("got error %v, want %v", err, migration.ErrRunning)
+	}
+
+	if err := s.Cancel(oldBatchID); err != nil {
+		t.Fatal(err)
+	}
+	waitState(t, s, oldBatchID, migration.StateCancelled)
+
+	if err := s.Cancel(oldBatchID); !errors.Is(err, migration.ErrNotFound) {
+		t.Fatalf("got error %v, want %v", err, migration.ErrNotFound)
+	}
+	if _, err := s.Start(oldBatchID, stamper, save); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -6,10 +6,12 @@ package mock
 
 import (
 	"context"
+	"io"
 	"math/big"
 	"sync"
 
 	"github.com/ethersphere/bee/v2/pkg/postage"
+	"github.com/ethersphere/bee/v2/pkg/storage/inmemstore"
 )
 
 type optionFunc func(*mockPostage)
@@ -99,6 +101,71 @@ func (m *mockPostage) IssuerUsable(_ *postage.StampIssuer) bool {
 	return true
 }
 
+// Export writes the stamp issuer without any stamp items and marks it read-only.
+func (m *mockPostage) Export(ctx context.Context, batchID []byte, w io.Writer) error {
+	m.issuerLock.Lock()
+	issuer, exists := m.issuersMap[string(batchID)]
+	m.issuerLock.Unlock()
+	if !exists {
+		return postage.ErrNotFound
+	}
+
+	store := inmemstore.New()
+	if err := store.Put(&postage.StampIssuerItem{Issuer: issuer}); err != nil {
+		return err
+	}
+	if err := postage.ExportIssuer(ctx, store, batchID, w); err != nil {
+		return err
+	}
+
+	item := postage.NewStampIssuerItem(batchID)
+	if err := store.Get(item); err != nil {
+		return err
+	}
+	return m.Add(item.Issuer)
+}
+
+func (m *mockPostage) Import(ctx context.Context, r io.Reader) (*postage.StampIssuer, error) {
+	issuer, err := postage.ImportIssuer(ctx, inmemstore.New(), r)
+	if err != nil {
+		return nil, err
+	}
+
+	m.issuerLock.Lock()
+	defer m.issuerLock.Unlock()
+	if present, exists := m.issuersMap[string(issuer.ID())]; exists && !present.ReadOnly() {
+		return nil, postage.ErrIssuerExists
+	}
+	m.issuersMap[string(issuer.ID())] = issuer
+	return issuer, nil
+}
+
+// RevertExport makes the read-only stamp issuer writable again.
+func (m *mockPostage) RevertExport(batchID []byte) error {
+	m.issuerLock.Lock()
+	defer m.issuerLock.Unlock()
+
+	issuer, exists := m.issuersMap[string(batchID)]
+	if !exists {
+		return postage.ErrNotFound
+	}
+
+	store := inmemstore.New()
+	if err := store.Put(&postage.StampIssuerItem{Issuer: issuer}); err != nil {
+		return err
+	}
+	if err := postage.RevertExport(store, batchID); err != nil {
+		return err
+	}
+
+	item := postage.NewStampIssuerItem(batchID)
+	if err := store.Get(item); err != nil {
+		return err
+	}
+	m.issuersMap[string(batchID)] = item.Issuer
+	return nil
+}
+
 func (m *mockPostage) HandleCreate(_ *postage.Batch, _ *big.Int) error { return nil }
 
 func (m *mockPostage) HandleTopUp(_ []byte, _ *big.Int) {}
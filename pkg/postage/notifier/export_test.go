@@ -0,0 +1,15 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package notifier
+
+import "time"
+
+func (s *Service) Evaluate() {
+	s.evaluate()
+}
+
+func (s *Service) SetNow(f func() time.Time) {
+	s.now = f
+}
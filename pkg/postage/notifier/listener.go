@@ -0,0 +1,80 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package notifier
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethersphere/bee/v2/pkg/bigint"
+	"github.com/ethersphere/bee/v2/pkg/postage"
+)
+
+// BatchEventListener returns the listener which forwards the events of the
+// owned batches to next and publishes the top-ups and the dilutions. The events
+// replayed before the postage chain state is synced are not published.
+func (s *Service) BatchEventListener(next postage.BatchEventListener) postage.BatchEventListener {
+	return &batchEventListener{BatchEventListener: next, s: s}
+}
+
+type batchEventListener struct {
+	postage.BatchEventListener
+	s *Service
+}
+
+func (l *batchEventListener) synced() bool {
+	synced, err := l.s.opts.Synced()
+	return err == nil && synced
+}
+
+// HandleTopUp implements the postage.BatchEventListener interface.
+func (l *batchEventListener) HandleTopUp(id []byte, amount *big.Int) {
+	l.BatchEventListener.HandleTopUp(id, amount)
+
+	if l.synced() {
+		e := l.s.newEvent(EventTopUp, id)
+		e.Amount = bigint.Wrap(new(big.Int).Set(amount))
+		l.s.publish(e)
+	}
+}
+
+// HandleDepthIncrease implements the postage.BatchEventListener interface.
+func (l *batchEventListener) HandleDepthIncrease(id []byte, newDepth uint8) {
+	l.BatchEventListener.HandleDepthIncrease(id, newDepth)
+
+	if l.synced() {
+		e := l.s.newEvent(EventDilution, id)
+		e.Depth = newDepth
+		l.s.publish(e)
+	}
+}
+
+// BatchExpiryHandler returns the handler which forwards
+// the expiries to next and publishes the ones of the owned batches.
+func (s *Service) BatchExpiryHandler(next postage.BatchExpiryHandler) postage.BatchExpiryHandler {
+	return &batchExpiryHandler{next: next, s: s}
+}
+
+type batchExpiryHandler struct {
+	next postage.BatchExpiryHandler
+	s    *Service
+}
+
+// HandleStampExpiry implements the postage.BatchExpiryHandler interface.
+func (h *batchExpiryHandler) HandleStampExpiry(ctx context.Context, id []byte) error {
+	// the issuer of the batch is removed by the next handler
+	owned := h.s.owned(id)
+	if err := h.next.HandleStampExpiry(ctx, id); err != nil {
+		return err
+	}
+
+	if owned {
+		h.s.mu.Lock()
+		delete(h.s.batches, string(id))
+		h.s.mu.Unlock()
+		h.s.publish(h.s.newEvent(EventExpired, id))
+	}
+	return nil
+}
@@ -0,0 +1,387 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package notifier publishes the events of the owned postage batches, the
+// crossings of the TTL and the bucket saturation thresholds, the expiries and
+// the confirmed top-ups and dilutions, to the configured webhooks and to the
+// local subscribers, so that the operators are alerted before data is lost.
+package notifier
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/bigint"
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/postage"
+)
+
+// loggerName is the tree path name of the logger for this package.
+const loggerName = "postagenotifier"
+
+const (
+	defaultInterval            = 10 * time.Minute
+	defaultSaturationThreshold = 90
+	defaultWebhookTimeout      = 10 * time.Second
+
+	// webhookAttempts is the number of the attempts to deliver an event to a webhook.
+	webhookAttempts = 3
+	// webhookQueueSize is the number of the events queued for the webhooks,
+	// the events published to a full queue are dropped.
+	webhookQueueSize = 256
+	// subscriberQueueSize is the number of the events queued for a subscriber,
+	// the events published to a full queue are dropped.
+	subscriberQueueSize = 64
+)
+
+// DefaultTTLThresholds are the default TTL thresholds of the notifications.
+var DefaultTTLThresholds = []time.Duration{7 * 24 * time.Hour, 24 * time.Hour}
+
+// EventType is the type of a batch event.
+type EventType string
+
+const (
+	EventTTLThreshold     EventType = "ttlThreshold"
+	EventBucketSaturation EventType = "bucketSaturation"
+	EventExpired          EventType = "expired"
+	EventTopUp            EventType = "topUp"
+	EventDilution         EventType = "dilution"
+)
+
+// Event is a notification about an owned batch.
+// It is delivered to the webhooks as JSON.
+type Event struct {
+	Type      EventType `json:"type"`
+	BatchID   string    `json:"batchID"`
+	Timestamp time.Time `json:"timestamp"`
+	// TTL is the remaining time to live of the batch in seconds.
+	TTL int64 `json:"ttl,omitempty"`
+	// Threshold is the crossed TTL threshold in seconds
+	// or the crossed bucket saturation percentage.
+	Threshold int64 `json:"threshold,omitempty"`
+	// Saturation is the fill percentage of the fullest bucket of the batch.
+	Saturation uint64 `json:"saturation,omitempty"`
+	// Depth is the new depth of the diluted batch.
+	Depth uint8 `json:"depth,omitempty"`
+	// Amount is the per chunk amount of the top-up.
+	Amount *bigint.BigInt `json:"amount,omitempty"`
+}
+
+// Options are the options of the notifier.
+type Options struct {
+	// WebhookURLs are the URLs the events are posted to.
+	WebhookURLs []string
+	// TTLThresholds are the TTLs the crossing of which is notified.
+	TTLThresholds []time.Duration
+	// SaturationThreshold is the bucket fill percentage
+	// the crossing of which is notified.
+	SaturationThreshold uint8
+	// Interval is the interval of the thresholds evaluation.
+	Interval time.Duration
+	// BlockTime is the time of a block of the chain.
+	BlockTime time.Duration
+	// Synced reports whether the postage chain state is synced.
+	Synced func() (bool, error)
+	// Client is the HTTP client the webhooks are called with.
+	Client *http.Client
+}
+
+// batchState are the thresholds already notified for a batch,
+// each crossing is notified once until the batch recovers.
+type batchState struct {
+	ttlThreshold time.Duration // zero if no threshold is crossed
+	saturated    bool
+}
+
+// Service evaluates the thresholds of the owned batches in the
+// background and publishes the events of the owned batches.
+type Service struct {
+	logger     log.Logger
+	issuers    postage.Service
+	batchStore postage.Storer
+	opts       Options
+	now        func() time.Time
+
+	mu      sync.Mutex
+	batches map[string]*batchState
+	subs    map[chan Event]struct{}
+
+	webhooks chan Event
+	quit     chan struct{}
+	wg       sync.WaitGroup
+}
+
+// New creates the notifier of the batches of the issuers.
+func New(issuers postage.Service, batchStore postage.Storer, logger log.Logger, o Options) *Service {
+	if o.Interval <= 0 {
+		o.Interval = defaultInterval
+	}
+	if o.TTLThresholds == nil {
+		o.TTLThresholds = DefaultTTLThresholds
+	}
+	// the thresholds are evaluated from the highest to the lowest
+	o.TTLThresholds = slices.Clone(o.TTLThresholds)
+	slices.SortFunc(o.TTLThresholds, func(a, b time.Duration) int { return cmp.Compare(b, a) })
+	if o.SaturationThreshold == 0 {
+		o.SaturationThreshold = defaultSaturationThreshold
+	}
+	if o.Synced == nil {
+		o.Synced = func() (bool, error) { return true, nil }
+	}
+	if o.Client == nil {
+		o.Client = &http.Client{Timeout: defaultWebhookTimeout}
+	}
+
+	return &Service{
+		logger:     logger.WithName(loggerName).Register(),
+		issuers:    issuers,
+		batchStore: batchStore,
+		opts:       o,
+		now:        time.Now,
+		batches:    make(map[string]*batchState),
+		subs:       make(map[chan Event]struct{}),
+		webhooks:   make(chan Event, webhookQueueSize),
+		quit:       make(chan struct{}),
+	}
+}
+
+// Start starts the background evaluation of the thresholds
+// and the delivery of the events to the webhooks.
+func (s *Service) Start() {
+	s.wg.Add(1)
+	go s.run()
+
+	if len(s.opts.WebhookURLs) > 0 {
+		s.wg.Add(1)
+		go s.deliver()
+	}
+}
+
+// Subscribe returns the channel the events are sent to
+// and the function which cancels the subscription.
+func (s *Service) Subscribe() (<-chan Event, func()) {
+	c := make(chan Event, subscriberQueueSize)
+
+	s.mu.Lock()
+	s.subs[c] = struct{}{}
+	s.mu.Unlock()
+
+	var once sync.Once
+	return c, func() {
+		once.Do(func() {
+			s.mu.Lock()
+			delete(s.subs, c)
+			s.mu.Unlock()
+		})
+	}
+}
+
+// publish sends the event to the subscribers and the webhooks.
+func (s *Service) publish(e Event) {
+	s.logger.Debug("batch event", "type", e.Type, "batch_id", e.BatchID)
+
+	s.mu.Lock()
+	for c := range s.subs {
+		select {
+		case c <- e:
+		default:
+			s.logger.Debug("subscriber queue full, event dropped", "type", e.Type, "batch_id", e.BatchID)
+		}
+	}
+	s.mu.Unlock()
+
+	if len(s.opts.WebhookURLs) == 0 {
+		return
+	}
+	select {
+	case s.webhooks <- e:
+	default:
+		s.logger.Warning("webhook queue full, event dropped", "type", e.Type, "batch_id", e.BatchID)
+	}
+}
+
+func (s *Service) newEvent(t EventType, batchID []byte) Event {
+	return Event{Type: t, BatchID: hex.EncodeToString(batchID), Timestamp: s.now()}
+}
+
+// owned reports whether the batch is owned by the node.
+func (s *Service) owned(batchID []byte) bool {
+	for _, issuer := range s.issuers.StampIssuers() {
+		if bytes.Equal(issuer.ID(), batchID) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Service) run() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.quit:
+			return
+		case <-ticker.C:
+		}
+
+		synced, err := s.opts.Synced()
+		if err != nil || !synced {
+			s.logger.Debug("postage chain state not synced, thresholds evaluation skipped", "error", err)
+			continue
+		}
+		s.evaluate()
+	}
+}
+
+// evaluate evaluates the thresholds of all the owned batches.
+func (s *Service) evaluate() {
+	cs := s.batchStore.GetChainState()
+
+	owned := make(map[string]struct{})
+	for _, issuer := range s.issuers.StampIssuers() {
+		owned[string(issuer.ID())] = struct{}{}
+
+		batch, err := s.batchStore.Get(issuer.ID())
+		if err != nil {
+			s.logger.Debug("get batch failed", "batch_id", hex.EncodeToString(issuer.ID()), "error", err)
+			continue
+		}
+		s.evaluateBatch(cs, issuer, batch)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id := range s.batches {
+		if _, ok := owned[id]; !ok {
+			delete(s.batches, id)
+		}
+	}
+}
+
+// evaluateBatch publishes the crossings of the thresholds by the batch.
+func (s *Service) evaluateBatch(cs *postage.ChainState, issuer *postage.StampIssuer, batch *postage.Batch) {
+	var events []Event
+
+	s.mu.Lock()
+	b, ok := s.batches[string(batch.ID)]
+	if !ok {
+		b = new(batchState)
+		s.batches[string(batch.ID)] = b
+	}
+
+	if ttl, ok := cs.TTL(batch, s.opts.BlockTime); ok {
+		var crossed time.Duration
+		for _, threshold := range s.opts.TTLThresholds {
+			if ttl <= threshold {
+				crossed = threshold
+			}
+		}
+		if crossed > 0 && (b.ttlThreshold == 0 || crossed < b.ttlThreshold) {
+			e := s.newEvent(EventTTLThreshold, batch.ID)
+			e.TTL = int64(ttl / time.Second)
+			e.Threshold = int64(crossed / time.Second)
+			events = append(events, e)
+		}
+		b.ttlThreshold = crossed
+	}
+
+	saturation := uint64(issuer.Utilization()) * 100 / uint64(issuer.BucketUpperBound())
+	saturated := saturation >= uint64(s.opts.SaturationThreshold)
+	if saturated && !b.saturated {
+		e := s.newEvent(EventBucketSaturation, batch.ID)
+		e.Saturation = saturation
+		e.Threshold = int64(s.opts.SaturationThreshold)
+		events = append(events, e)
+	}
+	b.saturated = saturated
+	s.mu.Unlock()
+
+	for _, e := range events {
+		s.publish(e)
+	}
+}
+
+// deliver posts the events to the webhooks.
+func (s *Service) deliver() {
+	defer s.wg.Done()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-s.quit
+		cancel()
+	}()
+
+	for {
+		select {
+		case <-s.quit:
+			return
+		case e := <-s.webhooks:
+			body, err := json.Marshal(e)
+			if err != nil {
+				s.logger.Error(err, "marshal event failed")
+				continue
+			}
+			for _, url := range s.opts.WebhookURLs {
+				if err := s.post(ctx, url, body); err != nil {
+					s.logger.Warning("webhook delivery failed", "url", url, "type", e.Type, "batch_id", e.BatchID, "error", err)
+				}
+			}
+		}
+	}
+}
+
+// post posts the event to the webhook, retrying the failed attempts.
+func (s *Service) post(ctx context.Context, url string, body []byte) (err error) {
+	for attempt := range webhookAttempts {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(time.Duration(attempt) * time.Second):
+			}
+		}
+
+		req, rerr := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+		if rerr != nil {
+			return rerr
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, rerr := s.opts.Client.Do(req)
+		if rerr != nil {
+			err = rerr
+			continue
+		}
+		_ = resp.Body.Close()
+		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			return nil
+		}
+		err = fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return err
+}
+
+// Close stops the background evaluation of the thresholds
+// and the delivery of the events to the webhooks.
+func (s *Service) Close() error {
+	select {
+	case <-s.quit:
+	default:
+		close(s.quit)
+	}
+	s.wg.Wait()
+	return nil
+}
@@ -0,0 +1,248 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package notifier_test
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/crypto"
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/postage"
+	batchstoremock "github.com/ethersphere/bee/v2/pkg/postage/batchstore/mock"
+	postagemock "github.com/ethersphere/bee/v2/pkg/postage/mock"
+	"github.com/ethersphere/bee/v2/pkg/postage/notifier"
+	"github.com/ethersphere/bee/v2/pkg/storage/inmemstore"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+const (
+	blockTime = 5 * time.Second
+	depth     = 17
+)
+
+var (
+	price      = big.NewInt(10)
+	batchValue = big.NewInt(1 << 40)
+)
+
+// ownedBatch returns a batch with two slots in each bucket together with the
+// stamp issuer of the node, so that the notifier evaluates it. The TTL of the
+// batch is then set through the chain state with setTTL.
+func ownedBatch(t *testing.T) (*postage.Batch, *postage.StampIssuer) {
+	t.Helper()
+
+	batch := &postage.Batch{ID: swarm.RandAddress(t).Bytes(), Value: batchValue, Depth: depth, BucketDepth: depth - 1}
+	return batch, postage.NewStampIssuer("", "", batch.ID, batch.Value, batch.Depth, batch.BucketDepth, 0, true)
+}
+
+// setTTL sets the total amount of the chain state so that the batch has the ttl left.
+func setTTL(t *testing.T, bs *batchstoremock.BatchStore, batch *postage.Batch, ttl time.Duration) {
+	t.Helper()
+
+	left := new(big.Int).Mul(price, big.NewInt(int64(ttl/blockTime)))
+	err := bs.PutChainState(&postage.ChainState{TotalAmount: new(big.Int).Sub(batch.Value, left), CurrentPrice: price})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func expectEvent(t *testing.T, c <-chan notifier.Event, want notifier.EventType) notifier.Event {
+	t.Helper()
+
+	select {
+	case e := <-c:
+		if e.Type != want {
+			t.Fatalf("got event %q, want %q", e.Type, want)
+		}
+		return e
+	case <-time.After(5 * time.Second):
+		t.Fatalf("event %q not published", want)
+	}
+	return notifier.Event{}
+}
+
+func expectNoEvent(t *testing.T, c <-chan notifier.Event) {
+	t.Helper()
+
+	select {
+	case e := <-c:
+		t.Fatalf("unexpected event %q", e.Type)
+	default:
+	}
+}
+
+func TestThresholds(t *testing.T) {
+	t.Parallel()
+
+	batch, issuer := ownedBatch(t)
+	bs := batchstoremock.New(batchstoremock.WithBatch(batch))
+	setTTL(t, bs, batch, 10*24*time.Hour)
+
+	s := notifier.New(postagemock.New(postagemock.WithIssuer(issuer)), bs, log.Noop, notifier.Options{BlockTime: blockTime})
+	t.Cleanup(func() { _ = s.Close() })
+	events, unsubscribe := s.Subscribe()
+	defer unsubscribe()
+
+	s.Evaluate()
+	expectNoEvent(t, events)
+
+	setTTL(t, bs, batch, 5*24*time.Hour)
+	s.Evaluate()
+	e := expectEvent(t, events, notifier.EventTTLThreshold)
+	if e.BatchID != hex.EncodeToString(batch.ID) {
+		t.Fatalf("got batch %s, want %x", e.BatchID, batch.ID)
+	}
+	if e.Threshold != int64(7*24*time.Hour/time.Second) || e.TTL != int64(5*24*time.Hour/time.Second) {
+		t.Fatalf("got threshold %d and ttl %d", e.Threshold, e.TTL)
+	}
+
+	// the crossing is notified once
+	s.Evaluate()
+	expectNoEvent(t, events)
+
+	setTTL(t, bs, batch, 12*time.Hour)
+	s.Evaluate()
+	e = expectEvent(t, events, notifier.EventTTLThreshold)
+	if e.Threshold != int64(24*time.Hour/time.Second) {
+		t.Fatalf("got threshold %d, want %d", e.Threshold, int64(24*time.Hour/time.Second))
+	}
+
+	// the batch recovers with a top-up and crosses the threshold again
+	setTTL(t, bs, batch, 10*24*time.Hour)
+	s.Evaluate()
+	expectNoEvent(t, events)
+	setTTL(t, bs, batch, 5*24*time.Hour)
+	s.Evaluate()
+	expectEvent(t, events, notifier.EventTTLThreshold)
+
+	// the stamps fill a bucket of the batch
+	pk, err := crypto.GenerateSecp256k1Key()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stamper := postage.NewStamper(inmemstore.New(), issuer, crypto.NewDefaultSigner(pk))
+	addr := swarm.RandAddress(t)
+	for i := range 2 {
+		addr := addr.Clone()
+		addr.Bytes()[swarm.HashSize-1] = byte(i)
+		if _, err := stamper.Stamp(addr, addr); err != nil {
+			t.Fatal(err)
+		}
+	}
+	s.Evaluate()
+	e = expectEvent(t, events, notifier.EventBucketSaturation)
+	if e.Saturation != 100 || e.Threshold != 90 {
+		t.Fatalf("got saturation %d and threshold %d, want 100 and 90", e.Saturation, e.Threshold)
+	}
+	s.Evaluate()
+	expectNoEvent(t, events)
+}
+
+func TestListener(t *testing.T) {
+	t.Parallel()
+
+	batch, issuer := ownedBatch(t)
+	post := postagemock.New(postagemock.WithIssuer(issuer))
+	var synced atomic.Bool
+	s := notifier.New(post, batchstoremock.New(batchstoremock.WithBatch(batch)), log.Noop, notifier.Options{
+		BlockTime: blockTime,
+		Synced:    func() (bool, error) { return synced.Load(), nil },
+	})
+	t.Cleanup(func() { _ = s.Close() })
+	events, unsubscribe := s.Subscribe()
+	defer unsubscribe()
+
+	listener := s.BatchEventListener(post)
+	expiry := s.BatchExpiryHandler(post)
+
+	// the events replayed during the sync are not published
+	listener.HandleTopUp(batch.ID, big.NewInt(100))
+	expectNoEvent(t, events)
+
+	synced.Store(true)
+	listener.HandleTopUp(batch.ID, big.NewInt(100))
+	e := expectEvent(t, events, notifier.EventTopUp)
+	if e.Amount == nil || e.Amount.Cmp(big.NewInt(100)) != 0 {
+		t.Fatalf("got amount %v, want 100", e.Amount)
+	}
+
+	listener.HandleDepthIncrease(batch.ID, depth+1)
+	e = expectEvent(t, events, notifier.EventDilution)
+	if e.Depth != depth+1 {
+		t.Fatalf("got depth %d, want %d", e.Depth, depth+1)
+	}
+
+	if err := expiry.HandleStampExpiry(context.Background(), swarm.RandAddress(t).Bytes()); err != nil {
+		t.Fatal(err)
+	}
+	expectNoEvent(t, events)
+
+	if err := expiry.HandleStampExpiry(context.Background(), batch.ID); err != nil {
+		t.Fatal(err)
+	}
+	expectEvent(t, events, notifier.EventExpired)
+	if len(post.StampIssuers()) != 0 {
+		t.Fatal("issuer of the expired batch not removed")
+	}
+
+	unsubscribe()
+	listener.HandleTopUp(batch.ID, big.NewInt(100))
+	expectNoEvent(t, events)
+}
+
+func TestWebhook(t *testing.T) {
+	t.Parallel()
+
+	var (
+		calls    atomic.Int32
+		received = make(chan notifier.Event, 1)
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the first delivery fails and is retried
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		var e notifier.Event
+		if err := json.NewDecoder(r.Body).Decode(&e); err != nil {
+			t.Error(err)
+		}
+		received <- e
+	}))
+	t.Cleanup(srv.Close)
+
+	batch, issuer := ownedBatch(t)
+	post := postagemock.New(postagemock.WithIssuer(issuer))
+	s := notifier.New(post, batchstoremock.New(batchstoremock.WithBatch(batch)), log.Noop, notifier.Options{
+		WebhookURLs: []string{srv.URL},
+		BlockTime:   blockTime,
+	})
+	s.Start()
+	t.Cleanup(func() { _ = s.Close() })
+
+	if err := s.BatchExpiryHandler(post).HandleStampExpiry(context.Background(), batch.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case e := <-received:
+		if e.Type != notifier.EventExpired || e.BatchID != hex.EncodeToString(batch.ID) {
+			t.Fatalf("got event %q of batch %s", e.Type, e.BatchID)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("event not delivered to the webhook")
+	}
+	if n := calls.Load(); n != 2 {
+		t.Fatalf("got %d webhook calls, want 2", n)
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package planner estimates the depth and the amount of the postage batch
+// needed to store a set of files for a given time.
+package planner
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"math/bits"
+	"math/rand"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/file/redundancy"
+	"github.com/ethersphere/bee/v2/pkg/postage"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+const (
+	// MinDepth is the smallest depth of a batch.
+	MinDepth = postage.BucketDepth + 1
+	// MaxDepth is the largest depth recommended by the planner.
+	MaxDepth = 41
+
+	// poissonLimit is the mean above which the bucket
+	// fill is sampled from the normal approximation.
+	poissonLimit = 30
+)
+
+var (
+	// ErrTooLarge is returned when the files do not fit into a batch of MaxDepth.
+	ErrTooLarge = errors.New("planner: files too large")
+	// ErrNoPrice is returned when the current price of the storage is not known.
+	ErrNoPrice = errors.New("planner: price not available")
+)
+
+// File describes a file to be uploaded.
+type File struct {
+	Size       int64
+	Redundancy redundancy.Level
+	Encrypt    bool
+}
+
+// Plan is the estimated batch for the files.
+type Plan struct {
+	Chunks        int64    // Number of the stamped chunks.
+	MaxBucketFill uint64   // Highest simulated fill of a collision bucket.
+	Depth         uint8    // Recommended depth of the batch.
+	Utilization   float64  // Share of the fullest bucket used by the files.
+	Amount        *big.Int // Amount per chunk in PLUR covering the TTL.
+	Cost          *big.Int // Total cost of the batch in PLUR.
+}
+
+// Options are the parameters of the estimation.
+type Options struct {
+	TTL       time.Duration // Time the files are stored for.
+	Price     *big.Int      // Current price per chunk per block in PLUR.
+	BlockTime time.Duration // Time between the blocks.
+	Rounds    int           // Rounds of the bucket saturation simulation.
+	Rand      *rand.Rand    // Source of the simulation, seeded from the time if nil.
+}
+
+// ChunkCount returns the number of the chunks of the file, including the
+// intermediate chunks, the erasure coding parities and the dispersed
+// replicas of the root chunk.
+func ChunkCount(f File) int64 {
+	maxShards, parities := f.Redundancy.GetMaxShards(), f.Redundancy.GetParities
+	if f.Encrypt {
+		maxShards, parities = f.Redundancy.GetMaxEncShards(), f.Redundancy.GetEncParities
+	}
+	shards := int64(maxShards)
+
+	n := f.Size / swarm.ChunkSize
+	if f.Size%swarm.ChunkSize > 0 {
+		n++
+	}
+	n = max(n, 1)
+	total := n
+	for n > 1 {
+		total += n / shards * int64(parities(maxShards))
+		if rem := n % shards; rem > 0 {
+			total += int64(parities(int(rem)))
+		}
+		n = (n + shards - 1) / shards
+		total += n
+	}
+	return total + int64(f.Redundancy.GetReplicaCount())
+}
+
+// MaxBucketFill simulates the distribution of the chunks with random
+// addresses into the collision buckets of a batch and returns the highest
+// bucket fill seen in the given number of rounds.
+func MaxBucketFill(chunks int64, rounds int, r *rand.Rand) uint64 {
+	const buckets = 1 << postage.BucketDepth
+
+	mean := float64(chunks) / buckets
+	var fill uint64
+	for range max(rounds, 1) {
+		for range buckets {
+			fill = max(fill, samplePoisson(mean, r))
+		}
+	}
+	return fill
+}
+
+// samplePoisson samples the number of the chunks in a bucket, which is
+// Poisson distributed with the given mean as the buckets are many.
+func samplePoisson(mean float64, r *rand.Rand) uint64 {
+	if mean > poissonLimit {
+		return uint64(max(math.Round(mean+math.Sqrt(mean)*r.NormFloat64()), 0))
+	}
+
+	// Knuth's multiplication method
+	var (
+		limit = math.Exp(-mean)
+		p     = r.Float64()
+		k     uint64
+	)
+	for p > limit {
+		p *= r.Float64()
+		k++
+	}
+	return k
+}
+
+// Estimate returns the plan of the batch storing the files for the TTL.
+// The depth is the smallest one whose buckets hold the simulated fill.
+func Estimate(files []File, o Options) (*Plan, error) {
+	if o.Price == nil || o.Price.Sign() <= 0 || o.BlockTime < time.Second {
+		return nil, ErrNoPrice
+	}
+	r := o.Rand
+	if r == nil {
+		r = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+
+	// a batch of MaxDepth stamps at most 2^MaxDepth chunks, the chunks
+	// of a file are far fewer than the int64 range, so the sum of them
+	// is checked before it can overflow
+	p := new(Plan)
+	for _, f := range files {
+		p.Chunks += ChunkCount(f)
+		if p.Chunks > 1<<MaxDepth {
+			return nil, ErrTooLarge
+		}
+	}
+	p.MaxBucketFill = MaxBucketFill(p.Chunks, o.Rounds, r)
+
+	// the bucket of a batch of depth d holds 2^(d-BucketDepth) chunks
+	depth := max(postage.BucketDepth+bits.Len64(max(p.MaxBucketFill, 1)-1), MinDepth)
+	if depth > MaxDepth {
+		return nil, ErrTooLarge
+	}
+	p.Depth = uint8(depth)
+	p.Utilization = float64(p.MaxBucketFill) / float64(uint64(1)<<(depth-postage.BucketDepth))
+
+	// the batch expires when the cumulative payout reaches its amount
+	blockTime := big.NewInt(int64(o.BlockTime / time.Second))
+	p.Amount = new(big.Int).Mul(big.NewInt(int64(o.TTL/time.Second)), o.Price)
+	p.Amount.Add(p.Amount, blockTime).Sub(p.Amount, big.NewInt(1)).Div(p.Amount, blockTime)
+	p.Cost = new(big.Int).Lsh(p.Amount, uint(depth))
+
+	return p, nil
+}
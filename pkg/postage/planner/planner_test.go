@@ -0,0 +1,130 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package planner_test
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/file/redundancy"
+	"github.com/ethersphere/bee/v2/pkg/postage/planner"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+func TestChunkCount(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name string
+		file planner.File
+		want int64
+	}{
+		{name: "empty", file: planner.File{}, want: 1},
+		{name: "single chunk", file: planner.File{Size: swarm.ChunkSize}, want: 1},
+		{name: "two chunks", file: planner.File{Size: swarm.ChunkSize + 1}, want: 3},
+		{name: "full intermediate", file: planner.File{Size: swarm.Branches * swarm.ChunkSize}, want: swarm.Branches + 1},
+		{name: "two levels", file: planner.File{Size: (swarm.Branches + 1) * swarm.ChunkSize}, want: swarm.Branches + 1 + 2 + 1},
+		{name: "encrypted", file: planner.File{Size: (swarm.EncryptedBranches + 1) * swarm.ChunkSize, Encrypt: true}, want: swarm.EncryptedBranches + 1 + 2 + 1},
+		// two data chunks, three parities, the root chunk and two replicas of it
+		{name: "medium redundancy", file: planner.File{Size: 2 * swarm.ChunkSize, Redundancy: redundancy.MEDIUM}, want: 2 + 3 + 1 + 2},
+		{name: "largest size", file: planner.File{Size: math.MaxInt64}, want: 1<<51 + 1<<44 + 1<<37 + 1<<30 + 1<<23 + 1<<16 + 1<<9 + 1<<2 + 1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if have := planner.ChunkCount(tc.file); have != tc.want {
+				t.Fatalf("have %d chunks, want %d", have, tc.want)
+			}
+		})
+	}
+}
+
+func TestMaxBucketFill(t *testing.T) {
+	t.Parallel()
+
+	r := rand.New(rand.NewSource(1))
+
+	if fill := planner.MaxBucketFill(0, 1, r); fill != 0 {
+		t.Fatalf("have fill %d for no chunks, want 0", fill)
+	}
+
+	// the fullest bucket is above the mean, but not by far
+	const mean = 1000
+	fill := planner.MaxBucketFill(mean<<16, 2, r)
+	if fill <= mean || fill > mean*5/4 {
+		t.Fatalf("fill %d out of range for mean %d", fill, mean)
+	}
+}
+
+func TestEstimate(t *testing.T) {
+	t.Parallel()
+
+	opts := planner.Options{
+		TTL:       11 * time.Second,
+		Price:     big.NewInt(3),
+		BlockTime: 5 * time.Second,
+		Rounds:    1,
+		Rand:      rand.New(rand.NewSource(1)),
+	}
+
+	t.Run("small", func(t *testing.T) {
+		p, err := planner.Estimate([]planner.File{{Size: 1 << 20}}, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if p.Chunks != 259 {
+			t.Fatalf("have %d chunks, want 259", p.Chunks)
+		}
+		if p.Depth != planner.MinDepth {
+			t.Fatalf("have depth %d, want %d", p.Depth, planner.MinDepth)
+		}
+		// 11s of 3 PLUR blocks every 5s rounded up
+		if p.Amount.Cmp(big.NewInt(7)) != 0 {
+			t.Fatalf("have amount %d, want 7", p.Amount)
+		}
+		if want := big.NewInt(7 << planner.MinDepth); p.Cost.Cmp(want) != 0 {
+			t.Fatalf("have cost %d, want %d", p.Cost, want)
+		}
+	})
+
+	t.Run("large", func(t *testing.T) {
+		p, err := planner.Estimate([]planner.File{{Size: 1 << 36}, {Size: 1 << 36, Redundancy: redundancy.STRONG}}, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if p.MaxBucketFill > 1<<(p.Depth-16) || p.MaxBucketFill <= 1<<(p.Depth-17) {
+			t.Fatalf("depth %d does not fit the bucket fill %d", p.Depth, p.MaxBucketFill)
+		}
+		if p.Utilization <= 0.5 || p.Utilization > 1 {
+			t.Fatalf("utilization %f out of range", p.Utilization)
+		}
+	})
+
+	t.Run("too large", func(t *testing.T) {
+		if _, err := planner.Estimate([]planner.File{{Size: 1 << 60}}, opts); !errors.Is(err, planner.ErrTooLarge) {
+			t.Fatalf("want %v, got %v", planner.ErrTooLarge, err)
+		}
+		// the sum of the chunks of the files does not overflow
+		files := make([]planner.File, 10000)
+		for i := range files {
+			files[i] = planner.File{Size: math.MaxInt64, Redundancy: redundancy.PARANOID}
+		}
+		if _, err := planner.Estimate(files, opts); !errors.Is(err, planner.ErrTooLarge) {
+			t.Fatalf("want %v, got %v", planner.ErrTooLarge, err)
+		}
+	})
+
+	t.Run("no price", func(t *testing.T) {
+		o := opts
+		o.Price = big.NewInt(0)
+		if _, err := planner.Estimate(nil, o); !errors.Is(err, planner.ErrNoPrice) {
+			t.Fatalf("want %v, got %v", planner.ErrNoPrice, err)
+		}
+	})
+}
@@ -0,0 +1,18 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package policy
+
+import (
+	"context"
+	"time"
+)
+
+func (s *Service) Evaluate(ctx context.Context) {
+	s.evaluate(ctx)
+}
+
+func (s *Service) SetNow(f func() time.Time) {
+	s.now = f
+}
@@ -0,0 +1,492 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package policy evaluates the rules attached to the owned postage batches
+// against the chain state, and tops up or dilutes the batches accordingly so
+// that they do not expire or fill up unnoticed. The top-ups are limited by
+// the spending cap of the node.
+package policy
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/postage"
+	"github.com/ethersphere/bee/v2/pkg/storage"
+)
+
+// loggerName is the tree path name of the logger for this package.
+const loggerName = "postagepolicy"
+
+const (
+	rulesKeyPrefix = "postage_policy_rules_"
+	spendingKey    = "postage_policy_spending"
+
+	defaultInterval    = 10 * time.Minute
+	defaultSpendPeriod = 24 * time.Hour
+	// pendingTimeout is the time after which an action on the batch
+	// is considered failed when its effect is not seen in the chain state.
+	pendingTimeout = time.Hour
+)
+
+var (
+	// ErrNotFound is returned when the batch is not owned or has no rules attached.
+	ErrNotFound = errors.New("not found")
+	// ErrInvalidRules is returned when the rules are not valid.
+	ErrInvalidRules = errors.New("invalid rules")
+	// ErrSpendLimitExceeded is returned when a top-up would exceed the spending cap.
+	ErrSpendLimitExceeded = errors.New("spend limit exceeded")
+)
+
+// Contract tops up and dilutes the postage batches.
+type Contract interface {
+	TopUpBatch(ctx context.Context, batchID []byte, topupBalance *big.Int) (common.Hash, error)
+	DiluteBatch(ctx context.Context, batchID []byte, newDepth uint8) (common.Hash, error)
+}
+
+// Rules are the rules attached to an owned batch.
+type Rules struct {
+	// MinTTL is the TTL below which the batch is topped up, disabled if zero.
+	MinTTL time.Duration `json:"minTTL"`
+	// TopUpTTL is the TTL the batch is topped up to, MinTTL if lower.
+	TopUpTTL time.Duration `json:"topUpTTL"`
+	// MaxUtilization is the utilization percentage above which
+	// the batch is diluted by one depth, disabled if zero.
+	MaxUtilization uint8 `json:"maxUtilization"`
+	// MaxDepth is the depth the batch is not diluted above, unlimited if zero.
+	MaxDepth uint8 `json:"maxDepth"`
+}
+
+func (r Rules) validate() error {
+	if r.MinTTL < 0 || r.TopUpTTL < 0 {
+		return fmt.Errorf("%w: negative ttl", ErrInvalidRules)
+	}
+	if r.MaxUtilization > 100 {
+		return fmt.Errorf("%w: utilization above 100%%", ErrInvalidRules)
+	}
+	if r.MinTTL == 0 && r.MaxUtilization == 0 {
+		return fmt.Errorf("%w: no rule enabled", ErrInvalidRules)
+	}
+	return nil
+}
+
+// ActionType is the type of the action taken on a batch.
+type ActionType string
+
+const (
+	ActionTopUp  ActionType = "topup"
+	ActionDilute ActionType = "dilute"
+)
+
+// Action is the action taken on a batch by the policy.
+type Action struct {
+	Type      ActionType
+	Timestamp time.Time
+	Amount    *big.Int // per chunk amount of the top-up
+	Depth     uint8    // new depth of the dilution
+	TxHash    common.Hash
+}
+
+// Status is the state of the policy of a batch.
+type Status struct {
+	Rules      Rules
+	LastAction *Action
+	LastError  string
+}
+
+// Options are the options of the policy service.
+type Options struct {
+	// Interval is the interval of the rules evaluation.
+	Interval time.Duration
+	// BlockTime is the time of a block of the chain.
+	BlockTime time.Duration
+	// SpendLimit is the amount the top-ups may spend in a SpendPeriod,
+	// the top-ups are disabled if it is not set.
+	SpendLimit *big.Int
+	// SpendPeriod is the period of the spending cap.
+	SpendPeriod time.Duration
+	// Synced reports whether the postage chain state is synced.
+	Synced func() (bool, error)
+}
+
+// spending is the amount spent in the current spending period.
+type spending struct {
+	PeriodStart time.Time `json:"periodStart"`
+	Spent       *big.Int  `json:"spent"`
+}
+
+// batchState is the policy state of a batch.
+type batchState struct {
+	status Status
+
+	// the value and depth of the batch when the last action was
+	// taken, the batch is skipped until they change in the chain state
+	pendingValue *big.Int
+	pendingDepth uint8
+	pendingSince time.Time
+}
+
+// Service evaluates the rules of the batches in the background.
+type Service struct {
+	logger     log.Logger
+	stateStore storage.StateStorer
+	issuers    postage.Service
+	batchStore postage.Storer
+	contract   Contract
+	opts       Options
+	now        func() time.Time
+
+	mu       sync.Mutex
+	batches  map[string]*batchState
+	spending spending
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// New creates the policy service with the rules loaded from the state store.
+func New(
+	stateStore storage.StateStorer,
+	issuers postage.Service,
+	batchStore postage.Storer,
+	contract Contract,
+	logger log.Logger,
+	o Options,
+) (*Service, error) {
+	if o.Interval <= 0 {
+		o.Interval = defaultInterval
+	}
+	if o.SpendPeriod <= 0 {
+		o.SpendPeriod = defaultSpendPeriod
+	}
+	if o.SpendLimit == nil {
+		o.SpendLimit = new(big.Int)
+	}
+	if o.Synced == nil {
+		o.Synced = func() (bool, error) { return true, nil }
+	}
+
+	s := &Service{
+		logger:     logger.WithName(loggerName).Register(),
+		stateStore: stateStore,
+		issuers:    issuers,
+		batchStore: batchStore,
+		contract:   contract,
+		opts:       o,
+		now:        time.Now,
+		batches:    make(map[string]*batchState),
+		spending:   spending{Spent: new(big.Int)},
+		quit:       make(chan struct{}),
+	}
+
+	err := stateStore.Iterate(rulesKeyPrefix, func(key, value []byte) (bool, error) {
+		id, err := hex.DecodeString(strings.TrimPrefix(string(key), rulesKeyPrefix))
+		if err != nil {
+			return true, fmt.Errorf("parse batch id: %w", err)
+		}
+		var r Rules
+		if err := json.Unmarshal(value, &r); err != nil {
+			return true, fmt.Errorf("unmarshal rules: %w", err)
+		}
+		s.batches[string(id)] = &batchState{status: Status{Rules: r}}
+		return false, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("load rules: %w", err)
+	}
+
+	if err := stateStore.Get(spendingKey, &s.spending); err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return nil, fmt.Errorf("load spending: %w", err)
+	}
+	if s.spending.Spent == nil {
+		s.spending.Spent = new(big.Int)
+	}
+
+	return s, nil
+}
+
+// Start starts the background evaluation of the rules.
+func (s *Service) Start() {
+	s.wg.Add(1)
+	go s.run()
+}
+
+func rulesKey(batchID []byte) string {
+	return rulesKeyPrefix + hex.EncodeToString(batchID)
+}
+
+// owned returns the stamp issuer of the batch owned by the node.
+func (s *Service) owned(batchID []byte) (*postage.StampIssuer, bool) {
+	for _, issuer := range s.issuers.StampIssuers() {
+		if bytes.Equal(issuer.ID(), batchID) {
+			return issuer, true
+		}
+	}
+	return nil, false
+}
+
+// SetRules attaches the rules to the owned batch, replacing the existing ones.
+func (s *Service) SetRules(batchID []byte, r Rules) error {
+	if err := r.validate(); err != nil {
+		return err
+	}
+	if _, ok := s.owned(batchID); !ok {
+		return ErrNotFound
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.stateStore.Put(rulesKey(batchID), r); err != nil {
+		return err
+	}
+	if b, ok := s.batches[string(batchID)]; ok {
+		b.status.Rules = r
+	} else {
+		s.batches[string(batchID)] = &batchState{status: Status{Rules: r}}
+	}
+	return nil
+}
+
+// Status returns the state of the policy of the batch.
+func (s *Service) Status(batchID []byte) (Status, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, ok := s.batches[string(batchID)]
+	if !ok {
+		return Status{}, ErrNotFound
+	}
+	return b.status, nil
+}
+
+// RemoveRules removes the rules of the batch.
+func (s *Service) RemoveRules(batchID []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.batches[string(batchID)]; !ok {
+		return ErrNotFound
+	}
+	if err := s.stateStore.Delete(rulesKey(batchID)); err != nil {
+		return err
+	}
+	delete(s.batches, string(batchID))
+	return nil
+}
+
+func (s *Service) run() {
+	defer s.wg.Done()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-s.quit
+		cancel()
+	}()
+
+	ticker := time.NewTicker(s.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.quit:
+			return
+		case <-ticker.C:
+		}
+
+		synced, err := s.opts.Synced()
+		if err != nil || !synced {
+			s.logger.Debug("postage chain state not synced, rules evaluation skipped", "error", err)
+			continue
+		}
+		s.evaluate(ctx)
+	}
+}
+
+// evaluate evaluates the rules of all the batches.
+func (s *Service) evaluate(ctx context.Context) {
+	s.mu.Lock()
+	ids := make([]string, 0, len(s.batches))
+	for id := range s.batches {
+		ids = append(ids, id)
+	}
+	s.mu.Unlock()
+
+	for _, id := range ids {
+		if ctx.Err() != nil {
+			return
+		}
+		s.evaluateBatch(ctx, []byte(id))
+	}
+}
+
+// evaluateBatch takes at most one action on the batch, the dilution first
+// as it halves the TTL which is then restored by the following top-up.
+func (s *Service) evaluateBatch(ctx context.Context, batchID []byte) {
+	logger := s.logger.WithValues("batch_id", hex.EncodeToString(batchID)).Build()
+
+	issuer, ok := s.owned(batchID)
+	if !ok {
+		// the issuer is removed when the batch expires
+		logger.Debug("batch not owned anymore, rules removed")
+		if err := s.RemoveRules(batchID); err != nil && !errors.Is(err, ErrNotFound) {
+			logger.Error(err, "remove rules failed")
+		}
+		return
+	}
+
+	batch, err := s.batchStore.Get(batchID)
+	if err != nil {
+		logger.Debug("get batch failed", "error", err)
+		return
+	}
+
+	s.mu.Lock()
+	b, ok := s.batches[string(batchID)]
+	if !ok {
+		s.mu.Unlock()
+		return
+	}
+	rules := b.status.Rules
+	pending := b.pendingValue != nil &&
+		b.pendingValue.Cmp(batch.Value) == 0 &&
+		b.pendingDepth == batch.Depth &&
+		s.now().Sub(b.pendingSince) < pendingTimeout
+	s.mu.Unlock()
+
+	if pending {
+		logger.Debug("previous action pending")
+		return
+	}
+
+	action, err := s.apply(ctx, rules, issuer, batch)
+	if action == nil && err == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if b, ok = s.batches[string(batchID)]; !ok {
+		return
+	}
+	if err != nil {
+		logger.Error(err, "batch policy failed")
+		b.status.LastError = err.Error()
+		return
+	}
+	logger.Info("batch policy applied", "action", action.Type, "amount", action.Amount, "depth", action.Depth, "tx", action.TxHash)
+	b.status.LastAction = action
+	b.status.LastError = ""
+	b.pendingValue = new(big.Int).Set(batch.Value)
+	b.pendingDepth = batch.Depth
+	b.pendingSince = action.Timestamp
+}
+
+// apply takes the action required by the rules, if any.
+func (s *Service) apply(ctx context.Context, r Rules, issuer *postage.StampIssuer, batch *postage.Batch) (*Action, error) {
+	if r.MaxUtilization > 0 && (r.MaxDepth == 0 || batch.Depth < r.MaxDepth) {
+		utilization := uint64(issuer.Utilization()) * 100 / uint64(issuer.BucketUpperBound())
+		if utilization > uint64(r.MaxUtilization) {
+			depth := batch.Depth + 1
+			txHash, err := s.contract.DiluteBatch(ctx, batch.ID, depth)
+			if err != nil {
+				return nil, fmt.Errorf("dilute batch to depth %d: %w", depth, err)
+			}
+			return &Action{Type: ActionDilute, Timestamp: s.now(), Depth: depth, TxHash: txHash}, nil
+		}
+	}
+
+	if r.MinTTL > 0 {
+		amount := s.topUpAmount(r, batch)
+		if amount == nil {
+			return nil, nil
+		}
+		total := new(big.Int).Lsh(amount, uint(batch.Depth))
+		if err := s.reserve(total); err != nil {
+			return nil, fmt.Errorf("top up batch by %s: %w", total, err)
+		}
+		txHash, err := s.contract.TopUpBatch(ctx, batch.ID, amount)
+		if err != nil {
+			s.release(total)
+			return nil, fmt.Errorf("top up batch by %s: %w", total, err)
+		}
+		return &Action{Type: ActionTopUp, Timestamp: s.now(), Amount: amount, TxHash: txHash}, nil
+	}
+
+	return nil, nil
+}
+
+// topUpAmount returns the per chunk amount which extends the TTL of the
+// batch to the TopUpTTL, or nil if the TTL is not below the MinTTL.
+func (s *Service) topUpAmount(r Rules, batch *postage.Batch) *big.Int {
+	cs := s.batchStore.GetChainState()
+	if ttl, ok := cs.TTL(batch, s.opts.BlockTime); !ok || ttl >= r.MinTTL {
+		return nil
+	}
+
+	blockTime := int64(s.opts.BlockTime)
+	target := max(r.TopUpTTL, r.MinTTL)
+	blocks := (int64(target) + blockTime - 1) / blockTime
+	amount := new(big.Int).Mul(cs.CurrentPrice, big.NewInt(blocks))
+	amount.Sub(amount, cs.Balance(batch))
+	if amount.Sign() <= 0 {
+		return nil
+	}
+	return amount
+}
+
+// reserve adds the amount to the spending of the current period
+// if it does not exceed the spending cap.
+func (s *Service) reserve(amount *big.Int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if now.Sub(s.spending.PeriodStart) >= s.opts.SpendPeriod {
+		s.spending = spending{PeriodStart: now, Spent: new(big.Int)}
+	}
+
+	spent := new(big.Int).Add(s.spending.Spent, amount)
+	if spent.Cmp(s.opts.SpendLimit) > 0 {
+		return fmt.Errorf("%w: spent %s of %s", ErrSpendLimitExceeded, s.spending.Spent, s.opts.SpendLimit)
+	}
+	s.spending.Spent = spent
+	return s.stateStore.Put(spendingKey, s.spending)
+}
+
+// release returns the amount of the failed top-up to the spending cap.
+func (s *Service) release(amount *big.Int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.spending.Spent = new(big.Int).Sub(s.spending.Spent, amount)
+	if s.spending.Spent.Sign() < 0 {
+		s.spending.Spent.SetInt64(0)
+	}
+	if err := s.stateStore.Put(spendingKey, s.spending); err != nil {
+		s.logger.Error(err, "store spending failed")
+	}
+}
+
+// Close stops the background evaluation of the rules.
+func (s *Service) Close() error {
+	select {
+	case <-s.quit:
+	default:
+		close(s.quit)
+	}
+	s.wg.Wait()
+	return nil
+}
@@ -0,0 +1,281 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package policy_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethersphere/bee/v2/pkg/crypto"
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/postage"
+	batchstoremock "github.com/ethersphere/bee/v2/pkg/postage/batchstore/mock"
+	postagemock "github.com/ethersphere/bee/v2/pkg/postage/mock"
+	"github.com/ethersphere/bee/v2/pkg/postage/policy"
+	contractmock "github.com/ethersphere/bee/v2/pkg/postage/postagecontract/mock"
+	statestore "github.com/ethersphere/bee/v2/pkg/statestore/mock"
+	"github.com/ethersphere/bee/v2/pkg/storage/inmemstore"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+const (
+	blockTime = 5 * time.Second
+	depth     = 17
+)
+
+var price = big.NewInt(10)
+
+type contract struct {
+	mu      sync.Mutex
+	topUps  []*big.Int
+	dilutes []uint8
+}
+
+func (c *contract) mock() policy.Contract {
+	return contractmock.New(
+		contractmock.WithTopUpBatchFunc(func(_ context.Context, _ []byte, amount *big.Int) (common.Hash, error) {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			c.topUps = append(c.topUps, amount)
+			return common.Hash{1}, nil
+		}),
+		contractmock.WithDiluteBatchFunc(func(_ context.Context, _ []byte, newDepth uint8) (common.Hash, error) {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			c.dilutes = append(c.dilutes, newDepth)
+			return common.Hash{2}, nil
+		}),
+	)
+}
+
+// newBatch returns the batch with the balance for the ttl and its stamp issuer.
+func newBatch(t *testing.T, ttl time.Duration) (*postage.Batch, *postage.StampIssuer) {
+	t.Helper()
+
+	id := swarm.RandAddress(t).Bytes()
+	value := new(big.Int).Mul(price, big.NewInt(int64(ttl/blockTime)))
+	batch := &postage.Batch{ID: id, Value: value, Depth: depth, BucketDepth: 16}
+	issuer := postage.NewStampIssuer("label", "keyID", id, value, depth, 16, 0, true)
+	return batch, issuer
+}
+
+func newService(t *testing.T, batch *postage.Batch, issuer *postage.StampIssuer, c policy.Contract, limit int64) (*policy.Service, *batchstoremock.BatchStore) {
+	t.Helper()
+
+	bs := batchstoremock.New(
+		batchstoremock.WithBatch(batch),
+		batchstoremock.WithChainState(&postage.ChainState{TotalAmount: big.NewInt(0), CurrentPrice: price}),
+	)
+	s, err := policy.New(statestore.NewStateStore(), postagemock.New(postagemock.WithIssuer(issuer)), bs, c, log.Noop, policy.Options{
+		BlockTime:  blockTime,
+		SpendLimit: big.NewInt(limit),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := s.Close(); err != nil {
+			t.Error(err)
+		}
+	})
+	return s, bs
+}
+
+func TestTopUp(t *testing.T) {
+	t.Parallel()
+
+	batch, issuer := newBatch(t, 10*24*time.Hour)
+	c := new(contract)
+	s, bs := newService(t, batch, issuer, c.mock(), 1<<50)
+
+	err := s.SetRules(batch.ID, policy.Rules{MinTTL: 30 * 24 * time.Hour, TopUpTTL: 60 * 24 * time.Hour})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s.Evaluate(context.Background())
+
+	// the balance of 10 days is topped up to the balance of 60 days
+	want := new(big.Int).Mul(price, big.NewInt(int64(50*24*time.Hour/blockTime)))
+	if len(c.topUps) != 1 || c.topUps[0].Cmp(want) != 0 {
+		t.Fatalf("got top-ups %v, want %s", c.topUps, want)
+	}
+	status, err := s.Status(batch.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.LastAction == nil || status.LastAction.Type != policy.ActionTopUp || status.LastAction.Amount.Cmp(want) != 0 {
+		t.Fatalf("got last action %+v, want top-up by %s", status.LastAction, want)
+	}
+
+	// the batch is skipped until the top-up is seen in the chain state
+	s.Evaluate(context.Background())
+	if len(c.topUps) != 1 {
+		t.Fatalf("got %d top-ups, want 1", len(c.topUps))
+	}
+
+	if err := bs.Update(batch, new(big.Int).Add(batch.Value, want), batch.Depth); err != nil {
+		t.Fatal(err)
+	}
+	s.Evaluate(context.Background())
+	if len(c.topUps) != 1 {
+		t.Fatalf("got %d top-ups, want 1", len(c.topUps))
+	}
+}
+
+func TestSpendLimit(t *testing.T) {
+	t.Parallel()
+
+	batch, issuer := newBatch(t, 24*time.Hour)
+	c := new(contract)
+	s, _ := newService(t, batch, issuer, c.mock(), 1000)
+
+	err := s.SetRules(batch.ID, policy.Rules{MinTTL: 30 * 24 * time.Hour})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s.Evaluate(context.Background())
+
+	if len(c.topUps) != 0 {
+		t.Fatalf("got %d top-ups, want none", len(c.topUps))
+	}
+	status, err := s.Status(batch.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.LastAction != nil || status.LastError == "" {
+		t.Fatalf("got status %+v, want spend limit error", status)
+	}
+
+	// the spending cap is renewed in the next period
+	s.SetNow(func() time.Time { return time.Now().Add(25 * time.Hour) })
+	s.Evaluate(context.Background())
+	if len(c.topUps) != 0 {
+		t.Fatalf("got %d top-ups, want none", len(c.topUps))
+	}
+}
+
+func TestDilute(t *testing.T) {
+	t.Parallel()
+
+	batch, issuer := newBatch(t, 60*24*time.Hour)
+	c := new(contract)
+	s, _ := newService(t, batch, issuer, c.mock(), 0)
+
+	err := s.SetRules(batch.ID, policy.Rules{MaxUtilization: 40, MaxDepth: depth + 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s.Evaluate(context.Background())
+	if len(c.dilutes) != 0 {
+		t.Fatalf("got %d dilutions, want none", len(c.dilutes))
+	}
+
+	// a stamp fills the half of the bucket of the batch
+	pk, err := crypto.GenerateSecp256k1Key()
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := swarm.RandAddress(t)
+	if _, err := postage.NewStamper(inmemstore.New(), issuer, crypto.NewDefaultSigner(pk)).Stamp(addr, addr); err != nil {
+		t.Fatal(err)
+	}
+
+	s.Evaluate(context.Background())
+	if len(c.dilutes) != 1 || c.dilutes[0] != depth+1 {
+		t.Fatalf("got dilutions %v, want to depth %d", c.dilutes, depth+1)
+	}
+}
+
+func TestRules(t *testing.T) {
+	t.Parallel()
+
+	batch, issuer := newBatch(t, time.Hour)
+	c := new(contract)
+	s, _ := newService(t, batch, issuer, c.mock(), 0)
+
+	if _, err := s.Status(batch.ID); !errors.Is(err, policy.ErrNotFound) {
+		t.Fatalf("got error %v, want %v", err, policy.ErrNotFound)
+	}
+	if err := s.SetRules(swarm.RandAddress(t).Bytes(), policy.Rules{MinTTL: time.Hour}); !errors.Is(err, policy.ErrNotFound) {
+		t.Fatalf("got error %v, want %v", err, policy.ErrNotFound)
+	}
+	if err := s.SetRules(batch.ID, policy.Rules{}); !errors.Is(err, policy.ErrInvalidRules) {
+		t.Fatalf("got error %v, want %v", err, policy.ErrInvalidRules)
+	}
+	if err := s.SetRules(batch.ID, policy.Rules{MaxUtilization: 101}); !errors.Is(err, policy.ErrInvalidRules) {
+		t.Fatalf("got error %v, want %v", err, policy.ErrInvalidRules)
+	}
+
+	rules := policy.Rules{MinTTL: time.Hour, MaxUtilization: 80}
+	if err := s.SetRules(batch.ID, rules); err != nil {
+		t.Fatal(err)
+	}
+	status, err := s.Status(batch.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.Rules != rules {
+		t.Fatalf("got rules %+v, want %+v", status.Rules, rules)
+	}
+
+	if err := s.RemoveRules(batch.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Status(batch.ID); !errors.Is(err, policy.ErrNotFound) {
+		t.Fatalf("got error %v, want %v", err, policy.ErrNotFound)
+	}
+}
+
+func TestRulesPersisted(t *testing.T) {
+	t.Parallel()
+
+	batch, issuer := newBatch(t, time.Hour)
+	store := statestore.NewStateStore()
+	issuers := postagemock.New(postagemock.WithIssuer(issuer))
+	bs := batchstoremock.New(batchstoremock.WithBatch(batch))
+
+	s, err := policy.New(store, issuers, bs, new(contract).mock(), log.Noop, policy.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rules := policy.Rules{MinTTL: time.Hour}
+	if err := s.SetRules(batch.ID, rules); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err = policy.New(store, issuers, bs, new(contract).mock(), log.Noop, policy.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	status, err := s.Status(batch.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.Rules != rules {
+		t.Fatalf("got rules %+v, want %+v", status.Rules, rules)
+	}
+
+	// the rules of the expired batches are removed
+	if err := issuers.HandleStampExpiry(context.Background(), batch.ID); err != nil {
+		t.Fatal(err)
+	}
+	s.Evaluate(context.Background())
+	if _, err := s.Status(batch.ID); !errors.Is(err, policy.ErrNotFound) {
+		t.Fatalf("got error %v, want %v", err, policy.ErrNotFound)
+	}
+}
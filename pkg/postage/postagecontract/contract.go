@@ -55,6 +55,7 @@ type PostageBatchExpirer interface {
 
 type postageContract struct {
 	owner                       common.Address
+	batchOwner                  common.Address
 	postageStampContractAddress common.Address
 	postageStampContractABI     abi.ABI
 	bzzTokenAddress             common.Address
@@ -70,8 +71,12 @@ type postageContract struct {
 	gasLimit uint64
 }
 
+// New constructs the postage contract service. The owner pays for the
+// batches and the batchOwner owns them and signs their stamps, they differ
+// when the stamps are signed by a remote signer.
 func New(
 	owner common.Address,
+	batchOwner common.Address,
 	postageStampContractAddress common.Address,
 	postageStampContractABI abi.ABI,
 	bzzTokenAddress common.Address,
@@ -92,6 +97,7 @@ func New(
 
 	return &postageContract{
 		owner:                       owner,
+		batchOwner:                  batchOwner,
 		postageStampContractAddress: postageStampContractAddress,
 		postageStampContractABI:     postageStampContractABI,
 		bzzTokenAddress:             bzzTokenAddress,
@@ -386,7 +392,7 @@ func (c *postageContract) CreateBatch(ctx context.Context, initialBalance *big.I
 		return
 	}
 
-	receipt, err := c.sendCreateBatchTransaction(ctx, c.owner, initialBalance, depth, common.BytesToHash(nonce), immutable)
+	receipt, err := c.sendCreateBatchTransaction(ctx, c.batchOwner, initialBalance, depth, common.BytesToHash(nonce), immutable)
 	if err != nil {
 		return
 	}
@@ -403,7 +409,7 @@ func (c *postageContract) CreateBatch(ctx context.Context, initialBalance *big.I
 			batchID = createdEvent.BatchId[:]
 			err = c.postageService.Add(postage.NewStampIssuer(
 				label,
-				c.owner.Hex(),
+				c.batchOwner.Hex(),
 				batchID,
 				initialBalance,
 				createdEvent.Depth,
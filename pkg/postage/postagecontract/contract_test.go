@@ -10,19 +10,27 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	chaincfg "github.com/ethersphere/bee/v2/pkg/config"
+	"github.com/ethersphere/bee/v2/pkg/crypto"
+	"github.com/ethersphere/bee/v2/pkg/log"
 	"github.com/ethersphere/bee/v2/pkg/postage"
 	postagestoreMock "github.com/ethersphere/bee/v2/pkg/postage/batchstore/mock"
 	postageMock "github.com/ethersphere/bee/v2/pkg/postage/mock"
 	"github.com/ethersphere/bee/v2/pkg/postage/postagecontract"
+	"github.com/ethersphere/bee/v2/pkg/postage/stampsigner"
 	postagetesting "github.com/ethersphere/bee/v2/pkg/postage/testing"
+	"github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/storage/inmemstore"
+	testingc "github.com/ethersphere/bee/v2/pkg/storage/testing"
 	"github.com/ethersphere/bee/v2/pkg/transaction"
 	transactionMock "github.com/ethersphere/bee/v2/pkg/transaction/mock"
 	"github.com/ethersphere/bee/v2/pkg/util/abiutil"
+	"github.com/ethersphere/go-sw3-abi/sw3abi"
 )
 
 var postageStampContractABI = abiutil.MustParseABI(chaincfg.Testnet.PostageStampABI)
@@ -70,6 +78,7 @@ func TestCreateBatch(t *testing.T) {
 
 		counter := 0
 		contract := postagecontract.New(
+			owner,
 			owner,
 			postageStampAddress,
 			postageStampContractABI,
@@ -165,6 +174,7 @@ func TestCreateBatch(t *testing.T) {
 		depth := uint8(9)
 
 		contract := postagecontract.New(
+			owner,
 			owner,
 			postageStampAddress,
 			postageStampContractABI,
@@ -187,6 +197,7 @@ func TestCreateBatch(t *testing.T) {
 		totalAmount := big.NewInt(102399)
 
 		contract := postagecontract.New(
+			owner,
 			owner,
 			postageStampAddress,
 			postageStampContractABI,
@@ -226,6 +237,7 @@ func TestCreateBatch(t *testing.T) {
 		}
 
 		contract := postagecontract.New(
+			owner,
 			owner,
 			postageStampAddress,
 			postageStampContractABI,
@@ -258,6 +270,139 @@ func TestCreateBatch(t *testing.T) {
 
 }
 
+// TestCreateBatchRemoteSigner checks that the batches bought for a remote
+// stamp signer are owned by the signer, so that its stamps are valid.
+func TestCreateBatchRemoteSigner(t *testing.T) {
+	defer func(b uint8) {
+		postagecontract.BucketDepth = b
+	}(postagecontract.BucketDepth)
+	postagecontract.BucketDepth = 16
+
+	key, err := crypto.GenerateSecp256k1Key()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := httptest.NewServer(stampsigner.NewHandler(crypto.NewDefaultSigner(key), "token", log.Noop))
+	t.Cleanup(ts.Close)
+	remoteSigner := stampsigner.New(ts.URL, "token", ts.Client())
+	batchOwner, err := remoteSigner.EthereumAddress()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	owner := common.HexToAddress("abcd")
+	postageStampAddress := common.HexToAddress("ffff")
+	bzzTokenAddress := common.HexToAddress("eeee")
+	initialBalance := big.NewInt(100)
+	depth := uint8(17)
+	txHashApprove := common.HexToHash("abb0")
+	txHashCreate := common.HexToHash("c3a7")
+	batchID := common.HexToHash("dddd")
+	postageMock := postageMock.New()
+
+	expectedCallData, err := postageStampContractABI.Pack("createBatch", batchOwner, initialBalance, depth, postagecontract.BucketDepth, common.Hash{}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedBalanceCallData, err := abiutil.MustParseABI(sw3abi.ERC20ABIv0_6_9).Pack("balanceOf", owner)
+	if err != nil {
+		t.Fatal(err)
+	}
+	createEvent, err := postageStampContractABI.Events["BatchCreated"].Inputs.NonIndexed().Pack(
+		initialBalance,
+		initialBalance,
+		batchOwner,
+		depth,
+		postagecontract.BucketDepth,
+		false,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	contract := postagecontract.New(
+		owner,
+		batchOwner,
+		postageStampAddress,
+		postageStampContractABI,
+		bzzTokenAddress,
+		transactionMock.New(
+			transactionMock.WithSendFunc(func(ctx context.Context, request *transaction.TxRequest, boost int) (txHash common.Hash, err error) {
+				if *request.To == bzzTokenAddress {
+					return txHashApprove, nil
+				}
+				if bytes.Equal(expectedCallData[:100], request.Data[:100]) {
+					return txHashCreate, nil
+				}
+				return common.Hash{}, fmt.Errorf("got wrong call data. wanted %x, got %x", expectedCallData, request.Data)
+			}),
+			transactionMock.WithWaitForReceiptFunc(func(ctx context.Context, txHash common.Hash) (receipt *types.Receipt, err error) {
+				if txHash == txHashCreate {
+					return &types.Receipt{
+						Logs: []*types.Log{{
+							Address: postageStampAddress,
+							Data:    createEvent,
+							Topics:  []common.Hash{postageStampContractABI.Events["BatchCreated"].ID, batchID},
+						}},
+						Status: 1,
+					}, nil
+				}
+				return &types.Receipt{Status: 1}, nil
+			}),
+			transactionMock.WithCallFunc(func(ctx context.Context, request *transaction.TxRequest) (result []byte, err error) {
+				switch {
+				case *request.To == bzzTokenAddress:
+					// the batch is paid for by the node
+					if !bytes.Equal(expectedBalanceCallData, request.Data) {
+						return nil, fmt.Errorf("got wrong balance call data. wanted %x, got %x", expectedBalanceCallData, request.Data)
+					}
+					return new(big.Int).Lsh(initialBalance, uint(depth)).FillBytes(make([]byte, 32)), nil
+				case bytes.Equal(postageStampContractABI.Methods["lastPrice"].ID, request.Data):
+					return big.NewInt(2).FillBytes(make([]byte, 32)), nil
+				case bytes.Equal(postageStampContractABI.Methods["minimumValidityBlocks"].ID, request.Data):
+					return big.NewInt(25).FillBytes(make([]byte, 32)), nil
+				default:
+					// no expired batches
+					return big.NewInt(0).FillBytes(make([]byte, 32)), nil
+				}
+			}),
+		),
+		postageMock,
+		postagestoreMock.New(),
+		true,
+		false,
+	)
+
+	_, id, err := contract.CreateBatch(context.Background(), initialBalance, depth, false, "label")
+	if err != nil {
+		t.Fatal(err)
+	}
+	issuer, _, err := postageMock.GetStampIssuer(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chunk := testingc.GenerateTestRandomChunk()
+	idAddr, err := storage.IdentityAddress(chunk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stamp, err := postage.NewStamper(inmemstore.New(), issuer, remoteSigner).Stamp(chunk.Address(), idAddr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	batchStore := postagestoreMock.New(postagestoreMock.WithBatch(&postage.Batch{
+		ID:          id,
+		Owner:       batchOwner.Bytes(),
+		Depth:       depth,
+		BucketDepth: postagecontract.BucketDepth,
+	}))
+	if _, err := postage.ValidStamp(batchStore)(chunk.WithStamp(stamp)); err != nil {
+		t.Fatalf("stamp of the remote signer invalid: %v", err)
+	}
+}
+
 func newCreateEvent(postageContractAddress common.Address, batchId common.Hash) *types.Log {
 	event := postageStampContractABI.Events["BatchCreated"]
 	b, err := event.Inputs.NonIndexed().Pack(
@@ -315,6 +460,7 @@ func TestTopUpBatch(t *testing.T) {
 		}
 
 		contract := postagecontract.New(
+			owner,
 			owner,
 			postageStampAddress,
 			postageStampContractABI,
@@ -377,6 +523,7 @@ func TestTopUpBatch(t *testing.T) {
 	t.Run("batch doesn't exist", func(t *testing.T) {
 		errNotFound := errors.New("not found")
 		contract := postagecontract.New(
+			owner,
 			owner,
 			postageStampAddress,
 			postageStampContractABI,
@@ -400,6 +547,7 @@ func TestTopUpBatch(t *testing.T) {
 		batchStoreMock := postagestoreMock.New(postagestoreMock.WithBatch(batch))
 
 		contract := postagecontract.New(
+			owner,
 			owner,
 			postageStampAddress,
 			postageStampContractABI,
@@ -485,6 +633,7 @@ func TestDiluteBatch(t *testing.T) {
 		txHashApprove := common.HexToHash("abb0")
 		counter := 0
 		contract := postagecontract.New(
+			owner,
 			owner,
 			postageStampAddress,
 			postageStampContractABI,
@@ -566,6 +715,7 @@ func TestDiluteBatch(t *testing.T) {
 	t.Run("batch doesn't exist", func(t *testing.T) {
 		errNotFound := errors.New("not found")
 		contract := postagecontract.New(
+			owner,
 			owner,
 			postageStampAddress,
 			postageStampContractABI,
@@ -589,6 +739,7 @@ func TestDiluteBatch(t *testing.T) {
 		batchStoreMock := postagestoreMock.New(postagestoreMock.WithBatch(batch))
 
 		contract := postagecontract.New(
+			owner,
 			owner,
 			postageStampAddress,
 			postageStampContractABI,
@@ -643,6 +794,7 @@ func TestBatchExpirer(t *testing.T) {
 			t.Fatal(err)
 		}
 		contract := postagecontract.New(
+			owner,
 			owner,
 			postageContractAddress,
 			postageStampContractABI,
@@ -691,6 +843,7 @@ func TestBatchExpirer(t *testing.T) {
 			t.Fatal("expected error")
 		}
 		contract := postagecontract.New(
+			owner,
 			owner,
 			postageContractAddress,
 			postageStampContractABI,
@@ -725,6 +878,7 @@ func TestBatchExpirer(t *testing.T) {
 			t.Fatal("expected error")
 		}
 		contract := postagecontract.New(
+			owner,
 			owner,
 			postageContractAddress,
 			postageStampContractABI,
@@ -787,6 +941,7 @@ func TestBatchExpirer(t *testing.T) {
 			t.Fatal(err)
 		}
 		contract := postagecontract.New(
+			owner,
 			owner,
 			postageContractAddress,
 			postageStampContractABI,
@@ -835,6 +990,7 @@ func TestBatchExpirer(t *testing.T) {
 			t.Fatal(err)
 		}
 		contract := postagecontract.New(
+			owner,
 			owner,
 			postageContractAddress,
 			postageStampContractABI,
@@ -871,6 +1027,7 @@ func TestBatchExpirer(t *testing.T) {
 			t.Fatal(err)
 		}
 		contract := postagecontract.New(
+			owner,
 			owner,
 			postageContractAddress,
 			postageStampContractABI,
@@ -910,6 +1067,7 @@ func TestBatchExpirer(t *testing.T) {
 			t.Fatal(err)
 		}
 		contract := postagecontract.New(
+			owner,
 			owner,
 			postageContractAddress,
 			postageStampContractABI,
@@ -40,6 +40,14 @@ type Service interface {
 	StampIssuers() []*StampIssuer
 	GetStampIssuer([]byte) (*StampIssuer, func() error, error)
 	IssuerUsable(*StampIssuer) bool
+	// Export marks the stamp issuer of the given batch read-only
+	// and writes it with its stamp items to the writer.
+	Export(ctx context.Context, batchID []byte, w io.Writer) error
+	// Import adds the stamp issuer exported by another node.
+	Import(ctx context.Context, r io.Reader) (*StampIssuer, error)
+	// RevertExport makes the exported stamp issuer of the given batch
+	// writable again, when its export was not imported by another node.
+	RevertExport(batchID []byte) error
 	BatchEventListener
 	BatchExpiryHandler
 	io.Closer
@@ -53,16 +61,19 @@ type service struct {
 	store        storage.Store
 	postageStore Storer
 	chainID      int64
+	owner        []byte
 	issuers      []*StampIssuer
 }
 
-// NewService constructs a new Service.
-func NewService(logger log.Logger, store storage.Store, postageStore Storer, chainID int64) (Service, error) {
+// NewService constructs a new Service. The owner is the address
+// of the signer of the stamps issued by the node.
+func NewService(logger log.Logger, store storage.Store, postageStore Storer, chainID int64, owner []byte) (Service, error) {
 	s := &service{
 		logger:       logger.WithName(loggerName).Register(),
 		store:        store,
 		postageStore: postageStore,
 		chainID:      chainID,
+		owner:        owner,
 	}
 
 	return s, s.store.Iterate(
@@ -172,6 +183,81 @@ func (ps *service) GetStampIssuer(batchID []byte) (*StampIssuer, func() error, e
 	return nil, nil, ErrNotFound
 }
 
+// Export implements the Service interface.
+func (ps *service) Export(ctx context.Context, batchID []byte, w io.Writer) error {
+	ps.mtx.Lock()
+	i := ps.index(batchID)
+	if i < 0 {
+		ps.mtx.Unlock()
+		return ErrNotFound
+	}
+	issuer := ps.issuers[i]
+	ps.mtx.Unlock()
+
+	return exportIssuer(ctx, ps.store, issuer, w)
+}
+
+// Import implements the Service interface. The stamp issuer replaces
+// the present one only when the present one is read-only. The batch
+// must be owned by the signer of the stamps of the node.
+func (ps *service) Import(ctx context.Context, r io.Reader) (*StampIssuer, error) {
+	issuer, err := importIssuer(ctx, ps.store, r, func(batchID []byte) error {
+		b, err := ps.postageStore.Get(batchID)
+		switch {
+		case errors.Is(err, storage.ErrNotFound):
+			return fmt.Errorf("batch %x: %w", batchID, ErrNotFound)
+		case err != nil:
+			return fmt.Errorf("get batch %x: %w", batchID, err)
+		case !bytes.Equal(b.Owner, ps.owner):
+			return fmt.Errorf("batch %x owned by %x, stamps signed by %x: %w", batchID, b.Owner, ps.owner, ErrNotBatchOwner)
+		}
+
+		ps.mtx.Lock()
+		defer ps.mtx.Unlock()
+		if i := ps.index(batchID); i >= 0 && !ps.issuers[i].ReadOnly() {
+			return ErrIssuerExists
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+	if i := ps.index(issuer.data.BatchID); i >= 0 {
+		ps.issuers[i] = issuer
+	} else {
+		ps.issuers = append(ps.issuers, issuer)
+	}
+	return issuer, nil
+}
+
+// RevertExport implements the Service interface.
+func (ps *service) RevertExport(batchID []byte) error {
+	ps.mtx.Lock()
+	i := ps.index(batchID)
+	if i < 0 {
+		ps.mtx.Unlock()
+		return ErrNotFound
+	}
+	issuer := ps.issuers[i]
+	ps.mtx.Unlock()
+
+	return revertExport(ps.store, issuer)
+}
+
+// index returns the index of the stamp issuer of the given batch or -1.
+// Must be mutex locked before usage.
+func (ps *service) index(batchID []byte) int {
+	for i, st := range ps.issuers {
+		if bytes.Equal(batchID, st.data.BatchID) {
+			return i
+		}
+	}
+	return -1
+}
+
 // save persists the specified stamp issuer to the stamperstore.
 func (ps *service) save(st *StampIssuer) error {
 	st.mtx.Lock()
@@ -204,7 +290,7 @@ func (ps *service) HandleStampExpiry(ctx context.Context, id []byte) error {
 	}
 
 	if exists {
-		return ps.removeStampItems(ctx, id)
+		return errors.Join(ps.removeStampItems(ctx, id), removeUploads(ctx, ps.store, id))
 	}
 
 	return nil
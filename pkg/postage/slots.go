@@ -0,0 +1,130 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package postage
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+var (
+	// ErrIndexImmutable is returned when the stamp index is chosen for an immutable batch.
+	ErrIndexImmutable = errors.New("stamp index of immutable batch")
+	// ErrIndexOutOfRange is returned when the chosen stamp index is beyond the bucket upper bound.
+	ErrIndexOutOfRange = errors.New("stamp index out of range")
+)
+
+// BucketSlot is a stamp index of a collision bucket
+// and the chunk which was stamped with it the last.
+type BucketSlot struct {
+	Index     uint32
+	Address   swarm.Address
+	Timestamp uint64
+}
+
+// indexStamper issues the stamps of a mutable batch with the chosen index
+// of the bucket of the chunk, overwriting the chunk stamped with it before.
+type indexStamper struct {
+	store  storage.Store
+	issuer *StampIssuer
+	signer Signer
+	index  uint32
+}
+
+// NewIndexStamper constructs a Stamper which stamps the chunks with the given
+// within-bucket index. The bucket counters of the issuer are left untouched,
+// so the slots past the counter are overwritten again once the counter
+// reaches them.
+func NewIndexStamper(store storage.Store, issuer *StampIssuer, signer Signer, index uint32) Stamper {
+	return &indexStamper{store, issuer, signer, index}
+}
+
+// Stamp stamps the chunk with the chosen index and a fresh timestamp,
+// so the chunk supersedes the one stamped with the index before.
+// The stamp is signed outside of the lock of the issuer.
+func (st *indexStamper) Stamp(addr, idAddr swarm.Address) (*Stamp, error) {
+	item, err := st.issue(addr, idAddr)
+	if err != nil {
+		return nil, err
+	}
+	return signStamp(st.signer, addr, item)
+}
+
+// issue assigns the chosen index and a fresh timestamp to the stamp of the chunk.
+func (st *indexStamper) issue(addr, idAddr swarm.Address) (*StampItem, error) {
+	st.issuer.mtx.Lock()
+	defer st.issuer.mtx.Unlock()
+
+	switch {
+	case st.issuer.data.ReadOnly:
+		return nil, ErrReadOnly
+	case st.issuer.data.ImmutableFlag:
+		return nil, ErrIndexImmutable
+	case st.index >= st.issuer.BucketUpperBound():
+		return nil, ErrIndexOutOfRange
+	}
+
+	item := &StampItem{
+		BatchID:        st.issuer.data.BatchID,
+		chunkAddress:   idAddr,
+		BatchIndex:     indexToBytes(toBucket(st.issuer.BucketDepth(), addr), st.index),
+		BatchTimestamp: unixTime(),
+	}
+	if err := st.store.Put(item); err != nil {
+		return nil, fmt.Errorf("put stamp for %s: %w", item, err)
+	}
+	return item, nil
+}
+
+// BatchId gives back batch id of stamper
+func (st *indexStamper) BatchId() []byte {
+	return st.issuer.data.BatchID
+}
+
+// BucketSlots returns the used slots of the given bucket of the batch ordered
+// by the index. When several chunks were stamped with the same index, the slot
+// holds the chunk with the latest timestamp, as the network keeps that one.
+func BucketSlots(ctx context.Context, store storage.Store, batchID []byte, bucket uint32) ([]BucketSlot, error) {
+	latest := make(map[uint32]BucketSlot)
+	err := store.Iterate(
+		storage.Query{
+			Factory: func() storage.Item { return new(StampItem) },
+			Prefix:  string(batchID),
+		}, func(result storage.Result) (bool, error) {
+			if err := ctx.Err(); err != nil {
+				return true, err
+			}
+			item := result.Entry.(*StampItem)
+			if !bytes.Equal(item.BatchID, batchID) {
+				return false, nil
+			}
+			b, index := BucketIndexFromBytes(item.BatchIndex)
+			if b != bucket {
+				return false, nil
+			}
+			ts := TimestampFromBytes(item.BatchTimestamp)
+			if slot, ok := latest[index]; !ok || slot.Timestamp < ts {
+				latest[index] = BucketSlot{Index: index, Address: item.chunkAddress, Timestamp: ts}
+			}
+			return false, nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("iterate stamp items: %w", err)
+	}
+
+	slots := make([]BucketSlot, 0, len(latest))
+	for _, slot := range latest {
+		slots = append(slots, slot)
+	}
+	slices.SortFunc(slots, func(a, b BucketSlot) int { return cmp.Compare(a.Index, b.Index) })
+	return slots, nil
+}
@@ -0,0 +1,112 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package postage_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/crypto"
+	"github.com/ethersphere/bee/v2/pkg/postage"
+	"github.com/ethersphere/bee/v2/pkg/storage/inmemstore"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+func TestIndexStamper(t *testing.T) {
+	t.Parallel()
+
+	privKey, err := crypto.GenerateSecp256k1Key()
+	if err != nil {
+		t.Fatal(err)
+	}
+	owner, err := crypto.NewEthereumAddress(privKey.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer := crypto.NewDefaultSigner(privKey)
+
+	t.Run("overwrite", func(t *testing.T) {
+		t.Parallel()
+
+		store := inmemstore.New()
+		issuer := newTestStampIssuerMutability(t, 1000, false)
+
+		// fill the first slots of the bucket of the chunks
+		first := swarm.RandAddress(t)
+		stamper := postage.NewStamper(store, issuer, signer)
+		addrs := []swarm.Address{first}
+		for range 3 {
+			addrs = append(addrs, swarm.RandAddressAt(t, first, 8))
+		}
+		for _, addr := range addrs {
+			if _, err := stamper.Stamp(addr, addr); err != nil {
+				t.Fatal(err)
+			}
+		}
+		bucket, _ := postage.BucketIndexFromBytes(mustStamp(t, stamper, first).Index())
+
+		next := swarm.RandAddressAt(t, first, 8)
+		stamp, err := postage.NewIndexStamper(store, issuer, signer, 1).Stamp(next, next)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := stamp.Valid(next, owner, 16, 8, false); err != nil {
+			t.Fatalf("invalid stamp: %v", err)
+		}
+		if b, i := postage.BucketIndexFromBytes(stamp.Index()); b != bucket || i != 1 {
+			t.Fatalf("got bucket %d index %d, want bucket %d index 1", b, i, bucket)
+		}
+		if got := issuer.Buckets()[bucket]; got != 4 {
+			t.Fatalf("got bucket count %d, want 4", got)
+		}
+
+		slots, err := postage.BucketSlots(context.Background(), store, issuer.ID(), bucket)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []swarm.Address{addrs[0], next, addrs[2], addrs[3]}
+		if len(slots) != len(want) {
+			t.Fatalf("got %d slots, want %d", len(slots), len(want))
+		}
+		for i, slot := range slots {
+			if slot.Index != uint32(i) || !slot.Address.Equal(want[i]) {
+				t.Fatalf("slot %d: got index %d address %s, want %s", i, slot.Index, slot.Address, want[i])
+			}
+		}
+	})
+
+	t.Run("immutable batch", func(t *testing.T) {
+		t.Parallel()
+
+		issuer := newTestStampIssuerMutability(t, 1000, true)
+		addr := swarm.RandAddress(t)
+		_, err := postage.NewIndexStamper(inmemstore.New(), issuer, signer, 0).Stamp(addr, addr)
+		if !errors.Is(err, postage.ErrIndexImmutable) {
+			t.Fatalf("got error %v, want %v", err, postage.ErrIndexImmutable)
+		}
+	})
+
+	t.Run("out of range", func(t *testing.T) {
+		t.Parallel()
+
+		issuer := newTestStampIssuerMutability(t, 1000, false)
+		addr := swarm.RandAddress(t)
+		_, err := postage.NewIndexStamper(inmemstore.New(), issuer, signer, issuer.BucketUpperBound()).Stamp(addr, addr)
+		if !errors.Is(err, postage.ErrIndexOutOfRange) {
+			t.Fatalf("got error %v, want %v", err, postage.ErrIndexOutOfRange)
+		}
+	})
+}
+
+func mustStamp(t *testing.T, stamper postage.Stamper, addr swarm.Address) *postage.Stamp {
+	t.Helper()
+
+	stamp, err := stamper.Stamp(addr, addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return stamp
+}
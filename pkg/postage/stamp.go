@@ -213,7 +213,7 @@ func ValidStamp(batchStore Storer) ValidStampFn {
 func (s *Stamp) Valid(chunkAddr swarm.Address, ownerAddr []byte, depth, bucketDepth uint8, immutable bool) error {
 	signerAddr, err := RecoverBatchOwner(chunkAddr, s)
 	if err != nil {
-		return err
+		return fmt.Errorf("recover batch owner: %w, %w", err, ErrOwnerMismatch)
 	}
 	bucket, index := BucketIndexFromBytes(s.index)
 	if toBucket(bucketDepth, chunkAddr) != bucket {
@@ -228,6 +228,13 @@ func (s *Stamp) Valid(chunkAddr swarm.Address, ownerAddr []byte, depth, bucketDe
 	return nil
 }
 
+// IsForged reports whether the stamp validation error proves that the stamp
+// was not issued by the batch owner. Errors that may come from a lagging local
+// chain state, like an unknown batch, do not count as forgery.
+func IsForged(err error) bool {
+	return errors.Is(err, ErrOwnerMismatch) || errors.Is(err, ErrBucketMismatch) || errors.Is(err, ErrInvalidIndex)
+}
+
 // RecoverBatchOwner returns ethereum address that signed postage batch of supplied stamp.
 func RecoverBatchOwner(chunkAddr swarm.Address, stamp swarm.Stamp) ([]byte, error) {
 	toSign, err := ToSignDigest(chunkAddr.Bytes(), stamp.BatchID(), stamp.Index(), stamp.Timestamp())
@@ -6,7 +6,9 @@ package postage_test
 
 import (
 	"bytes"
+	"crypto/ecdsa"
 	"encoding/json"
+	"errors"
 	"math/big"
 	"testing"
 
@@ -17,6 +19,7 @@ import (
 	"github.com/ethersphere/bee/v2/pkg/storage"
 	"github.com/ethersphere/bee/v2/pkg/storage/inmemstore"
 	chunktesting "github.com/ethersphere/bee/v2/pkg/storage/testing"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
 )
 
 // TestStampMarshalling tests the idempotence  of binary marshal/unmarshals for Stamps.
@@ -55,6 +58,78 @@ func TestStampJsonMarshalling(t *testing.T) {
 	compareStamps(t, sExp, s)
 }
 
+// TestValidStampForged tests that only the stamps not issued by the batch
+// owner are reported as forged.
+func TestValidStampForged(t *testing.T) {
+	t.Parallel()
+
+	privKey, err := crypto.GenerateSecp256k1Key()
+	if err != nil {
+		t.Fatal(err)
+	}
+	owner, err := crypto.NewEthereumAddress(privKey.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, err := crypto.GenerateSecp256k1Key()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b := postagetesting.MustNewBatch(postagetesting.WithOwner(owner))
+	stamp := func(t *testing.T, key *ecdsa.PrivateKey) swarm.Chunk {
+		t.Helper()
+
+		issuer := postage.NewStampIssuer("label", "keyID", b.ID, big.NewInt(3), b.Depth, b.BucketDepth, 1000, true)
+		stamper := postage.NewStamper(inmemstore.New(), issuer, crypto.NewDefaultSigner(key))
+		ch := chunktesting.GenerateTestRandomChunk()
+		idAddress, err := storage.IdentityAddress(ch)
+		if err != nil {
+			t.Fatal(err)
+		}
+		st, err := stamper.Stamp(ch.Address(), idAddress)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ch.WithStamp(st)
+	}
+
+	t.Run("unknown batch", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := postage.ValidStamp(mock.New())(stamp(t, privKey))
+		if !errors.Is(err, postage.ErrNotFound) {
+			t.Fatalf("got error %v, want %v", err, postage.ErrNotFound)
+		}
+		if postage.IsForged(err) {
+			t.Fatal("unknown batch reported as forged")
+		}
+	})
+
+	t.Run("other owner", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := postage.ValidStamp(mock.New(mock.WithBatch(b)))(stamp(t, otherKey))
+		if !postage.IsForged(err) {
+			t.Fatalf("got error %v, want forged", err)
+		}
+	})
+
+	t.Run("bad signature", func(t *testing.T) {
+		t.Parallel()
+
+		ch := stamp(t, privKey)
+		st := ch.Stamp()
+		sig := make([]byte, len(st.Sig()))
+		sig[64] = 1
+		ch = ch.WithStamp(postage.NewStamp(st.BatchID(), st.Index(), st.Timestamp(), sig))
+		_, err := postage.ValidStamp(mock.New(mock.WithBatch(b)))(ch)
+		if !postage.IsForged(err) {
+			t.Fatalf("got error %v, want forged", err)
+		}
+	})
+}
+
 func compareStamps(t *testing.T, s1, s2 *postage.Stamp) {
 	t.Helper()
 
@@ -8,8 +8,10 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"slices"
+	"sync"
 
-	"github.com/ethersphere/bee/v2/pkg/crypto"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethersphere/bee/v2/pkg/storage"
 	"github.com/ethersphere/bee/v2/pkg/swarm"
 )
@@ -26,25 +28,67 @@ type Stamper interface {
 	BatchId() []byte
 }
 
+// Signer signs the stamps with the key of the batch owner.
+// It is satisfied by crypto.Signer and by the remote stamp signers
+// which keep the key of the batch owner out of the node.
+type Signer interface {
+	Sign(data []byte) ([]byte, error)
+	EthereumAddress() (common.Address, error)
+}
+
 // stamper connects a stampissuer with a signer.
 // A stamper is created for each upload session.
 type stamper struct {
 	store  storage.Store
 	issuer *StampIssuer
-	signer crypto.Signer
+	signer Signer
 }
 
 // NewStamper constructs a Stamper.
-func NewStamper(store storage.Store, issuer *StampIssuer, signer crypto.Signer) Stamper {
+func NewStamper(store storage.Store, issuer *StampIssuer, signer Signer) Stamper {
 	return &stamper{store, issuer, signer}
 }
 
 // Stamp takes chunk, see if the chunk can be included in the batch and
 // signs it with the owner of the batch of this Stamp issuer.
+// The stamp is signed outside of the lock of the issuer, so that
+// the concurrent uploads are not serialized behind a remote signer.
 func (st *stamper) Stamp(addr, idAddr swarm.Address) (*Stamp, error) {
+	item, err := st.issue(addr, idAddr)
+	if err != nil {
+		return nil, err
+	}
+	return signStamp(st.signer, addr, item)
+}
+
+// signStamp signs the stamp of the chunk with the index and
+// the timestamp assigned to the chunk in the stamp item.
+func signStamp(signer Signer, addr swarm.Address, item *StampItem) (*Stamp, error) {
+	toSign, err := ToSignDigest(
+		addr.Bytes(),
+		item.BatchID,
+		item.BatchIndex,
+		item.BatchTimestamp,
+	)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := signer.Sign(toSign)
+	if err != nil {
+		return nil, err
+	}
+	return NewStamp(item.BatchID, item.BatchIndex, item.BatchTimestamp, sig), nil
+}
+
+// issue assigns the bucket index and the timestamp of the stamp of the chunk.
+func (st *stamper) issue(addr, idAddr swarm.Address) (*StampItem, error) {
 	st.issuer.mtx.Lock()
 	defer st.issuer.mtx.Unlock()
 
+	if st.issuer.data.ReadOnly {
+		return nil, ErrReadOnly
+	}
+
 	item := &StampItem{
 		BatchID:      st.issuer.data.BatchID,
 		chunkAddress: idAddr,
@@ -66,26 +110,89 @@ func (st *stamper) Stamp(addr, idAddr swarm.Address) (*Stamp, error) {
 	default:
 		return nil, fmt.Errorf("get stamp for %s: %w", item, err)
 	}
+	return item, nil
+}
 
-	toSign, err := ToSignDigest(
-		addr.Bytes(),
-		st.issuer.data.BatchID,
-		item.BatchIndex,
-		item.BatchTimestamp,
-	)
-	if err != nil {
-		return nil, err
+// BatchId gives back batch id of stamper
+func (st *stamper) BatchId() []byte {
+	return st.issuer.data.BatchID
+}
+
+// poolStamper issues the stamps from the batches of a pool, choosing for
+// each chunk the batch with the most room left in the bucket of the chunk.
+type poolStamper struct {
+	store   storage.Store
+	issuers []*StampIssuer
+	signer  Signer
+
+	mu   sync.Mutex
+	last []byte // batch ID of the last issued stamp
+}
+
+// NewPoolStamper constructs a Stamper which issues the
+// stamps from the batches of the given stamp issuers.
+func NewPoolStamper(store storage.Store, issuers []*StampIssuer, signer Signer) Stamper {
+	return &poolStamper{store: store, issuers: issuers, signer: signer}
+}
+
+// Stamp issues the stamp from the batch which already stamped the chunk,
+// or else from the batch with the most room left in the bucket of the chunk.
+// The batches with the full bucket are skipped unless all of them are full,
+// in which case the mutable batches overwrite their oldest stamps.
+func (st *poolStamper) Stamp(addr, idAddr swarm.Address) (*Stamp, error) {
+	for _, issuer := range st.issuers {
+		item := &StampItem{
+			BatchID:      issuer.data.BatchID,
+			chunkAddress: idAddr,
+		}
+		switch err := st.store.Get(item); {
+		case err == nil:
+			return st.stamp(issuer, addr, idAddr)
+		case errors.Is(err, storage.ErrNotFound):
+		default:
+			return nil, fmt.Errorf("get stamp for %s: %w", item, err)
+		}
 	}
-	sig, err := st.signer.Sign(toSign)
+
+	candidates := slices.Clone(st.issuers)
+	for len(candidates) > 0 {
+		best, bestRoom := 0, uint32(0)
+		for i, issuer := range candidates {
+			if room := issuer.bucketRoom(addr); room > bestRoom {
+				best, bestRoom = i, room
+			}
+		}
+		stamp, err := st.stamp(candidates[best], addr, idAddr)
+		if errors.Is(err, ErrBucketFull) || errors.Is(err, ErrReadOnly) {
+			// the bucket is filled by a concurrent upload
+			// or the batch is exported to another node
+			candidates = slices.Delete(candidates, best, best+1)
+			continue
+		}
+		return stamp, err
+	}
+	return nil, ErrBucketFull
+}
+
+func (st *poolStamper) stamp(issuer *StampIssuer, addr, idAddr swarm.Address) (*Stamp, error) {
+	stamp, err := NewStamper(st.store, issuer, st.signer).Stamp(addr, idAddr)
 	if err != nil {
 		return nil, err
 	}
-	return NewStamp(st.issuer.data.BatchID, item.BatchIndex, item.BatchTimestamp, sig), nil
+
+	st.mu.Lock()
+	st.last = stamp.BatchID()
+	st.mu.Unlock()
+
+	return stamp, nil
 }
 
-// BatchId gives back batch id of stamper
-func (st *stamper) BatchId() []byte {
-	return st.issuer.data.BatchID
+// BatchId gives back the batch id of the last issued stamp.
+func (st *poolStamper) BatchId() []byte {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	return st.last
 }
 
 type presignedStamper struct {
@@ -167,3 +167,82 @@ func (t *testStore) Get(item storage.Item) error {
 	}
 	return t.Store.Get(item)
 }
+
+// TestPoolStamper tests that the pool stamper issues the stamps
+// from the batch with the most room left in the bucket of the chunk.
+func TestPoolStamper(t *testing.T) {
+	t.Parallel()
+
+	privKey, err := crypto.GenerateSecp256k1Key()
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer := crypto.NewDefaultSigner(privKey)
+
+	// collision depth is 8, batch depth is 12, bucket volume 2^4
+	newIssuer := func() *postage.StampIssuer {
+		return postage.NewStampIssuer("pool", "", newTestStampIssuer(t, 1000).ID(), big.NewInt(3), 12, 8, 1000, true)
+	}
+
+	t.Run("most room", func(t *testing.T) {
+		t.Parallel()
+
+		store := inmemstore.New()
+		a, b := newIssuer(), newIssuer()
+		pivot := swarm.RandAddress(t)
+
+		// fill the half of the bucket of batch a
+		stamper := postage.NewStamper(store, a, signer)
+		for i := 0; i < 8; i++ {
+			addr := swarm.RandAddressAt(t, pivot, 8)
+			if _, err := stamper.Stamp(addr, addr); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		pool := postage.NewPoolStamper(store, []*postage.StampIssuer{a, b}, signer)
+		addr := swarm.RandAddressAt(t, pivot, 8)
+		stamp, err := pool.Stamp(addr, addr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(stamp.BatchID(), b.ID()) {
+			t.Fatalf("got stamp of batch %x, want %x", stamp.BatchID(), b.ID())
+		}
+		if !bytes.Equal(pool.BatchId(), b.ID()) {
+			t.Fatalf("got batch id %x, want %x", pool.BatchId(), b.ID())
+		}
+
+		// the chunk stamped by batch a keeps being stamped by it
+		addr = swarm.RandAddressAt(t, pivot, 8)
+		if _, err := stamper.Stamp(addr, addr); err != nil {
+			t.Fatal(err)
+		}
+		stamp, err = pool.Stamp(addr, addr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(stamp.BatchID(), a.ID()) {
+			t.Fatalf("got stamp of batch %x, want %x", stamp.BatchID(), a.ID())
+		}
+	})
+
+	t.Run("bucket full", func(t *testing.T) {
+		t.Parallel()
+
+		pool := postage.NewPoolStamper(inmemstore.New(), []*postage.StampIssuer{newIssuer(), newIssuer()}, signer)
+		pivot := swarm.RandAddress(t)
+
+		// both batches fill their buckets before the pool is full
+		for i := 0; i < 32; i++ {
+			addr := swarm.RandAddressAt(t, pivot, 8)
+			if _, err := pool.Stamp(addr, addr); err != nil {
+				t.Fatalf("error adding stamp at step %d: %v", i, err)
+			}
+		}
+		addr := swarm.RandAddressAt(t, pivot, 8)
+		if _, err := pool.Stamp(addr, addr); !errors.Is(err, postage.ErrBucketFull) {
+			t.Fatalf("expected ErrBucketFull, got %v", err)
+		}
+	})
+}
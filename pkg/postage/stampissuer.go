@@ -130,20 +130,23 @@ type stampIssuerData struct {
 	MaxBucketCount uint32   `msgpack:"maxBucketCount"` // the count of the fullest bucket
 	BlockNumber    uint64   `msgpack:"blockNumber"`    // BlockNumber when this batch was created
 	ImmutableFlag  bool     `msgpack:"immutableFlag"`  // Specifies immutability of the created batch.
+	ReadOnly       bool     `msgpack:"readOnly"`       // The issuer was exported and does not issue stamps.
 }
 
 // Clone returns a deep copy of the stampIssuerData.
 func (s stampIssuerData) Clone() stampIssuerData {
 	return stampIssuerData{
-		Label:         s.Label,
-		KeyID:         s.KeyID,
-		BatchID:       append([]byte(nil), s.BatchID...),
-		BatchAmount:   new(big.Int).Set(s.BatchAmount),
-		BatchDepth:    s.BatchDepth,
-		BucketDepth:   s.BucketDepth,
-		Buckets:       append([]uint32(nil), s.Buckets...),
-		BlockNumber:   s.BlockNumber,
-		ImmutableFlag: s.ImmutableFlag,
+		Label:          s.Label,
+		KeyID:          s.KeyID,
+		BatchID:        append([]byte(nil), s.BatchID...),
+		BatchAmount:    new(big.Int).Set(s.BatchAmount),
+		BatchDepth:     s.BatchDepth,
+		BucketDepth:    s.BucketDepth,
+		Buckets:        append([]uint32(nil), s.Buckets...),
+		MaxBucketCount: s.MaxBucketCount,
+		BlockNumber:    s.BlockNumber,
+		ImmutableFlag:  s.ImmutableFlag,
+		ReadOnly:       s.ReadOnly,
 	}
 }
 
@@ -199,6 +202,15 @@ func (si *StampIssuer) increment(addr swarm.Address) (batchIndex []byte, batchTi
 	return indexToBytes(bIdx, bCnt), unixTime(), nil
 }
 
+// bucketRoom returns the number of the stamps which
+// can be issued in the bucket of the given address.
+func (si *StampIssuer) bucketRoom(addr swarm.Address) uint32 {
+	si.mtx.Lock()
+	defer si.mtx.Unlock()
+
+	return si.BucketUpperBound() - si.data.Buckets[toBucket(si.BucketDepth(), addr)]
+}
+
 // Label returns the label of the issuer.
 func (si *StampIssuer) Label() string {
 	return si.data.Label
@@ -260,6 +272,14 @@ func (si *StampIssuer) ImmutableFlag() bool {
 	return si.data.ImmutableFlag
 }
 
+// ReadOnly reports whether the issuer was exported to another
+// node and does not issue stamps anymore.
+func (si *StampIssuer) ReadOnly() bool {
+	si.mtx.Lock()
+	defer si.mtx.Unlock()
+	return si.data.ReadOnly
+}
+
 func (si *StampIssuer) Buckets() []uint32 {
 	si.mtx.Lock()
 	defer si.mtx.Unlock()
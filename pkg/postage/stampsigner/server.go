@@ -0,0 +1,76 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stampsigner
+
+import (
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+
+	"github.com/ethersphere/bee/v2/pkg/crypto"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/log"
+)
+
+// digestSize is the size of the signed data, the stamp digests only
+// are signed so that the key of the batch owner signs nothing else.
+const digestSize = 32
+
+// NewHandler returns the stand-in implementation of the stamp signer
+// service, signing the stamps with the given signer for the clients
+// with the given bearer token. It holds no state.
+func NewHandler(signer crypto.Signer, token string, logger log.Logger) http.Handler {
+	logger = logger.WithName("stampsigner").Register()
+
+	mux := http.NewServeMux()
+	mux.Handle("/address", jsonhttp.MethodHandler{
+		"GET": http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			address, err := signer.EthereumAddress()
+			if err != nil {
+				logger.Error(err, "ethereum address")
+				jsonhttp.InternalServerError(w, "ethereum address")
+				return
+			}
+			jsonhttp.OK(w, addressResponse{Address: address})
+		}),
+	})
+	mux.Handle("/sign", jsonhttp.MethodHandler{
+		"POST": http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var req signRequest
+			if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1024)).Decode(&req); err != nil {
+				jsonhttp.BadRequest(w, "invalid request")
+				return
+			}
+			data, err := hex.DecodeString(req.Data)
+			if err != nil || len(data) != digestSize {
+				jsonhttp.BadRequest(w, "invalid data")
+				return
+			}
+			sig, err := signer.Sign(data)
+			if err != nil {
+				logger.Error(err, "sign")
+				jsonhttp.InternalServerError(w, "sign")
+				return
+			}
+			jsonhttp.OK(w, signResponse{Signature: hex.EncodeToString(sig)})
+		}),
+	})
+	return authorize(token, mux)
+}
+
+// authorize lets through only the requests with the given bearer token,
+// none if the token is empty.
+func authorize(token string, h http.Handler) http.Handler {
+	want := []byte(bearerPrefix + token)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got := []byte(r.Header.Get("Authorization"))
+		if token == "" || subtle.ConstantTimeCompare(got, want) != 1 {
+			jsonhttp.Unauthorized(w, "unauthorized")
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,162 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package stampsigner provides the client of a remote service signing the
+// postage stamps with the key of the batch owner and a stand-in
+// implementation of the service. The node keeps tracking the bucket
+// indices, the service only signs the stamp digests.
+//
+// The service exposes two endpoints:
+//
+//	GET  /address returns {"address": "<hex ethereum address of the batch owner>"}
+//	POST /sign    takes {"data": "<hex 32 bytes digest>"} and returns {"signature": "<hex signature>"}
+//
+// The requests carry the "Authorization: Bearer <token>" header. The signature
+// is the 65 bytes ethereum signed message signature of the digest, as produced
+// by crypto.Signer.
+package stampsigner
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethersphere/bee/v2/pkg/crypto"
+	"github.com/ethersphere/bee/v2/pkg/postage"
+)
+
+const (
+	requestTimeout = 10 * time.Second
+	bearerPrefix   = "Bearer "
+)
+
+// ErrInvalidSignature is returned when the signature returned by the
+// service is not made with the key of the batch owner.
+var ErrInvalidSignature = errors.New("stamp signer: invalid signature")
+
+type addressResponse struct {
+	Address common.Address `json:"address"`
+}
+
+type signRequest struct {
+	Data string `json:"data"`
+}
+
+type signResponse struct {
+	Signature string `json:"signature"`
+}
+
+var _ postage.Signer = (*Client)(nil)
+
+// Client signs the stamps with the remote stamp signer service.
+type Client struct {
+	endpoint string
+	token    string
+	client   *http.Client
+
+	mu      sync.Mutex
+	address *common.Address // address of the batch owner, fetched once
+}
+
+// New constructs the Client of the service at the given
+// endpoint which authorizes the given bearer token.
+func New(endpoint, token string, client *http.Client) *Client {
+	if client == nil {
+		client = &http.Client{Timeout: requestTimeout}
+	}
+	return &Client{
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		token:    token,
+		client:   client,
+	}
+}
+
+// EthereumAddress returns the address of the batch owner whose key signs the stamps.
+func (c *Client) EthereumAddress() (common.Address, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.address != nil {
+		return *c.address, nil
+	}
+
+	var resp addressResponse
+	if err := c.do(http.MethodGet, "/address", nil, &resp); err != nil {
+		return common.Address{}, fmt.Errorf("stamp signer: get address: %w", err)
+	}
+	c.address = &resp.Address
+	return resp.Address, nil
+}
+
+// Sign returns the signature of the data made by the service. The signature
+// is verified against the address of the batch owner, so a misconfigured
+// service does not produce the stamps rejected by the network.
+func (c *Client) Sign(data []byte) ([]byte, error) {
+	owner, err := c.EthereumAddress()
+	if err != nil {
+		return nil, err
+	}
+
+	var resp signResponse
+	if err := c.do(http.MethodPost, "/sign", signRequest{Data: hex.EncodeToString(data)}, &resp); err != nil {
+		return nil, fmt.Errorf("stamp signer: sign: %w", err)
+	}
+	sig, err := hex.DecodeString(resp.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("stamp signer: decode signature: %w", err)
+	}
+
+	pubKey, err := crypto.Recover(sig, data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSignature, err)
+	}
+	signer, err := crypto.NewEthereumAddress(*pubKey)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(signer, owner.Bytes()) {
+		return nil, ErrInvalidSignature
+	}
+	return sig, nil
+}
+
+func (c *Client) do(method, path string, body, v any) error {
+	var buf bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&buf).Encode(body); err != nil {
+			return err
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, method, c.endpoint+path, &buf)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", bearerPrefix+c.token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected response status %s", res.Status)
+	}
+	return json.NewDecoder(res.Body).Decode(v)
+}
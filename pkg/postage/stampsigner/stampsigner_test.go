@@ -0,0 +1,115 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stampsigner_test
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/crypto"
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/postage"
+	"github.com/ethersphere/bee/v2/pkg/postage/stampsigner"
+	"github.com/ethersphere/bee/v2/pkg/storage/inmemstore"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+func newSigner(t *testing.T) crypto.Signer {
+	t.Helper()
+
+	key, err := crypto.GenerateSecp256k1Key()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return crypto.NewDefaultSigner(key)
+}
+
+func TestClient(t *testing.T) {
+	t.Parallel()
+
+	signer := newSigner(t)
+	ts := httptest.NewServer(stampsigner.NewHandler(signer, "token", log.Noop))
+	t.Cleanup(ts.Close)
+
+	client := stampsigner.New(ts.URL, "token", ts.Client())
+
+	want, err := signer.EthereumAddress()
+	if err != nil {
+		t.Fatal(err)
+	}
+	have, err := client.EthereumAddress()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have != want {
+		t.Fatalf("address mismatch: have %s, want %s", have, want)
+	}
+
+	t.Run("stamp", func(t *testing.T) {
+		t.Parallel()
+
+		batchID := swarm.RandAddress(t).Bytes()
+		issuer := postage.NewStampIssuer("label", "", batchID, big.NewInt(3), 16, 8, 1000, true)
+		chunk := swarm.RandAddress(t)
+
+		stamp, err := postage.NewStamper(inmemstore.New(), issuer, client).Stamp(chunk, chunk)
+		if err != nil {
+			t.Fatal(err)
+		}
+		owner, err := postage.RecoverBatchOwner(chunk, stamp)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(owner, want.Bytes()) {
+			t.Fatalf("stamp owner mismatch: have %x, want %s", owner, want)
+		}
+	})
+
+	t.Run("invalid data", func(t *testing.T) {
+		t.Parallel()
+
+		for _, data := range [][]byte{nil, make([]byte, 31), make([]byte, 33)} {
+			if _, err := client.Sign(data); err == nil {
+				t.Fatalf("expected error signing %d bytes of data", len(data))
+			}
+		}
+	})
+
+	t.Run("unauthorized", func(t *testing.T) {
+		t.Parallel()
+
+		for _, token := range []string{"", "other"} {
+			_, err := stampsigner.New(ts.URL, token, ts.Client()).Sign(swarm.RandAddress(t).Bytes())
+			if err == nil {
+				t.Fatalf("expected error signing with token %q", token)
+			}
+		}
+	})
+}
+
+func TestClientInvalidSignature(t *testing.T) {
+	t.Parallel()
+
+	// the service advertises a different address than the one of its key
+	handler := stampsigner.NewHandler(newSigner(t), "token", log.Noop)
+	other := stampsigner.NewHandler(newSigner(t), "token", log.Noop)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/address" {
+			other.ServeHTTP(w, r)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	}))
+	t.Cleanup(ts.Close)
+
+	_, err := stampsigner.New(ts.URL, "token", ts.Client()).Sign(swarm.RandAddress(t).Bytes())
+	if !errors.Is(err, stampsigner.ErrInvalidSignature) {
+		t.Fatalf("want %v, got %v", stampsigner.ErrInvalidSignature, err)
+	}
+}
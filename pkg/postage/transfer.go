@@ -0,0 +1,243 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package postage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// issuerExportVersion is the version of the stamp issuer export format.
+const issuerExportVersion = 1
+
+var (
+	// ErrReadOnly is the error returned when stamping with
+	// a stamp issuer which was exported to another node.
+	ErrReadOnly = errors.New("stamp issuer is read-only")
+	// ErrIssuerExists is the error returned when importing a stamp
+	// issuer which is already present and not read-only.
+	ErrIssuerExists = errors.New("stamp issuer already exists")
+	// ErrInvalidExport is the error returned when importing malformed data.
+	ErrInvalidExport = errors.New("invalid stamp issuer export")
+	// ErrNotExported is the error returned when reverting the export
+	// of a stamp issuer which is not read-only.
+	ErrNotExported = errors.New("stamp issuer not exported")
+	// ErrNotBatchOwner is the error returned when importing the stamp issuer
+	// of a batch which is not owned by the stamp signer of the node.
+	ErrNotBatchOwner = errors.New("stamp signer is not the batch owner")
+)
+
+// issuerExportHeader is the first record of a stamp issuer export.
+type issuerExportHeader struct {
+	Version uint8           `msgpack:"version"`
+	Issuer  stampIssuerData `msgpack:"issuer"`
+}
+
+// issuerExportItem is a stamp item record of a stamp issuer export.
+// Every record is preceded by a true boolean, the last one is
+// followed by a false boolean.
+type issuerExportItem struct {
+	ChunkAddress   []byte `msgpack:"chunkAddress"`
+	BatchIndex     []byte `msgpack:"batchIndex"`
+	BatchTimestamp []byte `msgpack:"batchTimestamp"`
+}
+
+// ExportIssuer writes the stamp issuer of the given batch with its stamp items
+// from the store to w. The stamp issuer is marked read-only before it is
+// written, so no more stamps are issued from it on this node. A read-only
+// stamp issuer is not exported again, so that its state is not imported by
+// two nodes.
+func ExportIssuer(ctx context.Context, store storage.Store, batchID []byte, w io.Writer) error {
+	item := NewStampIssuerItem(batchID)
+	switch err := store.Get(item); {
+	case errors.Is(err, storage.ErrNotFound):
+		return ErrNotFound
+	case err != nil:
+		return fmt.Errorf("get stamp issuer: %w", err)
+	}
+	return exportIssuer(ctx, store, item.Issuer, w)
+}
+
+// RevertExport marks the read-only stamp issuer of the given batch in the
+// store writable again. It must be called only when the export was not
+// imported by another node, as the batch would be issued from by both.
+func RevertExport(store storage.Store, batchID []byte) error {
+	item := NewStampIssuerItem(batchID)
+	switch err := store.Get(item); {
+	case errors.Is(err, storage.ErrNotFound):
+		return ErrNotFound
+	case err != nil:
+		return fmt.Errorf("get stamp issuer: %w", err)
+	}
+	return revertExport(store, item.Issuer)
+}
+
+// ImportIssuer reads the stamp issuer export from r into the store and
+// returns the imported stamp issuer. The stamp issuer already present in the
+// store is replaced only when it is read-only.
+func ImportIssuer(ctx context.Context, store storage.Store, r io.Reader) (*StampIssuer, error) {
+	return importIssuer(ctx, store, r, func(batchID []byte) error {
+		item := NewStampIssuerItem(batchID)
+		switch err := store.Get(item); {
+		case errors.Is(err, storage.ErrNotFound):
+			return nil
+		case err != nil:
+			return fmt.Errorf("get stamp issuer: %w", err)
+		case !item.Issuer.ReadOnly():
+			return ErrIssuerExists
+		}
+		return nil
+	})
+}
+
+// exportIssuer marks the issuer read-only, persists it and writes it
+// together with its stamp items to w. As the read-only issuer does not
+// issue stamps, the stamp items do not change while they are written.
+// The issuer is made writable again if the export is not completely
+// written, as then no other node can import it.
+func exportIssuer(ctx context.Context, store storage.Store, issuer *StampIssuer, w io.Writer) (err error) {
+	issuer.mtx.Lock()
+	if issuer.data.ReadOnly {
+		issuer.mtx.Unlock()
+		return ErrReadOnly
+	}
+	issuer.data.ReadOnly = true
+	err = store.Put(&StampIssuerItem{Issuer: issuer})
+	data := issuer.data.Clone()
+	issuer.mtx.Unlock()
+	if err != nil {
+		return fmt.Errorf("mark stamp issuer read-only: %w", err)
+	}
+	data.ReadOnly = false
+
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, revertExport(store, issuer))
+		}
+	}()
+
+	enc := msgpack.NewEncoder(w)
+	if err := enc.Encode(issuerExportHeader{Version: issuerExportVersion, Issuer: data}); err != nil {
+		return fmt.Errorf("write stamp issuer: %w", err)
+	}
+
+	err = store.Iterate(
+		storage.Query{
+			Factory: func() storage.Item { return new(StampItem) },
+			Prefix:  string(data.BatchID),
+		}, func(result storage.Result) (bool, error) {
+			if err := ctx.Err(); err != nil {
+				return true, err
+			}
+			item := result.Entry.(*StampItem)
+			if err := enc.EncodeBool(true); err != nil {
+				return true, err
+			}
+			return false, enc.Encode(issuerExportItem{
+				ChunkAddress:   item.chunkAddress.Bytes(),
+				BatchIndex:     item.BatchIndex,
+				BatchTimestamp: item.BatchTimestamp,
+			})
+		})
+	if err != nil {
+		return fmt.Errorf("write stamp items: %w", err)
+	}
+	if err := enc.EncodeBool(false); err != nil {
+		return fmt.Errorf("write stamp items: %w", err)
+	}
+	return nil
+}
+
+// revertExport marks the read-only issuer writable again and persists it.
+func revertExport(store storage.Store, issuer *StampIssuer) error {
+	issuer.mtx.Lock()
+	defer issuer.mtx.Unlock()
+
+	if !issuer.data.ReadOnly {
+		return ErrNotExported
+	}
+	issuer.data.ReadOnly = false
+	if err := store.Put(&StampIssuerItem{Issuer: issuer}); err != nil {
+		issuer.data.ReadOnly = true
+		return fmt.Errorf("mark stamp issuer writable: %w", err)
+	}
+	return nil
+}
+
+// importIssuer reads the stamp issuer export from r into the store. The check
+// function is called with the batch ID before anything is written. The stamp
+// issuer is stored after all of its stamp items, so an interrupted import
+// leaves no usable stamp issuer behind.
+func importIssuer(ctx context.Context, store storage.Store, r io.Reader, check func([]byte) error) (*StampIssuer, error) {
+	dec := msgpack.NewDecoder(r)
+
+	var header issuerExportHeader
+	if err := dec.Decode(&header); err != nil {
+		return nil, fmt.Errorf("%w: read stamp issuer: %w", ErrInvalidExport, err)
+	}
+	data := header.Issuer
+	switch {
+	case header.Version != issuerExportVersion:
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidExport, header.Version)
+	case len(data.BatchID) != swarm.HashSize,
+		data.BatchAmount == nil,
+		data.BucketDepth >= data.BatchDepth,
+		len(data.Buckets) != 1<<data.BucketDepth:
+		return nil, fmt.Errorf("%w: malformed stamp issuer", ErrInvalidExport)
+	}
+	data.ReadOnly = false
+
+	if err := check(data.BatchID); err != nil {
+		return nil, err
+	}
+
+	for {
+		more, err := dec.DecodeBool()
+		if err != nil {
+			return nil, fmt.Errorf("%w: read stamp items: %w", ErrInvalidExport, err)
+		}
+		if !more {
+			break
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		var rec issuerExportItem
+		if err := dec.Decode(&rec); err != nil {
+			return nil, fmt.Errorf("%w: read stamp item: %w", ErrInvalidExport, err)
+		}
+		if len(rec.ChunkAddress) != swarm.HashSize ||
+			len(rec.BatchIndex) != swarm.StampIndexSize ||
+			len(rec.BatchTimestamp) != swarm.StampTimestampSize {
+			return nil, fmt.Errorf("%w: malformed stamp item", ErrInvalidExport)
+		}
+		if bucket, _ := BucketIndexFromBytes(rec.BatchIndex); bucket >= uint32(len(data.Buckets)) {
+			return nil, fmt.Errorf("%w: stamp item bucket %d out of range", ErrInvalidExport, bucket)
+		}
+
+		err = store.Put(&StampItem{
+			BatchID:        data.BatchID,
+			chunkAddress:   swarm.NewAddress(rec.ChunkAddress),
+			BatchIndex:     rec.BatchIndex,
+			BatchTimestamp: rec.BatchTimestamp,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("put stamp item: %w", err)
+		}
+	}
+
+	issuer := &StampIssuer{data: data}
+	if err := store.Put(&StampIssuerItem{Issuer: issuer}); err != nil {
+		return nil, fmt.Errorf("put stamp issuer: %w", err)
+	}
+	return issuer, nil
+}
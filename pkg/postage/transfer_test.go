@@ -0,0 +1,255 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package postage_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"slices"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/crypto"
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/postage"
+	pstoremock "github.com/ethersphere/bee/v2/pkg/postage/batchstore/mock"
+	"github.com/ethersphere/bee/v2/pkg/storage/inmemstore"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+func TestExportImport(t *testing.T) {
+	t.Parallel()
+
+	privKey, err := crypto.GenerateSecp256k1Key()
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer := crypto.NewDefaultSigner(privKey)
+	owner, err := signer.EthereumAddress()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	issuer := newTestStampIssuer(t, 1000)
+	batchStore := pstoremock.New(pstoremock.WithBatch(&postage.Batch{ID: issuer.ID(), Owner: owner.Bytes()}))
+
+	newService := func(t *testing.T) (postage.Service, *inmemstore.Store) {
+		t.Helper()
+		store := inmemstore.New()
+		ps, err := postage.NewService(log.Noop, store, batchStore, 1, owner.Bytes())
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ps, store
+	}
+
+	source, sourceStore := newService(t)
+	if err := source.Add(issuer); err != nil {
+		t.Fatal(err)
+	}
+
+	chunks := make([]swarm.Address, 10)
+	stamps := make([]*postage.Stamp, len(chunks))
+	for i := range chunks {
+		chunks[i] = swarm.RandAddress(t)
+		stamps[i], err = postage.NewStamper(sourceStore, issuer, signer).Stamp(chunks[i], chunks[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var export bytes.Buffer
+	if err := source.Export(context.Background(), issuer.ID(), &export); err != nil {
+		t.Fatal(err)
+	}
+	exported := slices.Clone(export.Bytes())
+
+	t.Run("source is read-only", func(t *testing.T) {
+		if !issuer.ReadOnly() {
+			t.Fatal("exported issuer is not read-only")
+		}
+		_, err := postage.NewStamper(sourceStore, issuer, signer).Stamp(chunks[0], chunks[0])
+		if !errors.Is(err, postage.ErrReadOnly) {
+			t.Fatalf("want %v, got %v", postage.ErrReadOnly, err)
+		}
+	})
+
+	t.Run("export twice", func(t *testing.T) {
+		err := source.Export(context.Background(), issuer.ID(), io.Discard)
+		if !errors.Is(err, postage.ErrReadOnly) {
+			t.Fatalf("want %v, got %v", postage.ErrReadOnly, err)
+		}
+	})
+
+	t.Run("not batch owner", func(t *testing.T) {
+		ps, err := postage.NewService(log.Noop, inmemstore.New(), batchStore, 1, swarm.RandAddress(t).Bytes()[:20])
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = ps.Import(context.Background(), bytes.NewReader(exported))
+		if !errors.Is(err, postage.ErrNotBatchOwner) {
+			t.Fatalf("want %v, got %v", postage.ErrNotBatchOwner, err)
+		}
+	})
+
+	t.Run("unknown batch", func(t *testing.T) {
+		ps, err := postage.NewService(log.Noop, inmemstore.New(), pstoremock.New(), 1, owner.Bytes())
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = ps.Import(context.Background(), bytes.NewReader(exported))
+		if !errors.Is(err, postage.ErrNotFound) {
+			t.Fatalf("want %v, got %v", postage.ErrNotFound, err)
+		}
+	})
+
+	target, targetStore := newService(t)
+	imported, err := target.Import(context.Background(), bytes.NewReader(exported))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("target state", func(t *testing.T) {
+		if imported.ReadOnly() {
+			t.Fatal("imported issuer is read-only")
+		}
+		if !slices.Equal(imported.Buckets(), issuer.Buckets()) {
+			t.Fatal("buckets mismatch")
+		}
+		if imported.Utilization() != issuer.Utilization() {
+			t.Fatalf("utilization mismatch: want %d, got %d", issuer.Utilization(), imported.Utilization())
+		}
+
+		for i, chunk := range chunks {
+			stamp, err := postage.NewStamper(targetStore, imported, signer).Stamp(chunk, chunk)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(stamp.Index(), stamps[i].Index()) {
+				t.Fatalf("chunk %d restamped with index %x, want %x", i, stamp.Index(), stamps[i].Index())
+			}
+		}
+		if _, err := postage.NewStamper(targetStore, imported, signer).Stamp(swarm.RandAddress(t), swarm.RandAddress(t)); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("import twice", func(t *testing.T) {
+		_, err := target.Import(context.Background(), bytes.NewReader(exported))
+		if !errors.Is(err, postage.ErrIssuerExists) {
+			t.Fatalf("want %v, got %v", postage.ErrIssuerExists, err)
+		}
+	})
+
+	t.Run("import back", func(t *testing.T) {
+		var export bytes.Buffer
+		if err := target.Export(context.Background(), imported.ID(), &export); err != nil {
+			t.Fatal(err)
+		}
+		back, err := source.Import(context.Background(), &export)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if back.ReadOnly() {
+			t.Fatal("imported issuer is read-only")
+		}
+		if !slices.Contains(source.StampIssuers(), back) || slices.Contains(source.StampIssuers(), issuer) {
+			t.Fatal("read-only issuer is not replaced")
+		}
+	})
+
+	t.Run("store functions", func(t *testing.T) {
+		stored := postage.NewStampIssuerItem(issuer.ID())
+		if err := sourceStore.Get(stored); err != nil {
+			t.Fatal(err)
+		}
+
+		var export bytes.Buffer
+		if err := postage.ExportIssuer(context.Background(), sourceStore, issuer.ID(), &export); err != nil {
+			t.Fatal(err)
+		}
+		store := inmemstore.New()
+		if _, err := postage.ImportIssuer(context.Background(), store, &export); err != nil {
+			t.Fatal(err)
+		}
+		item := postage.NewStampIssuerItem(issuer.ID())
+		if err := store.Get(item); err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(item.Issuer.Buckets(), stored.Issuer.Buckets()) {
+			t.Fatal("buckets mismatch")
+		}
+
+		err := postage.ExportIssuer(context.Background(), store, swarm.RandAddress(t).Bytes(), &export)
+		if !errors.Is(err, postage.ErrNotFound) {
+			t.Fatalf("want %v, got %v", postage.ErrNotFound, err)
+		}
+	})
+
+	t.Run("interrupted export", func(t *testing.T) {
+		ps, store := newService(t)
+		issuer := newTestStampIssuer(t, 1000)
+		if err := ps.Add(issuer); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := postage.NewStamper(store, issuer, signer).Stamp(chunks[0], chunks[0]); err != nil {
+			t.Fatal(err)
+		}
+
+		err := ps.Export(context.Background(), issuer.ID(), failingWriter{})
+		if !errors.Is(err, errWrite) {
+			t.Fatalf("want %v, got %v", errWrite, err)
+		}
+		if issuer.ReadOnly() {
+			t.Fatal("issuer of the interrupted export is read-only")
+		}
+	})
+
+	t.Run("revert export", func(t *testing.T) {
+		ps, store := newService(t)
+		issuer := newTestStampIssuer(t, 1000)
+		if err := ps.Add(issuer); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := ps.RevertExport(issuer.ID()); !errors.Is(err, postage.ErrNotExported) {
+			t.Fatalf("want %v, got %v", postage.ErrNotExported, err)
+		}
+		if err := ps.Export(context.Background(), issuer.ID(), io.Discard); err != nil {
+			t.Fatal(err)
+		}
+		if err := ps.RevertExport(issuer.ID()); err != nil {
+			t.Fatal(err)
+		}
+		if issuer.ReadOnly() {
+			t.Fatal("reverted issuer is read-only")
+		}
+		if _, err := postage.NewStamper(store, issuer, signer).Stamp(chunks[0], chunks[0]); err != nil {
+			t.Fatal(err)
+		}
+		item := postage.NewStampIssuerItem(issuer.ID())
+		if err := store.Get(item); err != nil {
+			t.Fatal(err)
+		}
+		if item.Issuer.ReadOnly() {
+			t.Fatal("stored reverted issuer is read-only")
+		}
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		_, err := postage.ImportIssuer(context.Background(), inmemstore.New(), bytes.NewReader(exported[:len(exported)-1]))
+		if !errors.Is(err, postage.ErrInvalidExport) {
+			t.Fatalf("want %v, got %v", postage.ErrInvalidExport, err)
+		}
+	})
+}
+
+var errWrite = errors.New("write failed")
+
+// failingWriter fails all the writes like a dropped connection.
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errWrite }
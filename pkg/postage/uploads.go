@@ -0,0 +1,165 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package postage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+
+	"github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// errUploadItemBatchIDInvalid is returned when trying
+// to marshal an UploadItem with invalid batchID.
+var errUploadItemBatchIDInvalid = errors.New("marshal postage.UploadItem: batchID is invalid")
+
+// Upload is the root reference uploaded with the stamps of a batch.
+type Upload struct {
+	Reference swarm.Address
+	TagID     uint64
+	Timestamp int64  // Unix time of the upload in nanoseconds.
+	Chunks    uint64 // Number of the chunks stamped with the batch.
+	Size      uint64 // Size of the data of the chunks stamped with the batch.
+}
+
+// uploadItemData is the serialized form of the UploadItem.
+type uploadItemData struct {
+	BatchID   []byte `msgpack:"batchID"`
+	Reference []byte `msgpack:"reference"`
+	TagID     uint64 `msgpack:"tagID"`
+	Timestamp int64  `msgpack:"timestamp"`
+	Chunks    uint64 `msgpack:"chunks"`
+	Size      uint64 `msgpack:"size"`
+}
+
+// UploadItem is a storage.Item recording an Upload of the batch.
+// The items of a batch are ordered by the time of the upload.
+type UploadItem struct {
+	BatchID []byte
+	Upload  Upload
+}
+
+// ID implements the storage.Item interface.
+func (u *UploadItem) ID() string {
+	return fmt.Sprintf("%s/%020d/%s", string(u.BatchID), u.Upload.Timestamp, u.Upload.Reference)
+}
+
+// Namespace implements the storage.Item interface.
+func (u *UploadItem) Namespace() string {
+	return "batchUpload"
+}
+
+// Marshal implements the storage.Item interface.
+func (u *UploadItem) Marshal() ([]byte, error) {
+	if len(u.BatchID) != swarm.HashSize {
+		return nil, errUploadItemBatchIDInvalid
+	}
+	return msgpack.Marshal(uploadItemData{
+		BatchID:   u.BatchID,
+		Reference: u.Upload.Reference.Bytes(),
+		TagID:     u.Upload.TagID,
+		Timestamp: u.Upload.Timestamp,
+		Chunks:    u.Upload.Chunks,
+		Size:      u.Upload.Size,
+	})
+}
+
+// Unmarshal implements the storage.Item interface.
+func (u *UploadItem) Unmarshal(data []byte) error {
+	var v uploadItemData
+	if err := msgpack.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	u.BatchID = v.BatchID
+	u.Upload = Upload{
+		Reference: swarm.NewAddress(v.Reference),
+		TagID:     v.TagID,
+		Timestamp: v.Timestamp,
+		Chunks:    v.Chunks,
+		Size:      v.Size,
+	}
+	return nil
+}
+
+// Clone implements the storage.Item interface.
+func (u *UploadItem) Clone() storage.Item {
+	if u == nil {
+		return nil
+	}
+	upload := u.Upload
+	upload.Reference = u.Upload.Reference.Clone()
+	return &UploadItem{
+		BatchID: append([]byte(nil), u.BatchID...),
+		Upload:  upload,
+	}
+}
+
+// String implements the fmt.Stringer interface.
+func (u UploadItem) String() string {
+	return path.Join(u.Namespace(), u.ID())
+}
+
+var _ storage.Item = (*UploadItem)(nil)
+
+// RecordUpload records the upload made with the stamps of the batch.
+func RecordUpload(store storage.Store, batchID []byte, upload Upload) error {
+	return store.Put(&UploadItem{BatchID: batchID, Upload: upload})
+}
+
+// BatchUploads returns the uploads made with the stamps of the batch
+// ordered by their time, skipping the first offset ones and returning
+// at most limit of them.
+func BatchUploads(ctx context.Context, store storage.Store, batchID []byte, offset, limit int) ([]Upload, error) {
+	uploads := make([]Upload, 0)
+	err := store.Iterate(
+		storage.Query{
+			Factory: func() storage.Item { return new(UploadItem) },
+			Prefix:  string(batchID) + "/",
+		}, func(result storage.Result) (bool, error) {
+			if err := ctx.Err(); err != nil {
+				return true, err
+			}
+			if offset > 0 {
+				offset--
+				return false, nil
+			}
+			uploads = append(uploads, result.Entry.(*UploadItem).Upload)
+			return len(uploads) >= limit, nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("iterate uploads: %w", err)
+	}
+	return uploads, nil
+}
+
+// removeUploads removes the uploads recorded for the batch.
+func removeUploads(ctx context.Context, store storage.Store, batchID []byte) error {
+	var items []*UploadItem
+	err := store.Iterate(
+		storage.Query{
+			Factory: func() storage.Item { return new(UploadItem) },
+			Prefix:  string(batchID) + "/",
+		}, func(result storage.Result) (bool, error) {
+			if err := ctx.Err(); err != nil {
+				return true, err
+			}
+			items = append(items, result.Entry.(*UploadItem))
+			return false, nil
+		})
+	if err != nil {
+		return fmt.Errorf("iterate uploads: %w", err)
+	}
+
+	for _, item := range items {
+		if err := store.Delete(item); err != nil {
+			return fmt.Errorf("delete upload %s: %w", item, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package postage_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/postage"
+	pstoremock "github.com/ethersphere/bee/v2/pkg/postage/batchstore/mock"
+	"github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/storage/inmemstore"
+	"github.com/ethersphere/bee/v2/pkg/storage/storagetest"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+func TestUploadItem(t *testing.T) {
+	t.Parallel()
+
+	storagetest.TestItemMarshalAndUnmarshal(t, &storagetest.ItemMarshalAndUnmarshalTest{
+		Item: &postage.UploadItem{
+			BatchID: swarm.RandAddress(t).Bytes(),
+			Upload: postage.Upload{
+				Reference: swarm.RandAddress(t),
+				TagID:     3,
+				Timestamp: 1700000000000000000,
+				Chunks:    5,
+				Size:      20000,
+			},
+		},
+		Factory: func() storage.Item { return new(postage.UploadItem) },
+	})
+}
+
+func TestBatchUploads(t *testing.T) {
+	t.Parallel()
+
+	store := inmemstore.New()
+	batchID := swarm.RandAddress(t).Bytes()
+	otherID := swarm.RandAddress(t).Bytes()
+
+	var refs []swarm.Address
+	for i := range 5 {
+		ref := swarm.RandAddress(t)
+		refs = append(refs, ref)
+		if err := postage.RecordUpload(store, batchID, postage.Upload{Reference: ref, Timestamp: int64(i + 1), Chunks: 1}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := postage.RecordUpload(store, otherID, postage.Upload{Reference: swarm.RandAddress(t), Timestamp: 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	uploads, err := postage.BatchUploads(context.Background(), store, batchID, 1, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(uploads) != 3 {
+		t.Fatalf("got %d uploads, want 3", len(uploads))
+	}
+	for i, u := range uploads {
+		if !u.Reference.Equal(refs[i+1]) {
+			t.Fatalf("upload %d: got reference %s, want %s", i, u.Reference, refs[i+1])
+		}
+	}
+
+	// the uploads are removed with the expired batch
+	ps, err := postage.NewService(log.Noop, store, pstoremock.New(), 1, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ps.Add(newTestStampIssuerID(t, 1000, batchID)); err != nil {
+		t.Fatal(err)
+	}
+	if err := ps.HandleStampExpiry(context.Background(), batchID); err != nil {
+		t.Fatal(err)
+	}
+
+	uploads, err = postage.BatchUploads(context.Background(), store, batchID, 0, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(uploads) != 0 {
+		t.Fatalf("got %d uploads of the expired batch, want none", len(uploads))
+	}
+	uploads, err = postage.BatchUploads(context.Background(), store, otherID, 0, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(uploads) != 1 {
+		t.Fatalf("got %d uploads of the other batch, want 1", len(uploads))
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/ethersphere/bee/v2/pkg/postage"
 	"github.com/ethersphere/bee/v2/pkg/pullsync/pb"
 	"github.com/ethersphere/bee/v2/pkg/ratelimit"
+	"github.com/ethersphere/bee/v2/pkg/reputation"
 	"github.com/ethersphere/bee/v2/pkg/soc"
 	"github.com/ethersphere/bee/v2/pkg/storage"
 	"github.com/ethersphere/bee/v2/pkg/storer"
@@ -80,6 +81,8 @@ type Syncer struct {
 
 	limiter *ratelimit.Limiter
 
+	reputation reputation.Recorder
+
 	Interface
 	io.Closer
 }
@@ -105,9 +108,15 @@ func New(
 		quit:        make(chan struct{}),
 		maxPage:     maxPage,
 		limiter:     ratelimit.New(handleRequestsLimitRate, int(maxPage)),
+		reputation:  reputation.NopRecorder,
 	}
 }
 
+// SetReputationRecorder sets the recorder of the peers sending invalid stamps.
+func (s *Syncer) SetReputationRecorder(r reputation.Recorder) {
+	s.reputation = r
+}
+
 func (s *Syncer) Protocol() p2p.ProtocolSpec {
 	return p2p.ProtocolSpec{
 		Name:    protocolName,
@@ -314,7 +323,15 @@ func (s *Syncer) Sync(ctx context.Context, peer swarm.Address, bin uint8, start
 
 	chunksToPut := make([]swarm.Chunk, 0, ctr)
 
-	var chunkErr error
+	var (
+		chunkErr error
+		forged   bool // the offer page is penalized once, whatever the number of forged stamps
+	)
+	defer func() {
+		if forged {
+			s.reputation.Record(peer, reputation.EventInvalidStamp)
+		}
+	}()
 	for ; ctr > 0; ctr-- {
 		var delivery pb.Delivery
 		if err = r.ReadMsgWithContext(ctx, &delivery); err != nil {
@@ -353,6 +370,7 @@ func (s *Syncer) Sync(ctx context.Context, peer swarm.Address, bin uint8, start
 		chunk, err := s.validStamp(newChunk.WithStamp(stamp))
 		if err != nil {
 			s.logger.Debug("unverified stamp", "error", err, "peer_address", peer, "chunk_address", newChunk)
+			forged = forged || postage.IsForged(err)
 			chunkErr = errors.Join(chunkErr, err)
 			continue
 		}
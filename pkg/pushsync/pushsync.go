@@ -22,12 +22,14 @@ import (
 	"github.com/ethersphere/bee/v2/pkg/postage"
 	"github.com/ethersphere/bee/v2/pkg/pricer"
 	"github.com/ethersphere/bee/v2/pkg/pushsync/pb"
+	"github.com/ethersphere/bee/v2/pkg/reputation"
 	"github.com/ethersphere/bee/v2/pkg/skippeers"
 	"github.com/ethersphere/bee/v2/pkg/soc"
 	"github.com/ethersphere/bee/v2/pkg/stabilization"
 	"github.com/ethersphere/bee/v2/pkg/storage"
 	"github.com/ethersphere/bee/v2/pkg/swarm"
 	"github.com/ethersphere/bee/v2/pkg/topology"
+	"github.com/ethersphere/bee/v2/pkg/topology/peerscore"
 	"github.com/ethersphere/bee/v2/pkg/tracing"
 	"github.com/opentracing/opentracing-go"
 	"github.com/opentracing/opentracing-go/ext"
@@ -60,6 +62,7 @@ var (
 	ErrOutOfDepthStoring = errors.New("storing outside of the neighborhood")
 	ErrWarmup            = errors.New("node warmup time not complete")
 	ErrShallowReceipt    = errors.New("shallow receipt")
+	ErrInvalidReceipt    = errors.New("invalid receipt")
 )
 
 type PushSyncer interface {
@@ -97,6 +100,8 @@ type PushSync struct {
 	fullNode       bool
 	errSkip        *skippeers.List
 	stabilizer     stabilization.Subscriber
+	scores         peerscore.Recorder
+	reputation     reputation.Recorder
 
 	shallowReceiptTolerance uint8
 }
@@ -147,6 +152,8 @@ func New(
 		signer:                  signer,
 		errSkip:                 skippeers.NewList(time.Minute),
 		stabilizer:              stabilizer,
+		scores:                  peerscore.NopRecorder,
+		reputation:              reputation.NopRecorder,
 		shallowReceiptTolerance: shallowReceiptTolerance,
 	}
 
@@ -154,6 +161,18 @@ func New(
 	return ps
 }
 
+// SetPeerScoreRecorder sets the recorder of the peer successes and failures.
+// The failures are the peers added to the skip list of the failed pushes.
+func (ps *PushSync) SetPeerScoreRecorder(r peerscore.Recorder) {
+	ps.scores = r
+	ps.errSkip.OnAdd(r.RecordFailure)
+}
+
+// SetReputationRecorder sets the recorder of the peer misbehavior.
+func (ps *PushSync) SetReputationRecorder(r reputation.Recorder) {
+	ps.reputation = r
+}
+
 func (s *PushSync) Protocol() p2p.ProtocolSpec {
 	return p2p.ProtocolSpec{
 		Name:    protocolName,
@@ -256,6 +275,9 @@ func (ps *PushSync) handler(ctx context.Context, p p2p.Peer, stream p2p.Stream)
 
 		chunkToPut, err := ps.validStamp(chunk)
 		if err != nil {
+			if postage.IsForged(err) {
+				ps.reputation.Record(p.Address, reputation.EventInvalidStamp)
+			}
 			return fmt.Errorf("invalid stamp: %w", err)
 		}
 
@@ -474,15 +496,20 @@ func (ps *PushSync) pushToClosest(ctx context.Context, ch swarm.Chunk, origin bo
 			if result.err == nil {
 
 				if !origin { // forwarder nodes do not need to check the receipt
+					ps.scores.RecordSuccess(result.peer)
 					return result.receipt, nil
 				}
 
 				switch err := ps.checkReceipt(result.receipt); {
 				case err == nil:
+					ps.scores.RecordSuccess(result.peer)
 					return result.receipt, nil
 				case errors.Is(err, ErrShallowReceipt):
 					ps.errSkip.Add(idAddress, result.peer, skiplistDur)
+					ps.reputation.Record(result.peer, reputation.EventFailedReceipt)
 					return result.receipt, err
+				case errors.Is(err, ErrInvalidReceipt):
+					ps.reputation.Record(result.peer, reputation.EventFailedReceipt)
 				}
 			}
 
@@ -491,6 +518,9 @@ func (ps *PushSync) pushToClosest(ctx context.Context, ch swarm.Chunk, origin bo
 
 			sentErrorsLeft--
 			ps.errSkip.Add(idAddress, result.peer, skiplistDur)
+			if errors.Is(result.err, context.DeadlineExceeded) {
+				ps.reputation.Record(result.peer, reputation.EventTimeout)
+			}
 
 			retry()
 		}
@@ -560,12 +590,12 @@ func (ps *PushSync) checkReceipt(receipt *pb.Receipt) error {
 
 	publicKey, err := crypto.Recover(receipt.Signature, addr.Bytes())
 	if err != nil {
-		return fmt.Errorf("pushsync: receipt recover: %w", err)
+		return fmt.Errorf("pushsync: receipt recover: %w: %w", ErrInvalidReceipt, err)
 	}
 
 	peer, err := crypto.NewOverlayAddress(*publicKey, ps.networkID, receipt.Nonce)
 	if err != nil {
-		return fmt.Errorf("pushsync: receipt storer address: %w", err)
+		return fmt.Errorf("pushsync: receipt storer address: %w: %w", ErrInvalidReceipt, err)
 	}
 
 	po := swarm.Proximity(addr.Bytes(), peer.Bytes())
@@ -0,0 +1,11 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package reputation
+
+import "time"
+
+func (s *Service) SetTimeFunc(now func() time.Time) {
+	s.now = now
+}
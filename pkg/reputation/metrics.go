@@ -0,0 +1,41 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package reputation
+
+import (
+	m "github.com/ethersphere/bee/v2/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type metrics struct {
+	Events      *prometheus.CounterVec
+	Blocklisted prometheus.Counter
+}
+
+func newMetrics() metrics {
+	subsystem := "reputation"
+
+	return metrics{
+		Events: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "events_total",
+				Help:      "Number of recorded peer events by type.",
+			},
+			[]string{"event"},
+		),
+		Blocklisted: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "blocklisted_total",
+			Help:      "Number of peers blocklisted for low reputation.",
+		}),
+	}
+}
+
+func (s *Service) Metrics() []prometheus.Collector {
+	return m.PrometheusCollectorsFromFields(s.metrics)
+}
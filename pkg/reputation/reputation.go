@@ -0,0 +1,444 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package reputation keeps the reputation of peers based on the recorded
+// misbehavior events. The reputation is persisted to the state store, decays
+// over time and drives the connection, pruning and selection of peers.
+// It is the only place where the misbehaving peers are blocklisted, the
+// accounting records its disconnects here instead of blocklisting directly.
+// The skip lists of the push sync and retrieval only keep the peers which
+// are not retried for a chunk, the failed requests are recorded here.
+package reputation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/p2p"
+	"github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+// loggerName is the tree path name of the logger for this package.
+const loggerName = "reputation"
+
+const keyPrefix = "reputation_peer_"
+
+const (
+	defaultHalfLife       = time.Hour
+	defaultBadThreshold   = -50
+	defaultBlockThreshold = -100
+	defaultBlockDuration  = time.Hour
+	// flushInterval is the interval at which
+	// the changed records are persisted.
+	flushInterval = 10 * time.Second
+	// forgetScore is the score above which the reputation
+	// records of a peer are removed on the service start.
+	forgetScore = -0.01
+)
+
+// Event is a peer misbehavior event.
+type Event string
+
+const (
+	// EventInvalidStamp is recorded when the peer sends a chunk with an invalid postage stamp.
+	EventInvalidStamp Event = "invalid_stamp"
+	// EventFailedReceipt is recorded when the peer returns an invalid or shallow receipt.
+	EventFailedReceipt Event = "failed_receipt"
+	// EventAccountingDisconnect is recorded when the peer is disconnected for exceeding the debt limit.
+	EventAccountingDisconnect Event = "accounting_disconnect"
+	// EventTimeout is recorded when the peer does not respond to a request in time.
+	EventTimeout Event = "timeout"
+	// EventInvalidAddress is recorded when the peer gossips peer addresses with invalid signatures.
+	EventInvalidAddress Event = "invalid_address"
+	// EventGossipFlood is recorded when the peer gossips more peer addresses than allowed.
+	EventGossipFlood Event = "gossip_flood"
+	// EventFailedRefreshment is recorded when the peer fails the time based settlement.
+	EventFailedRefreshment Event = "failed_refreshment"
+)
+
+// DefaultPenalties are the score penalties of the events.
+var DefaultPenalties = map[Event]float64{
+	EventInvalidStamp:         20,
+	EventFailedReceipt:        10,
+	EventAccountingDisconnect: 25,
+	EventTimeout:              2,
+	EventInvalidAddress:       20,
+	EventGossipFlood:          5,
+	EventFailedRefreshment:    25,
+}
+
+// Recorder records the peer misbehavior events.
+type Recorder interface {
+	Record(peer swarm.Address, event Event)
+}
+
+// Blocklister records the events after which the peer is blocklisted at once.
+type Blocklister interface {
+	Recorder
+	// Blocklist records the event and blocklists the peer for the
+	// duration, or longer if the score drops to the block threshold.
+	Blocklist(peer swarm.Address, event Event, duration time.Duration, reason string) error
+}
+
+// Interface records the peer events and reports the peer reputation.
+type Interface interface {
+	Recorder
+	// Score returns the current reputation score of the peer,
+	// which is zero for a peer without any recorded events.
+	Score(peer swarm.Address) float64
+	// IsBad reports whether the reputation of the peer is so low that the
+	// peer should not be connected to or selected for requests.
+	IsBad(peer swarm.Address) bool
+}
+
+// Peer is the reputation of a peer.
+type Peer struct {
+	Address swarm.Address
+	Score   float64
+	Events  map[Event]uint64
+	Updated time.Time
+	Bad     bool
+}
+
+// record is the persisted reputation of a peer.
+type record struct {
+	Score   float64          `json:"score"`
+	Events  map[Event]uint64 `json:"events"`
+	Updated time.Time        `json:"updated"`
+}
+
+// Options are the reputation service options.
+type Options struct {
+	HalfLife       time.Duration     // the time after which the score is halved
+	BadThreshold   float64           // the score at and below which the peer is bad
+	BlockThreshold float64           // the score at and below which the peer is blocklisted
+	BlockDuration  time.Duration     // the duration of the blocklisting
+	Penalties      map[Event]float64 // the score penalties of the events
+}
+
+var (
+	_ Interface   = (*Service)(nil)
+	_ Blocklister = (*Service)(nil)
+	_ io.Closer   = (*Service)(nil)
+)
+
+// Service keeps the reputation of peers.
+type Service struct {
+	store       storage.StateStorer
+	blocklister p2p.Blocklister
+	logger      log.Logger
+	metrics     metrics
+	opts        Options
+	now         func() time.Time
+
+	mu    sync.Mutex
+	peers map[string]*record
+	dirty map[string]struct{} // the peers with records changed since the last flush
+
+	storeMu sync.Mutex // orders the state store writes of the flushes and resets
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// New returns a new reputation service with the reputation
+// of peers loaded from the state store.
+func New(store storage.StateStorer, blocklister p2p.Blocklister, logger log.Logger, o Options) (*Service, error) {
+	if o.HalfLife <= 0 {
+		o.HalfLife = defaultHalfLife
+	}
+	if o.BadThreshold == 0 {
+		o.BadThreshold = defaultBadThreshold
+	}
+	if o.BlockThreshold == 0 {
+		o.BlockThreshold = defaultBlockThreshold
+	}
+	if o.BlockDuration <= 0 {
+		o.BlockDuration = defaultBlockDuration
+	}
+	if o.Penalties == nil {
+		o.Penalties = DefaultPenalties
+	}
+
+	s := &Service{
+		store:       store,
+		blocklister: blocklister,
+		logger:      logger.WithName(loggerName).Register(),
+		metrics:     newMetrics(),
+		opts:        o,
+		now:         time.Now,
+		peers:       make(map[string]*record),
+		dirty:       make(map[string]struct{}),
+		quit:        make(chan struct{}),
+	}
+
+	err := store.Iterate(keyPrefix, func(key, value []byte) (bool, error) {
+		addr, err := swarm.ParseHexAddress(strings.TrimPrefix(string(key), keyPrefix))
+		if err != nil {
+			return true, fmt.Errorf("parse peer address: %w", err)
+		}
+		r := new(record)
+		if err := json.Unmarshal(value, r); err != nil {
+			return true, fmt.Errorf("peer %s: %w", addr, err)
+		}
+		s.peers[addr.ByteString()] = r
+		return false, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("load reputation: %w", err)
+	}
+
+	// forget the peers whose misbehavior has decayed away
+	now := s.now()
+	for k, r := range s.peers {
+		if s.score(r, now) > forgetScore {
+			if err := s.Reset(swarm.NewAddress([]byte(k))); err != nil {
+				return nil, fmt.Errorf("forget reputation: %w", err)
+			}
+		}
+	}
+
+	s.wg.Add(1)
+	go s.flushWorker()
+
+	return s, nil
+}
+
+// flushWorker persists the changed records periodically, so that
+// the state store is not written under the lock with every event.
+func (s *Service) flushWorker() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.quit:
+			return
+		case <-ticker.C:
+			s.flush()
+		}
+	}
+}
+
+// flush persists the records changed since the last flush.
+func (s *Service) flush() {
+	s.storeMu.Lock()
+	defer s.storeMu.Unlock()
+
+	s.mu.Lock()
+	records := make(map[string]record, len(s.dirty))
+	for k := range s.dirty {
+		if r, ok := s.peers[k]; ok {
+			records[k] = r.clone()
+		}
+	}
+	clear(s.dirty)
+	s.mu.Unlock()
+
+	for k, r := range records {
+		peer := swarm.NewAddress([]byte(k))
+		if err := s.store.Put(keyPrefix+peer.String(), r); err != nil {
+			s.logger.Error(err, "persist peer reputation failed", "peer_address", peer)
+		}
+	}
+}
+
+// clone returns a copy of the record
+// which can be persisted outside of the lock.
+func (r *record) clone() record {
+	events := make(map[Event]uint64, len(r.Events))
+	for e, n := range r.Events {
+		events[e] = n
+	}
+	return record{Score: r.Score, Events: events, Updated: r.Updated}
+}
+
+// score returns the score of the record decayed to the given time.
+func (s *Service) score(r *record, now time.Time) float64 {
+	elapsed := now.Sub(r.Updated)
+	if elapsed <= 0 {
+		return r.Score
+	}
+	return r.Score * math.Exp2(-float64(elapsed)/float64(s.opts.HalfLife))
+}
+
+// Record records the event for the peer and blocklists the
+// peer if its score drops to or below the block threshold.
+func (s *Service) Record(peer swarm.Address, event Event) {
+	score, ok := s.record(peer, event)
+	if !ok || score > s.opts.BlockThreshold {
+		return
+	}
+	_ = s.blocklist(peer, s.opts.BlockDuration, fmt.Sprintf("reputation: score %.2f after %s", score, event))
+}
+
+// Blocklist implements the Blocklister interface.
+func (s *Service) Blocklist(peer swarm.Address, event Event, duration time.Duration, reason string) error {
+	if score, ok := s.record(peer, event); ok && score <= s.opts.BlockThreshold {
+		duration = max(duration, s.opts.BlockDuration)
+	}
+	return s.blocklist(peer, duration, reason)
+}
+
+// record records the event for the peer and returns the new score.
+func (s *Service) record(peer swarm.Address, event Event) (float64, bool) {
+	penalty, ok := s.opts.Penalties[event]
+	if !ok {
+		s.logger.Debug("unknown event", "event", event)
+		return 0, false
+	}
+
+	s.metrics.Events.WithLabelValues(string(event)).Inc()
+
+	s.mu.Lock()
+	now := s.now()
+	r, ok := s.peers[peer.ByteString()]
+	if !ok {
+		r = &record{Events: make(map[Event]uint64)}
+		s.peers[peer.ByteString()] = r
+	}
+	r.Score = s.score(r, now) - penalty
+	r.Events[event]++
+	r.Updated = now
+	score := r.Score
+	s.dirty[peer.ByteString()] = struct{}{}
+	s.mu.Unlock()
+
+	s.logger.Debug("peer event recorded", "peer_address", peer, "event", event, "score", score)
+
+	return score, true
+}
+
+// blocklist blocklists the peer for the duration.
+func (s *Service) blocklist(peer swarm.Address, duration time.Duration, reason string) error {
+	if s.blocklister == nil {
+		return nil
+	}
+	if err := s.blocklister.Blocklist(peer, duration, reason); err != nil {
+		s.logger.Debug("blocklist peer failed", "peer_address", peer, "error", err)
+		return err
+	}
+	s.metrics.Blocklisted.Inc()
+	return nil
+}
+
+// Score implements the Interface interface.
+func (s *Service) Score(peer swarm.Address) float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, ok := s.peers[peer.ByteString()]
+	if !ok {
+		return 0
+	}
+	return s.score(r, s.now())
+}
+
+// IsBad implements the Interface interface.
+func (s *Service) IsBad(peer swarm.Address) bool {
+	return s.Score(peer) <= s.opts.BadThreshold
+}
+
+// Peers returns the reputation of all peers with
+// recorded events, ordered from the worst score.
+func (s *Service) Peers() []Peer {
+	s.mu.Lock()
+	now := s.now()
+	peers := make([]Peer, 0, len(s.peers))
+	for k, r := range s.peers {
+		peers = append(peers, s.peer(swarm.NewAddress([]byte(k)), r, now))
+	}
+	s.mu.Unlock()
+
+	sort.Slice(peers, func(i, j int) bool {
+		return peers[i].Score < peers[j].Score
+	})
+	return peers
+}
+
+// Peer returns the reputation of the peer.
+func (s *Service) Peer(addr swarm.Address) Peer {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, ok := s.peers[addr.ByteString()]
+	if !ok {
+		return Peer{Address: addr, Events: map[Event]uint64{}}
+	}
+	return s.peer(addr, r, s.now())
+}
+
+// peer must be called under lock.
+func (s *Service) peer(addr swarm.Address, r *record, now time.Time) Peer {
+	events := make(map[Event]uint64, len(r.Events))
+	for e, n := range r.Events {
+		events[e] = n
+	}
+	score := s.score(r, now)
+	return Peer{
+		Address: addr,
+		Score:   score,
+		Events:  events,
+		Updated: r.Updated,
+		Bad:     score <= s.opts.BadThreshold,
+	}
+}
+
+// Reset removes the reputation records of the peer.
+func (s *Service) Reset(addr swarm.Address) error {
+	s.storeMu.Lock()
+	defer s.storeMu.Unlock()
+
+	s.mu.Lock()
+	delete(s.peers, addr.ByteString())
+	delete(s.dirty, addr.ByteString())
+	s.mu.Unlock()
+
+	if err := s.store.Delete(keyPrefix + addr.String()); err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return err
+	}
+	return nil
+}
+
+// Close persists the changed records and stops the service.
+func (s *Service) Close() error {
+	close(s.quit)
+	s.wg.Wait()
+	s.flush()
+	return nil
+}
+
+// NewBlocklister returns a Blocklister which blocklists
+// the peers without keeping their reputation.
+func NewBlocklister(b p2p.Blocklister) Blocklister {
+	return blocklister{b}
+}
+
+type blocklister struct {
+	p2p.Blocklister
+}
+
+func (blocklister) Record(swarm.Address, Event) {}
+
+func (b blocklister) Blocklist(peer swarm.Address, _ Event, duration time.Duration, reason string) error {
+	return b.Blocklister.Blocklist(peer, duration, reason)
+}
+
+// NopRecorder is a Recorder which discards all the events.
+var NopRecorder Recorder = nopRecorder{}
+
+type nopRecorder struct{}
+
+func (nopRecorder) Record(swarm.Address, Event) {}
@@ -0,0 +1,184 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package reputation_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/log"
+	p2pmock "github.com/ethersphere/bee/v2/pkg/p2p/mock"
+	"github.com/ethersphere/bee/v2/pkg/reputation"
+	mockstate "github.com/ethersphere/bee/v2/pkg/statestore/mock"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+func TestRecord(t *testing.T) {
+	t.Parallel()
+
+	var blocklisted []swarm.Address
+	blocklister := p2pmock.New(p2pmock.WithBlocklistFunc(func(addr swarm.Address, d time.Duration, _ string) error {
+		if d != time.Minute {
+			t.Errorf("got blocklist duration %v, want %v", d, time.Minute)
+		}
+		blocklisted = append(blocklisted, addr)
+		return nil
+	}))
+
+	s, err := reputation.New(mockstate.NewStateStore(), blocklister, log.Noop, reputation.Options{
+		BadThreshold:   -20,
+		BlockThreshold: -40,
+		BlockDuration:  time.Minute,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = s.Close() })
+	now := time.Now()
+	s.SetTimeFunc(func() time.Time { return now })
+
+	peer, other := swarm.RandAddress(t), swarm.RandAddress(t)
+
+	s.Record(peer, reputation.EventInvalidStamp)
+	if got, want := s.Score(peer), -reputation.DefaultPenalties[reputation.EventInvalidStamp]; got != want {
+		t.Fatalf("got score %v, want %v", got, want)
+	}
+	if !s.IsBad(peer) {
+		t.Fatal("peer with a score at the bad threshold not reported bad")
+	}
+	if s.IsBad(other) || s.Score(other) != 0 {
+		t.Fatal("peer without events has reputation")
+	}
+	if len(blocklisted) != 0 {
+		t.Fatalf("peer blocklisted above the block threshold")
+	}
+
+	s.Record(peer, reputation.EventFailedReceipt)
+	s.Record(peer, reputation.EventFailedReceipt)
+	if len(blocklisted) != 1 || !blocklisted[0].Equal(peer) {
+		t.Fatalf("got blocklisted peers %v, want %s", blocklisted, peer)
+	}
+
+	got := s.Peer(peer)
+	if got.Events[reputation.EventInvalidStamp] != 1 || got.Events[reputation.EventFailedReceipt] != 2 || !got.Bad {
+		t.Fatalf("unexpected peer reputation %+v", got)
+	}
+}
+
+func TestDecay(t *testing.T) {
+	t.Parallel()
+
+	s, err := reputation.New(mockstate.NewStateStore(), nil, log.Noop, reputation.Options{HalfLife: time.Hour})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = s.Close() })
+	now := time.Now()
+	s.SetTimeFunc(func() time.Time { return now })
+
+	peer := swarm.RandAddress(t)
+	s.Record(peer, reputation.EventInvalidStamp)
+
+	now = now.Add(time.Hour)
+	if got, want := s.Score(peer), -reputation.DefaultPenalties[reputation.EventInvalidStamp]/2; got != want {
+		t.Fatalf("got score %v, want %v", got, want)
+	}
+}
+
+func TestPersistence(t *testing.T) {
+	t.Parallel()
+
+	store := mockstate.NewStateStore()
+
+	s, err := reputation.New(store, nil, log.Noop, reputation.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	peer := swarm.RandAddress(t)
+	s.Record(peer, reputation.EventTimeout)
+	s.Record(peer, reputation.EventAccountingDisconnect)
+
+	// the records are persisted in batches
+	var rec map[string]any
+	if err := store.Get("reputation_peer_"+peer.String(), &rec); err == nil {
+		t.Fatal("record persisted before the flush")
+	}
+
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err = reputation.New(store, nil, log.Noop, reputation.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	peers := s.Peers()
+	if len(peers) != 1 {
+		t.Fatalf("got %d peers, want 1", len(peers))
+	}
+	if !peers[0].Address.Equal(peer) {
+		t.Fatalf("got peer %s, want %s", peers[0].Address, peer)
+	}
+	if peers[0].Events[reputation.EventTimeout] != 1 || peers[0].Events[reputation.EventAccountingDisconnect] != 1 {
+		t.Fatalf("events not persisted: %v", peers[0].Events)
+	}
+	if peers[0].Score >= 0 {
+		t.Fatalf("score not persisted: %v", peers[0].Score)
+	}
+
+	if err := s.Reset(peer); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err = reputation.New(store, nil, log.Noop, reputation.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = s.Close() })
+	if len(s.Peers()) != 0 {
+		t.Fatal("reset peer reputation persisted")
+	}
+}
+
+func TestBlocklist(t *testing.T) {
+	t.Parallel()
+
+	var durations []time.Duration
+	blocklister := p2pmock.New(p2pmock.WithBlocklistFunc(func(_ swarm.Address, d time.Duration, _ string) error {
+		durations = append(durations, d)
+		return nil
+	}))
+
+	s, err := reputation.New(mockstate.NewStateStore(), blocklister, log.Noop, reputation.Options{
+		BlockThreshold: -40,
+		BlockDuration:  time.Hour,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = s.Close() })
+
+	peer := swarm.RandAddress(t)
+
+	// the first disconnect is blocklisted for the requested duration and the
+	// second one for the block duration, as the score drops to the threshold
+	for range 2 {
+		if err := s.Blocklist(peer, reputation.EventFailedRefreshment, time.Minute, "test"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if want := []time.Duration{time.Minute, time.Hour}; len(durations) != 2 || durations[0] != want[0] || durations[1] != want[1] {
+		t.Fatalf("got blocklist durations %v, want %v", durations, want)
+	}
+	if got := s.Peer(peer).Events[reputation.EventFailedRefreshment]; got != 2 {
+		t.Fatalf("got %d recorded events, want 2", got)
+	}
+}
@@ -20,12 +20,14 @@ import (
 	"github.com/ethersphere/bee/v2/pkg/p2p"
 	"github.com/ethersphere/bee/v2/pkg/p2p/protobuf"
 	"github.com/ethersphere/bee/v2/pkg/pricer"
+	"github.com/ethersphere/bee/v2/pkg/reputation"
 	pb "github.com/ethersphere/bee/v2/pkg/retrieval/pb"
 	"github.com/ethersphere/bee/v2/pkg/skippeers"
 	"github.com/ethersphere/bee/v2/pkg/soc"
 	storage "github.com/ethersphere/bee/v2/pkg/storage"
 	"github.com/ethersphere/bee/v2/pkg/swarm"
 	"github.com/ethersphere/bee/v2/pkg/topology"
+	"github.com/ethersphere/bee/v2/pkg/topology/peerscore"
 	"github.com/ethersphere/bee/v2/pkg/tracing"
 	"github.com/opentracing/opentracing-go"
 	"github.com/opentracing/opentracing-go/ext"
@@ -78,6 +80,8 @@ type Service struct {
 	tracer        *tracing.Tracer
 	caching       bool
 	errSkip       *skippeers.List
+	scores        peerscore.Recorder
+	reputation    reputation.Recorder
 }
 
 func New(
@@ -105,9 +109,23 @@ func New(
 		tracer:        tracer,
 		caching:       forwarderCaching,
 		errSkip:       skippeers.NewList(time.Minute),
+		scores:        peerscore.NopRecorder,
+		reputation:    reputation.NopRecorder,
 	}
 }
 
+// SetPeerScoreRecorder sets the recorder of the peer successes and failures.
+// The failures are the peers added to the skip list of the failed retrievals.
+func (s *Service) SetPeerScoreRecorder(r peerscore.Recorder) {
+	s.scores = r
+	s.errSkip.OnAdd(r.RecordFailure)
+}
+
+// SetReputationRecorder sets the recorder of the peers timing out.
+func (s *Service) SetReputationRecorder(r reputation.Recorder) {
+	s.reputation = r
+}
+
 func (s *Service) Protocol() p2p.ProtocolSpec {
 	return p2p.ProtocolSpec{
 		Name:    protocolName,
@@ -271,6 +289,7 @@ func (s *Service) RetrieveChunk(ctx context.Context, chunkAddr, sourcePeerAddr s
 				inflight--
 
 				if res.err == nil {
+					s.scores.RecordSuccess(res.peer)
 					loggerV1.Debug("retrieved chunk", "chunk_address", chunkAddr, "peer_address", res.peer, "peer_proximity", swarm.Proximity(res.peer.Bytes(), chunkAddr.Bytes()))
 					return res.chunk, nil
 				}
@@ -280,6 +299,9 @@ func (s *Service) RetrieveChunk(ctx context.Context, chunkAddr, sourcePeerAddr s
 
 				errorsLeft--
 				s.errSkip.Add(chunkAddr, res.peer, skiplistDur)
+				if errors.Is(res.err, context.DeadlineExceeded) {
+					s.reputation.Record(res.peer, reputation.EventTimeout)
+				}
 				retry()
 			}
 		}
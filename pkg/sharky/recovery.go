@@ -13,6 +13,7 @@ import (
 	"path"
 	"sync"
 
+	"github.com/ethersphere/bee/v2/pkg/crypto"
 	"github.com/hashicorp/go-multierror"
 )
 
@@ -22,11 +23,22 @@ type Recovery struct {
 	shards     []*slots
 	shardFiles []*os.File
 	datasize   int
+	sealer     *crypto.Sealer
 }
 
 var ErrShardNotFound = errors.New("shard not found")
 
 func NewRecovery(dir string, shardCnt int, datasize int) (*Recovery, error) {
+	return newRecovery(dir, shardCnt, datasize, nil)
+}
+
+// NewEncryptedRecovery returns the Recovery of a store created by NewEncrypted.
+func NewEncryptedRecovery(dir string, shardCnt int, datasize int, sealer *crypto.Sealer) (*Recovery, error) {
+	return newRecovery(dir, shardCnt, datasize, sealer)
+}
+
+func newRecovery(dir string, shardCnt int, datasize int, sealer *crypto.Sealer) (*Recovery, error) {
+	datasize = slotSize(datasize, sealer)
 	shards := make([]*slots, shardCnt)
 	shardFiles := make([]*os.File, shardCnt)
 
@@ -52,7 +64,7 @@ func NewRecovery(dir string, shardCnt int, datasize int) (*Recovery, error) {
 		shards[i] = sl
 		shardFiles[i] = file
 	}
-	return &Recovery{shards: shards, shardFiles: shardFiles, datasize: datasize}, nil
+	return &Recovery{shards: shards, shardFiles: shardFiles, datasize: datasize, sealer: sealer}, nil
 }
 
 // Add marks a location as used (not free).
@@ -75,7 +87,16 @@ func (r *Recovery) Add(loc Location) error {
 func (r *Recovery) Read(ctx context.Context, loc Location, buf []byte) error {
 	r.mtx.Lock()
 	defer r.mtx.Unlock()
-	_, err := r.shardFiles[loc.Shard].ReadAt(buf, int64(loc.Slot)*int64(r.datasize))
+	if r.sealer == nil {
+		_, err := r.shardFiles[loc.Shard].ReadAt(buf, int64(loc.Slot)*int64(r.datasize))
+		return err
+	}
+
+	sealed := make([]byte, int(loc.Length)+crypto.SealOverhead)
+	if _, err := r.shardFiles[loc.Shard].ReadAt(sealed, int64(loc.Slot)*int64(r.datasize)); err != nil {
+		return err
+	}
+	_, err := r.sealer.Open(buf[:0], sealed)
 	return err
 }
 
@@ -83,7 +104,11 @@ func (r *Recovery) Move(ctx context.Context, from Location, to Location) error {
 	r.mtx.Lock()
 	defer r.mtx.Unlock()
 
-	chData := make([]byte, from.Length)
+	length := int(from.Length)
+	if r.sealer != nil {
+		length += crypto.SealOverhead
+	}
+	chData := make([]byte, length)
 	_, err := r.shardFiles[from.Shard].ReadAt(chData, int64(from.Slot)*int64(r.datasize))
 	if err != nil {
 		return err
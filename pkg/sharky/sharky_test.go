@@ -18,6 +18,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ethersphere/bee/v2/pkg/crypto"
 	"github.com/ethersphere/bee/v2/pkg/sharky"
 	"golang.org/x/sync/errgroup"
 )
@@ -290,3 +291,59 @@ func TestConcurrency(t *testing.T) {
 		})
 	}
 }
+
+func TestEncrypted(t *testing.T) {
+	t.Parallel()
+
+	key := bytes.Repeat([]byte{0x1}, 32)
+	sealer, err := crypto.NewSealer(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	datasize := 8
+	dir := t.TempDir()
+	s, err := sharky.NewEncrypted(&dirFS{basedir: dir}, 1, datasize, sealer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	want := []byte("plain")
+
+	loc, err := s.Write(ctx, want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if int(loc.Length) != len(want) {
+		t.Fatalf("want location length %d, got %d", len(want), loc.Length)
+	}
+	if _, err := s.Write(ctx, make([]byte, datasize+1)); !errors.Is(err, sharky.ErrTooLong) {
+		t.Fatalf("want error %v, got %v", sharky.ErrTooLong, err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	raw, err := os.ReadFile(filepath.Join(dir, "shard_000"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(raw, want) {
+		t.Fatal("blob stored in plaintext")
+	}
+
+	s, err = sharky.NewEncrypted(&dirFS{basedir: dir}, 1, datasize, sealer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { s.Close() })
+
+	buf := make([]byte, loc.Length)
+	if err := s.Read(ctx, loc, buf); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf, want) {
+		t.Fatalf("want %q, got %q", want, buf)
+	}
+}
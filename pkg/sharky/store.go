@@ -12,6 +12,7 @@ import (
 	"strconv"
 	"sync"
 
+	"github.com/ethersphere/bee/v2/pkg/crypto"
 	"github.com/hashicorp/go-multierror"
 )
 
@@ -29,6 +30,7 @@ var (
 // - free slots allow write
 type Store struct {
 	maxDataSize int             // max length of blobs
+	sealer      *crypto.Sealer  // encrypts the blobs at rest if set
 	writes      chan write      // shared write operations channel
 	shards      []*shard        // shards
 	wg          *sync.WaitGroup // count started operations
@@ -43,8 +45,22 @@ type Store struct {
 // - shard size - positive integer multiple of 8 - for others expect undefined behaviour
 // - maxDataSize - positive integer representing the maximum blob size to be stored
 func New(basedir fs.FS, shardCnt int, maxDataSize int) (*Store, error) {
+	return newStore(basedir, shardCnt, maxDataSize, nil)
+}
+
+// NewEncrypted constructs a sharded blobstore like New which encrypts the
+// blobs at rest with the given sealer. The slots of an encrypted store are
+// longer by crypto.SealOverhead than the maxDataSize, so a store can not be
+// opened both encrypted and unencrypted. The locations report the length of
+// the plaintext blobs, so encryption is transparent to the callers.
+func NewEncrypted(basedir fs.FS, shardCnt int, maxDataSize int, sealer *crypto.Sealer) (*Store, error) {
+	return newStore(basedir, shardCnt, maxDataSize, sealer)
+}
+
+func newStore(basedir fs.FS, shardCnt int, maxDataSize int, sealer *crypto.Sealer) (*Store, error) {
 	store := &Store{
 		maxDataSize: maxDataSize,
+		sealer:      sealer,
 		writes:      make(chan write),
 		shards:      make([]*shard, shardCnt),
 		wg:          &sync.WaitGroup{},
@@ -52,7 +68,7 @@ func New(basedir fs.FS, shardCnt int, maxDataSize int) (*Store, error) {
 		metrics:     newMetrics(),
 	}
 	for i := range store.shards {
-		s, err := store.create(uint8(i), maxDataSize, basedir)
+		s, err := store.create(uint8(i), slotSize(maxDataSize, sealer), basedir)
 		if err != nil {
 			return nil, err
 		}
@@ -117,9 +133,21 @@ func (s *Store) create(index uint8, maxDataSize int, basedir fs.FS) (*shard, err
 // Read reads the content of the blob found at location into the byte buffer given
 // The location is assumed to be obtained by an earlier Write call storing the blob
 func (s *Store) Read(ctx context.Context, loc Location, buf []byte) (err error) {
-	sh := s.shards[loc.Shard]
+	if s.sealer != nil {
+		sealed := make([]byte, int(loc.Length)+crypto.SealOverhead)
+		if err := s.read(ctx, loc.Shard, loc.Slot, sealed); err != nil {
+			return err
+		}
+		_, err := s.sealer.Open(buf[:0], sealed)
+		return err
+	}
+	return s.read(ctx, loc.Shard, loc.Slot, buf[:loc.Length])
+}
+
+func (s *Store) read(ctx context.Context, shard uint8, slot uint32, buf []byte) (err error) {
+	sh := s.shards[shard]
 	select {
-	case sh.reads <- read{ctx: ctx, buf: buf[:loc.Length], slot: loc.Slot}:
+	case sh.reads <- read{ctx: ctx, buf: buf, slot: slot}:
 		s.metrics.TotalReadCalls.Inc()
 	case <-ctx.Done():
 		return ctx.Err()
@@ -150,6 +178,11 @@ func (s *Store) Write(ctx context.Context, data []byte) (loc Location, err error
 	if len(data) > s.maxDataSize {
 		return loc, ErrTooLong
 	}
+	if s.sealer != nil {
+		if data, err = s.sealer.Seal(nil, data); err != nil {
+			return loc, err
+		}
+	}
 	s.wg.Add(1)
 	defer s.wg.Done()
 
@@ -166,6 +199,9 @@ func (s *Store) Write(ctx context.Context, data []byte) (loc Location, err error
 
 	select {
 	case e := <-c:
+		if e.err == nil && s.sealer != nil {
+			e.loc.Length -= uint16(crypto.SealOverhead)
+		}
 		if e.err == nil {
 			shard := strconv.Itoa(int(e.loc.Shard))
 			s.metrics.CurrentShardSize.WithLabelValues(shard).Inc()
@@ -202,3 +238,11 @@ func (s *Store) Release(ctx context.Context, loc Location) error {
 	}
 	return err
 }
+
+// slotSize returns the size of the slots holding the blobs of maxDataSize.
+func slotSize(maxDataSize int, sealer *crypto.Sealer) int {
+	if sealer != nil {
+		return maxDataSize + crypto.SealOverhead
+	}
+	return maxDataSize
+}
@@ -0,0 +1,167 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simulation
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+// LinkOptions are the properties of the links between the simulated nodes.
+type LinkOptions struct {
+	Latency time.Duration // one way delay of the written data
+	Jitter  time.Duration // maximal random delay added to the latency
+	Loss    float64       // probability of a failed connection or stream, between 0 and 1
+}
+
+// Network is the in-memory network which connects the simulated p2p services.
+type Network struct {
+	mu        sync.RWMutex
+	link      LinkOptions
+	services  map[string]*Service // by underlay
+	partition map[string]int      // partition group by overlay, nil if not partitioned
+	nextPort  int
+
+	randMu sync.Mutex
+	rand   *rand.Rand
+}
+
+// NewNetwork returns the network with the links of the given options.
+func NewNetwork(link LinkOptions, seed int64) *Network {
+	return &Network{
+		link:     link,
+		services: make(map[string]*Service),
+		nextPort: 1634,
+		rand:     rand.New(rand.NewSource(seed)),
+	}
+}
+
+// SetLink changes the properties of all the links.
+func (n *Network) SetLink(link LinkOptions) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.link = link
+}
+
+// Link returns the properties of the links.
+func (n *Network) Link() LinkOptions {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	return n.link
+}
+
+// Partition splits the network into the groups of nodes which can reach
+// only the nodes of the same group, the connections between the groups are
+// closed. The nodes which are not in any of the groups form a group of
+// their own.
+func (n *Network) Partition(groups ...[]swarm.Address) {
+	partition := make(map[string]int)
+	for i, g := range groups {
+		for _, addr := range g {
+			partition[addr.ByteString()] = i + 1
+		}
+	}
+
+	n.mu.Lock()
+	n.partition = partition
+	services := n.servicesLocked()
+	n.mu.Unlock()
+
+	for _, s := range services {
+		for _, peer := range s.connectedPeers() {
+			if !n.reachable(s.overlay, peer.overlay) {
+				_ = s.Disconnect(peer.overlay, "network partition")
+			}
+		}
+	}
+}
+
+// Heal removes the network partition. The nodes do not reconnect on their own
+// to the peers which they pruned as unreachable during the partition, they
+// rediscover them only through the new connections, as on a real network.
+func (n *Network) Heal() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.partition = nil
+}
+
+// newUnderlay returns the unique underlay address of a new service.
+func (n *Network) newUnderlay() (ma.Multiaddr, error) {
+	n.mu.Lock()
+	port := n.nextPort
+	n.nextPort++
+	n.mu.Unlock()
+
+	return ma.NewMultiaddr(fmt.Sprintf("/ip4/127.0.0.1/tcp/%d", port))
+}
+
+func (n *Network) register(s *Service) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.services[s.address.Underlay.String()] = s
+}
+
+// lookup returns the service listening on the underlay address.
+func (n *Network) lookup(underlay ma.Multiaddr) (*Service, bool) {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	s, ok := n.services[underlay.String()]
+	return s, ok
+}
+
+func (n *Network) servicesLocked() []*Service {
+	services := make([]*Service, 0, len(n.services))
+	for _, s := range n.services {
+		services = append(services, s)
+	}
+	return services
+}
+
+// reachable reports whether the nodes are in the same network partition.
+func (n *Network) reachable(a, b swarm.Address) bool {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	if n.partition == nil {
+		return true
+	}
+	return n.partition[a.ByteString()] == n.partition[b.ByteString()]
+}
+
+// latency returns the delay of the data written to a link.
+func (n *Network) latency() time.Duration {
+	link := n.Link()
+	if link.Jitter <= 0 {
+		return link.Latency
+	}
+
+	n.randMu.Lock()
+	defer n.randMu.Unlock()
+
+	return link.Latency + time.Duration(n.rand.Int63n(int64(link.Jitter)))
+}
+
+// lost reports whether the connection or stream being opened is lost.
+func (n *Network) lost() bool {
+	link := n.Link()
+	if link.Loss <= 0 {
+		return false
+	}
+
+	n.randMu.Lock()
+	defer n.randMu.Unlock()
+
+	return n.rand.Float64() < link.Loss
+}
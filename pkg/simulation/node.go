@@ -0,0 +1,383 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simulation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	accountingmock "github.com/ethersphere/bee/v2/pkg/accounting/mock"
+	"github.com/ethersphere/bee/v2/pkg/addressbook"
+	"github.com/ethersphere/bee/v2/pkg/crypto"
+	"github.com/ethersphere/bee/v2/pkg/hive"
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/p2p"
+	"github.com/ethersphere/bee/v2/pkg/pingpong"
+	batchstoremock "github.com/ethersphere/bee/v2/pkg/postage/batchstore/mock"
+	pricermock "github.com/ethersphere/bee/v2/pkg/pricer/mock"
+	"github.com/ethersphere/bee/v2/pkg/puller"
+	"github.com/ethersphere/bee/v2/pkg/pullsync"
+	"github.com/ethersphere/bee/v2/pkg/pusher"
+	"github.com/ethersphere/bee/v2/pkg/pushsync"
+	"github.com/ethersphere/bee/v2/pkg/retrieval"
+	"github.com/ethersphere/bee/v2/pkg/soc"
+	"github.com/ethersphere/bee/v2/pkg/stabilization"
+	stabilmock "github.com/ethersphere/bee/v2/pkg/stabilization/mock"
+	statestore "github.com/ethersphere/bee/v2/pkg/statestore/mock"
+	"github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/storer"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"github.com/ethersphere/bee/v2/pkg/topology"
+	"github.com/ethersphere/bee/v2/pkg/topology/kademlia"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+// nodePrice is the price of the chunk for all the simulated nodes.
+const nodePrice = 10
+
+var errNodeStopped = errors.New("node stopped")
+
+// nodeOptions are the options shared by the simulated nodes.
+type nodeOptions struct {
+	networkID     uint64
+	storageRadius uint8
+	bootnodes     []ma.Multiaddr
+	kademlia      kademlia.Options
+	logger        log.Logger
+}
+
+// Node is a simulated node running the real topology, discovery, push, pull
+// and retrieval protocols over the in-memory network, with the chunks kept in
+// the reserve of an in-memory localstore. The state of the node is kept in
+// memory and preserved when the node is restarted.
+type Node struct {
+	overlay     swarm.Address
+	nonce       []byte
+	signer      crypto.Signer
+	service     *Service
+	stateStore  storage.StateStorer
+	addressBook addressbook.Interface
+	opts        nodeOptions
+
+	mu        sync.Mutex
+	running   bool
+	store     *storer.DB
+	kad       *kademlia.Kad
+	hive      *hive.Service
+	pushSync  *pushsync.PushSync
+	pullSync  *pullsync.Syncer
+	puller    *puller.Puller
+	retrieval *retrieval.Service
+}
+
+func newNode(n *Network, o nodeOptions) (*Node, error) {
+	key, err := crypto.GenerateSecp256k1Key()
+	if err != nil {
+		return nil, fmt.Errorf("generate key: %w", err)
+	}
+	signer := crypto.NewDefaultSigner(key)
+	nonce := make([]byte, 32)
+
+	overlay, err := crypto.NewOverlayAddress(key.PublicKey, o.networkID, nonce)
+	if err != nil {
+		return nil, fmt.Errorf("overlay address: %w", err)
+	}
+
+	stateStore := statestore.NewStateStore()
+	ab := addressbook.New(stateStore)
+
+	service, err := n.NewService(signer, overlay, o.networkID, nonce, ab, o.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Node{
+		overlay:     overlay,
+		nonce:       nonce,
+		signer:      signer,
+		service:     service,
+		stateStore:  stateStore,
+		addressBook: ab,
+		opts:        o,
+	}, nil
+}
+
+// Overlay returns the overlay address of the node.
+func (n *Node) Overlay() swarm.Address {
+	return n.overlay
+}
+
+// Service returns the p2p service of the node.
+func (n *Node) Service() *Service {
+	return n.service
+}
+
+// Running reports whether the node is running.
+func (n *Node) Running() bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return n.running
+}
+
+// Topology returns the kademlia of the running node.
+func (n *Node) Topology() *kademlia.Kad {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return n.kad
+}
+
+// Start starts the protocols of the node and connects it to the network.
+func (n *Node) Start(ctx context.Context) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.running {
+		return nil
+	}
+
+	logger := n.opts.logger.WithValues("node", n.overlay.String()).Build()
+
+	if n.store == nil {
+		store, err := n.newStore(ctx, logger)
+		if err != nil {
+			return err
+		}
+		n.store = store
+	}
+
+	detector, err := stabilization.NewDetector(stabilization.Config{
+		PeriodDuration:             time.Second,
+		NumPeriodsForStabilization: 2,
+		StabilizationFactor:        1,
+	})
+	if err != nil {
+		return fmt.Errorf("stabilization detector: %w", err)
+	}
+
+	hive := hive.New(n.service, n.addressBook, n.opts.networkID, false, true, logger)
+
+	kadOptions := n.opts.kademlia
+	for _, b := range n.opts.bootnodes {
+		if !b.Equal(n.service.address.Underlay) {
+			kadOptions.Bootnodes = append(kadOptions.Bootnodes, b)
+		}
+	}
+	kad, err := kademlia.New(n.overlay, n.addressBook, hive, n.service, detector, logger, kadOptions)
+	if err != nil {
+		return fmt.Errorf("kademlia: %w", err)
+	}
+	kad.SetStorageRadius(n.opts.storageRadius)
+
+	hive.SetAddPeersHandler(kad.AddPeers)
+	n.service.SetPickyNotifier(healthyNotifier{kad})
+
+	radius := func() (uint8, error) { return n.opts.storageRadius, nil }
+	validStamp := func(ch swarm.Chunk) (swarm.Chunk, error) { return ch, nil }
+	acc := accountingmock.NewAccounting()
+	pricer := pricermock.NewMockService(nodePrice, nodePrice)
+
+	pushSync := pushsync.New(n.overlay, n.opts.networkID, n.nonce, n.service, n.store, radius, kad, true, func(swarm.Chunk) {}, func(*soc.SOC) {}, validStamp, logger, acc, pricer, n.signer, nil, stabilmock.NewSubscriber(true), 0)
+	pullSync := pullsync.New(n.service, n.store, func(swarm.Chunk) {}, func(*soc.SOC) {}, validStamp, logger, pullsync.DefaultMaxPage)
+	retrieval := retrieval.New(n.overlay, radius, n.store, n.service, kad, logger, acc, pricer, nil, true)
+	pingPong := pingpong.New(n.service, logger, nil)
+	n.store.SetRetrievalService(retrieval)
+
+	n.service.start()
+
+	for _, p := range []interface {
+		Protocol() p2p.ProtocolSpec
+	}{hive, pingPong, pushSync, pullSync, retrieval} {
+		if err := n.service.AddProtocol(p.Protocol()); err != nil {
+			n.service.stop()
+			return fmt.Errorf("add protocol: %w", err)
+		}
+	}
+
+	kad.UpdateReachability(p2p.ReachabilityStatusPublic)
+	if err := kad.Start(ctx); err != nil {
+		n.service.stop()
+		return fmt.Errorf("start kademlia: %w", err)
+	}
+
+	puller := puller.New(n.overlay, n.stateStore, kad, n.store, pullSync, n.service, logger, puller.Options{})
+	puller.Start(ctx)
+
+	n.kad = kad
+	n.hive = hive
+	n.pushSync = pushSync
+	n.pullSync = pullSync
+	n.puller = puller
+	n.retrieval = retrieval
+	n.running = true
+	return nil
+}
+
+// newStore returns the in-memory localstore of the node with the reserve
+// worker started, which keeps the storage radius of the simulation.
+func (n *Node) newStore(ctx context.Context, logger log.Logger) (*storer.DB, error) {
+	store, err := storer.New(ctx, "", &storer.Options{
+		Logger:                logger,
+		Address:               n.overlay,
+		RadiusSetter:          noopRadiusSetter{},
+		Batchstore:            batchstoremock.New(batchstoremock.WithAcceptAllExistsFunc()),
+		ReserveCapacity:       storer.DefaultReserveCapacity,
+		ReserveWakeUpDuration: time.Minute,
+		MinimumStorageRadius:  uint(n.opts.storageRadius),
+		StartupStabilizer:     stabilmock.NewSubscriber(true),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("localstore: %w", err)
+	}
+
+	// the pullers of the node are started with the node, once the
+	// reserve worker has set the storage radius of the localstore
+	ready := make(chan struct{})
+	store.StartReserveWorker(context.Background(), readySyncer(ready), func() (uint8, error) {
+		return n.opts.storageRadius, nil
+	})
+	select {
+	case <-ready:
+	case <-ctx.Done():
+		return nil, errors.Join(ctx.Err(), store.Close())
+	}
+	return store, nil
+}
+
+// Stop disconnects the node from the network and stops its protocols.
+func (n *Node) Stop() error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if !n.running {
+		return nil
+	}
+	n.running = false
+
+	n.service.stop()
+
+	return errors.Join(
+		n.puller.Close(),
+		n.pullSync.Close(),
+		n.pushSync.Close(),
+		n.retrieval.Close(),
+		n.hive.Close(),
+		n.kad.Close(),
+	)
+}
+
+// close stops the node and closes its localstore.
+func (n *Node) close() error {
+	err := n.Stop()
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.store != nil {
+		err = errors.Join(err, n.store.Close())
+		n.store = nil
+	}
+	return err
+}
+
+// connectedPeers returns the reachable connected peers of the node as seen by
+// its topology, which are the peers available to the push and retrieval.
+func (n *Node) connectedPeers() map[string]struct{} {
+	kad := n.Topology()
+	if kad == nil {
+		return nil
+	}
+
+	peers := make(map[string]struct{})
+	_ = kad.EachConnectedPeer(func(addr swarm.Address, _ uint8) (bool, bool, error) {
+		peers[addr.ByteString()] = struct{}{}
+		return false, false, nil
+	}, topology.Select{Reachable: true})
+	return peers
+}
+
+// Upload pushes the chunk to its neighborhood from the node. The push of
+// the shallow receipt is retried as the pusher does, with the first hop of
+// the failed push skipped by the push sync, and the last shallow receipt
+// is accepted.
+func (n *Node) Upload(ctx context.Context, ch swarm.Chunk) (*pushsync.Receipt, error) {
+	n.mu.Lock()
+	pushSync := n.pushSync
+	running := n.running
+	n.mu.Unlock()
+
+	if !running {
+		return nil, errNodeStopped
+	}
+
+	for attempt := 1; ; attempt++ {
+		receipt, err := pushSync.PushChunkToClosest(ctx, ch)
+		if !errors.Is(err, pushsync.ErrShallowReceipt) {
+			return receipt, err
+		}
+		if attempt >= pusher.DefaultRetryCount {
+			return receipt, nil
+		}
+	}
+}
+
+// Retrieve retrieves the chunk from the network by the node.
+func (n *Node) Retrieve(ctx context.Context, addr swarm.Address) (swarm.Chunk, error) {
+	n.mu.Lock()
+	retrieval := n.retrieval
+	running := n.running
+	n.mu.Unlock()
+
+	if !running {
+		return nil, errNodeStopped
+	}
+	return retrieval.RetrieveChunk(ctx, addr, swarm.ZeroAddress)
+}
+
+// Stores reports whether the chunk is stored in the reserve of the node.
+func (n *Node) Stores(ch swarm.Chunk) bool {
+	n.mu.Lock()
+	store := n.store
+	n.mu.Unlock()
+
+	if store == nil {
+		return false
+	}
+	stampHash, err := ch.Stamp().Hash()
+	if err != nil {
+		return false
+	}
+	has, err := store.ReserveHas(ch.Address(), ch.Stamp().BatchID(), stampHash)
+	return err == nil && has
+}
+
+// healthyNotifier marks the reachable peers healthy, as the
+// simulated nodes do not run the salud health checks.
+type healthyNotifier struct {
+	*kademlia.Kad
+}
+
+func (n healthyNotifier) Reachable(addr swarm.Address, status p2p.ReachabilityStatus) {
+	if status == p2p.ReachabilityStatusPublic {
+		n.UpdatePeerHealth(addr, true, 0)
+	}
+	n.Kad.Reachable(addr, status)
+}
+
+type noopRadiusSetter struct{}
+
+func (noopRadiusSetter) SetStorageRadius(uint8) {}
+
+// readySyncer is the syncer of the reserve worker which signals that
+// the storage radius is set, the pullers are started by the node.
+type readySyncer chan struct{}
+
+func (readySyncer) SyncRate() float64 { return 0 }
+
+func (s readySyncer) Start(context.Context) { close(s) }
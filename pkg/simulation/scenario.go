@@ -0,0 +1,203 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simulation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+// Step is a step of a scenario.
+type Step interface {
+	Run(ctx context.Context, s *Simulation) error
+}
+
+// StepFunc is an adapter to allow the use of
+// ordinary functions as the steps of a scenario.
+type StepFunc func(ctx context.Context, s *Simulation) error
+
+// Run implements the Step interface.
+func (f StepFunc) Run(ctx context.Context, s *Simulation) error {
+	return f(ctx, s)
+}
+
+// Scenario is the sequence of the steps run on a simulation.
+type Scenario struct {
+	Name  string
+	Steps []Step
+}
+
+// Run runs the steps of the scenario in order,
+// stopping at the first step which fails.
+func (sc Scenario) Run(ctx context.Context, s *Simulation) error {
+	for i, step := range sc.Steps {
+		if err := step.Run(ctx, s); err != nil {
+			return fmt.Errorf("scenario %q: step %d: %w", sc.Name, i, err)
+		}
+	}
+	return nil
+}
+
+// WaitConverged waits up to the timeout for the topology to converge.
+func WaitConverged(timeout time.Duration) Step {
+	return StepFunc(func(ctx context.Context, s *Simulation) error {
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		return s.WaitConverged(ctx)
+	})
+}
+
+// Upload uploads count random chunks from the i-th node.
+func Upload(i, count int) Step {
+	return StepFunc(func(ctx context.Context, s *Simulation) error {
+		for range count {
+			ch, err := s.RandomChunk()
+			if err != nil {
+				return err
+			}
+			if err := s.Upload(ctx, i, ch); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// CheckRetrievable checks that all the uploaded chunks are retrievable by the i-th node.
+func CheckRetrievable(i int) Step {
+	return StepFunc(func(ctx context.Context, s *Simulation) error {
+		for _, addr := range s.Uploaded() {
+			ch, err := s.Retrieve(ctx, i, addr)
+			if err != nil {
+				return err
+			}
+			if !ch.Address().Equal(addr) {
+				return fmt.Errorf("retrieved chunk %s instead of %s", ch.Address(), addr)
+			}
+		}
+		return nil
+	})
+}
+
+// CheckStored checks that all the uploaded chunks are stored
+// by at least one of the nodes of their neighborhood.
+func CheckStored() Step {
+	return StepFunc(func(_ context.Context, s *Simulation) error {
+	outer:
+		for _, ch := range s.uploadedChunks() {
+			for _, n := range s.Nodes() {
+				if swarm.Proximity(ch.Address().Bytes(), n.Overlay().Bytes()) >= s.StorageRadius() && n.Stores(ch) {
+					continue outer
+				}
+			}
+			return fmt.Errorf("chunk %s not stored in its neighborhood", ch.Address())
+		}
+		return nil
+	})
+}
+
+// WaitSynced waits up to the timeout for the uploaded chunks
+// to be synced to all the running nodes of their neighborhoods.
+func WaitSynced(timeout time.Duration) Step {
+	return StepFunc(func(ctx context.Context, s *Simulation) error {
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		return s.WaitSynced(ctx)
+	})
+}
+
+// StopNodes stops the nodes.
+func StopNodes(nodes ...int) Step {
+	return StepFunc(func(_ context.Context, s *Simulation) error {
+		for _, i := range nodes {
+			if err := s.StopNode(i); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// StartNodes starts the stopped nodes.
+func StartNodes(nodes ...int) Step {
+	return StepFunc(func(ctx context.Context, s *Simulation) error {
+		for _, i := range nodes {
+			if err := s.StartNode(ctx, i); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Churn starts all the stopped nodes and stops count random
+// running nodes other than the bootnodes.
+func Churn(count int) Step {
+	return StepFunc(func(ctx context.Context, s *Simulation) error {
+		stopped := s.randomNodes(len(s.Nodes()), false)
+		running := s.randomNodes(count, true)
+
+		for _, i := range running {
+			if err := s.StopNode(i); err != nil {
+				return err
+			}
+		}
+		for _, i := range stopped {
+			if err := s.StartNode(ctx, i); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Partition splits the network into the groups of nodes.
+func Partition(groups ...[]int) Step {
+	return StepFunc(func(_ context.Context, s *Simulation) error {
+		overlays := make([][]swarm.Address, 0, len(groups))
+		for _, g := range groups {
+			group := make([]swarm.Address, 0, len(g))
+			for _, i := range g {
+				group = append(group, s.Node(i).Overlay())
+			}
+			overlays = append(overlays, group)
+		}
+		s.Network().Partition(overlays...)
+		return nil
+	})
+}
+
+// Heal removes the network partition.
+func Heal() Step {
+	return StepFunc(func(_ context.Context, s *Simulation) error {
+		s.Network().Heal()
+		return nil
+	})
+}
+
+// SetLink changes the properties of the links between the nodes.
+func SetLink(link LinkOptions) Step {
+	return StepFunc(func(_ context.Context, s *Simulation) error {
+		s.Network().SetLink(link)
+		return nil
+	})
+}
+
+// Sleep waits for the duration.
+func Sleep(d time.Duration) Step {
+	return StepFunc(func(ctx context.Context, _ *Simulation) error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(d):
+			return nil
+		}
+	})
+}
@@ -0,0 +1,544 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simulation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/addressbook"
+	"github.com/ethersphere/bee/v2/pkg/bzz"
+	"github.com/ethersphere/bee/v2/pkg/crypto"
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/p2p"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+var (
+	errUnreachable       = errors.New("peer unreachable")
+	errConnectionRefused = errors.New("connection refused")
+	errStreamLost        = errors.New("stream lost")
+	errUnknownStream     = errors.New("unknown stream")
+)
+
+var (
+	_ p2p.Service      = (*Service)(nil)
+	_ p2p.Streamer     = (*Service)(nil)
+	_ p2p.Pinger       = (*Service)(nil)
+	_ p2p.Disconnecter = (*Service)(nil)
+)
+
+// blocklistEntry is a blocklisted peer.
+type blocklistEntry struct {
+	reason  string
+	expires time.Time // zero if not expiring
+}
+
+// Service is the in-memory p2p.Service of a simulated node. The connections
+// and streams of the services are routed through their network.
+type Service struct {
+	net         *Network
+	overlay     swarm.Address
+	address     bzz.Address
+	addressBook addressbook.Putter
+	logger      log.Logger
+
+	mu        sync.RWMutex
+	online    bool
+	halted    bool
+	protocols []p2p.ProtocolSpec
+	notifier  p2p.PickyNotifier
+	peers     map[string]*Service // connected peers by overlay
+	blocklist map[string]blocklistEntry
+}
+
+// NewService returns the service of the node with the given overlay,
+// registered in the network under a new underlay address.
+func (n *Network) NewService(signer crypto.Signer, overlay swarm.Address, networkID uint64, nonce []byte, ab addressbook.Putter, logger log.Logger) (*Service, error) {
+	underlay, err := n.newUnderlay()
+	if err != nil {
+		return nil, err
+	}
+	address, err := bzz.NewAddress(signer, underlay, overlay, networkID, nonce)
+	if err != nil {
+		return nil, fmt.Errorf("bzz address: %w", err)
+	}
+
+	s := &Service{
+		net:         n,
+		overlay:     overlay,
+		address:     *address,
+		addressBook: ab,
+		logger:      logger,
+		peers:       make(map[string]*Service),
+		blocklist:   make(map[string]blocklistEntry),
+	}
+	n.register(s)
+	return s, nil
+}
+
+// Address returns the bzz address of the service.
+func (s *Service) Address() bzz.Address {
+	return s.address
+}
+
+// start brings the service online.
+func (s *Service) start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.online = true
+	s.halted = false
+}
+
+// stop takes the service offline, closes all the connections
+// and removes the protocols and the notifier.
+func (s *Service) stop() {
+	s.mu.Lock()
+	s.online = false
+	s.mu.Unlock()
+
+	for _, peer := range s.connectedPeers() {
+		_ = s.Disconnect(peer.overlay, "node stopped")
+	}
+
+	s.mu.Lock()
+	s.protocols = nil
+	s.notifier = nil
+	s.mu.Unlock()
+}
+
+func (s *Service) isOnline() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.online
+}
+
+func (s *Service) AddProtocol(p p2p.ProtocolSpec) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.protocols = append(s.protocols, p)
+	return nil
+}
+
+func (s *Service) protocolSpecs() []p2p.ProtocolSpec {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return append([]p2p.ProtocolSpec(nil), s.protocols...)
+}
+
+func (s *Service) pickyNotifier() p2p.PickyNotifier {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.notifier
+}
+
+func (s *Service) SetPickyNotifier(n p2p.PickyNotifier) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.notifier = n
+}
+
+// Connect connects to the service listening on the underlay address, with the
+// connection accepted by the remote service in the same way as libp2p does.
+func (s *Service) Connect(ctx context.Context, addr ma.Multiaddr) (*bzz.Address, error) {
+	if !s.isOnline() {
+		return nil, p2p.ErrNetworkUnavailable
+	}
+
+	target, ok := s.net.lookup(addr)
+	if !ok || target == s {
+		return nil, fmt.Errorf("dial %s: %w", addr, errUnreachable)
+	}
+	if _, ok := s.peer(target.overlay); ok {
+		return &target.address, p2p.ErrAlreadyConnected
+	}
+	if s.isBlocklisted(target.overlay) {
+		return nil, p2p.ErrPeerBlocklisted
+	}
+	if !target.isOnline() || !s.net.reachable(s.overlay, target.overlay) || s.net.lost() {
+		return nil, fmt.Errorf("dial %s: %w", addr, errUnreachable)
+	}
+	if !target.accepts(s.overlay) {
+		return nil, fmt.Errorf("dial %s: %w", addr, errConnectionRefused)
+	}
+
+	// the handshake round trip
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-time.After(2 * s.net.latency()):
+	}
+
+	if err := s.addPeer(target); err != nil {
+		if errors.Is(err, p2p.ErrAlreadyConnected) {
+			s.reachable(target.overlay)
+			return &target.address, err
+		}
+		return nil, err
+	}
+	switch err := target.addPeer(s); {
+	case errors.Is(err, p2p.ErrAlreadyConnected):
+		// the target has been dialing at the same time
+		s.reachable(target.overlay)
+		return &target.address, err
+	case err != nil:
+		s.removePeer(target.overlay)
+		return nil, fmt.Errorf("dial %s: %w", addr, errUnreachable)
+	}
+
+	if err := s.addressBook.Put(target.overlay, target.address); err != nil {
+		_ = s.Disconnect(target.overlay, "unable to persist peer")
+		return nil, fmt.Errorf("storing bzz address: %w", err)
+	}
+
+	go target.connectedIn(s)
+
+	peer := p2p.Peer{Address: target.overlay, FullNode: true}
+	for _, p := range s.protocolSpecs() {
+		if p.ConnectOut == nil {
+			continue
+		}
+		if err := p.ConnectOut(ctx, peer); err != nil {
+			_ = s.Disconnect(target.overlay, "failed to process outbound connection notifier")
+			return nil, fmt.Errorf("connectOut: protocol: %s, version:%s: %w", p.Name, p.Version, err)
+		}
+	}
+
+	s.reachable(target.overlay)
+
+	return &target.address, nil
+}
+
+// reachable notifies the notifier that the connected peer is publicly reachable.
+func (s *Service) reachable(overlay swarm.Address) {
+	if n := s.pickyNotifier(); n != nil {
+		n.Reachable(overlay, p2p.ReachabilityStatusPublic)
+	}
+}
+
+// accepts reports whether the service accepts the connection from the peer.
+func (s *Service) accepts(overlay swarm.Address) bool {
+	s.mu.RLock()
+	halted := s.halted
+	s.mu.RUnlock()
+
+	if halted || s.isBlocklisted(overlay) {
+		return false
+	}
+	if n := s.pickyNotifier(); n != nil && !n.Pick(p2p.Peer{Address: overlay, FullNode: true}) {
+		return false
+	}
+	return true
+}
+
+// connectedIn handles the inbound connection from the peer.
+func (s *Service) connectedIn(peer *Service) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	if err := s.addressBook.Put(peer.overlay, peer.address); err != nil {
+		s.logger.Debug("storing bzz address failed", "peer", peer.overlay, "error", err)
+		_ = s.Disconnect(peer.overlay, "unable to persist peer")
+		return
+	}
+
+	p := p2p.Peer{Address: peer.overlay, FullNode: true}
+	for _, spec := range s.protocolSpecs() {
+		if spec.ConnectIn == nil {
+			continue
+		}
+		if err := spec.ConnectIn(ctx, p); err != nil {
+			s.logger.Debug("connectIn failed", "protocol", spec.Name, "version", spec.Version, "peer", peer.overlay, "error", err)
+			_ = s.Disconnect(peer.overlay, "failed to process inbound connection notifier")
+			return
+		}
+	}
+
+	n := s.pickyNotifier()
+	if n == nil {
+		return
+	}
+	if err := n.Connected(ctx, p, false); err != nil {
+		s.logger.Debug("notifier connected failed", "peer", peer.overlay, "error", err)
+		_ = s.Disconnect(peer.overlay, "unable to signal connection notifier")
+		return
+	}
+	s.reachable(peer.overlay)
+}
+
+// addPeer adds the connected peer if the service is online.
+func (s *Service) addPeer(peer *Service) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.online {
+		return p2p.ErrNetworkUnavailable
+	}
+	if _, ok := s.peers[peer.overlay.ByteString()]; ok {
+		return p2p.ErrAlreadyConnected
+	}
+	s.peers[peer.overlay.ByteString()] = peer
+	return nil
+}
+
+// removePeer removes the connected peer, it returns false if it is not connected.
+func (s *Service) removePeer(overlay swarm.Address) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.peers[overlay.ByteString()]; !ok {
+		return false
+	}
+	delete(s.peers, overlay.ByteString())
+	return true
+}
+
+func (s *Service) peer(overlay swarm.Address) (*Service, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	peer, ok := s.peers[overlay.ByteString()]
+	return peer, ok
+}
+
+func (s *Service) connectedPeers() []*Service {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	peers := make([]*Service, 0, len(s.peers))
+	for _, p := range s.peers {
+		peers = append(peers, p)
+	}
+	return peers
+}
+
+// Disconnect closes the connection to the peer on both sides.
+func (s *Service) Disconnect(overlay swarm.Address, reason string) error {
+	s.logger.Debug("simulation disconnect: disconnecting peer", "peer_address", overlay, "reason", reason)
+
+	peer, found := s.peer(overlay)
+	if !found || !s.removePeer(overlay) {
+		return p2p.ErrPeerNotFound
+	}
+
+	s.disconnected(overlay, true, reason)
+
+	if peer.removePeer(s.overlay) {
+		peer.disconnected(s.overlay, false, "")
+	}
+	return nil
+}
+
+// disconnected notifies the protocols and the notifier about the closed connection,
+// with the reason of the disconnection initiated by the service.
+func (s *Service) disconnected(overlay swarm.Address, out bool, reason string) {
+	p := p2p.Peer{Address: overlay, FullNode: true}
+	for _, spec := range s.protocolSpecs() {
+		f := spec.DisconnectIn
+		if out {
+			f = spec.DisconnectOut
+		}
+		if f == nil {
+			continue
+		}
+		if err := f(p); err != nil {
+			s.logger.Debug("disconnect notifier failed", "protocol", spec.Name, "version", spec.Version, "peer", overlay, "error", err)
+		}
+	}
+
+	if n := s.pickyNotifier(); n != nil {
+		if r, ok := n.(p2p.DisconnectReasoner); ok && out {
+			r.DisconnectReason(overlay, reason)
+		}
+		n.Disconnected(p)
+	}
+}
+
+func (s *Service) Blocklist(overlay swarm.Address, duration time.Duration, reason string) error {
+	if _, ok := s.peer(overlay); !ok {
+		return p2p.ErrPeerNotFound
+	}
+
+	var expires time.Time
+	if duration > 0 {
+		expires = time.Now().Add(duration)
+	}
+
+	s.mu.Lock()
+	s.blocklist[overlay.ByteString()] = blocklistEntry{reason: reason, expires: expires}
+	s.mu.Unlock()
+
+	_ = s.Disconnect(overlay, reason)
+	return nil
+}
+
+func (s *Service) isBlocklisted(overlay swarm.Address) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.blocklist[overlay.ByteString()]
+	if !ok {
+		return false
+	}
+	if !e.expires.IsZero() && time.Now().After(e.expires) {
+		delete(s.blocklist, overlay.ByteString())
+		return false
+	}
+	return true
+}
+
+func (s *Service) Blocklisted(overlay swarm.Address) (bool, error) {
+	return s.isBlocklisted(overlay), nil
+}
+
+func (s *Service) BlocklistedPeers() ([]p2p.BlockListedPeer, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	peers := make([]p2p.BlockListedPeer, 0, len(s.blocklist))
+	for k, e := range s.blocklist {
+		var duration time.Duration
+		if !e.expires.IsZero() {
+			if now.After(e.expires) {
+				delete(s.blocklist, k)
+				continue
+			}
+			duration = e.expires.Sub(now)
+		}
+		peers = append(peers, p2p.BlockListedPeer{
+			Peer:     p2p.Peer{Address: swarm.NewAddress([]byte(k)), FullNode: true},
+			Reason:   e.reason,
+			Duration: duration,
+		})
+	}
+	return peers, nil
+}
+
+func (s *Service) Peers() []p2p.Peer {
+	peers := s.connectedPeers()
+	ps := make([]p2p.Peer, 0, len(peers))
+	for _, p := range peers {
+		ps = append(ps, p2p.Peer{Address: p.overlay, FullNode: true})
+	}
+	return ps
+}
+
+func (s *Service) Addresses() ([]ma.Multiaddr, error) {
+	return []ma.Multiaddr{s.address.Underlay}, nil
+}
+
+func (s *Service) Halt() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.halted = true
+}
+
+func (s *Service) NetworkStatus() p2p.NetworkStatus {
+	if s.isOnline() {
+		return p2p.NetworkStatusAvailable
+	}
+	return p2p.NetworkStatusUnavailable
+}
+
+// Ping measures the round trip time to the service listening on the underlay address.
+func (s *Service) Ping(ctx context.Context, addr ma.Multiaddr) (time.Duration, error) {
+	target, ok := s.net.lookup(addr)
+	if !ok || !target.isOnline() || !s.net.reachable(s.overlay, target.overlay) || s.net.lost() {
+		return 0, fmt.Errorf("ping %s: %w", addr, errUnreachable)
+	}
+
+	start := time.Now()
+	select {
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	case <-time.After(2 * s.net.latency()):
+	}
+	return time.Since(start), nil
+}
+
+// NewStream opens the stream to the connected peer and runs the peer's
+// handler of the stream, handling its errors in the same way as libp2p does.
+func (s *Service) NewStream(ctx context.Context, overlay swarm.Address, headers p2p.Headers, protocol, version, streamName string) (p2p.Stream, error) {
+	peer, ok := s.peer(overlay)
+	if !ok {
+		return nil, p2p.ErrPeerNotFound
+	}
+
+	spec, ok := peer.streamSpec(protocol, version, streamName)
+	if !ok {
+		return nil, p2p.NewIncompatibleStreamError(fmt.Errorf("%s: %w", p2p.NewSwarmStreamName(protocol, version, streamName), errUnknownStream))
+	}
+
+	if s.net.lost() {
+		return nil, fmt.Errorf("new stream %s: %w", p2p.NewSwarmStreamName(protocol, version, streamName), errStreamLost)
+	}
+
+	if headers == nil {
+		headers = make(p2p.Headers)
+	}
+	local, remote := newStreamPair(s.net.latency)
+	local.headers = headers
+	remote.headers = headers
+	if spec.Headler != nil {
+		local.responseHeaders = spec.Headler(headers, s.overlay)
+		remote.responseHeaders = local.responseHeaders
+	}
+
+	go peer.handleStream(spec, s.overlay, remote)
+
+	return local, nil
+}
+
+func (s *Service) streamSpec(protocol, version, streamName string) (p2p.StreamSpec, bool) {
+	for _, p := range s.protocolSpecs() {
+		if p.Name != protocol || p.Version != version {
+			continue
+		}
+		for _, ss := range p.StreamSpecs {
+			if ss.Name == streamName {
+				return ss, true
+			}
+		}
+	}
+	return p2p.StreamSpec{}, false
+}
+
+func (s *Service) handleStream(spec p2p.StreamSpec, overlay swarm.Address, stream *stream) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err := spec.Handler(ctx, p2p.Peer{Address: overlay, FullNode: true}, stream)
+	if err == nil {
+		return
+	}
+
+	_ = stream.Reset()
+
+	var de *p2p.DisconnectError
+	if errors.As(err, &de) {
+		_ = s.Disconnect(overlay, de.Error())
+	}
+
+	var bpe *p2p.BlockPeerError
+	if errors.As(err, &bpe) {
+		if err := s.Blocklist(overlay, bpe.Duration(), bpe.Error()); err != nil {
+			s.logger.Debug("blocklist: could not blocklist peer", "peer_address", overlay, "error", err)
+		}
+	}
+
+	s.logger.Debug("handle protocol failed", "stream", spec.Name, "peer", overlay, "error", err)
+}
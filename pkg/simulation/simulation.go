@@ -0,0 +1,394 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package simulation runs many nodes with the real topology, discovery,
+// push, pull and retrieval protocols in a single process, connected over an
+// in-memory network with configurable latency and loss. The nodes store the
+// chunks in the reserve of an in-memory localstore which is synced within
+// the neighborhoods by the puller. It is used to run the scenarios of
+// uploads, churn and network partitions. The postage stamps are not
+// validated and there is no chain, so the redistribution game and the
+// storage radius changes of the batch expiry are not simulated. The salud
+// health checks do not run either, all the reachable peers are healthy.
+package simulation
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/cac"
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/postage"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"github.com/ethersphere/bee/v2/pkg/topology/kademlia"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+const (
+	defaultNetworkID = 1
+	// neighborhoodSize is the minimal number of nodes of each
+	// neighborhood of the default storage radius.
+	neighborhoodSize = 2
+	// convergeCheckInterval is the interval of the convergence checks.
+	convergeCheckInterval = 100 * time.Millisecond
+)
+
+var (
+	// ErrNotConverged is returned when the topology of the nodes has not converged.
+	ErrNotConverged = errors.New("topology not converged")
+	// ErrNotSynced is returned when the uploaded chunks are not
+	// stored by all the nodes of their neighborhoods.
+	ErrNotSynced = errors.New("chunks not synced")
+)
+
+// Options are the options of the simulation.
+type Options struct {
+	Nodes         int         // number of the simulated nodes
+	Bootnodes     int         // number of the nodes used as bootnodes, 1 if not set
+	NetworkID     uint64      // 1 if not set
+	StorageRadius *uint8      // storage radius of the nodes, derived from the node overlays if not set
+	Link          LinkOptions // properties of the links between the nodes
+	Seed          int64       // seed of the simulated randomness
+	Kademlia      kademlia.Options
+	Logger        log.Logger
+}
+
+// Simulation is the set of the simulated nodes of a network.
+type Simulation struct {
+	net           *Network
+	nodes         []*Node
+	bootnodes     int
+	storageRadius uint8
+
+	mu       sync.Mutex
+	rand     *rand.Rand
+	uploaded []swarm.Chunk
+}
+
+// New creates the nodes of the simulation and starts them.
+func New(ctx context.Context, o Options) (*Simulation, error) {
+	if o.Nodes <= 0 {
+		return nil, errors.New("no nodes")
+	}
+	if o.Bootnodes <= 0 {
+		o.Bootnodes = 1
+	}
+	o.Bootnodes = min(o.Bootnodes, o.Nodes)
+	if o.NetworkID == 0 {
+		o.NetworkID = defaultNetworkID
+	}
+	if o.Logger == nil {
+		o.Logger = log.Noop
+	}
+
+	s := &Simulation{
+		net:       NewNetwork(o.Link, o.Seed),
+		bootnodes: o.Bootnodes,
+		rand:      rand.New(rand.NewSource(o.Seed)),
+	}
+
+	nodeOpts := nodeOptions{
+		networkID: o.NetworkID,
+		kademlia:  o.Kademlia,
+		logger:    o.Logger,
+	}
+
+	// the bootnodes are created first to have their underlays known to the others
+	for i := 0; i < o.Nodes; i++ {
+		n, err := newNode(s.net, nodeOpts)
+		if err != nil {
+			return nil, fmt.Errorf("node %d: %w", i, err)
+		}
+		s.nodes = append(s.nodes, n)
+		if i < o.Bootnodes {
+			nodeOpts.bootnodes = append([]ma.Multiaddr(nil), nodeOpts.bootnodes...)
+			nodeOpts.bootnodes = append(nodeOpts.bootnodes, n.service.address.Underlay)
+		}
+	}
+	for _, n := range s.nodes[:o.Bootnodes] {
+		n.opts.bootnodes = nodeOpts.bootnodes
+	}
+
+	s.storageRadius = defaultStorageRadius(s.nodes[o.Bootnodes:])
+	if o.StorageRadius != nil {
+		s.storageRadius = *o.StorageRadius
+	}
+	for _, n := range s.nodes {
+		n.opts.storageRadius = s.storageRadius
+	}
+
+	for i, n := range s.nodes {
+		if err := n.Start(ctx); err != nil {
+			_ = s.Close()
+			return nil, fmt.Errorf("start node %d: %w", i, err)
+		}
+	}
+
+	return s, nil
+}
+
+// defaultStorageRadius returns the largest storage radius with each
+// of the neighborhoods of at least neighborhoodSize of the nodes, so
+// that no chunk is left without the nodes to store it.
+func defaultStorageRadius(nodes []*Node) uint8 {
+	var radius uint8
+	for r := uint8(1); 1<<r <= len(nodes)/neighborhoodSize; r++ {
+		sizes := make(map[uint64]int)
+		for _, n := range nodes {
+			sizes[binary.BigEndian.Uint64(n.overlay.Bytes())>>(64-r)]++
+		}
+		if len(sizes) < 1<<r {
+			break
+		}
+		for _, size := range sizes {
+			if size < neighborhoodSize {
+				return radius
+			}
+		}
+		radius = r
+	}
+	return radius
+}
+
+// Network returns the network of the simulation.
+func (s *Simulation) Network() *Network {
+	return s.net
+}
+
+// Nodes returns all the nodes of the simulation.
+func (s *Simulation) Nodes() []*Node {
+	return s.nodes
+}
+
+// Node returns the i-th node of the simulation.
+func (s *Simulation) Node(i int) *Node {
+	return s.nodes[i]
+}
+
+// StorageRadius returns the storage radius of the nodes.
+func (s *Simulation) StorageRadius() uint8 {
+	return s.storageRadius
+}
+
+// Uploaded returns the addresses of the chunks uploaded by the scenario steps.
+func (s *Simulation) Uploaded() []swarm.Address {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	addrs := make([]swarm.Address, 0, len(s.uploaded))
+	for _, ch := range s.uploaded {
+		addrs = append(addrs, ch.Address())
+	}
+	return addrs
+}
+
+// uploadedChunks returns the chunks uploaded by the scenario steps.
+func (s *Simulation) uploadedChunks() []swarm.Chunk {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]swarm.Chunk(nil), s.uploaded...)
+}
+
+func (s *Simulation) addUploaded(ch swarm.Chunk) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.uploaded = append(s.uploaded, ch)
+}
+
+// running returns the indices of the running nodes which are not bootnodes.
+func (s *Simulation) running() []int {
+	var running []int
+	for i := s.bootnodes; i < len(s.nodes); i++ {
+		if s.nodes[i].Running() {
+			running = append(running, i)
+		}
+	}
+	return running
+}
+
+// CheckConverged checks whether the topology of the running nodes has
+// converged: each node is connected to all the reachable running nodes of
+// its neighborhood, and to at least one of the reachable running nodes of
+// each of the shallower bins which have any, with the connected peers
+// taken from the topology of the node. The bootnodes serve the discovery
+// only, as the topology does not select them for the protocols, so they
+// are not taken into account.
+func (s *Simulation) CheckConverged() error {
+	running := s.running()
+	for _, i := range running {
+		n := s.nodes[i]
+		peers := n.connectedPeers()
+
+		var binNodes, binConnected [swarm.MaxBins]int
+		for _, j := range running {
+			peer := s.nodes[j]
+			if i == j || !s.net.reachable(n.overlay, peer.overlay) {
+				continue
+			}
+			po := swarm.Proximity(n.overlay.Bytes(), peer.overlay.Bytes())
+			_, connected := peers[peer.overlay.ByteString()]
+			if po >= s.storageRadius && !connected {
+				return fmt.Errorf("%w: node %d not connected to neighbor %d", ErrNotConverged, i, j)
+			}
+			binNodes[po]++
+			if connected {
+				binConnected[po]++
+			}
+		}
+		for po := uint8(0); po < s.storageRadius; po++ {
+			if binNodes[po] > 0 && binConnected[po] == 0 {
+				return fmt.Errorf("%w: node %d has no peers in bin %d", ErrNotConverged, i, po)
+			}
+		}
+	}
+	return nil
+}
+
+// WaitConverged waits for the topology of the running nodes to converge.
+func (s *Simulation) WaitConverged(ctx context.Context) error {
+	ticker := time.NewTicker(convergeCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		err := s.CheckConverged()
+		if err == nil {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w: %w", ctx.Err(), err)
+		case <-ticker.C:
+		}
+	}
+}
+
+// CheckSynced checks that each of the uploaded chunks is stored by all the
+// running nodes of its neighborhood, the bootnodes are not taken into account.
+func (s *Simulation) CheckSynced() error {
+	running := s.running()
+	for _, ch := range s.uploadedChunks() {
+		for _, i := range running {
+			n := s.nodes[i]
+			if swarm.Proximity(ch.Address().Bytes(), n.overlay.Bytes()) < s.storageRadius {
+				continue
+			}
+			if !n.Stores(ch) {
+				return fmt.Errorf("%w: chunk %s not stored by node %d", ErrNotSynced, ch.Address(), i)
+			}
+		}
+	}
+	return nil
+}
+
+// WaitSynced waits for the uploaded chunks to be synced to all the running
+// nodes of their neighborhoods.
+func (s *Simulation) WaitSynced(ctx context.Context) error {
+	ticker := time.NewTicker(convergeCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		err := s.CheckSynced()
+		if err == nil {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w: %w", ctx.Err(), err)
+		case <-ticker.C:
+		}
+	}
+}
+
+// Upload uploads the chunk from the i-th node.
+func (s *Simulation) Upload(ctx context.Context, i int, ch swarm.Chunk) error {
+	if _, err := s.nodes[i].Upload(ctx, ch); err != nil {
+		return fmt.Errorf("upload %s from node %d: %w", ch.Address(), i, err)
+	}
+	s.addUploaded(ch)
+	return nil
+}
+
+// Retrieve retrieves the chunk by the i-th node.
+func (s *Simulation) Retrieve(ctx context.Context, i int, addr swarm.Address) (swarm.Chunk, error) {
+	ch, err := s.nodes[i].Retrieve(ctx, addr)
+	if err != nil {
+		return nil, fmt.Errorf("retrieve %s by node %d: %w", addr, i, err)
+	}
+	return ch, nil
+}
+
+// RandomChunk returns a new content addressed chunk
+// of random data with a stamp of random content.
+func (s *Simulation) RandomChunk() (swarm.Chunk, error) {
+	data := make([]byte, swarm.ChunkSize)
+	batchID := make([]byte, swarm.HashSize)
+	index := make([]byte, 8)
+	timestamp := make([]byte, 8)
+	sig := make([]byte, 65)
+
+	s.mu.Lock()
+	for _, b := range [][]byte{data, batchID, index, timestamp, sig} {
+		_, _ = s.rand.Read(b)
+	}
+	s.mu.Unlock()
+
+	ch, err := cac.New(data)
+	if err != nil {
+		return nil, err
+	}
+	return ch.WithStamp(postage.NewStamp(batchID, index, timestamp, sig)), nil
+}
+
+// StopNode stops the i-th node.
+func (s *Simulation) StopNode(i int) error {
+	if err := s.nodes[i].Stop(); err != nil {
+		return fmt.Errorf("stop node %d: %w", i, err)
+	}
+	return nil
+}
+
+// StartNode starts the stopped i-th node.
+func (s *Simulation) StartNode(ctx context.Context, i int) error {
+	if err := s.nodes[i].Start(ctx); err != nil {
+		return fmt.Errorf("start node %d: %w", i, err)
+	}
+	return nil
+}
+
+// randomNodes returns count random indices of the running or stopped
+// nodes which are not bootnodes.
+func (s *Simulation) randomNodes(count int, running bool) []int {
+	var candidates []int
+	for i := s.bootnodes; i < len(s.nodes); i++ {
+		if s.nodes[i].Running() == running {
+			candidates = append(candidates, i)
+		}
+	}
+
+	s.mu.Lock()
+	s.rand.Shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+	s.mu.Unlock()
+
+	return candidates[:min(count, len(candidates))]
+}
+
+// Close stops all the nodes and closes their localstores.
+func (s *Simulation) Close() error {
+	var errs []error
+	for i, n := range s.nodes {
+		if err := n.close(); err != nil {
+			errs = append(errs, fmt.Errorf("close node %d: %w", i, err))
+		}
+	}
+	return errors.Join(errs...)
+}
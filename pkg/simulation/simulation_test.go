@@ -0,0 +1,185 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simulation_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/simulation"
+)
+
+const convergeTimeout = time.Minute
+
+func newSimulation(t *testing.T, o simulation.Options) *simulation.Simulation {
+	t.Helper()
+
+	sim, err := simulation.New(context.Background(), o)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := sim.Close(); err != nil {
+			t.Error(err)
+		}
+	})
+	return sim
+}
+
+func TestUploadRetrieve(t *testing.T) {
+	t.Parallel()
+
+	sim := newSimulation(t, simulation.Options{
+		Nodes:     20,
+		Bootnodes: 2,
+		Link:      simulation.LinkOptions{Latency: time.Millisecond, Jitter: time.Millisecond},
+	})
+
+	err := simulation.Scenario{
+		Name: "upload and retrieve",
+		Steps: []simulation.Step{
+			simulation.WaitConverged(convergeTimeout),
+			simulation.Upload(3, 20),
+			simulation.CheckStored(),
+			simulation.CheckRetrievable(17),
+		},
+	}.Run(context.Background(), sim)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := len(sim.Uploaded()); got != 20 {
+		t.Fatalf("got %d uploaded chunks, want %d", got, 20)
+	}
+}
+
+func TestPullSync(t *testing.T) {
+	t.Parallel()
+
+	sim := newSimulation(t, simulation.Options{Nodes: 16, Bootnodes: 2})
+
+	err := simulation.Scenario{
+		Name: "pull sync",
+		Steps: []simulation.Step{
+			simulation.WaitConverged(convergeTimeout),
+			simulation.Upload(5, 10),
+			simulation.WaitSynced(convergeTimeout),
+			// the restarted node syncs the chunks of its
+			// neighborhood uploaded while it was stopped
+			simulation.StopNodes(7),
+			simulation.Upload(5, 10),
+			simulation.StartNodes(7),
+			simulation.WaitConverged(convergeTimeout),
+			simulation.WaitSynced(convergeTimeout),
+		},
+	}.Run(context.Background(), sim)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPartition(t *testing.T) {
+	t.Parallel()
+
+	sim := newSimulation(t, simulation.Options{Nodes: 16, Bootnodes: 2})
+
+	var left, right []int
+	for i := range sim.Nodes() {
+		if i%2 == 0 {
+			left = append(left, i)
+		} else {
+			right = append(right, i)
+		}
+	}
+
+	err := simulation.Scenario{
+		Name: "partition",
+		Steps: []simulation.Step{
+			simulation.WaitConverged(convergeTimeout),
+			simulation.Partition(left, right),
+			simulation.StepFunc(func(_ context.Context, s *simulation.Simulation) error {
+				for _, i := range left {
+					for _, p := range s.Node(i).Service().Peers() {
+						for _, j := range right {
+							if p.Address.Equal(s.Node(j).Overlay()) {
+								return errors.New("connected across the partition")
+							}
+						}
+					}
+				}
+				return nil
+			}),
+			simulation.WaitConverged(convergeTimeout),
+			simulation.Heal(),
+			// the peers unreachable during the partition are pruned, the
+			// restarted nodes rediscover the other side from the bootnodes
+			simulation.StopNodes(right...),
+			simulation.StartNodes(right...),
+			simulation.WaitConverged(convergeTimeout),
+			simulation.Upload(3, 5),
+			simulation.CheckRetrievable(2),
+		},
+	}.Run(context.Background(), sim)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestChurn(t *testing.T) {
+	t.Parallel()
+
+	sim := newSimulation(t, simulation.Options{Nodes: 16})
+
+	err := simulation.Scenario{
+		Name: "churn",
+		Steps: []simulation.Step{
+			simulation.WaitConverged(convergeTimeout),
+			simulation.Churn(3),
+			simulation.WaitConverged(convergeTimeout),
+			simulation.Churn(3),
+			simulation.WaitConverged(convergeTimeout),
+			simulation.Churn(0),
+			simulation.WaitConverged(convergeTimeout),
+		},
+	}.Run(context.Background(), sim)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, n := range sim.Nodes() {
+		if !n.Running() {
+			t.Fatalf("node %d not running", i)
+		}
+	}
+}
+
+func TestLoss(t *testing.T) {
+	t.Parallel()
+
+	sim := newSimulation(t, simulation.Options{
+		Nodes: 8,
+		Link:  simulation.LinkOptions{Loss: 1},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	if err := sim.WaitConverged(ctx); !errors.Is(err, simulation.ErrNotConverged) {
+		t.Fatalf("got error %v, want %v", err, simulation.ErrNotConverged)
+	}
+
+	err := simulation.Scenario{
+		Name: "recovery",
+		Steps: []simulation.Step{
+			simulation.SetLink(simulation.LinkOptions{}),
+			simulation.WaitConverged(convergeTimeout),
+		},
+	}.Run(context.Background(), sim)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
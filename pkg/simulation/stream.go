@@ -0,0 +1,194 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simulation
+
+import (
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/p2p"
+)
+
+// closeTimeout is the time to wait for the other side
+// to close the stream on the full close of the stream.
+const closeTimeout = 30 * time.Second
+
+var (
+	errStreamReset   = errors.New("stream reset")
+	errStreamClosed  = errors.New("stream closed")
+	errCloseTimeout  = errors.New("stream close timeout")
+	errExpectedEOF   = errors.New("read: expected eof")
+	errWriteOnClosed = errors.New("write on closed stream")
+)
+
+// segment is the written data which can be read once ready.
+type segment struct {
+	data  []byte
+	ready time.Time
+}
+
+// pipe is the one directional channel of a stream which delivers the
+// written data to the reader with the delay of the link latency.
+type pipe struct {
+	mu       sync.Mutex
+	changed  chan struct{} // closed and replaced on every change
+	segments []segment
+	eof      bool  // the writing side is closed
+	err      error // the pipe is reset or closed for reading
+}
+
+func newPipe() *pipe {
+	return &pipe{changed: make(chan struct{})}
+}
+
+// notify must be called under lock.
+func (p *pipe) notify() {
+	close(p.changed)
+	p.changed = make(chan struct{})
+}
+
+func (p *pipe) write(b []byte, delay time.Duration) (int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.err != nil {
+		return 0, p.err
+	}
+	if p.eof {
+		return 0, errWriteOnClosed
+	}
+
+	// the order of the written data is preserved
+	ready := time.Now().Add(delay)
+	if n := len(p.segments); n > 0 && p.segments[n-1].ready.After(ready) {
+		ready = p.segments[n-1].ready
+	}
+	p.segments = append(p.segments, segment{data: append([]byte(nil), b...), ready: ready})
+	p.notify()
+	return len(b), nil
+}
+
+// read reads the ready data, waiting for it no longer than the timeout if set.
+func (p *pipe) read(b []byte, timeout <-chan time.Time) (int, error) {
+	for {
+		p.mu.Lock()
+		if p.err != nil {
+			p.mu.Unlock()
+			return 0, p.err
+		}
+		var wait <-chan time.Time
+		if len(p.segments) > 0 {
+			s := &p.segments[0]
+			if d := time.Until(s.ready); d > 0 {
+				wait = time.After(d)
+			} else {
+				n := copy(b, s.data)
+				if s.data = s.data[n:]; len(s.data) == 0 {
+					p.segments = p.segments[1:]
+				}
+				p.mu.Unlock()
+				return n, nil
+			}
+		} else if p.eof {
+			p.mu.Unlock()
+			return 0, io.EOF
+		}
+		changed := p.changed
+		p.mu.Unlock()
+
+		select {
+		case <-changed:
+		case <-wait:
+		case <-timeout:
+			return 0, errCloseTimeout
+		}
+	}
+}
+
+// close closes the writing side of the pipe.
+func (p *pipe) close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.eof = true
+	p.notify()
+}
+
+// fail fails all the following reads and writes with the error.
+func (p *pipe) fail(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.err == nil {
+		p.err = err
+	}
+	p.notify()
+}
+
+var _ p2p.Stream = (*stream)(nil)
+
+// stream is one side of an in-memory bidirectional stream.
+type stream struct {
+	in              *pipe
+	out             *pipe
+	latency         func() time.Duration
+	headers         p2p.Headers
+	responseHeaders p2p.Headers
+}
+
+// newStreamPair returns the two sides of a new stream over the link with the latency.
+func newStreamPair(latency func() time.Duration) (*stream, *stream) {
+	a, b := newPipe(), newPipe()
+	return &stream{in: a, out: b, latency: latency}, &stream{in: b, out: a, latency: latency}
+}
+
+func (s *stream) Read(b []byte) (int, error) {
+	return s.in.read(b, nil)
+}
+
+func (s *stream) Write(b []byte) (int, error) {
+	return s.out.write(b, s.latency())
+}
+
+func (s *stream) Headers() p2p.Headers {
+	return s.headers
+}
+
+func (s *stream) ResponseHeaders() p2p.Headers {
+	return s.responseHeaders
+}
+
+// Close closes the stream, the other side reads the data written so far.
+func (s *stream) Close() error {
+	s.out.close()
+	s.in.fail(errStreamClosed)
+	return nil
+}
+
+// FullClose closes the stream and waits for the other side to close it too.
+func (s *stream) FullClose() error {
+	defer s.Close()
+
+	s.out.close()
+
+	n, err := s.in.read(make([]byte, 1), time.After(closeTimeout))
+	if n > 0 || err == nil {
+		_ = s.Reset()
+		return errExpectedEOF
+	}
+	if !errors.Is(err, io.EOF) {
+		_ = s.Reset()
+		return err
+	}
+	return nil
+}
+
+func (s *stream) Reset() error {
+	s.in.fail(errStreamReset)
+	s.out.fail(errStreamReset)
+	return nil
+}
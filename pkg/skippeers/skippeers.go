@@ -21,6 +21,8 @@ type List struct {
 	quit chan struct{}
 	// key is chunk address, value is map of peer address to expiration
 	skip map[string]map[string]int64
+	// onAdd is called with every peer added to the list
+	onAdd func(peer swarm.Address)
 
 	wg sync.WaitGroup
 }
@@ -57,11 +59,26 @@ func (l *List) worker(d time.Duration) {
 	}
 }
 
+// OnAdd sets the function which is called with the peer of every entry added
+// to the list, so that the skip history feeds the records of the peer failures.
+func (l *List) OnAdd(f func(peer swarm.Address)) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	l.onAdd = f
+}
+
 func (l *List) Forever(chunk, peer swarm.Address) {
 	l.Add(chunk, peer, maxDuration)
 }
 
 func (l *List) Add(chunk, peer swarm.Address, expire time.Duration) {
+	if onAdd := l.add(chunk, peer, expire); onAdd != nil {
+		onAdd(peer)
+	}
+}
+
+func (l *List) add(chunk, peer swarm.Address, expire time.Duration) func(swarm.Address) {
 
 	l.mtx.Lock()
 	defer l.mtx.Unlock()
@@ -79,6 +96,8 @@ func (l *List) Add(chunk, peer swarm.Address, expire time.Duration) {
 	}
 
 	l.skip[chunk.ByteString()][peer.ByteString()] = t
+
+	return l.onAdd
 }
 
 func (l *List) ChunkPeers(ch swarm.Address) (peers []swarm.Address) {
@@ -142,3 +142,23 @@ func TestPruneWorker(t *testing.T) {
 		t.Fatal("peer2 should be in skiplist")
 	}
 }
+
+func TestOnAdd(t *testing.T) {
+	t.Parallel()
+
+	skipList := skippeers.NewList(0)
+	t.Cleanup(func() { skipList.Close() })
+
+	var added []swarm.Address
+	skipList.OnAdd(func(peer swarm.Address) {
+		added = append(added, peer)
+	})
+
+	peer := swarm.RandAddress(t)
+	skipList.Add(swarm.RandAddress(t), peer, time.Minute)
+	skipList.Forever(swarm.RandAddress(t), peer)
+
+	if len(added) != 2 || !added[0].Equal(peer) || !added[1].Equal(peer) {
+		t.Fatalf("got added peers %v, want %v twice", added, peer)
+	}
+}
@@ -42,6 +42,10 @@ func (i *Batch) Put(item storage.Item) error {
 		return fmt.Errorf("unable to marshal item: %w", err)
 	}
 
+	if val, err = seal(i.store.sealer, val); err != nil {
+		return err
+	}
+
 	i.mu.Lock()
 	i.batch.Put(key(item), val)
 	i.mu.Unlock()
@@ -0,0 +1,84 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package leveldbstore
+
+import (
+	"fmt"
+
+	"github.com/ethersphere/bee/v2/pkg/crypto"
+	"github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+)
+
+var _ storage.Reader = (*Snapshot)(nil)
+
+// Snapshot is a frozen, read-only view of the store at
+// the point in time when the snapshot was taken.
+type Snapshot struct {
+	snap   *leveldb.Snapshot
+	sealer *crypto.Sealer
+}
+
+// Snapshot returns a new point-in-time view of the store.
+// Release must be called once the snapshot is no longer needed.
+func (s *Store) Snapshot() (*Snapshot, error) {
+	snap, err := s.db.GetSnapshot()
+	if err != nil {
+		return nil, fmt.Errorf("get snapshot: %w", err)
+	}
+	return &Snapshot{snap: snap, sealer: s.sealer}, nil
+}
+
+// Get implements the storage.Reader interface.
+func (s *Snapshot) Get(item storage.Item) error {
+	return get(s.snap, s.sealer, item)
+}
+
+// Has implements the storage.Reader interface.
+func (s *Snapshot) Has(k storage.Key) (bool, error) {
+	return s.snap.Has(key(k), nil)
+}
+
+// GetSize implements the storage.Reader interface.
+func (s *Snapshot) GetSize(k storage.Key) (int, error) {
+	return getSize(s.snap, s.sealer, k)
+}
+
+// Iterate implements the storage.Reader interface.
+func (s *Snapshot) Iterate(q storage.Query, fn storage.IterateFn) error {
+	return iterate(s.snap, s.sealer, q, fn)
+}
+
+// Count implements the storage.Reader interface.
+func (s *Snapshot) Count(key storage.Key) (int, error) {
+	return count(s.snap, key)
+}
+
+// IterateRaw iterates over all the raw key/value pairs of the snapshot
+// regardless of their namespace. The values of an encrypted store are
+// passed decrypted. The passed slices must not be retained by the callback.
+func (s *Snapshot) IterateRaw(fn func(key, value []byte) (bool, error)) error {
+	iter := s.snap.NewIterator(nil, &opt.ReadOptions{DontFillCache: true})
+	defer iter.Release()
+
+	for iter.Next() {
+		value, err := open(s.sealer, iter.Value())
+		if err != nil {
+			return err
+		}
+		if stop, err := fn(iter.Key(), value); err != nil {
+			return err
+		} else if stop {
+			break
+		}
+	}
+	return iter.Error()
+}
+
+// Release releases the snapshot. The snapshot must not be used afterwards.
+func (s *Snapshot) Release() {
+	s.snap.Release()
+}
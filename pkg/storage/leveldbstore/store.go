@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/ethersphere/bee/v2/pkg/crypto"
 	"github.com/ethersphere/bee/v2/pkg/storage"
 	"github.com/syndtr/goleveldb/leveldb"
 	ldbErrors "github.com/syndtr/goleveldb/leveldb/errors"
@@ -39,6 +40,14 @@ func (f filters) matchAny(k string, v []byte) bool {
 	return false
 }
 
+// reader is the subset of the levelDB read operations
+// shared by the database and its snapshots.
+type reader interface {
+	Get(key []byte, ro *opt.ReadOptions) ([]byte, error)
+	Has(key []byte, ro *opt.ReadOptions) (bool, error)
+	NewIterator(slice *util.Range, ro *opt.ReadOptions) iterator.Iterator
+}
+
 // Storer returns the underlying db store.
 type Storer interface {
 	DB() *leveldb.DB
@@ -50,13 +59,25 @@ var (
 )
 
 type Store struct {
-	db   *leveldb.DB
-	path string
+	db     *leveldb.DB
+	path   string
+	sealer *crypto.Sealer
 }
 
 // New returns a new store the backed by leveldb.
 // If path == "", the leveldb will run with in memory backend storage.
 func New(path string, opts *opt.Options) (*Store, error) {
+	return newStore(path, opts, nil)
+}
+
+// NewEncrypted returns a new store like New which encrypts the values at
+// rest with the given sealer. The keys are stored in plaintext in order to
+// preserve the ordering of the iterations.
+func NewEncrypted(path string, opts *opt.Options, sealer *crypto.Sealer) (*Store, error) {
+	return newStore(path, opts, sealer)
+}
+
+func newStore(path string, opts *opt.Options, sealer *crypto.Sealer) (*Store, error) {
 	var (
 		err error
 		db  *leveldb.DB
@@ -76,8 +97,9 @@ func New(path string, opts *opt.Options) (*Store, error) {
 	}
 
 	return &Store{
-		db:   db,
-		path: path,
+		db:     db,
+		path:   path,
+		sealer: sealer,
 	}, nil
 }
 
@@ -93,7 +115,51 @@ func (s *Store) Close() (err error) {
 
 // Get implements the storage.Store interface.
 func (s *Store) Get(item storage.Item) error {
-	val, err := s.db.Get(key(item), nil)
+	return get(s.db, s.sealer, item)
+}
+
+// Has implements the storage.Store interface.
+func (s *Store) Has(k storage.Key) (bool, error) {
+	return s.db.Has(key(k), nil)
+}
+
+// GetSize implements the storage.Store interface.
+func (s *Store) GetSize(k storage.Key) (int, error) {
+	return getSize(s.db, s.sealer, k)
+}
+
+// Iterate implements the storage.Store interface.
+func (s *Store) Iterate(q storage.Query, fn storage.IterateFn) error {
+	return iterate(s.db, s.sealer, q, fn)
+}
+
+// Count implements the storage.Store interface.
+func (s *Store) Count(key storage.Key) (int, error) {
+	return count(s.db, key)
+}
+
+// seal encrypts the value if the sealer is set.
+func seal(s *crypto.Sealer, val []byte) ([]byte, error) {
+	if s == nil {
+		return val, nil
+	}
+	return s.Seal(nil, val)
+}
+
+// open decrypts the value if the sealer is set.
+func open(s *crypto.Sealer, val []byte) ([]byte, error) {
+	if s == nil {
+		return val, nil
+	}
+	val, err := s.Open(nil, val)
+	if err != nil {
+		return nil, fmt.Errorf("failed decrypting value: %w", err)
+	}
+	return val, nil
+}
+
+func get(r reader, s *crypto.Sealer, item storage.Item) error {
+	val, err := r.Get(key(item), nil)
 
 	if errors.Is(err, leveldb.ErrNotFound) {
 		return storage.ErrNotFound
@@ -103,6 +169,10 @@ func (s *Store) Get(item storage.Item) error {
 		return err
 	}
 
+	if val, err = open(s, val); err != nil {
+		return err
+	}
+
 	if err = item.Unmarshal(val); err != nil {
 		return fmt.Errorf("failed decoding value %w", err)
 	}
@@ -110,14 +180,8 @@ func (s *Store) Get(item storage.Item) error {
 	return nil
 }
 
-// Has implements the storage.Store interface.
-func (s *Store) Has(k storage.Key) (bool, error) {
-	return s.db.Has(key(k), nil)
-}
-
-// GetSize implements the storage.Store interface.
-func (s *Store) GetSize(k storage.Key) (int, error) {
-	val, err := s.db.Get(key(k), nil)
+func getSize(r reader, s *crypto.Sealer, k storage.Key) (int, error) {
+	val, err := r.Get(key(k), nil)
 
 	if errors.Is(err, leveldb.ErrNotFound) {
 		return 0, storage.ErrNotFound
@@ -127,11 +191,13 @@ func (s *Store) GetSize(k storage.Key) (int, error) {
 		return 0, err
 	}
 
+	if s != nil {
+		return len(val) - crypto.SealOverhead, nil
+	}
 	return len(val), nil
 }
 
-// Iterate implements the storage.Store interface.
-func (s *Store) Iterate(q storage.Query, fn storage.IterateFn) error {
+func iterate(r reader, s *crypto.Sealer, q storage.Query, fn storage.IterateFn) error {
 	if err := q.Validate(); err != nil {
 		return fmt.Errorf("failed iteration: %w", err)
 	}
@@ -153,7 +219,7 @@ func (s *Store) Iterate(q storage.Query, fn storage.IterateFn) error {
 
 	if q.PrefixAtStart {
 		prefix = q.Factory().Namespace()
-		iter = s.db.NewIterator(util.BytesPrefix([]byte(prefix)), iterOpts)
+		iter = r.NewIterator(util.BytesPrefix([]byte(prefix)), iterOpts)
 		exists := iter.Seek([]byte(prefix + separator + q.Prefix))
 		if !exists {
 			return nil
@@ -166,7 +232,7 @@ func (s *Store) Iterate(q storage.Query, fn storage.IterateFn) error {
 		if q.Factory().Namespace() != "" {
 			prefix = q.Factory().Namespace() + separator + q.Prefix
 		}
-		iter = s.db.NewIterator(util.BytesPrefix([]byte(prefix)), iterOpts)
+		iter = r.NewIterator(util.BytesPrefix([]byte(prefix)), iterOpts)
 	}
 
 	nextF := iter.Next
@@ -189,6 +255,20 @@ func (s *Store) Iterate(q storage.Query, fn storage.IterateFn) error {
 		nextVal := make([]byte, len(valRaw))
 		copy(nextVal, valRaw)
 
+		// the value is decrypted only if it is needed, the size
+		// of the plaintext is known without the decryption.
+		size := len(nextVal)
+		if s != nil {
+			size -= crypto.SealOverhead
+			if len(q.Filters) > 0 || q.ItemProperty == storage.QueryItem {
+				var err error
+				if nextVal, err = open(s, nextVal); err != nil {
+					retErr = errors.Join(retErr, err)
+					break
+				}
+			}
+		}
+
 		key := strings.TrimPrefix(string(nextKey), prefix)
 
 		if filters(q.Filters).matchAny(key, nextVal) {
@@ -207,7 +287,7 @@ func (s *Store) Iterate(q storage.Query, fn storage.IterateFn) error {
 
 		switch q.ItemProperty {
 		case storage.QueryItemID, storage.QueryItemSize:
-			res = &storage.Result{ID: key, Size: len(nextVal)}
+			res = &storage.Result{ID: key, Size: size}
 		case storage.QueryItem:
 			newItem := q.Factory()
 			err = newItem.Unmarshal(nextVal)
@@ -239,10 +319,9 @@ func (s *Store) Iterate(q storage.Query, fn storage.IterateFn) error {
 	return retErr
 }
 
-// Count implements the storage.Store interface.
-func (s *Store) Count(key storage.Key) (int, error) {
+func count(r reader, key storage.Key) (int, error) {
 	keys := util.BytesPrefix([]byte(key.Namespace() + separator))
-	iter := s.db.NewIterator(keys, nil)
+	iter := r.NewIterator(keys, nil)
 
 	var c int
 	for iter.Next() {
@@ -261,9 +340,29 @@ func (s *Store) Put(item storage.Item) error {
 		return fmt.Errorf("failed serializing: %w", err)
 	}
 
+	if value, err = seal(s.sealer, value); err != nil {
+		return err
+	}
+
 	return s.db.Put(key(item), value, nil)
 }
 
+// PutRaw stores the value under the given raw key, which is
+// expected to be taken from Snapshot.IterateRaw.
+func (s *Store) PutRaw(key, value []byte) error {
+	value, err := seal(s.sealer, value)
+	if err != nil {
+		return err
+	}
+	return s.db.Put(key, value, nil)
+}
+
+// DeleteRaw deletes the value under the given raw key, which is
+// expected to be taken from Snapshot.IterateRaw.
+func (s *Store) DeleteRaw(key []byte) error {
+	return s.db.Delete(key, nil)
+}
+
 // Delete implements the storage.Store interface.
 func (s *Store) Delete(item storage.Item) error {
 	// this is a small hack to make the deletion of old entries work. As they
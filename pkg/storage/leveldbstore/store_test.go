@@ -5,8 +5,11 @@
 package leveldbstore_test
 
 import (
+	"bytes"
 	"testing"
 
+	"github.com/ethersphere/bee/v2/pkg/crypto"
+	"github.com/ethersphere/bee/v2/pkg/storage"
 	"github.com/ethersphere/bee/v2/pkg/storage/leveldbstore"
 	"github.com/ethersphere/bee/v2/pkg/storage/storagetest"
 	"github.com/syndtr/goleveldb/leveldb/opt"
@@ -23,6 +26,86 @@ func TestStore(t *testing.T) {
 	storagetest.TestStore(t, store)
 }
 
+func TestEncryptedStore(t *testing.T) {
+	t.Parallel()
+
+	sealer, err := crypto.NewSealer(bytes.Repeat([]byte{0x1}, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("store", func(t *testing.T) {
+		t.Parallel()
+
+		store, err := leveldbstore.NewEncrypted(t.TempDir(), nil, sealer)
+		if err != nil {
+			t.Fatalf("create store failed: %v", err)
+		}
+		t.Cleanup(func() { _ = store.Close() })
+		storagetest.TestStore(t, store)
+	})
+
+	t.Run("batched store", func(t *testing.T) {
+		t.Parallel()
+
+		store, err := leveldbstore.NewEncrypted("", nil, sealer)
+		if err != nil {
+			t.Fatalf("create store failed: %v", err)
+		}
+		t.Cleanup(func() { _ = store.Close() })
+		storagetest.TestBatchedStore(t, store)
+	})
+
+	t.Run("values encrypted", func(t *testing.T) {
+		t.Parallel()
+
+		store, err := leveldbstore.NewEncrypted("", nil, sealer)
+		if err != nil {
+			t.Fatalf("create store failed: %v", err)
+		}
+		t.Cleanup(func() { _ = store.Close() })
+
+		want := []byte("plaintext value")
+		if err := store.Put(&testItem{id: "id", value: want}); err != nil {
+			t.Fatal(err)
+		}
+
+		iter := store.DB().NewIterator(nil, nil)
+		for iter.Next() {
+			if bytes.Contains(iter.Value(), want) {
+				t.Fatal("value stored in plaintext")
+			}
+		}
+		iter.Release()
+
+		item := &testItem{id: "id"}
+		if err := store.Get(item); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(item.value, want) {
+			t.Fatalf("want value %q, got %q", want, item.value)
+		}
+		if size, err := store.GetSize(item); err != nil || size != len(want) {
+			t.Fatalf("want size %d, got %d (error %v)", len(want), size, err)
+		}
+	})
+}
+
+// testItem is a minimal storage.Item with an arbitrary value.
+type testItem struct {
+	id    string
+	value []byte
+}
+
+func (i *testItem) ID() string                 { return i.id }
+func (i *testItem) Namespace() string          { return "test" }
+func (i *testItem) Marshal() ([]byte, error)   { return i.value, nil }
+func (i *testItem) Unmarshal(buf []byte) error { i.value = append([]byte(nil), buf...); return nil }
+func (i *testItem) Clone() storage.Item {
+	return &testItem{id: i.id, value: append([]byte(nil), i.value...)}
+}
+func (i *testItem) String() string { return i.Namespace() + "/" + i.id }
+
 func BenchmarkStore(b *testing.B) {
 	st, err := leveldbstore.New("", &opt.Options{
 		Compression: opt.SnappyCompression,
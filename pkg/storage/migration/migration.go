@@ -31,6 +31,14 @@ var (
 // The steps are separated by groups so different lists of steps can run individually, for example,
 // two groups of migrations that run before and after the storer is initialized.
 func Migrate(s storage.IndexStore, group string, sm Steps) error {
+	return MigrateWithProgress(s, group, sm, nil)
+}
+
+// MigrateWithProgress migrates the storage to the latest version like Migrate
+// and tracks the progress of the steps with the given Progress, which should
+// be the one the steps report to. The checkpoint of each step is removed once
+// the step is finished.
+func MigrateWithProgress(s storage.IndexStore, group string, sm Steps, p *Progress) error {
 	if err := ValidateVersions(sm); err != nil {
 		return err
 	}
@@ -45,6 +53,9 @@ func Migrate(s storage.IndexStore, group string, sm Steps) error {
 		if !ok {
 			return nil
 		}
+		if err := p.begin(s, group, nextVersion); err != nil {
+			return err
+		}
 		err := stepFn()
 		if err != nil {
 			return err
@@ -53,6 +64,9 @@ func Migrate(s storage.IndexStore, group string, sm Steps) error {
 		if err != nil {
 			return err
 		}
+		if err := p.end(s); err != nil {
+			return err
+		}
 	}
 }
 
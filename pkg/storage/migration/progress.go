@@ -0,0 +1,246 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package migration
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+
+	storage "github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/storage/storageutil"
+)
+
+// errCheckpointItemUnmarshalInvalidSize is returned when trying
+// to unmarshal buffer that is shorter than the checkpoint item header.
+var errCheckpointItemUnmarshalInvalidSize = errors.New("unmarshal checkpointItem: invalid size")
+
+// ProgressFn is called whenever a migration step reports its progress.
+// The total is zero when the step did not report the amount of its work.
+type ProgressFn func(group string, version, done, total uint64)
+
+// Progress tracks the progress of the running migration step and persists
+// its checkpoints, so that an interrupted step which saves them can continue
+// where it stopped. The steps without checkpoints start over.
+// The zero value is not usable, use NewProgress. All the methods are safe to
+// be called on a nil Progress, in which case they do nothing.
+type Progress struct {
+	mu       sync.Mutex
+	onUpdate ProgressFn
+	group    string
+	version  uint64
+	done     uint64
+	total    uint64
+	cursor   []byte
+}
+
+// NewProgress returns a new Progress which calls the given
+// function, if not nil, on every reported progress change.
+func NewProgress(onUpdate ProgressFn) *Progress {
+	return &Progress{onUpdate: onUpdate}
+}
+
+// begin starts the tracking of the given step
+// and loads its checkpoint from a previous run.
+func (p *Progress) begin(s storage.Reader, group string, version uint64) error {
+	if p == nil {
+		return nil
+	}
+
+	item := &checkpointItem{Group: group, Version: version}
+	err := s.Get(item)
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return fmt.Errorf("load checkpoint: %w", err)
+	}
+
+	p.mu.Lock()
+	p.group, p.version = group, version
+	p.done, p.total, p.cursor = item.Done, 0, item.Cursor
+	p.mu.Unlock()
+
+	p.update()
+	return nil
+}
+
+// end removes the checkpoint of the finished step.
+func (p *Progress) end(s storage.Writer) error {
+	if p == nil {
+		return nil
+	}
+
+	p.mu.Lock()
+	item := &checkpointItem{Group: p.group, Version: p.version}
+	p.mu.Unlock()
+
+	return s.Delete(item)
+}
+
+// SetTotal sets the estimated amount of work of the running step.
+func (p *Progress) SetTotal(total uint64) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	p.total = total
+	p.mu.Unlock()
+	p.update()
+}
+
+// Add increases the amount of the finished work of the running step.
+func (p *Progress) Add(n uint64) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	p.done += n
+	p.mu.Unlock()
+	p.update()
+}
+
+// Done returns the amount of the finished work of the running step
+// including the work done before an interruption.
+func (p *Progress) Done() uint64 {
+	if p == nil {
+		return 0
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.done
+}
+
+// Cursor returns the cursor saved by the last checkpoint of the running
+// step, or nil if the step has not been interrupted before.
+func (p *Progress) Cursor() []byte {
+	if p == nil {
+		return nil
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.cursor
+}
+
+// Checkpoint persists the finished work together with the step specific
+// cursor. The writer should be the same batch which commits the work up to
+// the cursor, so that both are persisted atomically.
+func (p *Progress) Checkpoint(w storage.Writer, cursor []byte) error {
+	if p == nil {
+		return nil
+	}
+	p.mu.Lock()
+	p.cursor = append([]byte(nil), cursor...)
+	item := &checkpointItem{Group: p.group, Version: p.version, Done: p.done, Cursor: p.cursor}
+	p.mu.Unlock()
+
+	return w.Put(item)
+}
+
+func (p *Progress) update() {
+	if p.onUpdate == nil {
+		return
+	}
+	p.mu.Lock()
+	group, version, done, total := p.group, p.version, p.done, p.total
+	p.mu.Unlock()
+	p.onUpdate(group, version, done, total)
+}
+
+// PendingStep describes a migration step which has not been applied yet.
+type PendingStep struct {
+	Version uint64
+	// Done is the work finished by an interrupted previous run of the step.
+	Done uint64
+	// Resumed is set when the step continues from a checkpoint.
+	Resumed bool
+}
+
+// Pending returns the steps of the given group which
+// will be applied by the next call to Migrate.
+func Pending(s storage.Reader, group string, sm Steps) ([]PendingStep, error) {
+	if err := ValidateVersions(sm); err != nil {
+		return nil, err
+	}
+
+	currentVersion, err := Version(s, group)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []PendingStep
+	for version := currentVersion + 1; ; version++ {
+		if _, ok := sm[version]; !ok {
+			return pending, nil
+		}
+		item := &checkpointItem{Group: group, Version: version}
+		switch err := s.Get(item); {
+		case errors.Is(err, storage.ErrNotFound):
+			pending = append(pending, PendingStep{Version: version})
+		case err != nil:
+			return nil, err
+		default:
+			pending = append(pending, PendingStep{Version: version, Done: item.Done, Resumed: true})
+		}
+	}
+}
+
+var _ storage.Item = (*checkpointItem)(nil)
+
+// checkpointItemHeaderSize is the size of the fixed part of the marshaled checkpoint item.
+const checkpointItemHeaderSize = 8
+
+// checkpointItem is the persisted progress of an interrupted migration step.
+type checkpointItem struct {
+	Group   string
+	Version uint64
+	Done    uint64
+	Cursor  []byte
+}
+
+// ID implements the storage.Item interface.
+func (c *checkpointItem) ID() string {
+	return storageutil.JoinFields(c.Group, strconv.FormatUint(c.Version, 10))
+}
+
+// Namespace implements the storage.Item interface.
+func (c checkpointItem) Namespace() string {
+	return "migrationCheckpoint"
+}
+
+// Marshal implements the storage.Item interface.
+func (c *checkpointItem) Marshal() ([]byte, error) {
+	buf := make([]byte, checkpointItemHeaderSize+len(c.Cursor))
+	binary.LittleEndian.PutUint64(buf, c.Done)
+	copy(buf[checkpointItemHeaderSize:], c.Cursor)
+	return buf, nil
+}
+
+// Unmarshal implements the storage.Item interface.
+func (c *checkpointItem) Unmarshal(bytes []byte) error {
+	if len(bytes) < checkpointItemHeaderSize {
+		return errCheckpointItemUnmarshalInvalidSize
+	}
+	c.Done = binary.LittleEndian.Uint64(bytes)
+	c.Cursor = append([]byte(nil), bytes[checkpointItemHeaderSize:]...)
+	return nil
+}
+
+// Clone implements the storage.Item interface.
+func (c *checkpointItem) Clone() storage.Item {
+	if c == nil {
+		return nil
+	}
+	return &checkpointItem{
+		Group:   c.Group,
+		Version: c.Version,
+		Done:    c.Done,
+		Cursor:  append([]byte(nil), c.Cursor...),
+	}
+}
+
+// String implements the fmt.Stringer interface.
+func (c checkpointItem) String() string {
+	return storageutil.JoinFields(c.Namespace(), c.ID())
+}
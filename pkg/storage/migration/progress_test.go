@@ -0,0 +1,99 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package migration_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/storage/inmemstore"
+	"github.com/ethersphere/bee/v2/pkg/storage/migration"
+)
+
+func TestMigrateWithProgress(t *testing.T) {
+	t.Parallel()
+
+	s := inmemstore.New()
+
+	type update struct{ version, done, total uint64 }
+	var updates []update
+	progress := migration.NewProgress(func(group string, version, done, total uint64) {
+		if group != "migration" {
+			t.Errorf("unexpected group %q", group)
+		}
+		updates = append(updates, update{version, done, total})
+	})
+
+	var (
+		interrupt = true
+		cursors   []string
+	)
+	steps := migration.Steps{
+		1: func() error { return nil },
+		2: func() error {
+			cursors = append(cursors, string(progress.Cursor()))
+			progress.SetTotal(10)
+			for i := progress.Done(); i < 10; i++ {
+				progress.Add(1)
+				if err := progress.Checkpoint(s, []byte{byte('a' + i)}); err != nil {
+					return err
+				}
+				if i == 4 && interrupt {
+					return errStep
+				}
+			}
+			return nil
+		},
+	}
+
+	pending, err := migration.Pending(s, "migration", steps)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 2 || pending[0].Version != 1 || pending[1].Version != 2 || pending[1].Resumed {
+		t.Fatalf("unexpected pending steps %+v", pending)
+	}
+
+	if err := migration.MigrateWithProgress(s, "migration", steps, progress); !errors.Is(err, errStep) {
+		t.Fatalf("want error %v, got %v", errStep, err)
+	}
+
+	pending, err = migration.Pending(s, "migration", steps)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 1 || pending[0].Version != 2 || !pending[0].Resumed || pending[0].Done != 5 {
+		t.Fatalf("unexpected pending steps %+v", pending)
+	}
+
+	interrupt = false
+	if err := migration.MigrateWithProgress(s, "migration", steps, progress); err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []string{"", "e"}; len(cursors) != 2 || cursors[0] != want[0] || cursors[1] != want[1] {
+		t.Fatalf("want cursors %q, got %q", want, cursors)
+	}
+	if last := updates[len(updates)-1]; last != (update{2, 10, 10}) {
+		t.Fatalf("unexpected last progress update %+v", last)
+	}
+
+	pending, err = migration.Pending(s, "migration", steps)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 0 {
+		t.Fatalf("want no pending steps, got %+v", pending)
+	}
+	// the checkpoint of the finished step is removed.
+	if n, err := s.Count(checkpointKey{}); err != nil || n != 0 {
+		t.Fatalf("want no checkpoints, got %d (error %v)", n, err)
+	}
+}
+
+type checkpointKey struct{}
+
+func (checkpointKey) ID() string        { return "" }
+func (checkpointKey) Namespace() string { return "migrationCheckpoint" }
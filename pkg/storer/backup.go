@@ -0,0 +1,586 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package storer
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/crypto"
+	"github.com/ethersphere/bee/v2/pkg/sharky"
+	"github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/storage/leveldbstore"
+	"github.com/ethersphere/bee/v2/pkg/storer/internal/chunkstore"
+	"github.com/ethersphere/bee/v2/pkg/storer/internal/transaction"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+// The backup archive is a stream of records preceded by a header:
+//
+//	|--magic(10)--|--version(1)--|--flags(1)--|--since(8)--|--timestamp(8)--|
+//
+// Each record is stored as:
+//
+//	|--kind(1)--|--keyLen(uvarint)--|--key--|--valueLen(uvarint)--|--value--|
+//
+// The records of a sealed archive are each sealed with the localstore
+// encryption key and stored as:
+//
+//	|--sealedLen(uvarint)--|--sealed record--|
+//
+// The chunk records come first, followed by the index records. The archive
+// is terminated by an end record whose value holds the number of chunk and
+// index records written.
+const (
+	backupMagic   = "beebackup\x00"
+	backupVersion = 1
+
+	backupFlagSealed byte = 1 << 0
+
+	backupRecordEnd   byte = 0
+	backupRecordChunk byte = 1
+	backupRecordIndex byte = 2
+
+	backupMaxRecordSize = 1 << 20
+)
+
+// backupChunkIndexes are the namespaces of the index entries kept for each
+// chunk. An incremental backup holds only those entries of them which reference
+// the exported chunks. The entries of the other namespaces, like the retrieval
+// index with the reference counts, the pinning collections and the counters,
+// are always held in full, so that the removals are restored as well.
+var backupChunkIndexes = [][]byte{
+	[]byte("chunkStamp/"),
+	[]byte("stampIndex/"),
+	[]byte("cacheEntry/"),
+	[]byte("cacheOrderIndex/"),
+	[]byte("pushIndex/"),
+	[]byte("UploadItem/"),
+	[]byte("chunkBin/"),
+	[]byte("batchRadius/"),
+}
+
+// isChunkIndex reports whether the raw index key belongs to the backupChunkIndexes.
+func isChunkIndex(key []byte) bool {
+	for _, prefix := range backupChunkIndexes {
+		if bytes.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// referencesChunk reports whether any of the given byte slices
+// holds one of the chunk addresses of the set.
+func referencesChunk(addrs map[string]struct{}, bs ...[]byte) bool {
+	for _, b := range bs {
+		for i := 0; i+swarm.HashSize <= len(b); i++ {
+			if _, ok := addrs[string(b[i:i+swarm.HashSize])]; ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+var (
+	// ErrInvalidBackup is returned when the backup archive is malformed.
+	ErrInvalidBackup = errors.New("invalid backup archive")
+	// ErrBackupChainBroken is returned when the incremental backups do not
+	// follow each other without gaps.
+	ErrBackupChainBroken = errors.New("backup chain broken")
+	// ErrRestoreNotEmpty is returned when a backup is restored over
+	// a localstore which already holds chunks.
+	ErrRestoreNotEmpty = errors.New("localstore is not empty")
+)
+
+// BackupInfo describes a backup archive.
+type BackupInfo struct {
+	// Since is the unix timestamp from which the chunks were included.
+	// Zero means that the backup is a full one.
+	Since uint64 `json:"since"`
+	// Timestamp is the unix timestamp of the point in time view of the
+	// backup. It is to be used as Since for the next incremental backup.
+	Timestamp uint64 `json:"timestamp"`
+	// Chunks is the number of chunks in the archive.
+	Chunks uint64 `json:"chunks"`
+	// Entries is the number of index entries in the archive.
+	Entries uint64 `json:"entries"`
+	// Sealed tells if the records of the archive are sealed with the
+	// encryption key of the localstore the backup was taken from.
+	Sealed bool `json:"sealed"`
+}
+
+// Backuper is the interface for taking online backups of the localstore.
+type Backuper interface {
+	Backup(ctx context.Context, w io.Writer, since uint64) (BackupInfo, error)
+}
+
+var _ Backuper = (*DB)(nil)
+
+// Backup writes a consistent point-in-time archive of the localstore to w
+// while the node keeps running. The archive holds the chunks stored at or
+// after the since unix timestamp, so a zero since produces a full backup and
+// the Timestamp of the previous backup produces an incremental one. The per
+// chunk index entries are held only for the exported chunks, see
+// backupChunkIndexes.
+// When the localstore is encrypted at rest, the records of the archive are
+// sealed with the same encryption key.
+func (db *DB) Backup(ctx context.Context, w io.Writer, since uint64) (BackupInfo, error) {
+	snapshotter, ok := db.storage.(transaction.Snapshotter)
+	if !ok {
+		return BackupInfo{}, transaction.ErrSnapshotNotSupported
+	}
+
+	// The timestamp is taken before the snapshot so that the chunks stored
+	// in the same second end up in the next incremental backup as well.
+	info := BackupInfo{Since: since, Timestamp: uint64(time.Now().Unix()), Sealed: db.sealer != nil}
+
+	snap, err := snapshotter.Snapshot()
+	if err != nil {
+		return BackupInfo{}, fmt.Errorf("snapshot: %w", err)
+	}
+	defer snap.Release()
+
+	buf := bufio.NewWriter(w)
+	if err := writeBackupHeader(buf, info); err != nil {
+		return BackupInfo{}, err
+	}
+	bw := &backupWriter{w: buf, sealer: db.sealer}
+
+	// exported holds the addresses of the chunks of an incremental backup,
+	// a full backup holds all the index entries.
+	var exported map[string]struct{}
+	if since > 0 {
+		exported = make(map[string]struct{})
+	}
+
+	chunkStore := snap.ChunkStore()
+	err = snap.IndexStore().Iterate(storage.Query{
+		Factory: func() storage.Item { return new(chunkstore.RetrievalIndexItem) },
+	}, func(r storage.Result) (bool, error) {
+		if err := ctx.Err(); err != nil {
+			return true, err
+		}
+		item := r.Entry.(*chunkstore.RetrievalIndexItem)
+		if item.Timestamp < since {
+			return false, nil
+		}
+		ch, err := chunkStore.Get(ctx, item.Address)
+		if err != nil {
+			return true, fmt.Errorf("read chunk %s: %w", item.Address, err)
+		}
+		if err := bw.writeRecord(backupRecordChunk, item.Address.Bytes(), ch.Data()); err != nil {
+			return true, err
+		}
+		if exported != nil {
+			exported[item.Address.ByteString()] = struct{}{}
+		}
+		info.Chunks++
+		return false, nil
+	})
+	if err != nil {
+		return BackupInfo{}, fmt.Errorf("backup chunks: %w", err)
+	}
+
+	err = snap.IterateRaw(func(key, value []byte) (bool, error) {
+		if err := ctx.Err(); err != nil {
+			return true, err
+		}
+		if exported != nil && isChunkIndex(key) && !referencesChunk(exported, key, value) {
+			return false, nil
+		}
+		if err := bw.writeRecord(backupRecordIndex, key, value); err != nil {
+			return true, err
+		}
+		info.Entries++
+		return false, nil
+	})
+	if err != nil {
+		return BackupInfo{}, fmt.Errorf("backup index: %w", err)
+	}
+
+	trailer := make([]byte, 16)
+	binary.BigEndian.PutUint64(trailer, info.Chunks)
+	binary.BigEndian.PutUint64(trailer[8:], info.Entries)
+	if err := bw.writeRecord(backupRecordEnd, nil, trailer); err != nil {
+		return BackupInfo{}, err
+	}
+
+	if err := buf.Flush(); err != nil {
+		return BackupInfo{}, fmt.Errorf("flush backup: %w", err)
+	}
+
+	db.logger.Info("localstore backup finished", "since", info.Since, "timestamp", info.Timestamp, "chunks", info.Chunks, "entries", info.Entries)
+
+	return info, nil
+}
+
+// RestoreBackup restores the localstore at basePath from a full backup
+// archive followed by its incremental backups, in the order they were taken.
+// The chunks and their per chunk index entries are gathered from all the
+// archives while the rest of the index store is restored from the last one.
+// The localstore must not hold any chunks.
+// The sealed archives are opened with the encryption key of the options.
+func RestoreBackup(ctx context.Context, basePath string, opts *Options, archives ...io.Reader) (BackupInfo, error) {
+	logger := opts.Logger
+
+	if len(archives) == 0 {
+		return BackupInfo{}, errors.New("no backup archives provided")
+	}
+
+	store, err := initStore(basePath, opts)
+	if err != nil {
+		return BackupInfo{}, fmt.Errorf("failed creating levelDB index store: %w", err)
+	}
+	defer func() {
+		if err := store.Close(); err != nil {
+			logger.Error(err, "failed closing store")
+		}
+	}()
+
+	if n, err := store.Count(&chunkstore.RetrievalIndexItem{}); err != nil {
+		return BackupInfo{}, fmt.Errorf("count chunks: %w", err)
+	} else if n > 0 {
+		return BackupInfo{}, ErrRestoreNotEmpty
+	}
+
+	sharkyBasePath := path.Join(basePath, sharkyPath)
+	if err := os.MkdirAll(sharkyBasePath, 0o777); err != nil {
+		return BackupInfo{}, err
+	}
+	sharkyStore, err := newSharky(sharkyBasePath, opts)
+	if err != nil {
+		return BackupInfo{}, fmt.Errorf("failed creating sharky instance: %w", err)
+	}
+	defer func() {
+		if err := sharkyStore.Close(); err != nil {
+			logger.Error(err, "failed closing sharky")
+		}
+	}()
+
+	sealer, err := opts.sealer()
+	if err != nil {
+		return BackupInfo{}, err
+	}
+
+	var (
+		last    BackupInfo
+		missing int
+		prefix  = []byte(chunkstore.RetrievalIndexItem{}.Namespace() + "/")
+	)
+	for i, archive := range archives {
+		br := bufio.NewReader(archive)
+		info, err := readBackupHeader(br)
+		if err != nil {
+			return BackupInfo{}, fmt.Errorf("archive %d: %w", i, err)
+		}
+		switch {
+		case i == 0 && info.Since != 0:
+			return BackupInfo{}, fmt.Errorf("archive %d: first archive is not a full backup: %w", i, ErrBackupChainBroken)
+		case i > 0 && info.Since > last.Timestamp:
+			return BackupInfo{}, fmt.Errorf("archive %d: since %d is after the previous timestamp %d: %w", i, info.Since, last.Timestamp, ErrBackupChainBroken)
+		case info.Sealed && sealer == nil:
+			return BackupInfo{}, fmt.Errorf("archive %d: sealed archive: %w", i, ErrEncryptionKeyRequired)
+		}
+		final := i == len(archives)-1
+		ar := &backupReader{r: br}
+		if info.Sealed {
+			ar.sealer = sealer
+		}
+
+		var chunks, entries uint64
+		for done := false; !done; {
+			if err := ctx.Err(); err != nil {
+				return BackupInfo{}, err
+			}
+
+			kind, key, value, err := ar.readRecord()
+			if err != nil {
+				return BackupInfo{}, fmt.Errorf("archive %d: %w", i, err)
+			}
+
+			switch kind {
+			case backupRecordChunk:
+				if err := restoreChunk(ctx, store, sharkyStore, swarm.NewAddress(key), value); err != nil {
+					return BackupInfo{}, fmt.Errorf("archive %d: %w", i, err)
+				}
+				chunks++
+			case backupRecordIndex:
+				entries++
+				if !final && !isChunkIndex(key) {
+					continue
+				}
+				if !bytes.HasPrefix(key, prefix) {
+					if err := store.PutRaw(key, value); err != nil {
+						return BackupInfo{}, fmt.Errorf("restore index entry: %w", err)
+					}
+					continue
+				}
+				archived := new(chunkstore.RetrievalIndexItem)
+				if err := archived.Unmarshal(value); err != nil {
+					return BackupInfo{}, fmt.Errorf("archive %d: %w", i, err)
+				}
+				restored := &chunkstore.RetrievalIndexItem{Address: archived.Address}
+				switch err := store.Get(restored); {
+				case errors.Is(err, storage.ErrNotFound):
+					missing++
+					logger.Warning("chunk data missing from the backup chain", "address", archived.Address)
+					continue
+				case err != nil:
+					return BackupInfo{}, fmt.Errorf("read restored chunk %s: %w", archived.Address, err)
+				}
+				archived.Location = restored.Location
+				if err := store.Put(archived); err != nil {
+					return BackupInfo{}, fmt.Errorf("restore index entry: %w", err)
+				}
+			case backupRecordEnd:
+				if len(value) != 16 ||
+					binary.BigEndian.Uint64(value) != chunks ||
+					binary.BigEndian.Uint64(value[8:]) != entries {
+					return BackupInfo{}, fmt.Errorf("archive %d: record count mismatch: %w", i, ErrInvalidBackup)
+				}
+				info.Chunks, info.Entries = chunks, entries
+				done = true
+			default:
+				return BackupInfo{}, fmt.Errorf("archive %d: unknown record kind %d: %w", i, kind, ErrInvalidBackup)
+			}
+		}
+
+		logger.Info("backup archive restored", "since", info.Since, "timestamp", info.Timestamp, "chunks", info.Chunks, "entries", info.Entries)
+		last = info
+	}
+
+	// The chunks which were deleted after they were backed up
+	// are not referenced by the final index, so they are dropped.
+	var dangling []*chunkstore.RetrievalIndexItem
+	err = chunkstore.IterateItems(store, func(item *chunkstore.RetrievalIndexItem) error {
+		if item.RefCnt == 0 {
+			dangling = append(dangling, item)
+		}
+		return nil
+	})
+	if err != nil {
+		return BackupInfo{}, fmt.Errorf("iterate restored chunks: %w", err)
+	}
+	deleted := make(map[string]struct{}, len(dangling))
+	for _, item := range dangling {
+		if err := errors.Join(
+			sharkyStore.Release(ctx, item.Location),
+			store.Delete(item),
+		); err != nil {
+			return BackupInfo{}, fmt.Errorf("drop deleted chunk %s: %w", item.Address, err)
+		}
+		deleted[item.Address.ByteString()] = struct{}{}
+	}
+	if err := dropChunkIndexes(store, deleted); err != nil {
+		return BackupInfo{}, err
+	}
+
+	if missing > 0 {
+		return last, fmt.Errorf("%d chunks missing from the backup chain: %w", missing, ErrBackupChainBroken)
+	}
+
+	return last, nil
+}
+
+// dropChunkIndexes deletes the per chunk index entries restored from the
+// earlier archives which reference the chunks deleted in the meantime.
+func dropChunkIndexes(store *leveldbstore.Store, deleted map[string]struct{}) error {
+	if len(deleted) == 0 {
+		return nil
+	}
+
+	snap, err := store.Snapshot()
+	if err != nil {
+		return fmt.Errorf("snapshot: %w", err)
+	}
+	defer snap.Release()
+
+	err = snap.IterateRaw(func(key, value []byte) (bool, error) {
+		if !isChunkIndex(key) || !referencesChunk(deleted, key, value) {
+			return false, nil
+		}
+		return false, store.DeleteRaw(key)
+	})
+	if err != nil {
+		return fmt.Errorf("drop index entries of deleted chunks: %w", err)
+	}
+	return nil
+}
+
+// restoreChunk writes the chunk data to sharky and records its location in
+// a provisional retrieval index entry, replacing the data restored from the
+// previous archives. The entry is completed from the final index.
+func restoreChunk(ctx context.Context, store storage.Store, sh *sharky.Store, addr swarm.Address, data []byte) error {
+	item := &chunkstore.RetrievalIndexItem{Address: addr}
+	switch err := store.Get(item); {
+	case err == nil:
+		if err := sh.Release(ctx, item.Location); err != nil {
+			return fmt.Errorf("release chunk %s: %w", addr, err)
+		}
+	case !errors.Is(err, storage.ErrNotFound):
+		return fmt.Errorf("read chunk %s: %w", addr, err)
+	}
+
+	loc, err := sh.Write(ctx, data)
+	if err != nil {
+		return fmt.Errorf("write chunk %s: %w", addr, err)
+	}
+
+	return store.Put(&chunkstore.RetrievalIndexItem{Address: addr, Location: loc})
+}
+
+// ReadBackupInfo reads the header of the backup archive.
+func ReadBackupInfo(r io.Reader) (BackupInfo, error) {
+	return readBackupHeader(r)
+}
+
+func writeBackupHeader(w io.Writer, info BackupInfo) error {
+	buf := make([]byte, len(backupMagic)+2+16)
+	i := copy(buf, backupMagic)
+	buf[i] = backupVersion
+	i++
+	if info.Sealed {
+		buf[i] |= backupFlagSealed
+	}
+	i++
+	binary.BigEndian.PutUint64(buf[i:], info.Since)
+	binary.BigEndian.PutUint64(buf[i+8:], info.Timestamp)
+
+	if _, err := w.Write(buf); err != nil {
+		return fmt.Errorf("write backup header: %w", err)
+	}
+	return nil
+}
+
+func readBackupHeader(r io.Reader) (BackupInfo, error) {
+	buf := make([]byte, len(backupMagic)+2+16)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return BackupInfo{}, fmt.Errorf("read backup header: %w", err)
+	}
+	if string(buf[:len(backupMagic)]) != backupMagic {
+		return BackupInfo{}, fmt.Errorf("bad magic: %w", ErrInvalidBackup)
+	}
+	i := len(backupMagic)
+	if buf[i] != backupVersion {
+		return BackupInfo{}, fmt.Errorf("unsupported version %d: %w", buf[i], ErrInvalidBackup)
+	}
+	i++
+	flags := buf[i]
+	if flags&^backupFlagSealed != 0 {
+		return BackupInfo{}, fmt.Errorf("unknown flags %#x: %w", flags, ErrInvalidBackup)
+	}
+	i++
+	return BackupInfo{
+		Since:     binary.BigEndian.Uint64(buf[i:]),
+		Timestamp: binary.BigEndian.Uint64(buf[i+8:]),
+		Sealed:    flags&backupFlagSealed != 0,
+	}, nil
+}
+
+// backupWriter writes the records of the archive,
+// sealed if the sealer is set.
+type backupWriter struct {
+	w      io.Writer
+	sealer *crypto.Sealer
+}
+
+func (bw *backupWriter) writeRecord(kind byte, key, value []byte) error {
+	buf := make([]byte, 0, 1+2*binary.MaxVarintLen64+len(key)+len(value))
+	buf = append(buf, kind)
+	buf = binary.AppendUvarint(buf, uint64(len(key)))
+	buf = append(buf, key...)
+	buf = binary.AppendUvarint(buf, uint64(len(value)))
+	buf = append(buf, value...)
+
+	if bw.sealer != nil {
+		sealed, err := bw.sealer.Seal(nil, buf)
+		if err != nil {
+			return fmt.Errorf("seal backup record: %w", err)
+		}
+		buf = binary.AppendUvarint(make([]byte, 0, binary.MaxVarintLen64+len(sealed)), uint64(len(sealed)))
+		buf = append(buf, sealed...)
+	}
+
+	if _, err := bw.w.Write(buf); err != nil {
+		return fmt.Errorf("write backup record: %w", err)
+	}
+	return nil
+}
+
+// backupReader reads the records of the archive,
+// opening them if the sealer is set.
+type backupReader struct {
+	r      *bufio.Reader
+	sealer *crypto.Sealer
+}
+
+func (br *backupReader) readRecord() (kind byte, key, value []byte, err error) {
+	if br.sealer == nil {
+		return readBackupRecord(br.r)
+	}
+
+	n, err := binary.ReadUvarint(br.r)
+	if err != nil {
+		return 0, nil, nil, fmt.Errorf("read sealed record: %w", errors.Join(err, ErrInvalidBackup))
+	}
+	if n > 2*backupMaxRecordSize+crypto.SealOverhead {
+		return 0, nil, nil, fmt.Errorf("sealed record too large: %w", ErrInvalidBackup)
+	}
+	sealed := make([]byte, n)
+	if _, err := io.ReadFull(br.r, sealed); err != nil {
+		return 0, nil, nil, fmt.Errorf("read sealed record: %w", errors.Join(err, ErrInvalidBackup))
+	}
+	record, err := br.sealer.Open(nil, sealed)
+	if err != nil {
+		return 0, nil, nil, fmt.Errorf("open sealed record: %w", errors.Join(err, ErrEncryptionKeyInvalid))
+	}
+	return readBackupRecord(bytes.NewReader(record))
+}
+
+// recordReader is satisfied by bufio.Reader and bytes.Reader.
+type recordReader interface {
+	io.Reader
+	io.ByteReader
+}
+
+func readBackupRecord(r recordReader) (kind byte, key, value []byte, err error) {
+	kind, err = r.ReadByte()
+	if err != nil {
+		return 0, nil, nil, fmt.Errorf("read record kind: %w", errors.Join(err, ErrInvalidBackup))
+	}
+
+	readField := func() ([]byte, error) {
+		n, err := binary.ReadUvarint(r)
+		if err != nil {
+			return nil, errors.Join(err, ErrInvalidBackup)
+		}
+		if n > backupMaxRecordSize {
+			return nil, fmt.Errorf("record field too large: %w", ErrInvalidBackup)
+		}
+		buf := make([]byte, n)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, errors.Join(err, ErrInvalidBackup)
+		}
+		return buf, nil
+	}
+
+	if key, err = readField(); err != nil {
+		return 0, nil, nil, fmt.Errorf("read record key: %w", err)
+	}
+	if value, err = readField(); err != nil {
+		return 0, nil, nil, fmt.Errorf("read record value: %w", err)
+	}
+	return kind, key, value, nil
+}
@@ -0,0 +1,303 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package storer_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	chunktesting "github.com/ethersphere/bee/v2/pkg/storage/testing"
+	storer "github.com/ethersphere/bee/v2/pkg/storer"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+func TestBackupRestore(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	pin := func(t *testing.T, db *storer.DB, chunks []swarm.Chunk) {
+		t.Helper()
+
+		session, err := db.NewCollection(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, ch := range chunks {
+			if err := session.Put(ctx, ch); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := session.Done(chunks[0].Address()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	srcDir := t.TempDir()
+	src, err := storer.New(ctx, srcDir, dbTestOps(swarm.RandAddress(t), 0, nil, nil, time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = src.Close() })
+
+	deleted := chunktesting.GenerateTestRandomChunks(10)
+	kept := chunktesting.GenerateTestRandomChunks(10)
+	added := chunktesting.GenerateTestRandomChunks(10)
+
+	pin(t, src, deleted)
+	pin(t, src, kept)
+
+	// chunks stored in the same second as the backup is taken are
+	// included in the next incremental backup as well.
+	time.Sleep(time.Second)
+
+	full := new(bytes.Buffer)
+	fullInfo, err := src.Backup(ctx, full, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fullInfo.Chunks != 20 {
+		t.Fatalf("want 20 chunks in the full backup, got %d", fullInfo.Chunks)
+	}
+
+	pin(t, src, added)
+	if err := src.DeletePin(ctx, deleted[0].Address()); err != nil {
+		t.Fatal(err)
+	}
+
+	incremental := new(bytes.Buffer)
+	incrementalInfo, err := src.Backup(ctx, incremental, fullInfo.Timestamp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if incrementalInfo.Chunks != 10 {
+		t.Fatalf("want 10 chunks in the incremental backup, got %d", incrementalInfo.Chunks)
+	}
+
+	t.Run("broken chain", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := storer.RestoreBackup(ctx, t.TempDir(), dbTestOps(swarm.RandAddress(t), 0, nil, nil, time.Minute), bytes.NewReader(incremental.Bytes()))
+		if !errors.Is(err, storer.ErrBackupChainBroken) {
+			t.Fatalf("want error %v, got %v", storer.ErrBackupChainBroken, err)
+		}
+	})
+
+	t.Run("restore", func(t *testing.T) {
+		t.Parallel()
+
+		dstDir := t.TempDir()
+		opts := dbTestOps(swarm.RandAddress(t), 0, nil, nil, time.Minute)
+
+		info, err := storer.RestoreBackup(ctx, dstDir, opts,
+			bytes.NewReader(full.Bytes()),
+			bytes.NewReader(incremental.Bytes()),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.Timestamp != incrementalInfo.Timestamp {
+			t.Fatalf("want timestamp %d, got %d", incrementalInfo.Timestamp, info.Timestamp)
+		}
+
+		_, err = storer.RestoreBackup(ctx, dstDir, opts, bytes.NewReader(full.Bytes()))
+		if !errors.Is(err, storer.ErrRestoreNotEmpty) {
+			t.Fatalf("want error %v, got %v", storer.ErrRestoreNotEmpty, err)
+		}
+
+		dst, err := storer.New(ctx, dstDir, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { _ = dst.Close() })
+
+		for _, chunks := range [][]swarm.Chunk{kept, added} {
+			has, err := dst.HasPin(chunks[0].Address())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !has {
+				t.Fatalf("pin %s not restored", chunks[0].Address())
+			}
+			for _, ch := range chunks {
+				got, err := dst.ChunkStore().Get(ctx, ch.Address())
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !bytes.Equal(got.Data(), ch.Data()) {
+					t.Fatalf("chunk %s data mismatch", ch.Address())
+				}
+			}
+		}
+
+		has, err := dst.HasPin(deleted[0].Address())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if has {
+			t.Fatal("deleted pin restored")
+		}
+		for _, ch := range deleted {
+			if has, _ := dst.ChunkStore().Has(ctx, ch.Address()); has {
+				t.Fatalf("deleted chunk %s restored", ch.Address())
+			}
+		}
+	})
+
+	t.Run("read info", func(t *testing.T) {
+		t.Parallel()
+
+		info, err := storer.ReadBackupInfo(io.LimitReader(bytes.NewReader(incremental.Bytes()), 64))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.Since != fullInfo.Timestamp || info.Timestamp != incrementalInfo.Timestamp {
+			t.Fatalf("unexpected backup info %+v", info)
+		}
+	})
+}
+
+func TestBackupRestoreSealed(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	opts := func(key []byte) *storer.Options {
+		opts := dbTestOps(swarm.RandAddress(t), 0, nil, nil, time.Minute)
+		opts.EncryptionKey = key
+		return opts
+	}
+	key := bytes.Repeat([]byte{0x1}, 32)
+
+	src, err := storer.New(ctx, t.TempDir(), opts(key))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = src.Close() })
+
+	chunks := chunktesting.GenerateTestRandomChunks(5)
+	session, err := src.NewCollection(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, ch := range chunks {
+		if err := session.Put(ctx, ch); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := session.Done(chunks[0].Address()); err != nil {
+		t.Fatal(err)
+	}
+
+	archive := new(bytes.Buffer)
+	info, err := src.Backup(ctx, archive, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !info.Sealed {
+		t.Fatal("backup of an encrypted localstore not sealed")
+	}
+	for _, ch := range chunks {
+		if bytes.Contains(archive.Bytes(), ch.Data()) {
+			t.Fatalf("chunk %s data found in the sealed archive", ch.Address())
+		}
+	}
+
+	if _, err := storer.RestoreBackup(ctx, t.TempDir(), opts(nil), bytes.NewReader(archive.Bytes())); !errors.Is(err, storer.ErrEncryptionKeyRequired) {
+		t.Fatalf("want error %v, got %v", storer.ErrEncryptionKeyRequired, err)
+	}
+	if _, err := storer.RestoreBackup(ctx, t.TempDir(), opts(bytes.Repeat([]byte{0x2}, 32)), bytes.NewReader(archive.Bytes())); !errors.Is(err, storer.ErrEncryptionKeyInvalid) {
+		t.Fatalf("want error %v, got %v", storer.ErrEncryptionKeyInvalid, err)
+	}
+
+	dstDir := t.TempDir()
+	if _, err := storer.RestoreBackup(ctx, dstDir, opts(key), bytes.NewReader(archive.Bytes())); err != nil {
+		t.Fatal(err)
+	}
+	dst, err := storer.New(ctx, dstDir, opts(key))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = dst.Close() })
+
+	for _, ch := range chunks {
+		got, err := dst.ChunkStore().Get(ctx, ch.Address())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got.Data(), ch.Data()) {
+			t.Fatalf("chunk %s data mismatch", ch.Address())
+		}
+	}
+}
+
+func TestBackupIncrementalIndex(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	opts := dbTestOps(swarm.RandAddress(t), 0, nil, nil, time.Minute)
+
+	src, err := storer.New(ctx, t.TempDir(), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = src.Close() })
+
+	cached := chunktesting.GenerateTestRandomChunks(10)
+	for _, ch := range cached {
+		if err := src.Cache().Put(ctx, ch); err != nil {
+			t.Fatal(err)
+		}
+	}
+	time.Sleep(time.Second)
+
+	full := new(bytes.Buffer)
+	fullInfo, err := src.Backup(ctx, full, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	incremental := new(bytes.Buffer)
+	incrementalInfo, err := src.Backup(ctx, incremental, fullInfo.Timestamp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if incrementalInfo.Chunks != 0 {
+		t.Fatalf("want no chunks in the incremental backup, got %d", incrementalInfo.Chunks)
+	}
+	// the cache entry and the cache order index entry of each cached chunk
+	// are left to the full backup.
+	if want := fullInfo.Entries - 2*uint64(len(cached)); incrementalInfo.Entries > want {
+		t.Fatalf("want at most %d entries in the incremental backup, got %d", want, incrementalInfo.Entries)
+	}
+
+	dstDir := t.TempDir()
+	_, err = storer.RestoreBackup(ctx, dstDir, opts,
+		bytes.NewReader(full.Bytes()),
+		bytes.NewReader(incremental.Bytes()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dst, err := storer.New(ctx, dstDir, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = dst.Close() })
+
+	for _, ch := range cached {
+		got, err := dst.Lookup().Get(ctx, ch.Address())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got.Data(), ch.Data()) {
+			t.Fatalf("chunk %s data mismatch", ch.Address())
+		}
+	}
+}
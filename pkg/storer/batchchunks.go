@@ -0,0 +1,25 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package storer
+
+import (
+	"context"
+
+	"github.com/ethersphere/bee/v2/pkg/storer/internal/chunkstamp"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+// IterateBatchChunks calls fn with the address of every chunk of the upload
+// store and the reserve which is stamped by the given batch. A chunk held
+// in both of them is reported twice. The pinned chunks are not stored with
+// their stamps, so they are not reported unless held by one of the stores.
+func (db *DB) IterateBatchChunks(ctx context.Context, batchID []byte, fn func(swarm.Address) (bool, error)) error {
+	return chunkstamp.IterateBatch(db.storage.IndexStore(), batchID, func(addr swarm.Address) (bool, error) {
+		if err := ctx.Err(); err != nil {
+			return true, err
+		}
+		return fn(addr)
+	})
+}
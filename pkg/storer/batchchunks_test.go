@@ -0,0 +1,87 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package storer_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	postagetesting "github.com/ethersphere/bee/v2/pkg/postage/testing"
+	chunk "github.com/ethersphere/bee/v2/pkg/storage/testing"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+func TestIterateBatchChunks(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	baseAddr := swarm.RandAddress(t)
+
+	st, err := diskStorer(t, dbTestOps(baseAddr, 100, nil, nil, time.Minute))()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	batch := postagetesting.MustNewBatch()
+	want := make(map[string]int)
+
+	for i := range 6 {
+		ch := chunk.GenerateTestRandomChunkAt(t, baseAddr, 0)
+		if i%2 == 0 {
+			ch = ch.WithStamp(postagetesting.MustNewBatchStamp(batch.ID))
+			want[ch.Address().ByteString()]++
+		}
+		if err := st.ReservePutter().Put(ctx, ch); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tag, err := st.NewSession()
+	if err != nil {
+		t.Fatal(err)
+	}
+	session, err := st.Upload(ctx, false, tag.TagID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	uploadChunks := chunk.GenerateTestRandomChunks(4)
+	for i, ch := range uploadChunks {
+		if i%2 == 0 {
+			ch = ch.WithStamp(postagetesting.MustNewBatchStamp(batch.ID))
+			want[ch.Address().ByteString()]++
+		}
+		if err := session.Put(ctx, ch); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := session.Done(uploadChunks[0].Address()); err != nil {
+		t.Fatal(err)
+	}
+
+	have := make(map[string]int)
+	err = st.IterateBatchChunks(ctx, batch.ID, func(addr swarm.Address) (bool, error) {
+		have[addr.ByteString()]++
+		return false, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(have) != len(want) {
+		t.Fatalf("got %d chunks, want %d", len(have), len(want))
+	}
+	for addr, n := range want {
+		if have[addr] != n {
+			t.Fatalf("chunk %x reported %d times, want %d", addr, have[addr], n)
+		}
+	}
+
+	cctx, cancel := context.WithCancel(ctx)
+	cancel()
+	err = st.IterateBatchChunks(cctx, batch.ID, func(swarm.Address) (bool, error) { return false, nil })
+	if err == nil {
+		t.Fatal("expected error of the cancelled context")
+	}
+}
@@ -14,7 +14,6 @@ import (
 
 	"github.com/ethersphere/bee/v2/pkg/sharky"
 	"github.com/ethersphere/bee/v2/pkg/storer/internal/chunkstore"
-	"github.com/ethersphere/bee/v2/pkg/swarm"
 )
 
 // Compact minimizes sharky disk usage by, using the current sharky locations from the storer,
@@ -32,7 +31,7 @@ func Compact(ctx context.Context, basePath string, opts *Options, validate bool)
 		}
 	}()
 
-	sharkyRecover, err := sharky.NewRecovery(path.Join(basePath, sharkyPath), sharkyNoOfShards, swarm.SocMaxChunkSize)
+	sharkyRecover, err := newSharkyRecovery(path.Join(basePath, sharkyPath), opts)
 	if err != nil {
 		return err
 	}
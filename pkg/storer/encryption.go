@@ -0,0 +1,258 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package storer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+
+	"github.com/ethersphere/bee/v2/pkg/crypto"
+	"github.com/ethersphere/bee/v2/pkg/sharky"
+	"github.com/ethersphere/bee/v2/pkg/storer/internal/chunkstore"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+)
+
+const (
+	// encryptionMarkerFileName is the file in the localstore directory which
+	// marks the store as encrypted and identifies the encryption key.
+	encryptionMarkerFileName = ".ENCRYPTED"
+	// encryptionKeyCheckPurpose is mixed into the hash of the key stored in
+	// the marker file, so that the file does not reveal the key itself.
+	encryptionKeyCheckPurpose = "localstore-key-check"
+	// encryptProgressLogStep is the number of index entries copied
+	// between two progress log lines of the encryption migration.
+	encryptProgressLogStep = 100_000
+)
+
+var (
+	// ErrEncryptionKeyRequired is returned when an encrypted
+	// localstore is opened without an encryption key.
+	ErrEncryptionKeyRequired = errors.New("localstore is encrypted: encryption key required")
+	// ErrEncryptionKeyInvalid is returned when the localstore is
+	// opened with a different key than the one it was encrypted with.
+	ErrEncryptionKeyInvalid = errors.New("localstore encryption key invalid")
+	// ErrStoreNotEncrypted is returned when an existing unencrypted
+	// localstore is opened with an encryption key. Such a store has
+	// to be migrated with Encrypt first.
+	ErrStoreNotEncrypted = errors.New("localstore is not encrypted")
+)
+
+// sealer returns the sealer of the localstore data,
+// or nil if the encryption at rest is not enabled.
+func (o *Options) sealer() (*crypto.Sealer, error) {
+	if len(o.EncryptionKey) == 0 {
+		return nil, nil
+	}
+	return crypto.NewSealer(o.EncryptionKey)
+}
+
+// encryptionKeyCheck returns the value which identifies the key in the marker file.
+func encryptionKeyCheck(key []byte) ([]byte, error) {
+	return crypto.LegacyKeccak256(append([]byte(encryptionKeyCheckPurpose), key...))
+}
+
+// checkEncryption verifies that the localstore at basePath is opened with
+// the key it was encrypted with. A new localstore opened with a key is
+// marked as encrypted.
+func checkEncryption(basePath string, opts *Options) error {
+	marker := path.Join(basePath, encryptionMarkerFileName)
+
+	want, err := os.ReadFile(marker)
+	switch {
+	case err == nil:
+		if len(opts.EncryptionKey) == 0 {
+			return ErrEncryptionKeyRequired
+		}
+		have, err := encryptionKeyCheck(opts.EncryptionKey)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(have, want) {
+			return ErrEncryptionKeyInvalid
+		}
+		return nil
+	case !errors.Is(err, fs.ErrNotExist):
+		return fmt.Errorf("read encryption marker: %w", err)
+	case len(opts.EncryptionKey) == 0:
+		return nil
+	}
+
+	switch _, err := os.Stat(path.Join(basePath, indexPath)); {
+	case err == nil:
+		return ErrStoreNotEncrypted
+	case !errors.Is(err, fs.ErrNotExist):
+		return err
+	}
+
+	check, err := encryptionKeyCheck(opts.EncryptionKey)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(basePath, 0o777); err != nil {
+		return err
+	}
+	return os.WriteFile(marker, check, 0o600)
+}
+
+// newSharky returns the sharky store in the given directory,
+// encrypted when the encryption at rest is enabled.
+func newSharky(basedir string, opts *Options) (*sharky.Store, error) {
+	sealer, err := opts.sealer()
+	if err != nil {
+		return nil, err
+	}
+	return sharky.NewEncrypted(&dirFS{basedir: basedir}, sharkyNoOfShards, swarm.SocMaxChunkSize, sealer)
+}
+
+// newSharkyRecovery returns the sharky recovery of the given directory,
+// encrypted when the encryption at rest is enabled.
+func newSharkyRecovery(basedir string, opts *Options) (*sharky.Recovery, error) {
+	sealer, err := opts.sealer()
+	if err != nil {
+		return nil, err
+	}
+	return sharky.NewEncryptedRecovery(basedir, sharkyNoOfShards, swarm.SocMaxChunkSize, sealer)
+}
+
+// Encrypt migrates the unencrypted localstore at basePath to an encrypted
+// one with the encryption key from the options. The encrypted copy is built
+// next to the localstore and swapped with it once complete, so an interrupted
+// migration leaves the original localstore intact. The path of the original
+// unencrypted localstore is returned and it is up to the caller to remove it.
+func Encrypt(ctx context.Context, basePath string, opts *Options) (string, error) {
+	logger := opts.Logger
+
+	if len(opts.EncryptionKey) == 0 {
+		return "", ErrEncryptionKeyRequired
+	}
+	switch _, err := os.Stat(path.Join(basePath, encryptionMarkerFileName)); {
+	case err == nil:
+		return "", errors.New("localstore is already encrypted")
+	case !errors.Is(err, fs.ErrNotExist):
+		return "", err
+	}
+
+	encPath := basePath + ".encrypting"
+	if err := os.RemoveAll(encPath); err != nil {
+		return "", err
+	}
+
+	logger.Info("localstore encryption started")
+
+	entries, chunks, err := encryptStore(ctx, basePath, encPath, opts)
+	if err != nil {
+		return "", errors.Join(fmt.Errorf("encrypt localstore: %w", err), os.RemoveAll(encPath))
+	}
+
+	plainPath := basePath + ".plaintext"
+	if err := os.Rename(basePath, plainPath); err != nil {
+		return "", err
+	}
+	if err := os.Rename(encPath, basePath); err != nil {
+		return "", errors.Join(err, os.Rename(plainPath, basePath))
+	}
+
+	logger.Info("localstore encryption finished", "entries", entries, "chunks", chunks)
+
+	return plainPath, nil
+}
+
+// encryptStore copies the unencrypted localstore at basePath
+// to a new encrypted localstore at encPath.
+func encryptStore(ctx context.Context, basePath, encPath string, opts *Options) (entries, chunks int, err error) {
+	logger := opts.Logger
+
+	plainOpts := &Options{
+		LdbOpenFilesLimit:         opts.LdbOpenFilesLimit,
+		LdbBlockCacheCapacity:     opts.LdbBlockCacheCapacity,
+		LdbWriteBufferSize:        opts.LdbWriteBufferSize,
+		LdbDisableSeeksCompaction: opts.LdbDisableSeeksCompaction,
+		Logger:                    logger,
+	}
+	plainStore, err := initStore(basePath, plainOpts)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed creating levelDB index store: %w", err)
+	}
+	defer func() {
+		if err := plainStore.Close(); err != nil {
+			logger.Error(err, "failed closing store")
+		}
+	}()
+	plainSharky, err := newSharky(path.Join(basePath, sharkyPath), plainOpts)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed creating sharky instance: %w", err)
+	}
+	defer func() {
+		if err := plainSharky.Close(); err != nil {
+			logger.Error(err, "failed closing sharky")
+		}
+	}()
+
+	encStore, err := initStore(encPath, opts)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed creating encrypted levelDB index store: %w", err)
+	}
+	defer func() {
+		err = errors.Join(err, encStore.Close())
+	}()
+	encSharkyPath := path.Join(encPath, sharkyPath)
+	if err := os.Mkdir(encSharkyPath, 0o777); err != nil {
+		return 0, 0, err
+	}
+	encSharky, err := newSharky(encSharkyPath, opts)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed creating encrypted sharky instance: %w", err)
+	}
+	defer func() {
+		err = errors.Join(err, encSharky.Close())
+	}()
+
+	var (
+		prefix = []byte(chunkstore.RetrievalIndexItem{}.Namespace() + "/")
+		buf    = make([]byte, swarm.SocMaxChunkSize)
+	)
+	copyEntry := func(key, value []byte) error {
+		if !bytes.HasPrefix(key, prefix) {
+			return encStore.PutRaw(key, value)
+		}
+		item := new(chunkstore.RetrievalIndexItem)
+		if err := item.Unmarshal(value); err != nil {
+			return err
+		}
+		data := buf[:item.Location.Length]
+		if err := plainSharky.Read(ctx, item.Location, data); err != nil {
+			return fmt.Errorf("read chunk %s: %w", item.Address, err)
+		}
+		loc, err := encSharky.Write(ctx, data)
+		if err != nil {
+			return fmt.Errorf("write chunk %s: %w", item.Address, err)
+		}
+		item.Location = loc
+		chunks++
+		return encStore.Put(item)
+	}
+
+	iter := plainStore.DB().NewIterator(nil, &opt.ReadOptions{DontFillCache: true})
+	defer iter.Release()
+
+	for iter.Next() {
+		if err := ctx.Err(); err != nil {
+			return entries, chunks, err
+		}
+		if err := copyEntry(iter.Key(), iter.Value()); err != nil {
+			return entries, chunks, err
+		}
+		if entries++; entries%encryptProgressLogStep == 0 {
+			logger.Info("localstore encryption in progress", "entries", entries, "chunks", chunks)
+		}
+	}
+	return entries, chunks, iter.Error()
+}
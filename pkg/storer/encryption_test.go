@@ -0,0 +1,115 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package storer_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	chunktesting "github.com/ethersphere/bee/v2/pkg/storage/testing"
+	storer "github.com/ethersphere/bee/v2/pkg/storer"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+func TestEncrypt(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	basePath := filepath.Join(t.TempDir(), "localstore")
+	key := bytes.Repeat([]byte{0x1}, 32)
+	chunks := chunktesting.GenerateTestRandomChunks(10)
+
+	opts := func(key []byte) *storer.Options {
+		opts := dbTestOps(swarm.RandAddress(t), 0, nil, nil, time.Minute)
+		opts.EncryptionKey = key
+		return opts
+	}
+
+	db, err := storer.New(ctx, basePath, opts(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	session, err := db.NewCollection(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, ch := range chunks {
+		if err := session.Put(ctx, ch); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := session.Done(chunks[0].Address()); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := storer.New(ctx, basePath, opts(key)); !errors.Is(err, storer.ErrStoreNotEncrypted) {
+		t.Fatalf("want error %v, got %v", storer.ErrStoreNotEncrypted, err)
+	}
+
+	plainPath, err := storer.Encrypt(ctx, basePath, opts(key))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(plainPath); err != nil {
+		t.Fatalf("unencrypted localstore not kept: %v", err)
+	}
+
+	if _, err := storer.New(ctx, basePath, opts(nil)); !errors.Is(err, storer.ErrEncryptionKeyRequired) {
+		t.Fatalf("want error %v, got %v", storer.ErrEncryptionKeyRequired, err)
+	}
+	if _, err := storer.New(ctx, basePath, opts(bytes.Repeat([]byte{0x2}, 32))); !errors.Is(err, storer.ErrEncryptionKeyInvalid) {
+		t.Fatalf("want error %v, got %v", storer.ErrEncryptionKeyInvalid, err)
+	}
+
+	err = filepath.Walk(basePath, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		for _, ch := range chunks {
+			if bytes.Contains(data, ch.Data()) {
+				t.Fatalf("chunk %s stored in plaintext in %s", ch.Address(), path)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db, err = storer.New(ctx, basePath, opts(key))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	for _, ch := range chunks {
+		got, err := db.ChunkStore().Get(ctx, ch.Address())
+		if err != nil {
+			t.Fatalf("get chunk %s: %v", ch.Address(), err)
+		}
+		if !bytes.Equal(got.Data(), ch.Data()) {
+			t.Fatalf("chunk %s: data mismatch", ch.Address())
+		}
+	}
+	has, err := db.HasPin(chunks[0].Address())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !has {
+		t.Fatal("pin collection lost by the encryption")
+	}
+}
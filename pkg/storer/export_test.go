@@ -5,6 +5,8 @@
 package storer
 
 import (
+	"context"
+
 	"github.com/ethersphere/bee/v2/pkg/storer/internal/events"
 	"github.com/ethersphere/bee/v2/pkg/storer/internal/reserve"
 )
@@ -35,3 +37,10 @@ func (db *DB) WaitForBgCacheWorkers() (unblock func()) {
 func DefaultOptions() *Options {
 	return defaultOptions()
 }
+
+// Scrub runs a single scrubbing pass without rate limiting and
+// returns the number of scanned, corrupted and repaired chunks.
+func (db *DB) Scrub(ctx context.Context) (scanned, corrupted, repaired int, err error) {
+	stats, err := db.scrubPass(ctx, func() error { return nil })
+	return stats.scanned, stats.corrupted, stats.repaired, err
+}
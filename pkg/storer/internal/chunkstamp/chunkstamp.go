@@ -296,3 +296,52 @@ func DeleteWithStamp(
 		stamp:   stamp,
 	})
 }
+
+// namespaceItem is used to iterate over
+// the stamps of all the chunks in all the scopes.
+type namespaceItem struct{ Item }
+
+func (namespaceItem) Namespace() string { return "chunkStamp" }
+
+// IterateAll iterates over the stamps of all the chunks in all the scopes
+// and reports the batch ID and the size of each of the stamp entries.
+func IterateAll(s storage.Reader, fn func(batchID []byte, size int) (bool, error)) error {
+	return s.Iterate(
+		storage.Query{
+			Factory:      func() storage.Item { return new(namespaceItem) },
+			ItemProperty: storage.QueryItemSize,
+		},
+		func(res storage.Result) (bool, error) {
+			// The ID is in the form of scope/address/batchID/index.
+			end := len(res.ID) - swarm.StampIndexSize - 1
+			if end < swarm.HashSize {
+				return false, nil
+			}
+			return fn([]byte(res.ID[end-swarm.HashSize:end]), len(res.ID)+res.Size)
+		},
+	)
+}
+
+// IterateBatch iterates over the stamps of all the chunks in all the scopes
+// and reports the address of each of the chunks stamped by the given batch.
+// A chunk stored in more than one scope is reported once for each of them.
+func IterateBatch(s storage.Reader, batchID []byte, fn func(addr swarm.Address) (bool, error)) error {
+	return s.Iterate(
+		storage.Query{
+			Factory:      func() storage.Item { return new(namespaceItem) },
+			ItemProperty: storage.QueryItemID,
+		},
+		func(res storage.Result) (bool, error) {
+			// The ID is in the form of scope/address/batchID/index.
+			end := len(res.ID) - swarm.StampIndexSize - 1
+			if end < 2*swarm.HashSize+1 {
+				return false, nil
+			}
+			if res.ID[end-swarm.HashSize:end] != string(batchID) {
+				return false, nil
+			}
+			start := end - 2*swarm.HashSize - 1
+			return fn(swarm.NewAddress([]byte(res.ID[start : start+swarm.HashSize])))
+		},
+	)
+}
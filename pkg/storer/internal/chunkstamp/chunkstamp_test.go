@@ -11,6 +11,7 @@ import (
 	"testing"
 
 	"github.com/ethersphere/bee/v2/pkg/postage"
+	postagetesting "github.com/ethersphere/bee/v2/pkg/postage/testing"
 	"github.com/ethersphere/bee/v2/pkg/storer/internal/transaction"
 
 	storage "github.com/ethersphere/bee/v2/pkg/storage"
@@ -279,3 +280,37 @@ func TestStoreLoadDelete(t *testing.T) {
 		})
 	}
 }
+
+func TestIterateBatch(t *testing.T) {
+	t.Parallel()
+
+	ts := internal.NewInmemStorage()
+	batchID := postagetesting.MustNewID()
+
+	want := make(map[string]int)
+	for i, chunk := range chunktest.GenerateTestRandomChunks(10) {
+		if i%2 == 0 {
+			chunk = chunk.WithStamp(postagetesting.MustNewBatchStamp(batchID))
+			want[chunk.Address().ByteString()] = 2
+		}
+		for _, ns := range []string{"upload", "reserve"} {
+			if err := ts.Run(context.Background(), func(s transaction.Store) error {
+				return chunkstamp.Store(s.IndexStore(), ns, chunk)
+			}); err != nil {
+				t.Fatalf("Store(...): unexpected error: %v", err)
+			}
+		}
+	}
+
+	have := make(map[string]int)
+	err := chunkstamp.IterateBatch(ts.IndexStore(), batchID, func(addr swarm.Address) (bool, error) {
+		have[addr.ByteString()]++
+		return false, nil
+	})
+	if err != nil {
+		t.Fatalf("IterateBatch(...): unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Fatalf("IterateBatch(...): mismatch (-want +have):\n%s", diff)
+	}
+}
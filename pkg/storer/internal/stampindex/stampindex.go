@@ -217,3 +217,22 @@ func Delete(s storage.Writer, scope string, stamp swarm.Stamp) error {
 	}
 	return nil
 }
+
+// IterateAll iterates over the stamp index entries of all the scopes
+// and reports the batch ID and the size of each of the entries.
+func IterateAll(s storage.Reader, fn func(batchID []byte, size int) (bool, error)) error {
+	return s.Iterate(
+		storage.Query{
+			Factory:      func() storage.Item { return new(Item) },
+			ItemProperty: storage.QueryItemSize,
+		},
+		func(res storage.Result) (bool, error) {
+			// The ID is in the form of scope/batchID/index.
+			end := len(res.ID) - swarm.StampIndexSize - 1
+			if end < swarm.HashSize {
+				return false, nil
+			}
+			return fn([]byte(res.ID[end-swarm.HashSize:end]), len(res.ID)+res.Size)
+		},
+	)
+}
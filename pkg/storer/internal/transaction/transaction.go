@@ -24,11 +24,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	m "github.com/ethersphere/bee/v2/pkg/metrics"
 	"github.com/ethersphere/bee/v2/pkg/sharky"
 	"github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/storage/leveldbstore"
 	"github.com/ethersphere/bee/v2/pkg/storer/internal/chunkstore"
 	"github.com/ethersphere/bee/v2/pkg/swarm"
 	"github.com/prometheus/client_golang/prometheus"
@@ -57,18 +59,45 @@ type Storage interface {
 	Close() error
 }
 
+// Snapshot is a consistent, read-only view of the storage taken at a single
+// point in time. The sharky locations referenced by the snapshot are not
+// reused until the snapshot is released.
+type Snapshot interface {
+	ReadOnlyStore
+	// IterateRaw iterates over all the raw key/value pairs of the index store.
+	IterateRaw(func(key, value []byte) (bool, error)) error
+	// Release releases the snapshot and frees the sharky locations
+	// that were released while the snapshot was held.
+	Release()
+}
+
+// Snapshotter is implemented by the storages which are able to provide
+// a point-in-time Snapshot of their content.
+type Snapshotter interface {
+	Snapshot() (Snapshot, error)
+}
+
+// ErrSnapshotNotSupported is returned when the underlying
+// index store is not able to provide snapshots.
+var ErrSnapshotNotSupported = errors.New("snapshot not supported")
+
 type store struct {
 	sharky      *sharky.Store
 	bstore      storage.BatchStore
 	metrics     metrics
 	chunkLocker *multex.Multex
+
+	snapshotMu   sync.Mutex // snapshotMu guards snapshots and deferredLocs.
+	snapshots    int
+	deferredLocs []sharky.Location
 }
 
 func NewStorage(sharky *sharky.Store, bstore storage.BatchStore) Storage {
-	return &store{sharky, bstore, newMetrics(), multex.New()}
+	return &store{sharky: sharky, bstore: bstore, metrics: newMetrics(), chunkLocker: multex.New()}
 }
 
 type transaction struct {
+	store      *store
 	start      time.Time
 	batch      storage.Batch
 	indexstore storage.IndexStore
@@ -92,6 +121,7 @@ func (s *store) NewTransaction(ctx context.Context) (Transaction, func()) {
 	sharky := &sharkyTrx{s.sharky, s.metrics, nil, nil}
 
 	t := &transaction{
+		store:      s,
 		start:      time.Now(),
 		batch:      b,
 		indexstore: index,
@@ -153,6 +183,87 @@ func (s *store) StatusMetrics() []prometheus.Collector {
 	}
 }
 
+// Snapshot implements the Snapshotter interface.
+func (s *store) Snapshot() (Snapshot, error) {
+	ss, ok := s.bstore.(interface {
+		Snapshot() (*leveldbstore.Snapshot, error)
+	})
+	if !ok {
+		return nil, ErrSnapshotNotSupported
+	}
+
+	// The snapshot counter must be incremented before the index
+	// snapshot is taken, so that no location referenced by the
+	// index snapshot gets released in between.
+	s.snapshotMu.Lock()
+	s.snapshots++
+	s.snapshotMu.Unlock()
+
+	snap, err := ss.Snapshot()
+	if err != nil {
+		s.releaseSnapshot()
+		return nil, err
+	}
+
+	return &snapshot{store: s, snap: snap}, nil
+}
+
+// release frees the sharky location, or defers it
+// if there are snapshots which might still reference it.
+func (s *store) release(loc sharky.Location) error {
+	s.snapshotMu.Lock()
+	defer s.snapshotMu.Unlock()
+
+	if s.snapshots > 0 {
+		s.deferredLocs = append(s.deferredLocs, loc)
+		return nil
+	}
+	return s.sharky.Release(context.TODO(), loc)
+}
+
+// releaseSnapshot decrements the snapshot counter and frees the deferred
+// sharky locations once the last snapshot is released.
+func (s *store) releaseSnapshot() {
+	s.snapshotMu.Lock()
+	defer s.snapshotMu.Unlock()
+
+	s.snapshots--
+	if s.snapshots > 0 {
+		return
+	}
+	for _, loc := range s.deferredLocs {
+		_ = s.sharky.Release(context.TODO(), loc)
+	}
+	s.deferredLocs = nil
+}
+
+type snapshot struct {
+	store *store
+	snap  *leveldbstore.Snapshot
+	once  sync.Once
+}
+
+func (s *snapshot) IndexStore() storage.Reader {
+	return s.snap
+}
+
+func (s *snapshot) ChunkStore() storage.ReadOnlyChunkStore {
+	indexStore := &indexTrx{s.snap, nil, s.store.metrics}
+	sharkyTrx := &sharkyTrx{s.store.sharky, s.store.metrics, nil, nil}
+	return &chunkStoreTrx{indexStore, sharkyTrx, s.store.chunkLocker, nil, s.store.metrics, true}
+}
+
+func (s *snapshot) IterateRaw(fn func(key, value []byte) (bool, error)) error {
+	return s.snap.IterateRaw(fn)
+}
+
+func (s *snapshot) Release() {
+	s.once.Do(func() {
+		s.snap.Release()
+		s.store.releaseSnapshot()
+	})
+}
+
 func (s *store) Close() error {
 	return errors.Join(s.bstore.Close(), s.sharky.Close())
 }
@@ -188,7 +299,7 @@ func (t *transaction) Commit() (err error) {
 	// the batch commit was successful, we can now release the accumulated locations from sharky.
 	for _, l := range t.sharkyTrx.releasedLocs {
 		h := handleMetric("sharky_release", t.metrics)
-		rerr := t.sharkyTrx.sharky.Release(context.TODO(), l)
+		rerr := t.store.release(l)
 		h(&rerr)
 		if rerr != nil {
 			err = errors.Join(err, fmt.Errorf("failed releasing location after commit %s: %w", l, rerr))
@@ -187,3 +187,54 @@ func Test_TransactionStorage(t *testing.T) {
 		}
 	})
 }
+
+func Test_TransactionSnapshot(t *testing.T) {
+	t.Parallel()
+
+	sharkyStore, err := sharky.New(&dirFS{basedir: t.TempDir()}, 1, swarm.SocMaxChunkSize)
+	assert.NoError(t, err)
+
+	store, err := leveldbstore.New("", nil)
+	assert.NoError(t, err)
+
+	st := transaction.NewStorage(sharkyStore, store)
+	t.Cleanup(func() {
+		assert.NoError(t, st.Close())
+	})
+
+	ch1 := test.GenerateTestRandomChunk()
+	assert.NoError(t, st.Run(context.Background(), func(s transaction.Store) error {
+		return s.ChunkStore().Put(context.Background(), ch1)
+	}))
+
+	snap, err := st.(transaction.Snapshotter).Snapshot()
+	assert.NoError(t, err)
+
+	// delete the chunk and write a new one, which would reuse the
+	// released sharky slot if it was not held by the snapshot.
+	ch2 := test.GenerateTestRandomChunk()
+	assert.NoError(t, st.Run(context.Background(), func(s transaction.Store) error {
+		return s.ChunkStore().Delete(context.Background(), ch1.Address())
+	}))
+	assert.NoError(t, st.Run(context.Background(), func(s transaction.Store) error {
+		return s.ChunkStore().Put(context.Background(), ch2)
+	}))
+
+	has, err := st.ChunkStore().Has(context.Background(), ch1.Address())
+	assert.NoError(t, err)
+	assert.False(t, has)
+
+	got, err := snap.ChunkStore().Get(context.Background(), ch1.Address())
+	assert.NoError(t, err)
+	assert.Equal(t, ch1.Data(), got.Data())
+
+	has, err = snap.ChunkStore().Has(context.Background(), ch2.Address())
+	assert.NoError(t, err)
+	assert.False(t, has)
+
+	snap.Release()
+
+	got, err = st.ChunkStore().Get(context.Background(), ch2.Address())
+	assert.NoError(t, err)
+	assert.Equal(t, ch2.Data(), got.Data())
+}
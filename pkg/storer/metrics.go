@@ -31,6 +31,11 @@ type metrics struct {
 	LevelDBStats            *prometheus.HistogramVec
 	ExpiryTriggersCount     prometheus.Counter
 	ExpiryRunsCount         prometheus.Counter
+	ScrubbedChunkCount      prometheus.Counter
+	CorruptedChunkCount     prometheus.Counter
+	ScrubRepairs            *prometheus.CounterVec
+	MigrationStepDone       *prometheus.GaugeVec
+	MigrationStepTotal      *prometheus.GaugeVec
 
 	ReserveMissingBatch prometheus.Gauge
 }
@@ -163,6 +168,49 @@ func newMetrics() metrics {
 				Help:      "Number of times the expiry worker was fired.",
 			},
 		),
+		ScrubbedChunkCount: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "scrubbed_chunk_count",
+				Help:      "Number of chunks verified by the scrubber.",
+			},
+		),
+		CorruptedChunkCount: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "corrupted_chunk_count",
+				Help:      "Number of corrupted chunks found by the scrubber.",
+			},
+		),
+		ScrubRepairs: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "scrub_repairs",
+				Help:      "Number of corrupted chunk repairs by status.",
+			},
+			[]string{"status"},
+		),
+		MigrationStepDone: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "migration_step_done",
+				Help:      "Number of items processed by the migration step.",
+			},
+			[]string{"group", "step"},
+		),
+		MigrationStepTotal: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "migration_step_total",
+				Help:      "Estimated number of items to be processed by the migration step.",
+			},
+			[]string{"group", "step"},
+		),
 	}
 }
 
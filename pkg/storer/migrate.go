@@ -0,0 +1,141 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package storer
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/storage/migration"
+	"github.com/ethersphere/bee/v2/pkg/storer/internal/transaction"
+	localmigration "github.com/ethersphere/bee/v2/pkg/storer/migration"
+)
+
+const (
+	// coreMigrationGroup is the group of migrations run before the localstore is initiated.
+	coreMigrationGroup = "core-migration"
+	// migrationGroup is the group of migrations run after the localstore is initiated.
+	migrationGroup = "migration"
+
+	// migrationProgressLogInterval is the minimal interval between two progress log lines.
+	migrationProgressLogInterval = 30 * time.Second
+)
+
+// MigrationStep describes a pending localstore migration step.
+type MigrationStep struct {
+	Group   string `json:"group"`
+	Version uint64 `json:"version"`
+	Name    string `json:"name"`
+	// Estimate is the number of index entries the step
+	// will process, -1 when the work is negligible.
+	Estimate int `json:"estimate"`
+	// Done is the work finished by an interrupted previous run of the step.
+	Done    uint64 `json:"done"`
+	Resumed bool   `json:"resumed"`
+}
+
+// PendingMigrations reports the migration steps that will be applied the next
+// time the localstore is opened, without applying them.
+func PendingMigrations(ctx context.Context, basePath string, opts *Options) ([]MigrationStep, error) {
+	store, err := initStore(basePath, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed creating levelDB index store: %w", err)
+	}
+	defer func() {
+		if err := store.Close(); err != nil {
+			opts.Logger.Error(err, "failed closing store")
+		}
+	}()
+
+	groups := []struct {
+		name  string
+		steps migration.Steps
+		info  map[uint64]localmigration.StepInfo
+	}{
+		{coreMigrationGroup, localmigration.BeforeInitSteps(store, opts.Logger, nil), localmigration.BeforeInitStepsInfo()},
+		{migrationGroup, localmigration.AfterInitSteps("", sharkyNoOfShards, nil, nil, opts.Logger, nil), localmigration.AfterInitStepsInfo()},
+	}
+
+	var steps []MigrationStep
+	for _, group := range groups {
+		pending, err := migration.Pending(store, group.name, group.steps)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", group.name, err)
+		}
+		for _, p := range pending {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			info := group.info[p.Version]
+			step := MigrationStep{
+				Group:    group.name,
+				Version:  p.Version,
+				Name:     info.Name,
+				Estimate: -1,
+				Done:     p.Done,
+				Resumed:  p.Resumed,
+			}
+			if info.Estimate != nil {
+				if step.Estimate, err = info.Estimate(store); err != nil {
+					return nil, fmt.Errorf("%s step %d estimate: %w", group.name, p.Version, err)
+				}
+			}
+			steps = append(steps, step)
+		}
+	}
+
+	sort.SliceStable(steps, func(i, j int) bool {
+		return steps[i].Group == coreMigrationGroup && steps[j].Group != coreMigrationGroup
+	})
+	return steps, nil
+}
+
+// migrationProgress returns the progress tracker of the localstore
+// migrations which exposes the progress as metrics and periodic logs.
+func (m metrics) migrationProgress(logger log.Logger) *migration.Progress {
+	var (
+		mu   sync.Mutex
+		last time.Time
+	)
+	return migration.NewProgress(func(group string, version, done, total uint64) {
+		step := strconv.FormatUint(version, 10)
+		m.MigrationStepDone.WithLabelValues(group, step).Set(float64(done))
+		m.MigrationStepTotal.WithLabelValues(group, step).Set(float64(total))
+
+		mu.Lock()
+		defer mu.Unlock()
+		if time.Since(last) < migrationProgressLogInterval {
+			return
+		}
+		last = time.Now()
+		logger.Info("migration in progress", "group", group, "step", version, "done", done, "total", total)
+	})
+}
+
+// autoCommitIndexStore commits every write in its own transaction, so that
+// the versions and the checkpoints of the finished migration steps are
+// persisted even if a later step is interrupted.
+type autoCommitIndexStore struct {
+	storage.Reader
+	st transaction.Storage
+}
+
+func (s autoCommitIndexStore) Put(item storage.Item) error {
+	return s.st.Run(context.Background(), func(t transaction.Store) error {
+		return t.IndexStore().Put(item)
+	})
+}
+
+func (s autoCommitIndexStore) Delete(item storage.Item) error {
+	return s.st.Run(context.Background(), func(t transaction.Store) error {
+		return t.IndexStore().Delete(item)
+	})
+}
@@ -5,33 +5,84 @@
 package migration
 
 import (
+	"github.com/ethersphere/bee/v2/pkg/crypto"
 	"github.com/ethersphere/bee/v2/pkg/log"
 	"github.com/ethersphere/bee/v2/pkg/storage"
 	"github.com/ethersphere/bee/v2/pkg/storage/migration"
+	"github.com/ethersphere/bee/v2/pkg/storer/internal/cache"
+	"github.com/ethersphere/bee/v2/pkg/storer/internal/chunkstore"
+	"github.com/ethersphere/bee/v2/pkg/storer/internal/reserve"
 	"github.com/ethersphere/bee/v2/pkg/storer/internal/transaction"
+	"github.com/ethersphere/bee/v2/pkg/storer/internal/upload"
 )
 
 // AfterInitSteps lists all migration steps for localstore IndexStore after the localstore is initiated.
+// The long running steps report their progress to the given progress, which might be nil.
+// The sealer of the sharky store is nil unless the localstore is encrypted.
 func AfterInitSteps(
 	sharkyPath string,
 	sharkyNoOfShards int,
+	sealer *crypto.Sealer,
 	st transaction.Storage,
 	logger log.Logger,
+	progress *migration.Progress,
 ) migration.Steps {
 	return map[uint64]migration.StepFn{
 		1: step_01,
 		2: step_02(st),
-		3: ReserveRepairer(st, storage.ChunkType, logger),
-		4: step_04(sharkyPath, sharkyNoOfShards, st, logger),
-		5: step_05(st, logger),
-		6: step_06(st, logger),
+		3: ReserveRepairer(st, storage.ChunkType, logger, progress),
+		4: step_04(sharkyPath, sharkyNoOfShards, sealer, st, logger, progress),
+		5: step_05(st, logger, progress),
+		6: step_06(st, logger, progress),
 		7: resetReserveEpochTimestamp(st),
 	}
 }
 
 // BeforeInitSteps lists all migration steps for localstore IndexStore before the localstore is initiated.
-func BeforeInitSteps(st storage.BatchStore, logger log.Logger) migration.Steps {
+// The long running steps report their progress to the given progress, which might be nil.
+func BeforeInitSteps(st storage.BatchStore, logger log.Logger, progress *migration.Progress) migration.Steps {
 	return map[uint64]migration.StepFn{
-		1: RefCountSizeInc(st, logger),
+		1: RefCountSizeInc(st, logger, progress),
+	}
+}
+
+// StepInfo describes a localstore migration step.
+type StepInfo struct {
+	Name string
+	// Estimate returns the number of index entries the step
+	// will process. It is nil when the work is negligible.
+	Estimate func(storage.Reader) (int, error)
+}
+
+// AfterInitStepsInfo describes the steps listed by AfterInitSteps.
+func AfterInitStepsInfo() map[uint64]StepInfo {
+	return map[uint64]StepInfo{
+		1: {Name: "noop"},
+		2: {Name: "reset cache access timestamps", Estimate: countOf(&cache.CacheEntryItem{})},
+		3: {Name: "reserve repair", Estimate: countOf(&reserve.BatchRadiusItem{})},
+		4: {Name: "sharky recovery", Estimate: countOf(&chunkstore.RetrievalIndexItem{})},
+		5: {Name: "remove upload items", Estimate: func(r storage.Reader) (int, error) {
+			n := 0
+			err := upload.IterateAll(r, func(storage.Item) (bool, error) {
+				n++
+				return false, nil
+			})
+			return n, err
+		}},
+		6: {Name: "add stamp hash to reserve items", Estimate: countOf(&reserve.BatchRadiusItemV1{})},
+		7: {Name: "reset reserve epoch timestamp"},
+	}
+}
+
+// BeforeInitStepsInfo describes the steps listed by BeforeInitSteps.
+func BeforeInitStepsInfo() map[uint64]StepInfo {
+	return map[uint64]StepInfo{
+		1: {Name: "increase chunk reference counter size", Estimate: countOf(&OldRetrievalIndexItem{})},
+	}
+}
+
+func countOf(key storage.Key) func(storage.Reader) (int, error) {
+	return func(r storage.Reader) (int, error) {
+		return r.Count(key)
 	}
 }
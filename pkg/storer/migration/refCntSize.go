@@ -12,6 +12,7 @@ import (
 	"github.com/ethersphere/bee/v2/pkg/log"
 	"github.com/ethersphere/bee/v2/pkg/sharky"
 	"github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/storage/migration"
 	"github.com/ethersphere/bee/v2/pkg/storage/storageutil"
 	"github.com/ethersphere/bee/v2/pkg/storer/internal/chunkstore"
 	"github.com/ethersphere/bee/v2/pkg/swarm"
@@ -100,18 +101,32 @@ func (r OldRetrievalIndexItem) String() string {
 	return storageutil.JoinFields(r.Namespace(), r.ID())
 }
 
-func RefCountSizeInc(s storage.BatchStore, logger log.Logger) func() error {
+// RefCountSizeInc is a migration step that replaces the old retrieval index
+// items with the new ones with a larger reference counter. The step saves a
+// checkpoint after every committed batch and continues after the last
+// migrated item when it is interrupted.
+func RefCountSizeInc(s storage.BatchStore, logger log.Logger, progress *migration.Progress) func() error {
 	return func() error {
 
 		logger := logger.WithName("migration-RefCountSizeInc").Register()
 
 		logger.Info("starting migration of replacing chunkstore items to increase refCnt capacity")
 
+		// The migrated items can not be read as old items
+		// anymore, so the iteration starts after the cursor.
+		cursor := string(progress.Cursor())
+		if cursor != "" {
+			logger.Info("resuming interrupted migration", "migrated", progress.Done())
+		}
+
 		var itemsToDelete []*OldRetrievalIndexItem
 
 		err := s.Iterate(
 			storage.Query{
-				Factory: func() storage.Item { return &OldRetrievalIndexItem{} },
+				Factory:       func() storage.Item { return &OldRetrievalIndexItem{} },
+				Prefix:        cursor,
+				PrefixAtStart: cursor != "",
+				SkipFirst:     cursor != "",
 			},
 			func(res storage.Result) (bool, error) {
 				item := res.Entry.(*OldRetrievalIndexItem)
@@ -123,6 +138,8 @@ func RefCountSizeInc(s storage.BatchStore, logger log.Logger) func() error {
 			return err
 		}
 
+		progress.SetTotal(progress.Done() + uint64(len(itemsToDelete)))
+
 		for i := 0; i < len(itemsToDelete); i += 10000 {
 			end := i + 10000
 			if end > len(itemsToDelete) {
@@ -144,6 +161,12 @@ func RefCountSizeInc(s storage.BatchStore, logger log.Logger) func() error {
 				}
 			}
 
+			progress.Add(uint64(end - i))
+			err = progress.Checkpoint(b, itemsToDelete[end-1].Address.Bytes())
+			if err != nil {
+				return err
+			}
+
 			err = b.Commit()
 			if err != nil {
 				return err
@@ -5,12 +5,16 @@
 package migration_test
 
 import (
+	"bytes"
+	"errors"
 	"math/rand"
+	"sort"
 	"testing"
 
 	"github.com/ethersphere/bee/v2/pkg/log"
 	"github.com/ethersphere/bee/v2/pkg/sharky"
 	"github.com/ethersphere/bee/v2/pkg/storage/inmemstore"
+	"github.com/ethersphere/bee/v2/pkg/storage/migration"
 	"github.com/ethersphere/bee/v2/pkg/storer/internal/chunkstore"
 	localmigration "github.com/ethersphere/bee/v2/pkg/storer/migration"
 	"github.com/ethersphere/bee/v2/pkg/swarm"
@@ -37,7 +41,7 @@ func Test_RefCntSize(t *testing.T) {
 		assert.NoError(t, err)
 	}
 
-	assert.NoError(t, stepFn(store, log.Noop)())
+	assert.NoError(t, stepFn(store, log.Noop, nil)())
 
 	// check if all entries are migrated.
 	for _, entry := range oldItems {
@@ -50,3 +54,63 @@ func Test_RefCntSize(t *testing.T) {
 		assert.Equal(t, uint32(entry.RefCnt), cEntry.RefCnt)
 	}
 }
+
+func Test_RefCntSize_Resume(t *testing.T) {
+	t.Parallel()
+
+	store := inmemstore.New()
+
+	var oldItems []*localmigration.OldRetrievalIndexItem
+	for i := 0; i < 10; i++ {
+		entry := &localmigration.OldRetrievalIndexItem{
+			Address:   swarm.RandAddress(t),
+			Timestamp: uint64(rand.Int()),
+			Location:  sharky.Location{Shard: uint8(rand.Int()), Slot: uint32(rand.Int()), Length: uint16(rand.Int())},
+			RefCnt:    uint8(rand.Int()),
+		}
+		oldItems = append(oldItems, entry)
+		assert.NoError(t, store.Put(entry))
+	}
+	sort.Slice(oldItems, func(i, j int) bool {
+		return bytes.Compare(oldItems[i].Address.Bytes(), oldItems[j].Address.Bytes()) < 0
+	})
+
+	progress := migration.NewProgress(nil)
+
+	// simulate a run interrupted after the first half of the items is migrated.
+	errInterrupted := errors.New("interrupted")
+	err := migration.MigrateWithProgress(store, "migration", migration.Steps{
+		1: func() error {
+			for _, item := range oldItems[:5] {
+				err := store.Put(&chunkstore.RetrievalIndexItem{
+					Address:   item.Address,
+					Timestamp: item.Timestamp,
+					Location:  item.Location,
+					RefCnt:    uint32(item.RefCnt),
+				})
+				if err != nil {
+					return err
+				}
+			}
+			progress.Add(5)
+			if err := progress.Checkpoint(store, oldItems[4].Address.Bytes()); err != nil {
+				return err
+			}
+			return errInterrupted
+		},
+	}, progress)
+	assert.ErrorIs(t, err, errInterrupted)
+
+	err = migration.MigrateWithProgress(store, "migration", migration.Steps{
+		1: localmigration.RefCountSizeInc(store, log.Noop, progress),
+	}, progress)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(10), progress.Done())
+
+	for _, entry := range oldItems {
+		cEntry := &chunkstore.RetrievalIndexItem{Address: entry.Address}
+		assert.NoError(t, store.Get(cEntry))
+		assert.Equal(t, entry.Location, cEntry.Location)
+		assert.Equal(t, uint32(entry.RefCnt), cEntry.RefCnt)
+	}
+}
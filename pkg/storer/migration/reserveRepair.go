@@ -15,6 +15,7 @@ import (
 
 	"github.com/ethersphere/bee/v2/pkg/log"
 	"github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/storage/migration"
 	"github.com/ethersphere/bee/v2/pkg/storer/internal/chunkstamp"
 	"github.com/ethersphere/bee/v2/pkg/storer/internal/reserve"
 	"github.com/ethersphere/bee/v2/pkg/storer/internal/transaction"
@@ -24,10 +25,13 @@ import (
 
 // ReserveRepairer is a migration step that removes all BinItem entries and migrates
 // ChunkBinItem and BatchRadiusItem entries to use a new BinID field.
+// The repair does not save checkpoints, an interrupted step starts over
+// and issues the BinIDs of all the entries again.
 func ReserveRepairer(
 	st transaction.Storage,
 	chunkTypeFunc func(swarm.Chunk) swarm.ChunkType,
 	logger log.Logger,
+	progress *migration.Progress,
 ) func() error {
 	return func() error {
 		/*
@@ -155,6 +159,7 @@ func ReserveRepairer(
 		}
 
 		logger.Info("counted all batch radius entries", "total_entries", len(batchRadiusItems))
+		progress.SetTotal(uint64(len(batchRadiusItems)))
 
 		var missingChunks atomic.Int64
 		var invalidSharkyChunks atomic.Int64
@@ -180,6 +185,8 @@ func ReserveRepairer(
 			func(item *reserve.BatchRadiusItem) {
 				eg.Go(func() error {
 
+					defer progress.Add(1)
+
 					return st.Run(context.Background(), func(s transaction.Store) error {
 
 						chunk, err := s.ChunkStore().Get(context.Background(), item.Address)
@@ -7,20 +7,25 @@ package migration
 import (
 	"context"
 
+	"github.com/ethersphere/bee/v2/pkg/crypto"
 	"github.com/ethersphere/bee/v2/pkg/log"
 	"github.com/ethersphere/bee/v2/pkg/sharky"
+	"github.com/ethersphere/bee/v2/pkg/storage/migration"
 	"github.com/ethersphere/bee/v2/pkg/storer/internal/chunkstore"
 	"github.com/ethersphere/bee/v2/pkg/storer/internal/transaction"
 	"github.com/ethersphere/bee/v2/pkg/swarm"
 )
 
 // step_04 is the fourth step of the migration. It forces a sharky recovery to
-// be run on the localstore.
+// be run on the localstore. The sealer is nil unless the localstore is encrypted.
+// The recovery does not save checkpoints, an interrupted step starts over.
 func step_04(
 	sharkyBasePath string,
 	sharkyNoOfShards int,
+	sealer *crypto.Sealer,
 	st transaction.Storage,
 	logger log.Logger,
+	progress *migration.Progress,
 ) func() error {
 	return func() error {
 		// for in-mem store, skip this step
@@ -30,7 +35,7 @@ func step_04(
 		logger := logger.WithName("migration-step-04").Register()
 
 		logger.Info("starting sharky recovery")
-		sharkyRecover, err := sharky.NewRecovery(sharkyBasePath, sharkyNoOfShards, swarm.SocMaxChunkSize)
+		sharkyRecover, err := sharky.NewEncryptedRecovery(sharkyBasePath, sharkyNoOfShards, swarm.SocMaxChunkSize, sealer)
 		if err != nil {
 			return err
 		}
@@ -45,6 +50,7 @@ func step_04(
 			if err := sharkyRecover.Add(res.Location); err != nil {
 				return err
 			}
+			progress.Add(1)
 		}
 
 		if err := sharkyRecover.Save(); err != nil {
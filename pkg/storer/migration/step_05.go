@@ -10,24 +10,34 @@ import (
 
 	"github.com/ethersphere/bee/v2/pkg/log"
 	"github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/storage/migration"
 	"github.com/ethersphere/bee/v2/pkg/storer/internal/transaction"
 	"github.com/ethersphere/bee/v2/pkg/storer/internal/upload"
 )
 
 // step_05 is a migration step that removes all upload items from the store.
-func step_05(st transaction.Storage, logger log.Logger) func() error {
+// The step saves a checkpoint with every removed item and continues with the
+// remaining items when it is interrupted.
+func step_05(st transaction.Storage, logger log.Logger, progress *migration.Progress) func() error {
 	return func() error {
 
 		logger := logger.WithName("migration-step-05").Register()
 
 		logger.Info("start removing upload items")
+		if done := progress.Done(); done > 0 {
+			logger.Info("resuming interrupted migration", "removed", done)
+		}
 
 		itemC := make(chan storage.Item)
 		errC := make(chan error)
 		go func() {
 			for item := range itemC {
 				err := st.Run(context.Background(), func(s transaction.Store) error {
-					return s.IndexStore().Delete(item)
+					if err := s.IndexStore().Delete(item); err != nil {
+						return err
+					}
+					progress.Add(1)
+					return progress.Checkpoint(s.IndexStore(), nil)
 				})
 				if err != nil {
 					errC <- fmt.Errorf("delete upload item: %w", err)
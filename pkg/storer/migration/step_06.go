@@ -15,21 +15,28 @@ import (
 
 	"github.com/ethersphere/bee/v2/pkg/log"
 	"github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/storage/migration"
 	"github.com/ethersphere/bee/v2/pkg/storer/internal/chunkstamp"
 	"github.com/ethersphere/bee/v2/pkg/storer/internal/reserve"
 	"github.com/ethersphere/bee/v2/pkg/storer/internal/stampindex"
 	"github.com/ethersphere/bee/v2/pkg/storer/internal/transaction"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
 	"golang.org/x/sync/errgroup"
 )
 
 // step_06 is a migration step that adds a stampHash to all BatchRadiusItems, ChunkBinItems and StampIndexItems.
-func step_06(st transaction.Storage, logger log.Logger) func() error {
+// Every item is migrated in its own transaction together with a checkpoint, an
+// interrupted step skips the items which already have the stamp hash.
+func step_06(st transaction.Storage, logger log.Logger, progress *migration.Progress) func() error {
 	return func() error {
 		logger := logger.WithName("migration-step-06").Register()
 
 		logger.Info("start adding stampHash to BatchRadiusItems, ChunkBinItems and StampIndexItems")
+		if done := progress.Done(); done > 0 {
+			logger.Info("resuming interrupted migration", "migrated", done)
+		}
 
-		seenCount, doneCount, err := addStampHash(logger, st)
+		seenCount, doneCount, err := addStampHash(logger, st, progress)
 		if err != nil {
 			return fmt.Errorf("add stamp hash migration: %w", err)
 		}
@@ -38,8 +45,14 @@ func step_06(st transaction.Storage, logger log.Logger) func() error {
 	}
 }
 
-func addStampHash(logger log.Logger, st transaction.Storage) (int64, int64, error) {
+// batchRadiusItemV1IDSize is the size of the ID of the BatchRadiusItemV1,
+// the ID of the migrated BatchRadiusItem is longer by the stamp hash.
+const batchRadiusItemV1IDSize = swarm.HashSize + 1 + swarm.HashSize
+
+func addStampHash(logger log.Logger, st transaction.Storage, progress *migration.Progress) (int64, int64, error) {
 
+	// The old and the new items share the namespaces, so
+	// the counts include the items migrated before an interruption.
 	preBatchRadiusCnt, err := st.IndexStore().Count(&reserve.BatchRadiusItemV1{})
 	if err != nil {
 		return 0, 0, err
@@ -53,6 +66,7 @@ func addStampHash(logger log.Logger, st transaction.Storage) (int64, int64, erro
 	if preBatchRadiusCnt != preChunkBinCnt {
 		return 0, 0, fmt.Errorf("pre-migration check: index counts do not match, %d vs %d", preBatchRadiusCnt, preChunkBinCnt)
 	}
+	progress.SetTotal(uint64(preBatchRadiusCnt))
 
 	// Delete epoch timestamp
 	err = st.Run(context.Background(), func(s transaction.Store) error {
@@ -90,10 +104,19 @@ func addStampHash(logger log.Logger, st transaction.Storage) (int64, int64, erro
 
 	go func() {
 		_ = st.IndexStore().Iterate(storage.Query{
-			Factory: func() storage.Item { return new(reserve.BatchRadiusItemV1) },
+			Factory:      func() storage.Item { return new(reserve.BatchRadiusItemV1) },
+			ItemProperty: storage.QueryItemID,
 		}, func(result storage.Result) (bool, error) {
+			if len(result.ID) != batchRadiusItemV1IDSize {
+				return false, nil // migrated before an interruption
+			}
 			seenCount++
-			item := result.Entry.(*reserve.BatchRadiusItemV1)
+			id := []byte(result.ID)
+			item := &reserve.BatchRadiusItemV1{
+				BatchID: id[:swarm.HashSize],
+				Bin:     id[swarm.HashSize],
+				Address: swarm.NewAddress(id[swarm.HashSize+1:]),
+			}
 			select {
 			case itemC <- item:
 			case err := <-errC:
@@ -109,6 +132,10 @@ func addStampHash(logger log.Logger, st transaction.Storage) (int64, int64, erro
 		eg.Go(func() error {
 			err := st.Run(context.Background(), func(s transaction.Store) error {
 				idxStore := s.IndexStore()
+				err := idxStore.Get(batchRadiusItemV1)
+				if err != nil {
+					return err
+				}
 				stamp, err := chunkstamp.LoadWithBatchID(idxStore, "reserve", batchRadiusItemV1.Address, batchRadiusItemV1.BatchID)
 				if err != nil {
 					return err
@@ -170,7 +197,8 @@ func addStampHash(logger log.Logger, st transaction.Storage) (int64, int64, erro
 					return err
 				}
 				doneCount.Add(1)
-				return nil
+				progress.Add(1)
+				return progress.Checkpoint(idxStore, nil)
 			})
 			if err != nil {
 				errC <- err
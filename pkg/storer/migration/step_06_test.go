@@ -6,12 +6,14 @@ package migration_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/ethersphere/bee/v2/pkg/log"
 	"github.com/ethersphere/bee/v2/pkg/sharky"
 	"github.com/ethersphere/bee/v2/pkg/storage"
 	"github.com/ethersphere/bee/v2/pkg/storage/leveldbstore"
+	"github.com/ethersphere/bee/v2/pkg/storage/migration"
 	chunktest "github.com/ethersphere/bee/v2/pkg/storage/testing"
 	"github.com/ethersphere/bee/v2/pkg/storer/internal/chunkstamp"
 	"github.com/ethersphere/bee/v2/pkg/storer/internal/reserve"
@@ -45,14 +47,93 @@ func Test_Step_06(t *testing.T) {
 	})
 
 	chunks := chunktest.GenerateTestRandomChunks(100)
-	ctx := context.Background()
 
 	batchRadiusItems := make(map[string]oldAndNewItem[*reserve.BatchRadiusItemV1, *reserve.BatchRadiusItem])
 	chunkBinItems := make(map[string]oldAndNewItem[*reserve.ChunkBinItemV1, *reserve.ChunkBinItem])
 	stampIndexItems := make(map[string]oldAndNewItem[*stampindex.ItemV1, *stampindex.Item])
 
-	for i, ch := range chunks {
-		err = store.Run(ctx, func(s transaction.Store) error {
+	putStep06Items(t, store, chunks, 0, batchRadiusItems, chunkBinItems, stampIndexItems)
+
+	err = localmigration.Step_06(store, log.Noop, nil)()
+	require.NoError(t, err)
+
+	has, err := store.IndexStore().Has(&reserve.EpochItem{})
+	if has {
+		t.Fatal("epoch item should be deleted")
+	}
+	require.NoError(t, err)
+
+	checkBatchRadiusItems(t, store.IndexStore(), len(chunks), batchRadiusItems)
+	checkChunkBinItems(t, store.IndexStore(), len(chunks), chunkBinItems)
+	checkStampIndex(t, store.IndexStore(), len(chunks), stampIndexItems)
+}
+
+func Test_Step_06_Resume(t *testing.T) {
+	t.Parallel()
+
+	sharkyStore, err := sharky.New(&dirFS{basedir: t.TempDir()}, 1, swarm.SocMaxChunkSize)
+	require.NoError(t, err)
+
+	lstore, err := leveldbstore.New("", nil)
+	require.NoError(t, err)
+
+	store := transaction.NewStorage(sharkyStore, lstore)
+	t.Cleanup(func() {
+		err := store.Close()
+		require.NoError(t, err)
+	})
+
+	chunks := chunktest.GenerateTestRandomChunks(10)
+
+	batchRadiusItems := make(map[string]oldAndNewItem[*reserve.BatchRadiusItemV1, *reserve.BatchRadiusItem])
+	chunkBinItems := make(map[string]oldAndNewItem[*reserve.ChunkBinItemV1, *reserve.ChunkBinItem])
+	stampIndexItems := make(map[string]oldAndNewItem[*stampindex.ItemV1, *stampindex.Item])
+
+	progress := migration.NewProgress(nil)
+
+	// simulate a run interrupted after the first half of the items is migrated.
+	errInterrupted := errors.New("interrupted")
+	err = migration.MigrateWithProgress(lstore, "migration", migration.Steps{
+		1: func() error {
+			putStep06Items(t, store, chunks[:5], 0, batchRadiusItems, chunkBinItems, stampIndexItems)
+			if err := localmigration.Step_06(store, log.Noop, progress)(); err != nil {
+				return err
+			}
+			putStep06Items(t, store, chunks[5:], 5, batchRadiusItems, chunkBinItems, stampIndexItems)
+			return errInterrupted
+		},
+	}, progress)
+	require.ErrorIs(t, err, errInterrupted)
+
+	err = migration.MigrateWithProgress(lstore, "migration", migration.Steps{
+		1: localmigration.Step_06(store, log.Noop, progress),
+	}, progress)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(len(chunks)), progress.Done())
+
+	checkBatchRadiusItems(t, store.IndexStore(), len(chunks), batchRadiusItems)
+	checkChunkBinItems(t, store.IndexStore(), len(chunks), chunkBinItems)
+	checkStampIndex(t, store.IndexStore(), len(chunks), stampIndexItems)
+}
+
+// putStep06Items stores the reserve items of the chunks in the
+// format before the step 06, the bins start at the given offset.
+func putStep06Items(
+	t *testing.T,
+	store transaction.Storage,
+	chunks []swarm.Chunk,
+	offset int,
+	batchRadiusItems map[string]oldAndNewItem[*reserve.BatchRadiusItemV1, *reserve.BatchRadiusItem],
+	chunkBinItems map[string]oldAndNewItem[*reserve.ChunkBinItemV1, *reserve.ChunkBinItem],
+	stampIndexItems map[string]oldAndNewItem[*stampindex.ItemV1, *stampindex.Item],
+) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	for j, ch := range chunks {
+		i := offset + j
+		err := store.Run(ctx, func(s transaction.Store) error {
 			b := &reserve.BatchRadiusItemV1{
 				Bin:     uint8(i),
 				BatchID: ch.Stamp().BatchID(),
@@ -98,19 +179,6 @@ func Test_Step_06(t *testing.T) {
 		require.NoError(t, err)
 	}
 
-	require.NoError(t, err)
-	err = localmigration.Step_06(store, log.Noop)()
-	require.NoError(t, err)
-
-	has, err := store.IndexStore().Has(&reserve.EpochItem{})
-	if has {
-		t.Fatal("epoch item should be deleted")
-	}
-	require.NoError(t, err)
-
-	checkBatchRadiusItems(t, store.IndexStore(), len(chunks), batchRadiusItems)
-	checkChunkBinItems(t, store.IndexStore(), len(chunks), chunkBinItems)
-	checkStampIndex(t, store.IndexStore(), len(chunks), stampIndexItems)
 }
 
 func checkBatchRadiusItems(t *testing.T, s storage.Reader, wantCount int, m map[string]oldAndNewItem[*reserve.BatchRadiusItemV1, *reserve.BatchRadiusItem]) {
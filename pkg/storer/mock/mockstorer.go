@@ -6,6 +6,7 @@ package mockstorer
 
 import (
 	"context"
+	"io"
 	"sync"
 	"time"
 
@@ -28,6 +29,8 @@ type mockStorer struct {
 	activeSessions map[uint64]*storer.SessionInfo
 	chunkPushC     chan *pusher.Op
 	debugInfo      storer.Info
+	usage          storer.Usage
+	doneErr        error
 }
 
 type putterSession struct {
@@ -72,10 +75,28 @@ func NewWithDebugInfo(info storer.Info) *mockStorer {
 	return st
 }
 
+func NewWithUsage(usage storer.Usage) *mockStorer {
+	st := New()
+	st.usage = usage
+	return st
+}
+
+// NewWithDoneErr returns a mock storer whose upload sessions fail to complete
+// with the given error.
+func NewWithDoneErr(err error) *mockStorer {
+	st := New()
+	st.doneErr = err
+	return st
+}
+
 func (m *mockStorer) Upload(_ context.Context, pin bool, tagID uint64) (storer.PutterSession, error) {
 	return &putterSession{
 		chunkStore: m.chunkStore,
 		done: func(address swarm.Address) error {
+			if m.doneErr != nil {
+				return m.doneErr
+			}
+
 			m.mu.Lock()
 			defer m.mu.Unlock()
 
@@ -228,6 +249,14 @@ func (m *mockStorer) DebugInfo(_ context.Context) (storer.Info, error) {
 	return m.debugInfo, nil
 }
 
+func (m *mockStorer) Usage(_ context.Context) (storer.Usage, error) {
+	return m.usage, nil
+}
+
+func (m *mockStorer) Backup(_ context.Context, _ io.Writer, since uint64) (storer.BackupInfo, error) {
+	return storer.BackupInfo{Since: since, Timestamp: uint64(now().Unix())}, nil
+}
+
 func (m *mockStorer) NeighborhoodsStat(ctx context.Context) ([]*storer.NeighborhoodStat, error) {
 	return nil, nil
 }
@@ -15,7 +15,6 @@ import (
 	"github.com/ethersphere/bee/v2/pkg/sharky"
 	storage "github.com/ethersphere/bee/v2/pkg/storage"
 	"github.com/ethersphere/bee/v2/pkg/storer/internal/chunkstore"
-	"github.com/ethersphere/bee/v2/pkg/swarm"
 )
 
 const (
@@ -40,7 +39,7 @@ func sharkyRecovery(ctx context.Context, sharkyBasePath string, store storage.St
 		logger.Info("localstore sharky recovery finished", "time", time.Since(t))
 	}(time.Now())
 
-	sharkyRecover, err := sharky.NewRecovery(sharkyBasePath, sharkyNoOfShards, swarm.SocMaxChunkSize)
+	sharkyRecover, err := newSharkyRecovery(sharkyBasePath, opts)
 	if err != nil {
 		return closer, err
 	}
@@ -0,0 +1,201 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package storer
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/cac"
+	"github.com/ethersphere/bee/v2/pkg/sharky"
+	"github.com/ethersphere/bee/v2/pkg/soc"
+	"github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/storer/internal/chunkstore"
+	"github.com/ethersphere/bee/v2/pkg/storer/internal/transaction"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+const (
+	// scrubBatchSize is the number of retrieval index entries
+	// collected at once, so that no index iterator is held
+	// open for the whole duration of a rate limited pass.
+	scrubBatchSize = 1000
+	// scrubRepairTimeout limits the time spent on fetching
+	// a single corrupted chunk from the network.
+	scrubRepairTimeout = time.Minute
+	// maxScrubRate is the highest scrubbing rate, at which
+	// the period of the scrubber ticker is a nanosecond.
+	maxScrubRate = int(time.Second)
+)
+
+type scrubOpts struct {
+	rate     int
+	interval time.Duration
+}
+
+// scrubStats is the summary of a single scrubbing pass.
+type scrubStats struct {
+	scanned   int
+	corrupted int
+	repaired  int
+}
+
+// StartScrubber starts the background worker which continuously reads the
+// chunk data from sharky at the configured rate, verifies the content
+// addressed and single owner chunk validity and repairs the corrupted chunks
+// by re-fetching them from the network. It is a no-op when the scrubbing rate
+// is not configured. The retrieval service should be set beforehand.
+func (db *DB) StartScrubber(ctx context.Context) {
+	if db.scrubOptions.rate <= 0 {
+		return
+	}
+	db.inFlight.Add(1)
+	go db.scrubWorker(ctx)
+}
+
+func (db *DB) scrubWorker(ctx context.Context) {
+	defer db.inFlight.Done()
+
+	ticker := time.NewTicker(time.Second / time.Duration(db.scrubOptions.rate))
+	defer ticker.Stop()
+
+	wait := func() error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-db.quit:
+			return ErrDBQuit
+		case <-ticker.C:
+			return nil
+		}
+	}
+
+	for {
+		start := time.Now()
+		stats, err := db.scrubPass(ctx, wait)
+		if err != nil {
+			if !errors.Is(err, context.Canceled) && !errors.Is(err, ErrDBQuit) {
+				db.logger.Error(err, "scrub pass failed")
+			}
+			return
+		}
+		db.logger.Info("scrub pass finished",
+			"duration", time.Since(start),
+			"scanned", stats.scanned,
+			"corrupted", stats.corrupted,
+			"repaired", stats.repaired,
+		)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-db.quit:
+			return
+		case <-time.After(db.scrubOptions.interval):
+		}
+	}
+}
+
+// scrubPass verifies every chunk in the chunkstore once. The wait function
+// is called before each chunk is read in order to limit the rate of the pass.
+func (db *DB) scrubPass(ctx context.Context, wait func() error) (scrubStats, error) {
+	var (
+		stats  scrubStats
+		cursor swarm.Address
+	)
+
+	for {
+		items := make([]*chunkstore.RetrievalIndexItem, 0, scrubBatchSize)
+		err := db.storage.IndexStore().Iterate(storage.Query{
+			Factory:       func() storage.Item { return new(chunkstore.RetrievalIndexItem) },
+			Prefix:        cursor.ByteString(),
+			PrefixAtStart: true,
+		}, func(r storage.Result) (bool, error) {
+			item := r.Entry.(*chunkstore.RetrievalIndexItem)
+			if item.Address.Equal(cursor) {
+				return false, nil
+			}
+			items = append(items, item)
+			return len(items) == scrubBatchSize, nil
+		})
+		if err != nil {
+			return stats, err
+		}
+		if len(items) == 0 {
+			return stats, nil
+		}
+		cursor = items[len(items)-1].Address
+
+		for _, item := range items {
+			if err := wait(); err != nil {
+				return stats, err
+			}
+			corrupted, repaired := db.scrubChunk(ctx, item.Address)
+			stats.scanned++
+			if corrupted {
+				stats.corrupted++
+			}
+			if repaired {
+				stats.repaired++
+			}
+		}
+	}
+}
+
+// scrubChunk verifies the stored data of the chunk with the given address
+// and tries to repair it from the network in case it is corrupted.
+func (db *DB) scrubChunk(ctx context.Context, addr swarm.Address) (corrupted, repaired bool) {
+	db.metrics.ScrubbedChunkCount.Inc()
+
+	ch, err := db.storage.ChunkStore().Get(ctx, addr)
+	switch {
+	case errors.Is(err, storage.ErrNotFound):
+		// The chunk was removed in the meantime.
+		return false, false
+	case errors.Is(err, context.Canceled),
+		errors.Is(err, context.DeadlineExceeded),
+		errors.Is(err, leveldb.ErrClosed),
+		errors.Is(err, sharky.ErrQuitting):
+		// The pass or the store is shutting down, the chunk was not verified.
+		return false, false
+	case err != nil:
+		db.logger.Warning("scrub: unreadable chunk", "address", addr, "error", err)
+	case cac.Valid(ch) || soc.Valid(ch):
+		return false, false
+	default:
+		db.logger.Warning("scrub: invalid cac/soc chunk", "address", addr)
+	}
+	db.metrics.CorruptedChunkCount.Inc()
+
+	if err := db.repairChunk(ctx, addr); err != nil {
+		db.metrics.ScrubRepairs.WithLabelValues("failure").Inc()
+		db.logger.Error(err, "scrub: failed to repair corrupted chunk", "address", addr)
+		return true, false
+	}
+	db.metrics.ScrubRepairs.WithLabelValues("success").Inc()
+	db.logger.Info("scrub: repaired corrupted chunk", "address", addr)
+	return true, true
+}
+
+// repairChunk fetches the chunk from the network and replaces the corrupted
+// data in the chunkstore while keeping the rest of the indexes intact.
+func (db *DB) repairChunk(ctx context.Context, addr swarm.Address) error {
+	ctx, cancel := context.WithTimeout(ctx, scrubRepairTimeout)
+	defer cancel()
+
+	ch, err := db.retrieval.RetrieveChunk(ctx, addr, swarm.ZeroAddress)
+	if err != nil {
+		return err
+	}
+	if !ch.Address().Equal(addr) || !(cac.Valid(ch) || soc.Valid(ch)) {
+		return errors.New("retrieved chunk is invalid")
+	}
+
+	return db.storage.Run(ctx, func(s transaction.Store) error {
+		return s.ChunkStore().Replace(ctx, ch, false)
+	})
+}
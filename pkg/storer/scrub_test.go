@@ -0,0 +1,154 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package storer_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/storage"
+	chunk "github.com/ethersphere/bee/v2/pkg/storage/testing"
+	"github.com/ethersphere/bee/v2/pkg/storer"
+	"github.com/ethersphere/bee/v2/pkg/storer/internal/transaction"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+func TestScrub(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	db, err := diskStorer(t, dbTestOps(swarm.RandAddress(t), 0, nil, nil, time.Minute))()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cached := chunk.GenerateTestRandomChunks(5)
+	for _, ch := range cached {
+		if err := db.Cache().Put(ctx, ch); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	pinned := chunk.GenerateTestRandomChunks(5)
+	session, err := db.NewCollection(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, ch := range pinned {
+		if err := session.Put(ctx, ch); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := session.Done(pinned[0].Address()); err != nil {
+		t.Fatal(err)
+	}
+
+	// corrupt overwrites the stored data of the chunk in place.
+	corrupt := func(ch swarm.Chunk) {
+		t.Helper()
+
+		data := bytes.Repeat([]byte{0xff}, len(ch.Data()))
+		err := db.Storage().Run(ctx, func(s transaction.Store) error {
+			return s.ChunkStore().Replace(ctx, swarm.NewChunk(ch.Address(), data), false)
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	corrupt(cached[0])
+	corrupt(pinned[1])
+	unrepairable := cached[2]
+	corrupt(unrepairable)
+
+	network := map[string]swarm.Chunk{
+		cached[0].Address().ByteString(): cached[0],
+		pinned[1].Address().ByteString(): pinned[1],
+	}
+	db.SetRetrievalService(&testRetrieval{fn: func(addr swarm.Address) (swarm.Chunk, error) {
+		if ch, ok := network[addr.ByteString()]; ok {
+			return ch, nil
+		}
+		return nil, storage.ErrNotFound
+	}})
+
+	scanned, corrupted, repaired, err := db.Scrub(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if scanned != 10 || corrupted != 3 || repaired != 2 {
+		t.Fatalf("want 10 scanned, 3 corrupted and 2 repaired, got %d, %d and %d", scanned, corrupted, repaired)
+	}
+
+	for _, ch := range append(cached, pinned...) {
+		got, err := db.ChunkStore().Get(ctx, ch.Address())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := !ch.Equal(unrepairable); bytes.Equal(got.Data(), ch.Data()) != want {
+			t.Fatalf("chunk %s: want data repaired %t", ch.Address(), want)
+		}
+	}
+
+	has, err := db.HasPin(pinned[0].Address())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !has {
+		t.Fatal("pin lost after repair")
+	}
+
+	_, corrupted, _, err = db.Scrub(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if corrupted != 1 {
+		t.Fatalf("want 1 corrupted chunk on the second pass, got %d", corrupted)
+	}
+
+	// the chunks that could not be read because of the cancelled
+	// pass are not counted as corrupted, only the unrepairable
+	// chunk may still be found before the store notices the
+	// cancellation.
+	cctx, cancel := context.WithCancel(ctx)
+	cancel()
+	_, corrupted, _, err = db.Scrub(cctx)
+	if err != nil && !errors.Is(err, context.Canceled) {
+		t.Fatal(err)
+	}
+	if corrupted > 1 {
+		t.Fatalf("want at most 1 corrupted chunk on a cancelled pass, got %d", corrupted)
+	}
+}
+
+func TestScrubOptions(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name     string
+		rate     int
+		interval time.Duration
+	}{
+		{"rate above the maximum", int(time.Second) + 1, time.Hour},
+		{"zero interval", 10, 0},
+		{"negative interval", 10, -time.Hour},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			opts := dbTestOps(swarm.RandAddress(t), 0, nil, nil, time.Minute)
+			opts.ScrubRate = tc.rate
+			opts.ScrubInterval = tc.interval
+
+			if _, err := storer.New(context.Background(), "", opts); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
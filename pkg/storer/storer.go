@@ -22,6 +22,7 @@ import (
 	"github.com/ethersphere/bee/v2/pkg/stabilization"
 	"github.com/ethersphere/bee/v2/pkg/storer/internal/transaction"
 
+	"github.com/ethersphere/bee/v2/pkg/crypto"
 	m "github.com/ethersphere/bee/v2/pkg/metrics"
 	"github.com/ethersphere/bee/v2/pkg/postage"
 	"github.com/ethersphere/bee/v2/pkg/pusher"
@@ -256,20 +257,28 @@ const (
 func initStore(basePath string, opts *Options) (*leveldbstore.Store, error) {
 	ldbBasePath := path.Join(basePath, indexPath)
 
+	if err := checkEncryption(basePath, opts); err != nil {
+		return nil, err
+	}
+	sealer, err := opts.sealer()
+	if err != nil {
+		return nil, err
+	}
+
 	if _, err := os.Stat(ldbBasePath); os.IsNotExist(err) {
 		err := os.MkdirAll(ldbBasePath, 0o777)
 		if err != nil {
 			return nil, err
 		}
 	}
-	store, err := leveldbstore.New(path.Join(basePath, "indexstore"), &opt.Options{
+	store, err := leveldbstore.NewEncrypted(path.Join(basePath, "indexstore"), &opt.Options{
 		OpenFilesCacheCapacity: int(opts.LdbOpenFilesLimit),
 		BlockCacheCapacity:     int(opts.LdbBlockCacheCapacity),
 		WriteBuffer:            int(opts.LdbWriteBufferSize),
 		DisableSeeksCompaction: opts.LdbDisableSeeksCompaction,
 		CompactionL0Trigger:    8,
 		Filter:                 filter.NewBloomFilter(64),
-	})
+	}, sealer)
 	if err != nil {
 		return nil, fmt.Errorf("failed creating levelDB index store: %w", err)
 	}
@@ -281,13 +290,14 @@ func initDiskRepository(
 	ctx context.Context,
 	basePath string,
 	opts *Options,
+	progress *migration.Progress,
 ) (transaction.Storage, *PinIntegrity, io.Closer, error) {
 	store, err := initStore(basePath, opts)
 	if err != nil {
 		return nil, nil, nil, fmt.Errorf("failed creating levelDB index store: %w", err)
 	}
 
-	err = migration.Migrate(store, "core-migration", localmigration.BeforeInitSteps(store, opts.Logger))
+	err = migration.MigrateWithProgress(store, coreMigrationGroup, localmigration.BeforeInitSteps(store, opts.Logger, progress), progress)
 	if err != nil {
 		return nil, nil, nil, errors.Join(store.Close(), fmt.Errorf("failed core migration: %w", err))
 	}
@@ -350,11 +360,7 @@ func initDiskRepository(
 		return nil, nil, nil, fmt.Errorf("failed to recover sharky: %w", err)
 	}
 
-	sharky, err := sharky.New(
-		&dirFS{basedir: sharkyBasePath},
-		sharkyNoOfShards,
-		swarm.SocMaxChunkSize,
-	)
+	sharky, err := newSharky(sharkyBasePath, opts)
 	if err != nil {
 		return nil, nil, nil, fmt.Errorf("failed creating sharky instance: %w", err)
 	}
@@ -380,6 +386,10 @@ type Options struct {
 	Logger                    log.Logger
 	Tracer                    *tracing.Tracer
 
+	// EncryptionKey is the 32 bytes long key with which the chunk data and
+	// the index values are encrypted at rest. Encryption is disabled if empty.
+	EncryptionKey []byte
+
 	Address           swarm.Address
 	StartupStabilizer stabilization.Subscriber
 	Batchstore        postage.Storer
@@ -396,6 +406,12 @@ type Options struct {
 	CacheMinEvictCount uint64
 
 	MinimumStorageRadius uint
+
+	// ScrubRate is the number of chunks per second verified by the
+	// background scrubber, zero disables the scrubbing.
+	ScrubRate int
+	// ScrubInterval is the pause between two scrubbing passes.
+	ScrubInterval time.Duration
 }
 
 func defaultOptions() *Options {
@@ -408,6 +424,7 @@ func defaultOptions() *Options {
 		Logger:                    log.Noop,
 		ReserveCapacity:           DefaultReserveCapacity,
 		ReserveWakeUpDuration:     time.Minute * 30,
+		ScrubInterval:             time.Hour * 24,
 	}
 }
 
@@ -447,6 +464,8 @@ type DB struct {
 	setSyncerOnce    sync.Once
 	syncer           Syncer
 	reserveOptions   reserveOpts
+	scrubOptions     scrubOpts
+	sealer           *crypto.Sealer // nil if the encryption at rest is not enabled
 
 	pinIntegrity *PinIntegrity
 }
@@ -477,9 +496,17 @@ func New(ctx context.Context, dirPath string, opts *Options) (*DB, error) {
 		opts.Logger = log.Noop
 	}
 
+	if opts.ScrubRate > maxScrubRate {
+		return nil, fmt.Errorf("scrub rate %d is above the maximum of %d chunks per second", opts.ScrubRate, maxScrubRate)
+	}
+	if opts.ScrubRate > 0 && opts.ScrubInterval <= 0 {
+		return nil, fmt.Errorf("scrub interval %v is not positive", opts.ScrubInterval)
+	}
+
 	lock := multex.New()
 	metrics := newMetrics()
 	opts.LdbStats.CompareAndSwap(nil, metrics.LevelDBStats)
+	progress := metrics.migrationProgress(opts.Logger.WithName(loggerName).Register())
 
 	if dirPath == "" {
 		st, dbCloser, err = initInmemRepository()
@@ -487,7 +514,7 @@ func New(ctx context.Context, dirPath string, opts *Options) (*DB, error) {
 			return nil, err
 		}
 	} else {
-		st, pinIntegrity, dbCloser, err = initDiskRepository(ctx, dirPath, opts)
+		st, pinIntegrity, dbCloser, err = initDiskRepository(ctx, dirPath, opts, progress)
 		if err != nil {
 			return nil, err
 		}
@@ -503,14 +530,17 @@ func New(ctx context.Context, dirPath string, opts *Options) (*DB, error) {
 	if dirPath != "" {
 		sharkyBasePath = path.Join(dirPath, sharkyPath)
 	}
+	sealer, err := opts.sealer()
+	if err != nil {
+		return nil, err
+	}
 
-	err = st.Run(ctx, func(s transaction.Store) error {
-		return migration.Migrate(
-			s.IndexStore(),
-			"migration",
-			localmigration.AfterInitSteps(sharkyBasePath, sharkyNoOfShards, st, opts.Logger),
-		)
-	})
+	err = migration.MigrateWithProgress(
+		autoCommitIndexStore{Reader: st.IndexStore(), st: st},
+		migrationGroup,
+		localmigration.AfterInitSteps(sharkyBasePath, sharkyNoOfShards, sealer, st, opts.Logger, progress),
+		progress,
+	)
 	if err != nil {
 		return nil, fmt.Errorf("failed regular migration: %w", err)
 	}
@@ -542,6 +572,7 @@ func New(ctx context.Context, dirPath string, opts *Options) (*DB, error) {
 		dbCloser:         dbCloser,
 		batchstore:       opts.Batchstore,
 		validStamp:       opts.ValidStamp,
+		sealer:           sealer,
 		events:           events.NewSubscriber(),
 		reserveBinEvents: events.NewSubscriber(),
 		reserveOptions: reserveOpts{
@@ -552,6 +583,10 @@ func New(ctx context.Context, dirPath string, opts *Options) (*DB, error) {
 			minimumRadius:      uint8(opts.MinimumStorageRadius),
 			capacityDoubling:   opts.ReserveCapacityDoubling,
 		},
+		scrubOptions: scrubOpts{
+			rate:     opts.ScrubRate,
+			interval: opts.ScrubInterval,
+		},
 		directUploadLimiter: make(chan struct{}, pusher.ConcurrentPushes),
 		pinIntegrity:        pinIntegrity,
 	}
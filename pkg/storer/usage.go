@@ -0,0 +1,281 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package storer
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"sort"
+
+	"github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/storer/internal/cache"
+	"github.com/ethersphere/bee/v2/pkg/storer/internal/chunkstamp"
+	"github.com/ethersphere/bee/v2/pkg/storer/internal/chunkstore"
+	pinstore "github.com/ethersphere/bee/v2/pkg/storer/internal/pinning"
+	"github.com/ethersphere/bee/v2/pkg/storer/internal/reserve"
+	"github.com/ethersphere/bee/v2/pkg/storer/internal/stampindex"
+	"github.com/ethersphere/bee/v2/pkg/storer/internal/transaction"
+	"github.com/ethersphere/bee/v2/pkg/storer/internal/upload"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+// UsageStat reports the number of chunks and
+// the bytes of chunk data they occupy in sharky.
+type UsageStat struct {
+	Chunks uint64 `json:"chunks"`
+	Bytes  uint64 `json:"bytes"`
+}
+
+func (u *UsageStat) add(size uint64) {
+	u.Chunks++
+	u.Bytes += size
+}
+
+// IndexStat reports the number of index
+// entries and the bytes they occupy.
+type IndexStat struct {
+	Entries uint64 `json:"entries"`
+	Bytes   uint64 `json:"bytes"`
+}
+
+// BatchUsage is the storage usage attributed to a single postage batch.
+type BatchUsage struct {
+	BatchID    string    `json:"batchID"`
+	Reserve    UsageStat `json:"reserve"`
+	Upload     UsageStat `json:"upload"`
+	StampIndex IndexStat `json:"stampIndex"`
+}
+
+// PinUsage is the storage usage of a single pinning collection.
+type PinUsage struct {
+	Root  swarm.Address `json:"root"`
+	Total UsageStat     `json:"total"`
+	// Shared are the chunks of the collection which
+	// are pinned by other collections as well.
+	Shared UsageStat `json:"shared"`
+}
+
+// Usage is the breakdown of the storage usage of the localstore.
+// The same chunk might be accounted in more than one of the
+// logical stores, the ChunkStore reports the deduplicated total.
+type Usage struct {
+	ChunkStore UsageStat `json:"chunkStore"`
+	Reserve    UsageStat `json:"reserve"`
+	Cache      UsageStat `json:"cache"`
+	Upload     UsageStat `json:"upload"`
+	Pinning    UsageStat `json:"pinning"`
+	// PinShared are the chunks pinned by more than one collection.
+	PinShared  UsageStat    `json:"pinShared"`
+	StampIndex IndexStat    `json:"stampIndex"`
+	Batches    []BatchUsage `json:"batches"`
+	Pins       []PinUsage   `json:"pins"`
+}
+
+// UsageAnalyzer is the interface for analyzing the storage usage.
+type UsageAnalyzer interface {
+	Usage(context.Context) (Usage, error)
+}
+
+var _ UsageAnalyzer = (*DB)(nil)
+
+// Usage analyzes the storage usage of the localstore. The analysis is done on
+// a point-in-time snapshot of the store when it is supported. The chunks of
+// all the pinning collections are held in memory in order to find the chunks
+// shared between the collections.
+func (db *DB) Usage(ctx context.Context) (Usage, error) {
+	var st storage.Reader = db.storage.IndexStore()
+	if snapshotter, ok := db.storage.(transaction.Snapshotter); ok {
+		snap, err := snapshotter.Snapshot()
+		if err != nil {
+			return Usage{}, err
+		}
+		defer snap.Release()
+		st = snap.IndexStore()
+	}
+
+	var (
+		usage   Usage
+		batches = make(map[string]*BatchUsage)
+	)
+
+	batch := func(batchID []byte) *BatchUsage {
+		b, ok := batches[string(batchID)]
+		if !ok {
+			b = &BatchUsage{BatchID: hex.EncodeToString(batchID)}
+			batches[string(batchID)] = b
+		}
+		return b
+	}
+
+	// sizeOf returns the size of the chunk data in sharky.
+	sizeOf := func(addr swarm.Address) (uint64, error) {
+		item := &chunkstore.RetrievalIndexItem{Address: addr}
+		switch err := st.Get(item); {
+		case errors.Is(err, storage.ErrNotFound):
+			return 0, nil
+		case err != nil:
+			return 0, err
+		}
+		return uint64(item.Location.Length), nil
+	}
+
+	checkDone := func() error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-db.quit:
+			return ErrDBQuit
+		default:
+			return nil
+		}
+	}
+
+	err := st.Iterate(storage.Query{
+		Factory: func() storage.Item { return new(chunkstore.RetrievalIndexItem) },
+	}, func(r storage.Result) (bool, error) {
+		if err := checkDone(); err != nil {
+			return true, err
+		}
+		usage.ChunkStore.add(uint64(r.Entry.(*chunkstore.RetrievalIndexItem).Location.Length))
+		return false, nil
+	})
+	if err != nil {
+		return Usage{}, err
+	}
+
+	err = st.Iterate(storage.Query{
+		Factory: func() storage.Item { return new(reserve.ChunkBinItem) },
+	}, func(r storage.Result) (bool, error) {
+		if err := checkDone(); err != nil {
+			return true, err
+		}
+		item := r.Entry.(*reserve.ChunkBinItem)
+		size, err := sizeOf(item.Address)
+		if err != nil {
+			return true, err
+		}
+		usage.Reserve.add(size)
+		batch(item.BatchID).Reserve.add(size)
+		return false, nil
+	})
+	if err != nil {
+		return Usage{}, err
+	}
+
+	err = st.Iterate(storage.Query{
+		Factory: func() storage.Item { return new(cache.CacheEntryItem) },
+	}, func(r storage.Result) (bool, error) {
+		if err := checkDone(); err != nil {
+			return true, err
+		}
+		size, err := sizeOf(r.Entry.(*cache.CacheEntryItem).Address)
+		if err != nil {
+			return true, err
+		}
+		usage.Cache.add(size)
+		return false, nil
+	})
+	if err != nil {
+		return Usage{}, err
+	}
+
+	err = upload.IterateAll(st, func(item storage.Item) (bool, error) {
+		if err := checkDone(); err != nil {
+			return true, err
+		}
+		// The ID is in the form of address/batchID.
+		id := item.ID()
+		if len(id) != 2*swarm.HashSize+1 {
+			return false, nil
+		}
+		size, err := sizeOf(swarm.NewAddress([]byte(id[:swarm.HashSize])))
+		if err != nil {
+			return true, err
+		}
+		usage.Upload.add(size)
+		batch([]byte(id[swarm.HashSize+1:])).Upload.add(size)
+		return false, nil
+	})
+	if err != nil {
+		return Usage{}, err
+	}
+
+	stampIndexFn := func(batchID []byte, size int) (bool, error) {
+		if err := checkDone(); err != nil {
+			return true, err
+		}
+		usage.StampIndex.Entries++
+		usage.StampIndex.Bytes += uint64(size)
+		b := batch(batchID)
+		b.StampIndex.Entries++
+		b.StampIndex.Bytes += uint64(size)
+		return false, nil
+	}
+	if err := stampindex.IterateAll(st, stampIndexFn); err != nil {
+		return Usage{}, err
+	}
+	if err := chunkstamp.IterateAll(st, stampIndexFn); err != nil {
+		return Usage{}, err
+	}
+
+	pins, err := pinstore.Pins(st)
+	if err != nil {
+		return Usage{}, err
+	}
+
+	pinned := make(map[string]int)
+	for _, root := range pins {
+		err := pinstore.IterateCollection(st, root, func(addr swarm.Address) (bool, error) {
+			if err := checkDone(); err != nil {
+				return true, err
+			}
+			pinned[addr.ByteString()]++
+			return false, nil
+		})
+		if err != nil {
+			return Usage{}, err
+		}
+	}
+
+	for _, root := range pins {
+		pin := PinUsage{Root: root}
+		err := pinstore.IterateCollection(st, root, func(addr swarm.Address) (bool, error) {
+			size, err := sizeOf(addr)
+			if err != nil {
+				return true, err
+			}
+			pin.Total.add(size)
+			if pinned[addr.ByteString()] > 1 {
+				pin.Shared.add(size)
+			}
+			return false, nil
+		})
+		if err != nil {
+			return Usage{}, err
+		}
+		usage.Pins = append(usage.Pins, pin)
+	}
+
+	for addr, n := range pinned {
+		size, err := sizeOf(swarm.NewAddress([]byte(addr)))
+		if err != nil {
+			return Usage{}, err
+		}
+		usage.Pinning.add(size)
+		if n > 1 {
+			usage.PinShared.add(size)
+		}
+	}
+
+	for _, b := range batches {
+		usage.Batches = append(usage.Batches, *b)
+	}
+	sort.Slice(usage.Batches, func(i, j int) bool {
+		return usage.Batches[i].BatchID < usage.Batches[j].BatchID
+	})
+
+	return usage, nil
+}
@@ -0,0 +1,132 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package storer_test
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	postagetesting "github.com/ethersphere/bee/v2/pkg/postage/testing"
+	chunk "github.com/ethersphere/bee/v2/pkg/storage/testing"
+	storer "github.com/ethersphere/bee/v2/pkg/storer"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+func TestUsage(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	baseAddr := swarm.RandAddress(t)
+
+	st, err := diskStorer(t, dbTestOps(baseAddr, 100, nil, nil, time.Minute))()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	size := func(chunks ...swarm.Chunk) (n uint64) {
+		for _, ch := range chunks {
+			n += uint64(len(ch.Data()))
+		}
+		return n
+	}
+
+	reserveBatch := postagetesting.MustNewBatch()
+	reserveChunks := make([]swarm.Chunk, 5)
+	for i := range reserveChunks {
+		reserveChunks[i] = chunk.GenerateTestRandomChunkAt(t, baseAddr, 0).WithStamp(postagetesting.MustNewBatchStamp(reserveBatch.ID))
+		if err := st.ReservePutter().Put(ctx, reserveChunks[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cacheChunks := chunk.GenerateTestRandomChunks(3)
+	for _, ch := range cacheChunks {
+		if err := st.Cache().Put(ctx, ch); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	shared := chunk.GenerateTestRandomChunks(2)
+	pin1 := append(chunk.GenerateTestRandomChunks(3), shared...)
+	pin2 := append(chunk.GenerateTestRandomChunks(4), shared...)
+	for _, chunks := range [][]swarm.Chunk{pin1, pin2} {
+		session, err := st.NewCollection(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, ch := range chunks {
+			if err := session.Put(ctx, ch); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := session.Done(chunks[0].Address()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	uploadBatch := postagetesting.MustNewBatch()
+	uploadChunks := make([]swarm.Chunk, 4)
+	tag, err := st.NewSession()
+	if err != nil {
+		t.Fatal(err)
+	}
+	session, err := st.Upload(ctx, false, tag.TagID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range uploadChunks {
+		uploadChunks[i] = chunk.GenerateTestRandomChunk().WithStamp(postagetesting.MustNewBatchStamp(uploadBatch.ID))
+		if err := session.Put(ctx, uploadChunks[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := session.Done(uploadChunks[0].Address()); err != nil {
+		t.Fatal(err)
+	}
+
+	usage, err := st.Usage(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name string
+		have storer.UsageStat
+		want storer.UsageStat
+	}{
+		{"reserve", usage.Reserve, storer.UsageStat{Chunks: 5, Bytes: size(reserveChunks...)}},
+		{"cache", usage.Cache, storer.UsageStat{Chunks: 3, Bytes: size(cacheChunks...)}},
+		{"upload", usage.Upload, storer.UsageStat{Chunks: 4, Bytes: size(uploadChunks...)}},
+		{"pinning", usage.Pinning, storer.UsageStat{Chunks: 9, Bytes: size(pin1...) + size(pin2...) - size(shared...)}},
+		{"pin shared", usage.PinShared, storer.UsageStat{Chunks: 2, Bytes: size(shared...)}},
+		{"chunkstore", usage.ChunkStore, storer.UsageStat{Chunks: 21, Bytes: size(reserveChunks...) + size(cacheChunks...) + size(uploadChunks...) + size(pin1...) + size(pin2...) - size(shared...)}},
+	} {
+		if tc.have != tc.want {
+			t.Errorf("%s: want %+v, have %+v", tc.name, tc.want, tc.have)
+		}
+	}
+
+	if len(usage.Pins) != 2 {
+		t.Fatalf("want 2 pins, have %d", len(usage.Pins))
+	}
+	for _, pin := range usage.Pins {
+		if pin.Shared != (storer.UsageStat{Chunks: 2, Bytes: size(shared...)}) {
+			t.Errorf("pin %s: unexpected shared usage %+v", pin.Root, pin.Shared)
+		}
+	}
+
+	batches := make(map[string]storer.BatchUsage)
+	for _, b := range usage.Batches {
+		batches[b.BatchID] = b
+	}
+	if have := batches[hex.EncodeToString(reserveBatch.ID)]; have.Reserve.Chunks != 5 || have.StampIndex.Entries == 0 {
+		t.Errorf("unexpected reserve batch usage %+v", have)
+	}
+	if have := batches[hex.EncodeToString(uploadBatch.ID)]; have.Upload.Chunks != 4 || have.StampIndex.Entries != 4 {
+		t.Errorf("unexpected upload batch usage %+v", have)
+	}
+}
@@ -39,8 +39,7 @@ func ValidateReserve(ctx context.Context, basePath string, opts *Options) error
 		}
 	}()
 
-	sharky, err := sharky.New(&dirFS{basedir: path.Join(basePath, sharkyPath)},
-		sharkyNoOfShards, swarm.SocMaxChunkSize)
+	sharky, err := newSharky(path.Join(basePath, sharkyPath), opts)
 	if err != nil {
 		return err
 	}
@@ -72,8 +71,7 @@ func ValidateRetrievalIndex(ctx context.Context, basePath string, opts *Options)
 		}
 	}()
 
-	sharky, err := sharky.New(&dirFS{basedir: path.Join(basePath, sharkyPath)},
-		sharkyNoOfShards, swarm.SocMaxChunkSize)
+	sharky, err := newSharky(path.Join(basePath, sharkyPath), opts)
 	if err != nil {
 		return err
 	}
@@ -194,8 +192,7 @@ func ValidatePinCollectionChunks(ctx context.Context, basePath, pin, location st
 		}
 	}()
 
-	fs := &dirFS{basedir: path.Join(basePath, sharkyPath)}
-	sharky, err := sharky.New(fs, sharkyNoOfShards, swarm.SocMaxChunkSize)
+	sharky, err := newSharky(path.Join(basePath, sharkyPath), opts)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,232 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kademlia
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"github.com/ethersphere/bee/v2/pkg/topology"
+	im "github.com/ethersphere/bee/v2/pkg/topology/kademlia/internal/metrics"
+)
+
+const (
+	defaultHistorySampleInterval = 10 * time.Second
+	defaultHistorySamples        = 360 // an hour of samples at the default interval
+	defaultHistoryEvents         = 1000
+
+	// disconnectReasonRemote is the reason of the disconnection
+	// which has not been initiated by the node.
+	disconnectReasonRemote = "closed by peer"
+	// disconnectReasonTTL is the time after which the reason is forgotten,
+	// as the disconnection of a peer which is not connected is not reported.
+	disconnectReasonTTL = time.Minute
+)
+
+// ring is the fixed size buffer which overwrites the oldest items.
+type ring[T any] struct {
+	items []T
+	next  int
+	full  bool
+}
+
+func newRing[T any](size int) *ring[T] {
+	return &ring[T]{items: make([]T, max(size, 1))}
+}
+
+func (r *ring[T]) add(v T) {
+	r.items[r.next] = v
+	r.next = (r.next + 1) % len(r.items)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+// each calls f for the items from the oldest to the newest.
+func (r *ring[T]) each(f func(T)) {
+	if r.full {
+		for _, v := range r.items[r.next:] {
+			f(v)
+		}
+	}
+	for _, v := range r.items[:r.next] {
+		f(v)
+	}
+}
+
+// history keeps the rolling history of the topology samples and events.
+type history struct {
+	mu      sync.Mutex
+	samples *ring[topology.HistorySample]
+	events  *ring[topology.HistoryEvent]
+	reasons map[string]disconnectReason // reasons of the pending disconnections initiated by the node
+}
+
+type disconnectReason struct {
+	reason string
+	set    time.Time
+}
+
+func newHistory(samples, events int) *history {
+	return &history{
+		samples: newRing[topology.HistorySample](samples),
+		events:  newRing[topology.HistoryEvent](events),
+		reasons: make(map[string]disconnectReason),
+	}
+}
+
+func (h *history) addSample(s topology.HistorySample) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.samples.add(s)
+}
+
+func (h *history) addEvent(e topology.HistoryEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.events.add(e)
+}
+
+// setReason sets the reason of the following disconnection of the peer
+// and forgets the reasons of the disconnections which have not followed.
+func (h *history) setReason(peer swarm.Address, reason string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := time.Now()
+	for k, r := range h.reasons {
+		if now.Sub(r.set) > disconnectReasonTTL {
+			delete(h.reasons, k)
+		}
+	}
+	h.reasons[peer.ByteString()] = disconnectReason{reason: reason, set: now}
+}
+
+// takeReason returns and forgets the reason of the disconnection of the peer.
+func (h *history) takeReason(peer swarm.Address) string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	r, ok := h.reasons[peer.ByteString()]
+	delete(h.reasons, peer.ByteString())
+	if !ok || time.Since(r.set) > disconnectReasonTTL {
+		return disconnectReasonRemote
+	}
+	return r.reason
+}
+
+// get returns the samples and events not older than since.
+func (h *history) get(since time.Time) topology.History {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	hs := topology.History{
+		Samples: make([]topology.HistorySample, 0),
+		Events:  make([]topology.HistoryEvent, 0),
+	}
+	h.samples.each(func(s topology.HistorySample) {
+		if !s.Timestamp.Before(since) {
+			hs.Samples = append(hs.Samples, s)
+		}
+	})
+	h.events.each(func(e topology.HistoryEvent) {
+		if !e.Timestamp.Before(since) {
+			hs.Events = append(hs.Events, e)
+		}
+	})
+	return hs
+}
+
+// History returns the samples and events of the topology
+// history which are not older than since.
+func (k *Kad) History(since time.Time) topology.History {
+	return k.history.get(since)
+}
+
+// DisconnectReason implements the p2p.DisconnectReasoner interface.
+func (k *Kad) DisconnectReason(peer swarm.Address, reason string) {
+	k.history.setReason(peer, reason)
+}
+
+// depths returns the kademlia depth and the storage radius.
+func (k *Kad) depths() (depth, radius uint8) {
+	k.depthMu.RLock()
+	defer k.depthMu.RUnlock()
+
+	return k.depth, k.storageRadius
+}
+
+// recordConnected records the connection to the peer,
+// it is called after the depth is recalculated.
+func (k *Kad) recordConnected(peer swarm.Address, direction im.PeerConnectionDirection) {
+	depth, radius := k.depths()
+	k.history.addEvent(topology.HistoryEvent{
+		Timestamp: time.Now(),
+		Type:      topology.HistoryEventConnect,
+		Peer:      &peer,
+		Bin:       swarm.Proximity(k.base.Bytes(), peer.Bytes()),
+		Direction: string(direction),
+		Depth:     depth,
+		Radius:    radius,
+	})
+}
+
+// recordDisconnected records the disconnection of the peer,
+// it is called after the depth is recalculated.
+func (k *Kad) recordDisconnected(peer swarm.Address) {
+	depth, radius := k.depths()
+	k.history.addEvent(topology.HistoryEvent{
+		Timestamp: time.Now(),
+		Type:      topology.HistoryEventDisconnect,
+		Peer:      &peer,
+		Bin:       swarm.Proximity(k.base.Bytes(), peer.Bytes()),
+		Reason:    k.history.takeReason(peer),
+		Depth:     depth,
+		Radius:    radius,
+	})
+}
+
+// sampleHistory periodically records the samples of the topology.
+func (k *Kad) sampleHistory() {
+	defer k.wg.Done()
+
+	ticker := time.NewTicker(k.opt.HistorySampleInterval)
+	defer ticker.Stop()
+
+	for {
+		k.history.addSample(k.historySample())
+
+		select {
+		case <-k.quit:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (k *Kad) historySample() topology.HistorySample {
+	depth, radius := k.depths()
+	s := topology.HistorySample{
+		Timestamp:     time.Now(),
+		Depth:         depth,
+		Radius:        radius,
+		Connected:     k.connectedPeers.Length(),
+		Population:    k.knownPeers.Length(),
+		BinConnected:  make([]int, swarm.MaxBins),
+		BinPopulation: make([]int, swarm.MaxBins),
+	}
+	_ = k.connectedPeers.EachBin(func(_ swarm.Address, po uint8) (bool, bool, error) {
+		s.BinConnected[po]++
+		return false, false, nil
+	})
+	_ = k.knownPeers.EachBin(func(_ swarm.Address, po uint8) (bool, bool, error) {
+		s.BinPopulation[po]++
+		return false, false, nil
+	})
+	return s
+}
@@ -20,12 +20,14 @@ import (
 	"github.com/ethersphere/bee/v2/pkg/discovery"
 	"github.com/ethersphere/bee/v2/pkg/log"
 	"github.com/ethersphere/bee/v2/pkg/p2p"
+	"github.com/ethersphere/bee/v2/pkg/reputation"
 	"github.com/ethersphere/bee/v2/pkg/shed"
 	"github.com/ethersphere/bee/v2/pkg/stabilization"
 	"github.com/ethersphere/bee/v2/pkg/swarm"
 	"github.com/ethersphere/bee/v2/pkg/topology"
 	im "github.com/ethersphere/bee/v2/pkg/topology/kademlia/internal/metrics"
 	"github.com/ethersphere/bee/v2/pkg/topology/kademlia/internal/waitnext"
+	"github.com/ethersphere/bee/v2/pkg/topology/peerscore"
 	"github.com/ethersphere/bee/v2/pkg/topology/pslice"
 	"github.com/ethersphere/bee/v2/pkg/util/ioutil"
 	ma "github.com/multiformats/go-multiaddr"
@@ -90,6 +92,8 @@ type Options struct {
 	StaticNodes    []swarm.Address
 	ExcludeFunc    excludeFunc
 	DataDir        string
+	PeerScorer     peerscore.Interface // chooses among the closest peers of the same proximity order
+	Reputation     reputation.Interface
 
 	BitSuffixLength             *int
 	TimeToRetry                 *time.Duration
@@ -100,6 +104,9 @@ type Options struct {
 	BootnodeOverSaturationPeers *int
 	BroadcastBinSize            *int
 	LowWaterMark                *int
+	HistorySampleInterval       *time.Duration
+	HistorySamples              *int
+	HistoryEvents               *int
 }
 
 // kadOptions are made from Options with default values set
@@ -111,6 +118,8 @@ type kadOptions struct {
 	PruneFunc      pruneFunc
 	StaticNodes    []swarm.Address
 	ExcludeFunc    excludeFunc
+	PeerScorer     peerscore.Interface
+	Reputation     reputation.Interface
 
 	TimeToRetry                 time.Duration
 	ShortRetry                  time.Duration
@@ -121,6 +130,9 @@ type kadOptions struct {
 	BootnodeOverSaturationPeers int
 	BroadcastBinSize            int
 	LowWaterMark                int
+	HistorySampleInterval       time.Duration
+	HistorySamples              int
+	HistoryEvents               int
 }
 
 func newKadOptions(o Options) kadOptions {
@@ -132,6 +144,8 @@ func newKadOptions(o Options) kadOptions {
 		PruneFunc:      o.PruneFunc,
 		StaticNodes:    o.StaticNodes,
 		ExcludeFunc:    o.ExcludeFunc,
+		PeerScorer:     o.PeerScorer,
+		Reputation:     o.Reputation,
 		// copy or use default
 		TimeToRetry:                 defaultValDuration(o.TimeToRetry, defaultTimeToRetry),
 		ShortRetry:                  defaultValDuration(o.ShortRetry, defaultShortRetry),
@@ -142,6 +156,9 @@ func newKadOptions(o Options) kadOptions {
 		BootnodeOverSaturationPeers: defaultValInt(o.BootnodeOverSaturationPeers, defaultBootNodeOverSaturationPeers),
 		BroadcastBinSize:            defaultValInt(o.BroadcastBinSize, defaultBroadcastBinSize),
 		LowWaterMark:                defaultValInt(o.LowWaterMark, defaultLowWaterMark),
+		HistorySampleInterval:       defaultValDuration(o.HistorySampleInterval, defaultHistorySampleInterval),
+		HistorySamples:              defaultValInt(o.HistorySamples, defaultHistorySamples),
+		HistoryEvents:               defaultValInt(o.HistoryEvents, defaultHistoryEvents),
 	}
 
 	if ko.SaturationFunc == nil {
@@ -203,6 +220,7 @@ type Kad struct {
 	bgBroadcastCancel context.CancelFunc
 	reachability      p2p.ReachabilityStatus
 	detector          *stabilization.Detector
+	history           *history
 }
 
 // New returns a new Kademlia.
@@ -254,6 +272,7 @@ func New(
 		staticPeer:        isStaticPeer(opt.StaticNodes),
 		storageRadius:     swarm.MaxPO,
 		detector:          detector,
+		history:           newHistory(opt.HistorySamples, opt.HistoryEvents),
 	}
 
 	if k.opt.PruneFunc == nil {
@@ -410,6 +429,11 @@ func (k *Kad) connectNeighbours(wg *sync.WaitGroup, peerConnChan chan<- *peerCon
 // to peers sent by the producers to the peerConnChan.
 func (k *Kad) connectionAttemptsHandler(ctx context.Context, wg *sync.WaitGroup, neighbourhoodChan, balanceChan <-chan *peerConnInfo) {
 	connect := func(peer *peerConnInfo) {
+		if k.isBadPeer(peer.addr) {
+			k.logger.Debug("skipping connection to peer with bad reputation", "peer_address", peer.addr)
+			return
+		}
+
 		bzzAddr, err := k.addressBook.Get(peer.addr)
 		switch {
 		case errors.Is(err, addressbook.ErrNotFound):
@@ -457,6 +481,7 @@ func (k *Kad) connectionAttemptsHandler(ctx context.Context, wg *sync.WaitGroup,
 		k.collector.Record(peer.addr, im.PeerLogIn(time.Now(), im.PeerConnectionDirectionOutbound))
 
 		k.recalcDepth()
+		k.recordConnected(peer.addr, im.PeerConnectionDirectionOutbound)
 
 		k.logger.Debug("connected to peer", "peer_address", peer.addr, "proximity_order", peer.po)
 		k.notifyManageLoop()
@@ -712,6 +737,16 @@ func (k *Kad) pruneOversaturatedBins(depth uint8) {
 				}
 			}
 
+			// pick the peer with the worst reputation, if any has misbehaved
+			if disconnectPeer.IsZero() && k.opt.Reputation != nil {
+				worstScore := 0.0
+				for _, peer := range peers {
+					if score := k.opt.Reputation.Score(peer); score < worstScore {
+						disconnectPeer, worstScore = peer, score
+					}
+				}
+			}
+
 			if disconnectPeer.IsZero() {
 				if unreachablePeer.IsZero() {
 					disconnectPeer = peers[rand.Intn(len(peers))]
@@ -748,8 +783,9 @@ func (k *Kad) Start(ctx context.Context) error {
 	// always discover bootnodes on startup to exclude them from protocol requests
 	k.connectBootNodes(ctx)
 
-	k.wg.Add(1)
+	k.wg.Add(2)
 	go k.manage()
+	go k.sampleHistory()
 
 	k.AddPeers(k.previouslyConnected()...)
 
@@ -847,6 +883,7 @@ func (k *Kad) connectBootNodes(ctx context.Context) {
 
 			k.metrics.TotalOutboundConnections.Inc()
 			k.collector.Record(bzzAddress.Overlay, im.PeerLogIn(time.Now(), im.PeerConnectionDirectionOutbound), im.IsBootnode(true))
+			k.recordConnected(bzzAddress.Overlay, im.PeerConnectionDirectionOutbound)
 			loggerV1.Debug("connected to bootnode", "bootnode_address", addr)
 			connected++
 
@@ -907,7 +944,7 @@ func (k *Kad) recalcDepth() {
 
 	// handle edge case separately
 	if peers.Length() <= k.opt.LowWaterMark {
-		k.depth = 0
+		k.setDepth(0)
 		return
 	}
 
@@ -959,7 +996,23 @@ func (k *Kad) recalcDepth() {
 		depth = candidate
 	}
 
+	k.setDepth(depth)
+}
+
+// setDepth sets the kademlia depth and records its change
+// in the history, it must be called under the depth lock.
+func (k *Kad) setDepth(depth uint8) {
+	if k.depth == depth {
+		return
+	}
 	k.depth = depth
+
+	k.history.addEvent(topology.HistoryEvent{
+		Timestamp: time.Now(),
+		Type:      topology.HistoryEventDepth,
+		Depth:     depth,
+		Radius:    k.storageRadius,
+	})
 }
 
 // connect connects to a peer and gossips its address to our connected peers,
@@ -1127,6 +1180,10 @@ func (k *Kad) AddPeers(addrs ...swarm.Address) {
 
 func (k *Kad) Pick(peer p2p.Peer) bool {
 	k.metrics.PickCalls.Inc()
+	if k.isBadPeer(peer.Address) {
+		k.metrics.PickCallsFalse.Inc()
+		return false
+	}
 	if k.bootnode || !peer.FullNode {
 		// shortcircuit for bootnode mode AND light node peers - always accept connections,
 		// at least until we find a better solution.
@@ -1142,6 +1199,11 @@ func (k *Kad) Pick(peer p2p.Peer) bool {
 	return false
 }
 
+// isBadPeer reports whether the peer has a bad reputation.
+func (k *Kad) isBadPeer(peer swarm.Address) bool {
+	return k.opt.Reputation != nil && k.opt.Reputation.IsBad(peer)
+}
+
 func (k *Kad) binPeers(bin uint8, reachable bool) (peers []swarm.Address) {
 	_ = k.EachConnectedPeerRev(func(p swarm.Address, po uint8) (bool, bool, error) {
 		if po == bin {
@@ -1172,6 +1234,7 @@ func (k *Kad) Connected(ctx context.Context, peer p2p.Peer, forceConnection bool
 		if err == nil {
 			k.metrics.TotalInboundConnections.Inc()
 			k.collector.Record(peer.Address, im.PeerLogIn(time.Now(), im.PeerConnectionDirectionInbound))
+			k.recordConnected(peer.Address, im.PeerConnectionDirectionInbound)
 		}
 	}()
 
@@ -1221,8 +1284,12 @@ func (k *Kad) Disconnected(peer p2p.Peer) {
 
 	k.metrics.TotalInboundDisconnections.Inc()
 	k.collector.Record(peer.Address, im.PeerLogOut(time.Now()))
+	if k.opt.PeerScorer != nil {
+		k.opt.PeerScorer.Forget(peer.Address)
+	}
 
 	k.recalcDepth()
+	k.recordDisconnected(peer.Address)
 
 	k.notifyManageLoop()
 	k.notifyPeerSig()
@@ -1277,7 +1344,7 @@ func (k *Kad) ClosestPeer(addr swarm.Address, includeSelf bool, filter topology.
 
 	// iterate starting from bin 0 to the maximum bin
 	err := k.EachConnectedPeerRev(func(peer swarm.Address, bin uint8) (bool, bool, error) {
-		if swarm.ContainsAddress(skipPeers, peer) {
+		if swarm.ContainsAddress(skipPeers, peer) || k.isBadPeer(peer) {
 			return false, false, nil
 		}
 
@@ -1290,6 +1357,21 @@ func (k *Kad) ClosestPeer(addr swarm.Address, includeSelf bool, filter topology.
 			return false, false, nil
 		}
 
+		if k.opt.PeerScorer != nil && !closest.Equal(k.base) {
+			// among the peers of the same proximity order to the address
+			// the one with the higher score is chosen over the closer one
+			peerPO, closestPO := swarm.Proximity(peer.Bytes(), addr.Bytes()), swarm.Proximity(closest.Bytes(), addr.Bytes())
+			if peerPO == closestPO {
+				peerScore, closestScore := k.opt.PeerScorer.Score(peer), k.opt.PeerScorer.Score(closest)
+				if peerScore > closestScore {
+					closest = peer
+				}
+				if peerScore != closestScore {
+					return false, false, nil
+				}
+			}
+		}
+
 		closer, err := peer.Closer(addr, closest)
 		if closer {
 			closest = peer
@@ -1364,6 +1446,10 @@ func (k *Kad) UpdateReachability(status p2p.ReachabilityStatus) {
 // p2p.ReachabilityStatusUnknown are ignored.
 func (k *Kad) UpdatePeerHealth(peer swarm.Address, health bool, dur time.Duration) {
 	k.collector.Record(peer, im.PeerHealth(health), im.PeerLatency(dur))
+	if k.opt.PeerScorer != nil {
+		k.opt.PeerScorer.RecordHealth(peer, health)
+		k.opt.PeerScorer.RecordLatency(peer, dur)
+	}
 }
 
 // SubscribeTopologyChange returns the channel that signals when the connected peers
@@ -1428,10 +1514,29 @@ func (k *Kad) SetStorageRadius(d uint8) {
 	k.metrics.CurrentStorageDepth.Set(float64(k.storageRadius))
 	k.logger.Debug("kademlia set storage radius", "radius", k.storageRadius)
 
+	k.history.addEvent(topology.HistoryEvent{
+		Timestamp: time.Now(),
+		Type:      topology.HistoryEventRadius,
+		Depth:     k.depth,
+		Radius:    d,
+	})
+
 	k.notifyManageLoop()
 	k.notifyPeerSig()
 }
 
+// PeerConnectivity returns the connectivity of the known peer.
+func (k *Kad) PeerConnectivity(addr swarm.Address) topology.PeerConnectivity {
+	c := topology.PeerConnectivity{
+		Connected:      k.connectedPeers.Exists(addr),
+		FailedAttempts: k.waitNext.Attempts(addr),
+	}
+	if ss := k.collector.Inspect(addr); ss != nil && ss.LastSeenTimestamp > 0 {
+		c.LastSeen = time.Unix(0, ss.LastSeenTimestamp)
+	}
+	return c
+}
+
 func (k *Kad) Snapshot() *topology.KadParams {
 	var infos []topology.BinInfo
 	for i := int(swarm.MaxPO); i >= 0; i-- {
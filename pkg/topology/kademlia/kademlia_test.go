@@ -25,6 +25,7 @@ import (
 	"github.com/ethersphere/bee/v2/pkg/log"
 	"github.com/ethersphere/bee/v2/pkg/p2p"
 	p2pmock "github.com/ethersphere/bee/v2/pkg/p2p/mock"
+	"github.com/ethersphere/bee/v2/pkg/reputation"
 	"github.com/ethersphere/bee/v2/pkg/spinlock"
 	"github.com/ethersphere/bee/v2/pkg/stabilization"
 	mockstate "github.com/ethersphere/bee/v2/pkg/statestore/mock"
@@ -32,6 +33,7 @@ import (
 	"github.com/ethersphere/bee/v2/pkg/topology"
 	"github.com/ethersphere/bee/v2/pkg/topology/kademlia"
 	im "github.com/ethersphere/bee/v2/pkg/topology/kademlia/internal/metrics"
+	"github.com/ethersphere/bee/v2/pkg/topology/peerscore"
 	"github.com/ethersphere/bee/v2/pkg/topology/pslice"
 	"github.com/ethersphere/bee/v2/pkg/util/testutil"
 )
@@ -660,6 +662,221 @@ func TestBootnodeMaxConnections(t *testing.T) {
 	}
 }
 
+// TestClosestPeerScorer tests that the peer scorer chooses
+// among the closest peers of the same proximity order.
+func TestClosestPeerScorer(t *testing.T) {
+	t.Parallel()
+
+	var (
+		scorer                   = peerscore.New(peerscore.Weights{Health: 1})
+		base, kad, ab, _, signer = newTestKademlia(t, nil, nil, kademlia.Options{PeerScorer: scorer})
+		addr                     = swarm.RandAddressAt(t, base, 2)
+		peer1                    = swarm.RandAddressAt(t, addr, 5)
+		peer2                    = swarm.RandAddressAt(t, addr, 5)
+		closer                   = swarm.RandAddressAt(t, addr, 8)
+	)
+
+	if err := kad.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	testutil.CleanupCloser(t, kad)
+
+	connectOne(t, signer, kad, ab, peer1, nil)
+	connectOne(t, signer, kad, ab, peer2, nil)
+
+	expectClosest := func(t *testing.T, want swarm.Address) {
+		t.Helper()
+
+		got, err := kad.ClosestPeer(addr, false, topology.Select{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !got.Equal(want) {
+			t.Fatalf("got closest peer %s, want %s", got, want)
+		}
+	}
+
+	kad.UpdatePeerHealth(peer1, true, time.Millisecond)
+	kad.UpdatePeerHealth(peer2, false, time.Millisecond)
+	expectClosest(t, peer1)
+
+	kad.UpdatePeerHealth(peer1, false, time.Millisecond)
+	kad.UpdatePeerHealth(peer2, true, time.Millisecond)
+	expectClosest(t, peer2)
+
+	// a peer of the higher proximity order is chosen regardless of the score
+	connectOne(t, signer, kad, ab, closer, nil)
+	kad.UpdatePeerHealth(closer, false, time.Millisecond)
+	expectClosest(t, closer)
+}
+
+// TestReputation tests that peers with a bad reputation
+// are neither picked nor chosen as the closest peer.
+func TestReputation(t *testing.T) {
+	t.Parallel()
+
+	rep, err := reputation.New(mockstate.NewStateStore(), nil, log.Noop, reputation.Options{BadThreshold: -10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = rep.Close() })
+
+	var (
+		base, kad, ab, _, signer = newTestKademlia(t, nil, nil, kademlia.Options{Reputation: rep})
+		addr                     = swarm.RandAddressAt(t, base, 2)
+		good                     = swarm.RandAddressAt(t, addr, 5)
+		bad                      = swarm.RandAddressAt(t, addr, 8)
+	)
+
+	if err := kad.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	testutil.CleanupCloser(t, kad)
+
+	connectOne(t, signer, kad, ab, good, nil)
+	connectOne(t, signer, kad, ab, bad, nil)
+
+	got, err := kad.ClosestPeer(addr, false, topology.Select{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.Equal(bad) {
+		t.Fatalf("got closest peer %s, want %s", got, bad)
+	}
+
+	rep.Record(bad, reputation.EventInvalidStamp)
+
+	got, err = kad.ClosestPeer(addr, false, topology.Select{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.Equal(good) {
+		t.Fatalf("got closest peer %s, want %s", got, good)
+	}
+
+	if kad.Pick(p2p.Peer{Address: bad, FullNode: true}) {
+		t.Fatal("picked peer with bad reputation")
+	}
+	if !kad.Pick(p2p.Peer{Address: good, FullNode: true}) {
+		t.Fatal("peer with good reputation not picked")
+	}
+}
+
+func TestHistory(t *testing.T) {
+	t.Parallel()
+
+	var (
+		base, kad, ab, _, signer = newTestKademlia(t, nil, nil, kademlia.Options{
+			HistorySampleInterval: ptrDuration(time.Hour),
+			HistoryEvents:         ptrInt(3),
+		})
+		first  = swarm.RandAddressAt(t, base, 1)
+		second = swarm.RandAddressAt(t, base, 2)
+	)
+
+	if err := kad.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	testutil.CleanupCloser(t, kad)
+
+	connectOne(t, signer, kad, ab, first, nil)
+	connectOne(t, signer, kad, ab, second, nil)
+
+	kad.DisconnectReason(first, "pruned")
+	removeOne(kad, first)
+	removeOne(kad, second)
+
+	var h topology.History
+	err := spinlock.Wait(spinLockWaitTime, func() bool {
+		h = kad.History(time.Time{})
+		return len(h.Samples) == 1
+	})
+	if err != nil {
+		t.Fatalf("got %d samples, want 1", len(h.Samples))
+	}
+	if got := len(h.Samples[0].BinConnected); got != int(swarm.MaxBins) {
+		t.Fatalf("got %d bins, want %d", got, swarm.MaxBins)
+	}
+
+	// the oldest of the events is overwritten
+	want := []struct {
+		typ    topology.HistoryEventType
+		peer   swarm.Address
+		bin    uint8
+		reason string
+	}{
+		{topology.HistoryEventConnect, second, 2, ""},
+		{topology.HistoryEventDisconnect, first, 1, "pruned"},
+		{topology.HistoryEventDisconnect, second, 2, "closed by peer"},
+	}
+	if len(h.Events) != len(want) {
+		t.Fatalf("got %d events, want %d", len(h.Events), len(want))
+	}
+	for i, w := range want {
+		e := h.Events[i]
+		if e.Type != w.typ || !e.Peer.Equal(w.peer) || e.Bin != w.bin || e.Reason != w.reason {
+			t.Fatalf("got event %d %+v, want %+v", i, e, w)
+		}
+	}
+	if h.Events[0].Direction != "inbound" {
+		t.Fatalf("got connection direction %q, want %q", h.Events[0].Direction, "inbound")
+	}
+
+	kad.SetStorageRadius(4)
+
+	h = kad.History(time.Now().Add(-time.Second))
+	e := h.Events[len(h.Events)-1]
+	if e.Type != topology.HistoryEventRadius || e.Radius != 4 {
+		t.Fatalf("got event %+v, want storage radius 4", e)
+	}
+
+	h = kad.History(time.Now().Add(time.Hour))
+	if len(h.Samples) != 0 || len(h.Events) != 0 {
+		t.Fatalf("got %d samples and %d events from the future", len(h.Samples), len(h.Events))
+	}
+}
+
+// TestHistoryDepth tests that the changes of the kademlia
+// depth are recorded apart from the storage radius.
+func TestHistoryDepth(t *testing.T) {
+	t.Parallel()
+
+	base, kad, ab, _, signer := newTestKademlia(t, nil, nil, kademlia.Options{
+		SaturationPeers: ptrInt(1),
+		ExcludeFunc:     defaultExcludeFunc,
+	})
+	if err := kad.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	testutil.CleanupCloser(t, kad)
+
+	for i := range 5 {
+		connectOne(t, signer, kad, ab, swarm.RandAddressAt(t, base, i), nil)
+	}
+
+	depth := kad.ConnectionDepth()
+	if depth == 0 {
+		t.Fatal("kademlia depth did not change")
+	}
+
+	h := kad.History(time.Time{})
+	var last *topology.HistoryEvent
+	for i, e := range h.Events {
+		if e.Type == topology.HistoryEventDepth {
+			last = &h.Events[i]
+		}
+		if e.Type == topology.HistoryEventRadius {
+			t.Fatalf("got storage radius event %+v without a change of the storage radius", e)
+		}
+	}
+	if last == nil || last.Depth != depth || last.Radius != kad.StorageRadius() {
+		t.Fatalf("got last depth event %+v, want depth %d", last, depth)
+	}
+	if e := h.Events[len(h.Events)-1]; e.Type != topology.HistoryEventConnect || e.Depth != depth {
+		t.Fatalf("got last event %+v, want connection at depth %d", e, depth)
+	}
+}
+
 // TestNotifierHooks tests that the Connected/Disconnected hooks
 // result in the correct behavior once called.
 func TestNotifierHooks(t *testing.T) {
@@ -0,0 +1,18 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package peerscore
+
+import "time"
+
+func (s *Service) SetTimeFunc(now func() time.Time) {
+	s.now = now
+}
+
+func (s *Service) PeersLen() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.peers)
+}
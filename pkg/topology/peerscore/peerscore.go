@@ -0,0 +1,207 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package peerscore provides the scoring of peers which is used by the
+// topology to choose among the peers of the same proximity order.
+package peerscore
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+const (
+	// latencyEWMASmoothing is the weight of a new latency sample.
+	latencyEWMASmoothing = 0.1
+	// latencyReference is the latency at which the latency score is one half.
+	latencyReference = 200 * time.Millisecond
+	// failureHalfLife is the time after which the weight of the
+	// recorded successes and failures of a peer is halved.
+	failureHalfLife = 10 * time.Minute
+	// unknownScore is the partial score of a peer with no records.
+	unknownScore = 0.5
+)
+
+// Scorer scores peers, a peer with the higher score is preferred.
+type Scorer interface {
+	Score(peer swarm.Address) float64
+}
+
+// Recorder records the observations the peer scores are based on.
+type Recorder interface {
+	// RecordLatency records the round-trip time measured with the peer.
+	RecordLatency(peer swarm.Address, rtt time.Duration)
+	// RecordHealth records the health status of the peer.
+	RecordHealth(peer swarm.Address, healthy bool)
+	// RecordSuccess records a successfully served request by the peer.
+	RecordSuccess(peer swarm.Address)
+	// RecordFailure records a request that the peer failed to serve.
+	RecordFailure(peer swarm.Address)
+}
+
+// Interface is the peer selection strategy fed by the peer observations.
+type Interface interface {
+	Scorer
+	Recorder
+	// Forget drops the records of the disconnected peer.
+	Forget(peer swarm.Address)
+}
+
+// Weights are the weights of the partial scores in the peer score.
+type Weights struct {
+	Latency     float64
+	Health      float64
+	Reliability float64
+}
+
+// Enabled reports whether any of the weights is set.
+func (w Weights) Enabled() bool {
+	return w.Latency != 0 || w.Health != 0 || w.Reliability != 0
+}
+
+// DefaultWeights weight all the partial scores equally.
+var DefaultWeights = Weights{
+	Latency:     1,
+	Health:      1,
+	Reliability: 1,
+}
+
+var _ Interface = (*Service)(nil)
+
+type peerRecord struct {
+	latency   time.Duration // latency exponentially weighted moving average
+	healthy   *bool
+	successes float64
+	failures  float64
+	updated   time.Time // the last decay of successes and failures
+}
+
+// decay decays the successes and failures of the peer
+// so that the old records weigh less than the recent ones.
+func (r *peerRecord) decay(now time.Time) {
+	if !r.updated.IsZero() {
+		f := math.Exp2(-float64(now.Sub(r.updated)) / float64(failureHalfLife))
+		r.successes *= f
+		r.failures *= f
+	}
+	r.updated = now
+}
+
+// Service is the peer scorer which combines the latency,
+// health and reliability scores of peers with the given weights.
+type Service struct {
+	weights Weights
+	now     func() time.Time
+
+	mu    sync.Mutex
+	peers map[string]*peerRecord
+}
+
+// New returns a new peer scorer with the given weights.
+func New(weights Weights) *Service {
+	return &Service{
+		weights: weights,
+		now:     time.Now,
+		peers:   make(map[string]*peerRecord),
+	}
+}
+
+// record must be called under lock.
+func (s *Service) record(peer swarm.Address) *peerRecord {
+	r, ok := s.peers[peer.ByteString()]
+	if !ok {
+		r = new(peerRecord)
+		s.peers[peer.ByteString()] = r
+	}
+	return r
+}
+
+// RecordLatency implements the Recorder interface.
+func (s *Service) RecordLatency(peer swarm.Address, rtt time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r := s.record(peer)
+	if r.latency == 0 {
+		r.latency = rtt
+		return
+	}
+	r.latency = time.Duration(latencyEWMASmoothing*float64(rtt) + (1-latencyEWMASmoothing)*float64(r.latency))
+}
+
+// RecordHealth implements the Recorder interface.
+func (s *Service) RecordHealth(peer swarm.Address, healthy bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.record(peer).healthy = &healthy
+}
+
+// RecordSuccess implements the Recorder interface.
+func (s *Service) RecordSuccess(peer swarm.Address) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r := s.record(peer)
+	r.decay(s.now())
+	r.successes++
+}
+
+// RecordFailure implements the Recorder interface.
+func (s *Service) RecordFailure(peer swarm.Address) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r := s.record(peer)
+	r.decay(s.now())
+	r.failures++
+}
+
+// Forget implements the Interface interface.
+func (s *Service) Forget(peer swarm.Address) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.peers, peer.ByteString())
+}
+
+// Score implements the Scorer interface. The score is the weighted sum
+// of the partial scores, each of them ranging from zero to one.
+func (s *Service) Score(peer swarm.Address) float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	latency, health, reliability := unknownScore, unknownScore, unknownScore
+
+	if r, ok := s.peers[peer.ByteString()]; ok {
+		if r.latency > 0 {
+			latency = float64(latencyReference) / float64(latencyReference+r.latency)
+		}
+		if r.healthy != nil {
+			health = 0
+			if *r.healthy {
+				health = 1
+			}
+		}
+		r.decay(s.now())
+		// the reliability is smoothed towards one half for the
+		// peers with only a few records of successes and failures
+		reliability = (r.successes + 1) / (r.successes + r.failures + 2)
+	}
+
+	return s.weights.Latency*latency + s.weights.Health*health + s.weights.Reliability*reliability
+}
+
+// NopRecorder is a Recorder which discards all the records.
+var NopRecorder Recorder = nopRecorder{}
+
+type nopRecorder struct{}
+
+func (nopRecorder) RecordLatency(swarm.Address, time.Duration) {}
+func (nopRecorder) RecordHealth(swarm.Address, bool)           {}
+func (nopRecorder) RecordSuccess(swarm.Address)                {}
+func (nopRecorder) RecordFailure(swarm.Address)                {}
@@ -0,0 +1,117 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package peerscore_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"github.com/ethersphere/bee/v2/pkg/topology/peerscore"
+)
+
+func TestScore(t *testing.T) {
+	t.Parallel()
+
+	t.Run("latency", func(t *testing.T) {
+		t.Parallel()
+
+		s := peerscore.New(peerscore.Weights{Latency: 1})
+		fast, slow, unknown := swarm.RandAddress(t), swarm.RandAddress(t), swarm.RandAddress(t)
+
+		s.RecordLatency(fast, 10*time.Millisecond)
+		s.RecordLatency(slow, time.Second)
+
+		if !(s.Score(fast) > s.Score(unknown) && s.Score(unknown) > s.Score(slow)) {
+			t.Fatalf("want fast %v > unknown %v > slow %v", s.Score(fast), s.Score(unknown), s.Score(slow))
+		}
+	})
+
+	t.Run("health", func(t *testing.T) {
+		t.Parallel()
+
+		s := peerscore.New(peerscore.Weights{Health: 1})
+		healthy, unhealthy := swarm.RandAddress(t), swarm.RandAddress(t)
+
+		s.RecordHealth(healthy, true)
+		s.RecordHealth(unhealthy, false)
+
+		if got := s.Score(healthy); got != 1 {
+			t.Fatalf("healthy peer score: got %v, want 1", got)
+		}
+		if got := s.Score(unhealthy); got != 0 {
+			t.Fatalf("unhealthy peer score: got %v, want 0", got)
+		}
+	})
+
+	t.Run("reliability", func(t *testing.T) {
+		t.Parallel()
+
+		s := peerscore.New(peerscore.Weights{Reliability: 1})
+		reliable, unreliable := swarm.RandAddress(t), swarm.RandAddress(t)
+
+		for i := 0; i < 5; i++ {
+			s.RecordSuccess(reliable)
+			s.RecordFailure(unreliable)
+		}
+
+		if !(s.Score(reliable) > s.Score(unreliable)) {
+			t.Fatalf("want reliable %v > unreliable %v", s.Score(reliable), s.Score(unreliable))
+		}
+	})
+
+	t.Run("weights", func(t *testing.T) {
+		t.Parallel()
+
+		s := peerscore.New(peerscore.Weights{Latency: 1, Health: 10})
+		fastUnhealthy, slowHealthy := swarm.RandAddress(t), swarm.RandAddress(t)
+
+		s.RecordLatency(fastUnhealthy, time.Millisecond)
+		s.RecordHealth(fastUnhealthy, false)
+		s.RecordLatency(slowHealthy, time.Second)
+		s.RecordHealth(slowHealthy, true)
+
+		if !(s.Score(slowHealthy) > s.Score(fastUnhealthy)) {
+			t.Fatalf("want slow healthy %v > fast unhealthy %v", s.Score(slowHealthy), s.Score(fastUnhealthy))
+		}
+	})
+}
+
+func TestFailureDecay(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	s := peerscore.New(peerscore.Weights{Reliability: 1})
+	s.SetTimeFunc(func() time.Time { return now })
+
+	peer := swarm.RandAddress(t)
+	for i := 0; i < 10; i++ {
+		s.RecordFailure(peer)
+	}
+	penalized := s.Score(peer)
+
+	now = now.Add(24 * time.Hour)
+
+	if got := s.Score(peer); got <= penalized || got < 0.49 {
+		t.Fatalf("failures not decayed: score %v, before %v", got, penalized)
+	}
+}
+
+func TestForget(t *testing.T) {
+	t.Parallel()
+
+	s := peerscore.New(peerscore.Weights{Health: 1})
+	peer := swarm.RandAddress(t)
+
+	s.RecordHealth(peer, false)
+	s.Forget(peer)
+
+	if got := s.Score(peer); got != 0.5 {
+		t.Fatalf("forgotten peer score: got %v, want 0.5", got)
+	}
+	if got := s.PeersLen(); got != 0 {
+		t.Fatalf("got %d peer records, want 0", got)
+	}
+}
@@ -141,6 +141,52 @@ type KadParams struct {
 	LightNodes          BinInfo   `json:"lightNodes"`          // light nodes bin info
 }
 
+// HistoryEventType is the type of the topology history event.
+type HistoryEventType string
+
+const (
+	HistoryEventConnect    HistoryEventType = "connect"
+	HistoryEventDisconnect HistoryEventType = "disconnect"
+	HistoryEventDepth      HistoryEventType = "depth"  // change of the kademlia depth
+	HistoryEventRadius     HistoryEventType = "radius" // change of the storage radius
+)
+
+// HistoryEvent is a change of the topology.
+type HistoryEvent struct {
+	Timestamp time.Time        `json:"timestamp"`
+	Type      HistoryEventType `json:"type"`
+	Peer      *swarm.Address   `json:"peer,omitempty"`      // connected or disconnected peer
+	Bin       uint8            `json:"bin"`                 // bin of the peer
+	Direction string           `json:"direction,omitempty"` // inbound or outbound connection
+	Reason    string           `json:"reason,omitempty"`    // reason of the disconnection
+	Depth     uint8            `json:"depth"`               // kademlia depth of the connected peers after the event
+	Radius    uint8            `json:"radius"`              // storage radius after the event
+}
+
+// HistorySample is the state of the topology at a point in time.
+type HistorySample struct {
+	Timestamp     time.Time `json:"timestamp"`
+	Depth         uint8     `json:"depth"`  // kademlia depth of the connected peers
+	Radius        uint8     `json:"radius"` // storage radius
+	Connected     int       `json:"connected"`
+	Population    int       `json:"population"`
+	BinConnected  []int     `json:"binConnected"`  // connected peers by bin
+	BinPopulation []int     `json:"binPopulation"` // known peers by bin
+}
+
+// History is the rolling history of the topology, ordered by time.
+type History struct {
+	Samples []HistorySample `json:"samples"`
+	Events  []HistoryEvent  `json:"events"`
+}
+
+// PeerConnectivity is the connectivity of a known peer.
+type PeerConnectivity struct {
+	Connected      bool
+	LastSeen       time.Time // last time the peer was connected, zero if never
+	FailedAttempts int       // failed connection attempts since the last connection
+}
+
 type Halter interface {
 	// Halt the topology from initiating new connections
 	// while allowing it to still run.